@@ -0,0 +1,151 @@
+package finance
+
+import (
+	"math"
+	"strconv"
+)
+
+// Static arbitrage checks
+const (
+	arbGridPoints = 200  // Log moneyness points checked between the outermost quotes of a smile
+	arbStep       = 1e-3 // Step in log moneyness of the differences of the total variance
+	arbTolerance  = 1e-9 // Violation below which rounding is assumed
+)
+
+// ArbitrageKind is the kind of a static arbitrage
+type ArbitrageKind int
+
+const (
+	ButterflyArbitrage ArbitrageKind = iota // Call prices not convex in strike, a negative implied density
+	CalendarArbitrage                       // Total implied variance decreasing in maturity at a fixed log moneyness
+)
+
+// String returns "butterfly" or "calendar", or "ArbitrageKind(n)" for an invalid value
+func (k ArbitrageKind) String() string {
+	switch k {
+	case ButterflyArbitrage:
+		return "butterfly"
+	case CalendarArbitrage:
+		return "calendar"
+	}
+	return "ArbitrageKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// MarshalText encodes the kind of arbitrage as its String, so that it appears by name in JSON
+func (k ArbitrageKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// ArbViolation reports a static arbitrage found in an implied volatility smile or surface, at the worst point of a
+// run of consecutive points checked that violate the same condition
+type ArbViolation struct {
+	Kind          ArbitrageKind `json:"kind"`                      // Kind of arbitrage
+	Strike        float64       `json:"strike"`                    // Strike at the worst point, at the earlier expiration for a calendar arbitrage
+	TimeYears     float64       `json:"time_years"`                // Expiration in years, the earlier for a calendar arbitrage
+	NextTimeYears float64       `json:"next_time_years,omitempty"` // Later expiration in years of a calendar arbitrage
+	Magnitude     float64       `json:"magnitude"`                 // How far the condition is violated, positive
+}
+
+// CheckButterflyArbitrage checks a smile for butterfly arbitrage by Durrleman's condition on the total implied
+// variance w(k) = σ²T at log moneyness k = ln(K/F), g(k) = (1 − k·w′/(2w))² − w′²/4·(1/w + 1/4) + w″/2 ≥ 0, which
+// holds where the call price is convex in strike, the implied density being proportional to g. It is checked at 200
+// points evenly spaced in k between the lowest and highest quoted strikes, with central differences 1e-3 apart within them, and
+// each run of points where g < −1e-9 is reported at its most negative g, the magnitude being −g, or infinite
+// where g is undefined, as for a zero variance. The smile's extrapolation beyond its quotes is not checked
+// smile: the smile
+// forward: the forward price, usually smile.Forward()
+// timeYears: the time to expiration in years, usually smile.TimeYears()
+func CheckButterflyArbitrage(smile VolSmile, forward, timeYears float64) []ArbViolation {
+	total := func(k float64) float64 {
+		vol := smile.Vol(forward * math.Exp(k))
+		return vol * vol * timeYears
+	}
+	// the differences stay within the quotes, away from the kinks of the extrapolation
+	lowest := math.Log(smile.strikes[0]/forward) + arbStep
+	highest := math.Log(smile.strikes[len(smile.strikes)-1]/forward) - arbStep
+	var violations []ArbViolation
+	var run *ArbViolation
+	for i := range arbGridPoints {
+		k := lowest + (highest-lowest)*float64(i)/float64(arbGridPoints-1)
+		w, up, down := total(k), total(k+arbStep), total(k-arbStep)
+		slope := (up - down) / (2 * arbStep)
+		curvature := (up - 2*w + down) / (arbStep * arbStep)
+		g := math.Pow(1-k*slope/(2*w), 2) - 0.25*slope*slope*(1/w+0.25) + 0.5*curvature
+		run, violations = extendRun(run, violations, -g, ArbViolation{
+			Kind:      ButterflyArbitrage,
+			Strike:    forward * math.Exp(k),
+			TimeYears: timeYears,
+			Magnitude: -g,
+		})
+	}
+	return violations
+}
+
+// CheckCalendarArbitrage checks a surface for calendar arbitrage, a total implied variance σ²T that decreases from
+// one expiration to the next at a fixed log moneyness ln(K/F_T). The surface must report its expirations and
+// forwards as SmileSurface does; between its expirations the total variance at a fixed log moneyness is taken to be
+// monotonic, as it is for SmileSurface, so that consecutive expirations are compared at 200 points evenly spaced in
+// log moneyness between the lowest and highest quoted strikes of either smile, or ±1 for a surface without quotes.
+// Each run of points where the variance falls by more than 1e-9 is reported at its largest fall, the magnitude,
+// infinite where the variance is undefined. A surface that does not report its expirations has none to compare, and
+// no violations
+// surface: the implied volatility surface
+func CheckCalendarArbitrage(surface VolSurface) []ArbViolation {
+	pillars, ok := surface.(interface {
+		Expirations() []float64
+		Forward(timeYears float64) float64
+	})
+	if !ok {
+		return nil
+	}
+	expirations := pillars.Expirations()
+	var violations []ArbViolation
+	for n := 1; n < len(expirations); n++ {
+		t1, t2 := expirations[n-1], expirations[n]
+		f1, f2 := pillars.Forward(t1), pillars.Forward(t2)
+		lowest, highest := -1.0, 1.0
+		if s, ok := surface.(SmileSurface); ok {
+			before, after := s.smiles[n-1], s.smiles[n]
+			lowest = math.Min(math.Log(before.strikes[0]/f1), math.Log(after.strikes[0]/f2))
+			highest = math.Max(math.Log(before.strikes[len(before.strikes)-1]/f1), math.Log(after.strikes[len(after.strikes)-1]/f2))
+		}
+		var run *ArbViolation
+		for i := range arbGridPoints {
+			k := lowest + (highest-lowest)*float64(i)/float64(arbGridPoints-1)
+			v1, v2 := surface.Vol(f1*math.Exp(k), t1), surface.Vol(f2*math.Exp(k), t2)
+			fall := v1*v1*t1 - v2*v2*t2
+			run, violations = extendRun(run, violations, fall, ArbViolation{
+				Kind:          CalendarArbitrage,
+				Strike:        f1 * math.Exp(k),
+				TimeYears:     t1,
+				NextTimeYears: t2,
+				Magnitude:     fall,
+			})
+		}
+	}
+	return violations
+}
+
+// extendRun adds a point to the current run of violations, starting one if the point violates its condition by
+// more than arbTolerance and keeping the run's worst point, and ends the run otherwise. Returns the run, nil once
+// ended, and the violations, the run's final worst point appended to them
+// run: the current run, or nil
+// violations: the violations
+// magnitude: how far the point violates the condition, not positive if it holds, NaN if undefined
+// point: the violation at the point
+func extendRun(run *ArbViolation, violations []ArbViolation, magnitude float64, point ArbViolation) (*ArbViolation, []ArbViolation) {
+	if !(magnitude > arbTolerance) && !math.IsNaN(magnitude) {
+		return nil, violations
+	}
+	if math.IsNaN(magnitude) {
+		point.Magnitude = math.Inf(1)
+	}
+	if run == nil {
+		violations = append(violations, point)
+		return &violations[len(violations)-1], violations
+	}
+	if point.Magnitude > run.Magnitude {
+		*run = point
+	}
+	return run, violations
+}
@@ -0,0 +1,84 @@
+package finance
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCheckButterflyArbitrage(t *testing.T) {
+	strikes := []float64{70.0, 80.0, 90.0, 100.0, 110.0, 120.0, 130.0}
+	clean, err := NewVolSmile(100.0, 0.5, strikes, []float64{0.3, 0.26, 0.225, 0.2, 0.185, 0.18, 0.182}, SplineInterpolation, FlatExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := CheckButterflyArbitrage(clean, clean.Forward(), clean.TimeYears()); len(got) != 0 {
+		t.Errorf("Unexpected butterfly arbitrage in a smooth smile: got %+v", got)
+	}
+
+	// a spike at 110 makes the calls concave on either side of it
+	spiked, err := NewVolSmile(100.0, 0.5, strikes, []float64{0.3, 0.26, 0.225, 0.2, 0.26, 0.18, 0.182}, SplineInterpolation, FlatExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := CheckButterflyArbitrage(spiked, spiked.Forward(), spiked.TimeYears())
+	if len(got) == 0 {
+		t.Fatalf("Unexpected butterfly arbitrage in a spiked smile: got none")
+	}
+	for _, v := range got {
+		if v.Kind != ButterflyArbitrage || !(v.Magnitude > 0) || v.TimeYears != 0.5 || !(v.Strike > 90.0 && v.Strike < 130.0) {
+			t.Errorf("Unexpected violation in a spiked smile: got %+v, want between 90 and 130", v)
+		}
+	}
+
+	// the SVI parameters of Axel Vogt, free of calendar arbitrage but with g as low as −0.0327 at k = 0.9
+	p := SVIParams{A: -0.041, B: 0.1331, Rho: 0.306, M: 0.3586, Sigma: 0.4153}
+	var vogtStrikes, vols []float64
+	for k := -1.5; k <= 1.51; k += 0.1 {
+		vogtStrikes = append(vogtStrikes, 100.0*math.Exp(k))
+		vols = append(vols, math.Sqrt(SVITotalVariance(k, p)))
+	}
+	vogt, err := NewVolSmile(100.0, 1.0, vogtStrikes, vols, SplineInterpolation, FlatExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got = CheckButterflyArbitrage(vogt, 100.0, 1.0)
+	if len(got) != 1 || math.Abs(math.Log(got[0].Strike/100.0)-0.9) > 0.05 || math.Abs(got[0].Magnitude-0.0327) > 1e-3 {
+		t.Errorf("Unexpected butterfly arbitrage of Vogt's smile: got %+v, want one of 0.0327 near log moneyness 0.9", got)
+	}
+}
+
+func TestCheckCalendarArbitrage(t *testing.T) {
+	strikes := []float64{80.0, 90.0, 100.0, 110.0, 120.0}
+	near, _ := NewVolSmile(100.0, 0.25, strikes, []float64{0.3, 0.26, 0.22, 0.2, 0.19}, SplineInterpolation, FlatExtrapolation)
+	far, _ := NewVolSmile(102.0, 1.0, strikes, []float64{0.25, 0.23, 0.21, 0.2, 0.195}, SplineInterpolation, FlatExtrapolation)
+	surface, err := NewSmileSurface([]VolSmile{near, far})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := CheckCalendarArbitrage(surface); len(got) != 0 {
+		t.Errorf("Unexpected calendar arbitrage: got %+v", got)
+	}
+
+	// at one year the downside of the far smile falls below the total variance of the near smile
+	crossed, _ := NewVolSmile(100.0, 1.0, strikes, []float64{0.12, 0.16, 0.2, 0.2, 0.2}, SplineInterpolation, FlatExtrapolation)
+	surface, err = NewSmileSurface([]VolSmile{near, crossed})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := CheckCalendarArbitrage(surface)
+	if len(got) != 1 {
+		t.Fatalf("Unexpected calendar arbitrage: got %+v, want one", got)
+	}
+	if v, want := got[0], 0.3*0.3*0.25-0.12*0.12; v.Kind != CalendarArbitrage || v.TimeYears != 0.25 || v.NextTimeYears != 1.0 || math.Abs(v.Strike-80.0) > 1e-9 || math.Abs(v.Magnitude-want) > 1e-12 {
+		t.Errorf("Unexpected calendar violation: got %+v, want at 80 with magnitude %v", v, want)
+	}
+	if data, err := json.Marshal(got[0]); err != nil || !strings.Contains(string(data), `"kind":"calendar"`) {
+		t.Errorf("Unexpected JSON of a violation: got %s (%v)", data, err)
+	}
+
+	if got := CheckCalendarArbitrage(FlatVolSurface(0.2)); got != nil {
+		t.Errorf("Unexpected calendar arbitrage of a flat surface: got %+v", got)
+	}
+}
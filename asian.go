@@ -0,0 +1,124 @@
+package finance
+
+import (
+	"math"
+)
+
+// Asian options are average-price options, paying the intrinsic value of the average of the underlying price over
+// their life against the strike. The average is taken from today to expiration, continuously unless a number of
+// observation dates is given, which are then equally spaced with the last at expiration. Discrete dividends are
+// escrowed, and every price is NaN with negative days to expiration
+
+// AsianAveraging is the state of the averaging of a discretely monitored Asian option, which may already be under way
+type AsianAveraging struct {
+	ObservedAverage float64 // Average of the prices observed so far, unused if Observed is 0
+	Observed        int     // Number of observation dates already passed
+	Remaining       int     // Number of observation dates still to come, equally spaced with the last at expiration
+}
+
+// GeometricAsianPrice computes the Kemna-Vorst (1990) price of an average-price option on the continuous geometric
+// average of the underlying price, which is lognormal: the Black-Scholes price with the volatility σ/√3 and the cost
+// of carry (r − q − σ²/6)/2. It is a lower bound for the call on the arithmetic average and an upper bound for the put
+// option: the option
+// vol: the volatility
+func GeometricAsianPrice(option Option, vol float64) float64 {
+	option = escrowed(option)
+	switch {
+	case option.DaysToExpiration < 0:
+		return math.NaN()
+	case option.DaysToExpiration == 0:
+		return option.IntrinsicValue()
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	carry := (option.RiskFreeRate - option.DividendYield - vol*vol/6) / 2
+	forward := option.UnderlyingPrice * math.Exp(carry*timeToExpiration)
+	return BlackScholesForwardPrice(forward, option.Strike, timeToExpiration, option.RiskFreeRate, vol/math.Sqrt(3), option.OptionType)
+}
+
+// ArithmeticAsianPrice computes the Turnbull-Wakeman (1991) approximation to the price of an average-price option on
+// the continuous arithmetic average of the underlying price, which is taken to be lognormal with the same first two
+// moments. The approximation is good to about 0.1% of the underlying price for the volatilities and maturities of
+// most traded contracts, overpricing out-of-the-money puts the most, and degrades as the volatility over the life of
+// the option grows
+// option: the option
+// vol: the volatility
+func ArithmeticAsianPrice(option Option, vol float64) float64 {
+	option = escrowed(option)
+	switch {
+	case option.DaysToExpiration < 0:
+		return math.NaN()
+	case option.DaysToExpiration == 0:
+		return option.IntrinsicValue()
+	}
+
+	// E[A] = S·(e^{bT} − 1)/bT and E[A²] = 2S²/((b + σ²)T)·((e^{(2b+σ²)T} − 1)/(2b + σ²)T − (e^{bT} − 1)/bT), or
+	// equivalently 2S²/((2b + σ²)T)·(e^{bT}·(e^{(b+σ²)T} − 1)/(b + σ²)T − (e^{bT} − 1)/bT), dividing by whichever of
+	// (b + σ²)T and (2b + σ²)T is the larger, at least σ²T/3, so that neither form is taken at its 0/0 limit
+	timeToExpiration := option.DaysToExpiration / 365.0
+	carry := option.RiskFreeRate - option.DividendYield
+	first := expm1Ratio(carry * timeToExpiration)
+	mixed, doubled := (carry+vol*vol)*timeToExpiration, (2*carry+vol*vol)*timeToExpiration
+	var second float64
+	if math.Abs(mixed) >= math.Abs(doubled) {
+		second = 2 * (expm1Ratio(doubled) - first) / mixed
+	} else {
+		second = 2 * (math.Exp(carry*timeToExpiration)*expm1Ratio(mixed) - first) / doubled
+	}
+	averageVol := math.Sqrt(math.Max(math.Log(second/(first*first)), 0) / timeToExpiration)
+	return BlackScholesForwardPrice(option.UnderlyingPrice*first, option.Strike, timeToExpiration, option.RiskFreeRate, averageVol, option.OptionType)
+}
+
+// DiscreteArithmeticAsianPrice computes the moment-matching approximation of Turnbull and Wakeman (1991) and Levy
+// (1992) to the price of an average-price option on the arithmetic average of the underlying price at discrete
+// observation dates, which may be partially elapsed. The average still to come is taken to be lognormal with the
+// same first two moments, against the strike less the part of the average already fixed. Once that part alone puts
+// the option in the money with certainty, a call is worth the discounted expected payoff and a put 0; with no
+// observations remaining the payoff is known. NaN for negative counts or no observation dates at all
+// option: the option
+// vol: the volatility
+// averaging: the state of the averaging
+func DiscreteArithmeticAsianPrice(option Option, vol float64, averaging AsianAveraging) float64 {
+	option = escrowed(option)
+	observed, remaining := averaging.Observed, averaging.Remaining
+	if option.DaysToExpiration < 0 || observed < 0 || remaining < 0 || observed+remaining == 0 {
+		return math.NaN()
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	if remaining == 0 {
+		option.UnderlyingPrice = averaging.ObservedAverage
+		return discount * option.IntrinsicValue()
+	}
+
+	// (average − K)⁺ = weight·(future average − effective strike)⁺, the future average being over the remaining dates
+	total := float64(observed + remaining)
+	weight := float64(remaining) / total
+	strike := (total*option.Strike - float64(observed)*averaging.ObservedAverage) / float64(remaining)
+	spot := option.UnderlyingPrice
+	if option.DaysToExpiration == 0 {
+		option.Strike = strike
+		return weight * option.IntrinsicValue()
+	}
+
+	// E[F] = S/n·Σ e^{b·tᵢ} and E[F²] = S²/n²·Σᵢ e^{(b+σ²)·tᵢ}·(e^{b·tᵢ} + 2·Σ_{j>i} e^{b·tⱼ}), summed from the last date
+	carry := option.RiskFreeRate - option.DividendYield
+	n := float64(remaining)
+	var first, second, later float64
+	for i := remaining; i >= 1; i-- {
+		date := timeToExpiration * float64(i) / n
+		growth := math.Exp(carry * date)
+		second += math.Exp((carry+vol*vol)*date) * (growth + 2*later)
+		later += growth
+		first += growth
+	}
+	first /= n
+	second /= n * n
+	if strike <= 0 {
+		if option.OptionType == Put {
+			return 0
+		}
+		return weight * discount * (spot*first - strike)
+	}
+	averageVol := math.Sqrt(math.Max(math.Log(second/(first*first)), 0) / timeToExpiration)
+	return weight * BlackScholesForwardPrice(spot*first, strike, timeToExpiration, option.RiskFreeRate, averageVol, option.OptionType)
+}
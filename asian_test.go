@@ -0,0 +1,189 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeometricAsianPrice(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas, geometric average-rate put example
+	option := Option{
+		Strike:           85.0,
+		DaysToExpiration: 0.25 * 365,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  80.0,
+		OptionType:       Put,
+		DividendYield:    -0.03,
+	}
+	if got := GeometricAsianPrice(option, 0.2); math.Abs(got-4.6922) > 5e-5 {
+		t.Errorf("Unexpected geometric average put price: got %v, want 4.6922", got)
+	}
+
+	// the continuous average is the limit of the discrete one as the observation dates grow dense
+	for _, optionType := range []OptionType{Call, Put} {
+		option.OptionType = optionType
+		if got, want := GeometricAsianPrice(option, 0.2), DiscreteGeometricAsianPrice(option, 0.2, 100000); math.Abs(got-want) > 1e-4 {
+			t.Errorf("Unexpected geometric price for type %v: got %v, want %v", optionType, got, want)
+		}
+	}
+}
+
+func TestArithmeticAsianPrice(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{80.0, 100.0, 120.0} {
+			for _, vol := range []float64{0.1, 0.3, 0.6} {
+				option := Option{
+					Strike:           strike,
+					DaysToExpiration: 365.0,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  100.0,
+					OptionType:       optionType,
+					DividendYield:    0.02,
+				}
+				arithmetic, geometric := ArithmeticAsianPrice(option, vol), GeometricAsianPrice(option, vol)
+				// the arithmetic average is never below the geometric one, which the approximation keeps for calls
+				if optionType == Call && arithmetic < geometric {
+					t.Errorf("Unexpected arithmetic price for type %v strike %v vol %v: got %v, geometric %v", optionType, strike, vol, arithmetic, geometric)
+				}
+				if got, want := arithmetic, DiscreteArithmeticAsianPrice(option, vol, AsianAveraging{Remaining: 100000}); math.Abs(got-want) > 1e-3 {
+					t.Errorf("Unexpected arithmetic price for type %v strike %v vol %v: got %v, dense discrete %v", optionType, strike, vol, got, want)
+				}
+			}
+		}
+	}
+
+	// with no cost of carry the moments take their limit
+	option := Option{Strike: 100.0, DaysToExpiration: 365.0, RiskFreeRate: 0.03, UnderlyingPrice: 100.0, OptionType: Call, DividendYield: 0.03}
+	nearby := option
+	nearby.DividendYield += 1e-9
+	if got, want := ArithmeticAsianPrice(option, 0.2), ArithmeticAsianPrice(nearby, 0.2); math.Abs(got-want) > 1e-7 {
+		t.Errorf("Unexpected zero-carry arithmetic price: got %v, want %v", got, want)
+	}
+
+	// nor is the second moment singular where the cost of carry is −σ²
+	option.RiskFreeRate, option.DividendYield = 0, 0.0625
+	nearby = option
+	nearby.DividendYield += 1e-9
+	if got, want := ArithmeticAsianPrice(option, 0.25), ArithmeticAsianPrice(nearby, 0.25); math.IsNaN(got) || math.Abs(got-want) > 1e-7 {
+		t.Errorf("Unexpected arithmetic price with a cost of carry of −σ²: got %v, want %v", got, want)
+	}
+}
+
+func TestArithmeticAsianPriceMonteCarlo(t *testing.T) {
+	// the continuous approximation agrees with simulation of a daily average, with the geometric average as control
+	// variate, to within 0.1% of the underlying price
+	const observations = 365
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 365.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.02,
+			}
+			control := &ControlVariate{Payoff: GeometricAveragePayoff, Price: DiscreteGeometricAsianPrice(option, 0.3, observations)}
+			result, err := MonteCarloPathPrice(option, 0.3, ArithmeticAveragePayoff, observations, MCConfig{Paths: 20000, Seed: 11, Antithetic: true, ControlVariate: control})
+			if err != nil {
+				t.Fatalf("Unexpected error for type %v strike %v: %v", optionType, strike, err)
+			}
+			if got := ArithmeticAsianPrice(option, 0.3); math.Abs(got-result.Price) > 1e-3*option.UnderlyingPrice+3*result.StdError {
+				t.Errorf("Unexpected price for type %v strike %v: got %v, want %v ± %v", optionType, strike, got, result.Price, result.StdError)
+			}
+		}
+	}
+}
+
+func TestDiscreteArithmeticAsianPriceMonteCarlo(t *testing.T) {
+	// the moment-matching approximation agrees with simulation, with the geometric average as control variate, to
+	// within 0.1% of the underlying price, its error being largest for out-of-the-money puts
+	const observations = 12
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 365.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.02,
+			}
+			control := &ControlVariate{Payoff: GeometricAveragePayoff, Price: DiscreteGeometricAsianPrice(option, 0.3, observations)}
+			result, err := MonteCarloPathPrice(option, 0.3, ArithmeticAveragePayoff, observations, MCConfig{Paths: 50000, Seed: 7, Antithetic: true, ControlVariate: control})
+			if err != nil {
+				t.Fatalf("Unexpected error for type %v strike %v: %v", optionType, strike, err)
+			}
+			got := DiscreteArithmeticAsianPrice(option, 0.3, AsianAveraging{Remaining: observations})
+			if math.Abs(got-result.Price) > 1e-3*option.UnderlyingPrice+3*result.StdError {
+				t.Errorf("Unexpected price for type %v strike %v: got %v, want %v ± %v", optionType, strike, got, result.Price, result.StdError)
+			}
+		}
+	}
+}
+
+func TestDiscreteArithmeticAsianPriceSeasoned(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.01,
+	}
+
+	// with one observation left, the average is lognormal and the price exact: 1/4 of a call struck at 4·100 − 3·96
+	for _, optionType := range []OptionType{Call, Put} {
+		option.OptionType = optionType
+		vanilla := option
+		vanilla.Strike = 4*100.0 - 3*96.0
+		got := DiscreteArithmeticAsianPrice(option, 0.25, AsianAveraging{ObservedAverage: 96.0, Observed: 3, Remaining: 1})
+		if want := BlackScholesOptionPrice(vanilla, 0.25) / 4; math.Abs(got-want) > 1e-12 {
+			t.Errorf("Unexpected single remaining observation price for type %v: got %v, want %v", optionType, got, want)
+		}
+	}
+
+	// an average already high enough puts the call in the money with certainty
+	timeToExpiration := option.DaysToExpiration / 365.0
+	option.OptionType = Call
+	deep := AsianAveraging{ObservedAverage: 200.0, Observed: 6, Remaining: 2}
+	forward := 100.0 * (math.Exp(0.04*timeToExpiration/2) + math.Exp(0.04*timeToExpiration)) / 2
+	want := math.Exp(-0.05*timeToExpiration) * ((6*200.0+2*forward)/8 - 100.0)
+	if got := DiscreteArithmeticAsianPrice(option, 0.25, deep); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected deep in the money call price: got %v, want %v", got, want)
+	}
+	option.OptionType = Put
+	if got := DiscreteArithmeticAsianPrice(option, 0.25, deep); got != 0 {
+		t.Errorf("Unexpected deep out of the money put price: got %v, want 0", got)
+	}
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"fixed average", DiscreteArithmeticAsianPrice(option, 0.25, AsianAveraging{ObservedAverage: 90.0, Observed: 12}), 10.0 * math.Exp(-0.05*timeToExpiration)},
+		{"expiration", DiscreteArithmeticAsianPrice(Option{Strike: 100.0, UnderlyingPrice: 90.0, OptionType: Put}, 0.25, AsianAveraging{ObservedAverage: 100.0, Observed: 1, Remaining: 1}), 5.0},
+		{"geometric expiration", GeometricAsianPrice(Option{Strike: 100.0, UnderlyingPrice: 90.0, OptionType: Put}, 0.25), 10.0},
+		{"arithmetic expiration", ArithmeticAsianPrice(Option{Strike: 100.0, UnderlyingPrice: 90.0, OptionType: Put}, 0.25), 10.0},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-12 {
+			t.Errorf("Unexpected %s price: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	negative := option
+	negative.DaysToExpiration = -1
+	for name, got := range map[string]float64{
+		"no observations":          DiscreteArithmeticAsianPrice(option, 0.25, AsianAveraging{}),
+		"negative observed":        DiscreteArithmeticAsianPrice(option, 0.25, AsianAveraging{Observed: -1, Remaining: 2}),
+		"negative remaining":       DiscreteArithmeticAsianPrice(option, 0.25, AsianAveraging{Observed: 2, Remaining: -1}),
+		"negative days discrete":   DiscreteArithmeticAsianPrice(negative, 0.25, AsianAveraging{Remaining: 2}),
+		"negative days geometric":  GeometricAsianPrice(negative, 0.25),
+		"negative days arithmetic": ArithmeticAsianPrice(negative, 0.25),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package finance
+
+import "math"
+
+// The Bachelier (normal) model assumes the underlying follows an arithmetic Brownian motion, so it accepts negative
+// underlying prices and strikes. UnderlyingPrice is the forward price of the underlying, as in the Black-76 model,
+// RiskFreeRate is used purely to discount the payoff and the volatility is quoted in price units per square root of
+// a year. DividendYield and Dividends are ignored. Expiration is handled as in BlackScholesOptionPrice and its Greeks
+
+// BachelierOptionPrice calculates the Bachelier price of an option on a forward
+// option: the option, with UnderlyingPrice holding the forward price
+// normalVol: the normal volatility
+func BachelierOptionPrice(option Option, normalVol float64) float64 {
+	timeToExpiration := option.DaysToExpiration / 365.0
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	moneyness := option.UnderlyingPrice - option.Strike
+	if option.OptionType == Put {
+		moneyness = -moneyness
+	}
+	stdDev := normalVol * math.Sqrt(timeToExpiration)
+	if stdDev == 0 {
+		return discount * math.Max(0, moneyness)
+	}
+	d := moneyness / stdDev
+	return discount * (moneyness*Phi(d) + stdDev*NormalDistributionDerivative(d))
+}
+
+// BachelierDelta computes the delta of an option under the Bachelier model, with respect to the forward price
+// option: the option, with UnderlyingPrice holding the forward price
+// normalVol: the normal volatility
+func BachelierDelta(option Option, normalVol float64) float64 {
+	if option.DaysToExpiration == 0 {
+		return expirationDelta(option)
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	d := bachelierD(option, normalVol)
+	if option.OptionType == Call {
+		return discount * Phi(d)
+	}
+	return discount * (Phi(d) - 1)
+}
+
+// BachelierGamma computes the gamma of an option under the Bachelier model, with respect to the forward price
+// option: the option, with UnderlyingPrice holding the forward price
+// normalVol: the normal volatility
+func BachelierGamma(option Option, normalVol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	return discount * NormalDistributionDerivative(bachelierD(option, normalVol)) / (normalVol * math.Sqrt(timeToExpiration))
+}
+
+// BachelierVega computes the vega of an option under the Bachelier model, per unit change in the normal volatility.
+// Vega is the same for calls and puts
+// option: the option, with UnderlyingPrice holding the forward price
+// normalVol: the normal volatility
+func BachelierVega(option Option, normalVol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	return discount * math.Sqrt(timeToExpiration) * NormalDistributionDerivative(bachelierD(option, normalVol))
+}
+
+// BachelierTheta computes the theta of an option under the Bachelier model, expressed per year, holding the
+// forward price fixed
+// option: the option, with UnderlyingPrice holding the forward price
+// normalVol: the normal volatility
+func BachelierTheta(option Option, normalVol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	decay := -discount * normalVol * NormalDistributionDerivative(bachelierD(option, normalVol)) / (2 * math.Sqrt(timeToExpiration))
+	return decay + option.RiskFreeRate*BachelierOptionPrice(option, normalVol)
+}
+
+// BachelierRho computes the rho of an option under the Bachelier model, expressed per unit (1.00) change in the
+// risk-free rate. Since the rate only discounts the payoff, rho is −T times the option price
+// option: the option, with UnderlyingPrice holding the forward price
+// normalVol: the normal volatility
+func BachelierRho(option Option, normalVol float64) float64 {
+	return -option.DaysToExpiration / 365.0 * BachelierOptionPrice(option, normalVol)
+}
+
+// BachelierImpliedVolatility computes the normal implied volatility of an option from its price using Brent's method.
+// Returns ErrNegativePrice for a negative price, ErrNegativeExpiry for negative days to expiration and
+// ErrPriceOutOfBounds for a price at or below the discounted intrinsic value or at expiration, where no volatility
+// moves the price off the intrinsic value
+// option: the option, with UnderlyingPrice holding the forward price
+func BachelierImpliedVolatility(option Option) (float64, error) {
+	switch {
+	case option.Price < 0:
+		return math.NaN(), ErrNegativePrice
+	case !(option.DaysToExpiration >= 0):
+		return math.NaN(), ErrNegativeExpiry
+	case option.DaysToExpiration == 0:
+		return math.NaN(), ErrPriceOutOfBounds
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	target := option.Price * math.Exp(option.RiskFreeRate*timeToExpiration) // undiscounted price
+	moneyness := option.UnderlyingPrice - option.Strike
+	if option.OptionType == Put {
+		moneyness = -moneyness
+	}
+	if !(target > math.Max(0, moneyness)) {
+		return math.NaN(), ErrPriceOutOfBounds
+	}
+
+	// E[(m + σ√T·Z)⁺] ≥ σ√T/√(2π) + min(m, 0), so this volatility prices at or above the target
+	upper := (target - math.Min(moneyness, 0)) * math.Sqrt(2*math.Pi/timeToExpiration)
+	undiscounted := option
+	undiscounted.RiskFreeRate = 0
+	objective := func(vol float64) float64 {
+		return BachelierOptionPrice(undiscounted, vol) - target
+	}
+	vol, _, err := brent(objective, 0, upper, impliedTolerance*upper, impliedMaxIterations)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return vol, nil
+}
+
+// LognormalToNormalVol converts an at-the-money lognormal (Black) volatility to the normal (Bachelier) volatility
+// that gives the same at-the-money option price
+// forward: the forward price, which must be positive
+// lognormalVol: the lognormal volatility
+// timeYears: the time to expiration in years
+func LognormalToNormalVol(forward, lognormalVol, timeYears float64) float64 {
+	sqrtT := math.Sqrt(timeYears)
+	return forward * math.Sqrt(2*math.Pi) / sqrtT * (2*Phi(0.5*lognormalVol*sqrtT) - 1)
+}
+
+// NormalToLognormalVol converts an at-the-money normal (Bachelier) volatility to the lognormal (Black) volatility
+// that gives the same at-the-money option price. Returns NaN when no lognormal volatility can match the price,
+// which happens when the forward is not positive or the normal price exceeds the forward
+// forward: the forward price
+// normalVol: the normal volatility
+// timeYears: the time to expiration in years
+func NormalToLognormalVol(forward, normalVol, timeYears float64) float64 {
+	sqrtT := math.Sqrt(timeYears)
+	normalised := normalVol * sqrtT / (forward * math.Sqrt(2*math.Pi))
+	if !(forward > 0 && normalised >= 0 && normalised < 1) {
+		return math.NaN()
+	}
+	return 2 / sqrtT * PhiInv(0.5*(1+normalised))
+}
+
+// bachelierD computes the standardised moneyness d = (F − K)/(σ√T) of the Bachelier formula
+// option: the option, with UnderlyingPrice holding the forward price
+// normalVol: the normal volatility
+func bachelierD(option Option, normalVol float64) float64 {
+	return (option.UnderlyingPrice - option.Strike) / (normalVol * math.Sqrt(option.DaysToExpiration/365.0))
+}
@@ -0,0 +1,198 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBachelierOptionPrice(t *testing.T) {
+	const tolerance = 1e-12
+
+	tests := []struct {
+		forward, strike, vol float64
+		optionType           OptionType
+		expected             float64
+	}{
+		{-0.25, 0.1, 0.8, Call, 0.09116679925407015},
+		{-0.25, 0.1, 0.8, Put, 0.4359559781151421},
+		{2.5, 2.0, 0.9, Call, 0.5698378694061027},
+	}
+
+	for _, test := range tests {
+		option := Option{
+			Strike:           test.strike,
+			DaysToExpiration: 182.5,
+			RiskFreeRate:     0.03,
+			UnderlyingPrice:  test.forward,
+			OptionType:       test.optionType,
+		}
+
+		if price := BachelierOptionPrice(option, test.vol); math.Abs(price-test.expected) > tolerance {
+			t.Errorf("Unexpected price for forward %v strike %v type %v: got %v, want %v", test.forward, test.strike, test.optionType, price, test.expected)
+		}
+	}
+}
+
+func TestBachelierPutCallParity(t *testing.T) {
+	const tolerance = 1e-12
+
+	for _, forward := range []float64{-1.5, -0.1, 0.0, 0.4, 3.0} {
+		call := Option{
+			Strike:           -0.2,
+			DaysToExpiration: 90.0,
+			RiskFreeRate:     0.02,
+			UnderlyingPrice:  forward,
+			OptionType:       Call,
+		}
+		put := call
+		put.OptionType = Put
+
+		discount := math.Exp(-call.RiskFreeRate * call.DaysToExpiration / 365.0)
+		got := BachelierOptionPrice(call, 0.6) - BachelierOptionPrice(put, 0.6)
+		if want := discount * (forward - call.Strike); math.Abs(got-want) > tolerance {
+			t.Errorf("Unexpected parity for forward %v: got %v, want %v", forward, got, want)
+		}
+	}
+}
+
+func TestBachelierGreeks(t *testing.T) {
+	const h = 1e-5
+
+	for _, optionType := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           0.05,
+			DaysToExpiration: 120.0,
+			RiskFreeRate:     0.03,
+			UnderlyingPrice:  -0.1,
+			OptionType:       optionType,
+		}
+		const vol = 0.5
+
+		bump := func(f func(*Option)) float64 {
+			bumped := option
+			f(&bumped)
+			return BachelierOptionPrice(bumped, vol) - BachelierOptionPrice(option, vol)
+		}
+		up, down := option, option
+		up.UnderlyingPrice += h
+		down.UnderlyingPrice -= h
+		delta := (BachelierOptionPrice(up, vol) - BachelierOptionPrice(down, vol)) / (2 * h)
+		gamma := (BachelierOptionPrice(up, vol) - 2*BachelierOptionPrice(option, vol) + BachelierOptionPrice(down, vol)) / (h * h)
+		vega := (BachelierOptionPrice(option, vol+h) - BachelierOptionPrice(option, vol-h)) / (2 * h)
+		theta := -bump(func(o *Option) { o.DaysToExpiration += 1e-3 }) / (1e-3 / 365.0)
+		rho := bump(func(o *Option) { o.RiskFreeRate += h }) / h
+
+		checks := []struct {
+			name      string
+			got, want float64
+			tolerance float64
+		}{
+			{"delta", BachelierDelta(option, vol), delta, 1e-8},
+			{"gamma", BachelierGamma(option, vol), gamma, 1e-3},
+			{"vega", BachelierVega(option, vol), vega, 1e-8},
+			{"theta", BachelierTheta(option, vol), theta, 1e-3},
+			{"rho", BachelierRho(option, vol), rho, 1e-5},
+		}
+		for _, c := range checks {
+			if diff := math.Abs(c.got - c.want); diff > c.tolerance {
+				t.Errorf("Unexpected %s for type %v: got %v, want %v", c.name, optionType, c.got, c.want)
+			}
+		}
+	}
+}
+
+func TestBachelierImpliedVolatility(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, forward := range []float64{-0.5, 0.0, 0.02, 1.0} {
+			option := Option{
+				Strike:           0.01,
+				DaysToExpiration: 60.0,
+				RiskFreeRate:     0.04,
+				UnderlyingPrice:  forward,
+				OptionType:       optionType,
+			}
+			option.Price = BachelierOptionPrice(option, 0.75)
+
+			vol, err := BachelierImpliedVolatility(option)
+			if err != nil {
+				t.Errorf("Unexpected error for type %v forward %v: %v", optionType, forward, err)
+				continue
+			}
+			if diff := math.Abs(vol - 0.75); diff > 1e-8 {
+				t.Errorf("Unexpected implied volatility for type %v forward %v: got %v, want %v", optionType, forward, vol, 0.75)
+			}
+		}
+	}
+
+	option := Option{
+		Price:            0.5,
+		Strike:           -1.0,
+		DaysToExpiration: 60.0,
+		UnderlyingPrice:  0.0,
+		OptionType:       Call,
+	}
+	if _, err := BachelierImpliedVolatility(option); !errors.Is(err, ErrPriceOutOfBounds) {
+		t.Errorf("Unexpected error: got %v, want %v", err, ErrPriceOutOfBounds)
+	}
+
+	option.Price = 1.5
+	option.DaysToExpiration = 0
+	if _, err := BachelierImpliedVolatility(option); !errors.Is(err, ErrPriceOutOfBounds) {
+		t.Errorf("Unexpected error at expiration: got %v, want %v", err, ErrPriceOutOfBounds)
+	}
+	option.DaysToExpiration = -1
+	if _, err := BachelierImpliedVolatility(option); !errors.Is(err, ErrNegativeExpiry) {
+		t.Errorf("Unexpected error for negative days to expiration: got %v, want %v", err, ErrNegativeExpiry)
+	}
+}
+
+func TestNormalLognormalVolConversion(t *testing.T) {
+	const forward, timeYears = 0.035, 2.0
+
+	normalVol := LognormalToNormalVol(forward, 0.3, timeYears)
+	// for small volatilities the normal volatility is close to the lognormal volatility times the forward
+	if diff := math.Abs(normalVol - 0.3*forward); diff > 1e-2*forward {
+		t.Errorf("Unexpected normal volatility: got %v, want about %v", normalVol, 0.3*forward)
+	}
+
+	atm := Option{
+		Strike:           forward,
+		DaysToExpiration: timeYears * 365.0,
+		RiskFreeRate:     0.02,
+		UnderlyingPrice:  forward,
+		OptionType:       Call,
+	}
+	if got, want := BachelierOptionPrice(atm, normalVol), Black76OptionPrice(atm, 0.3); math.Abs(got-want) > 1e-14 {
+		t.Errorf("Unexpected at-the-money price: got %v, want %v", got, want)
+	}
+
+	if lognormalVol := NormalToLognormalVol(forward, normalVol, timeYears); math.Abs(lognormalVol-0.3) > 1e-10 {
+		t.Errorf("Unexpected lognormal volatility: got %v, want %v", lognormalVol, 0.3)
+	}
+	if lognormalVol := NormalToLognormalVol(-forward, normalVol, timeYears); !math.IsNaN(lognormalVol) {
+		t.Errorf("Unexpected lognormal volatility for negative forward: got %v, want NaN", lognormalVol)
+	}
+}
+
+func TestBachelierAtExpiration(t *testing.T) {
+	for _, forward := range []float64{-0.5, 0.0, 0.5} {
+		option := Option{
+			Strike:           0.0,
+			DaysToExpiration: 0.0,
+			RiskFreeRate:     0.03,
+			UnderlyingPrice:  forward,
+			OptionType:       Call,
+		}
+
+		if got, want := BachelierOptionPrice(option, 0.5), math.Max(forward, 0); got != want {
+			t.Errorf("Unexpected price for forward %v: got %v, want %v", forward, got, want)
+		}
+		if got, want := BachelierDelta(option, 0.5), expirationDelta(option); got != want {
+			t.Errorf("Unexpected delta for forward %v: got %v, want %v", forward, got, want)
+		}
+		if got := BachelierGamma(option, 0.5); got != 0 {
+			t.Errorf("Unexpected gamma for forward %v: got %v, want 0", forward, got)
+		}
+	}
+}
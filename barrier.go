@@ -0,0 +1,238 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidBarrier is returned for a barrier option with a barrier that is not positive and finite, a rebate
+// that is negative or not finite, or an unknown barrier kind
+var ErrInvalidBarrier = errors.New("finance: invalid barrier option")
+
+// BarrierKind is the direction of a single barrier and whether touching it knocks the option in or out
+type BarrierKind int
+
+const (
+	DownAndOut BarrierKind = iota // Knocked out if the underlying falls to the barrier
+	DownAndIn                     // Knocked in if the underlying falls to the barrier
+	UpAndOut                      // Knocked out if the underlying rises to the barrier
+	UpAndIn                       // Knocked in if the underlying rises to the barrier
+)
+
+// BarrierOptionPrice prices a European option with a single barrier monitored continuously, by the closed forms of
+// Merton (1973) and Reiner and Rubinstein (1991). A knock-out option pays the rebate as soon as the barrier is hit,
+// and a knock-in option pays it at expiration if the barrier was never hit; with no rebate, a knock-in and a
+// knock-out option with the same barrier add up to the vanilla option. If the underlying price is already at or
+// beyond the barrier, the barrier has been hit: a knock-out option is worth the rebate and a knock-in option the
+// vanilla price. Otherwise, at expiration a knock-out option is worth its intrinsic value and a knock-in option the
+// rebate. Discrete dividends are escrowed, the barrier then applying to the escrowed underlying price
+// Returns the errors of BlackScholesOptionPriceE for invalid inputs and ErrInvalidBarrier for an invalid barrier,
+// rebate or kind
+// option: the option
+// vol: the volatility
+// barrier: the barrier
+// rebate: the cash rebate, zero for none
+// kind: the kind of barrier
+func BarrierOptionPrice(option Option, vol, barrier, rebate float64, kind BarrierKind) (float64, error) {
+	if err := validatePricing(option, vol); err != nil {
+		return math.NaN(), err
+	}
+	switch {
+	case !(barrier > 0) || math.IsInf(barrier, 1):
+		return math.NaN(), fmt.Errorf("%w: barrier %v", ErrInvalidBarrier, barrier)
+	case !(rebate >= 0) || math.IsInf(rebate, 1):
+		return math.NaN(), fmt.Errorf("%w: rebate %v", ErrInvalidBarrier, rebate)
+	case kind < DownAndOut || kind > UpAndIn:
+		return math.NaN(), fmt.Errorf("%w: unknown kind %d", ErrInvalidBarrier, kind)
+	}
+	option = escrowed(option)
+	down, out := kind == DownAndOut || kind == DownAndIn, kind == DownAndOut || kind == UpAndOut
+	spot := option.UnderlyingPrice
+	if (down && spot <= barrier) || (!down && spot >= barrier) {
+		if out {
+			return rebate, nil
+		}
+		return BlackScholesOptionPrice(option, vol), nil
+	}
+	if option.DaysToExpiration == 0 {
+		if out {
+			return option.IntrinsicValue(), nil
+		}
+		return rebate, nil
+	}
+
+	// Haug's building blocks, with eta 1 for a down and -1 for an up barrier and phi 1 for a call and -1 for a put
+	timeToExpiration := option.DaysToExpiration / 365.0
+	strike, rate, carry := option.Strike, option.RiskFreeRate, option.RiskFreeRate-option.DividendYield
+	eta, phi := 1.0, 1.0
+	if !down {
+		eta = -1
+	}
+	if option.OptionType == Put {
+		phi = -1
+	}
+	stdDev := vol * math.Sqrt(timeToExpiration)
+	mu := carry/(vol*vol) - 0.5
+	ratio := barrier / spot
+	discountedSpot := spot * math.Exp((carry-rate)*timeToExpiration)
+	discountedStrike := strike * math.Exp(-rate*timeToExpiration)
+
+	x1 := math.Log(spot/strike)/stdDev + (1+mu)*stdDev
+	x2 := math.Log(spot/barrier)/stdDev + (1+mu)*stdDev
+	y1 := math.Log(barrier*barrier/(spot*strike))/stdDev + (1+mu)*stdDev
+	y2 := math.Log(barrier/spot)/stdDev + (1+mu)*stdDev
+	vanilla := func(x float64) float64 {
+		return phi*discountedSpot*Phi(phi*x) - phi*discountedStrike*Phi(phi*(x-stdDev))
+	}
+	reflected := func(y float64) float64 {
+		return phi*discountedSpot*math.Pow(ratio, 2*(mu+1))*Phi(eta*y) - phi*discountedStrike*math.Pow(ratio, 2*mu)*Phi(eta*(y-stdDev))
+	}
+	a, b, c, d := vanilla(x1), vanilla(x2), reflected(y1), reflected(y2)
+
+	var price float64
+	above := strike > barrier
+	switch {
+	case kind == DownAndIn && option.OptionType == Call, kind == UpAndIn && option.OptionType == Put:
+		if above == down {
+			price = c
+		} else {
+			price = a - b + d
+		}
+	case kind == UpAndIn && option.OptionType == Call, kind == DownAndIn && option.OptionType == Put:
+		if above == down {
+			price = b - c + d
+		} else {
+			price = a
+		}
+	case kind == DownAndOut && option.OptionType == Call, kind == UpAndOut && option.OptionType == Put:
+		if above == down {
+			price = a - c
+		} else {
+			price = b - d
+		}
+	default: // up-and-out calls and down-and-out puts
+		if above == down {
+			price = a - b + c - d
+		} else {
+			price = 0
+		}
+	}
+
+	if rebate == 0 {
+		return price, nil
+	}
+	if !out {
+		// the rebate at expiration, times the probability of never reaching the barrier
+		return price + rebate*math.Exp(-rate*timeToExpiration)*(Phi(eta*(x2-stdDev))-math.Pow(ratio, 2*mu)*Phi(eta*(y2-stdDev))), nil
+	}
+	// the rebate at the first passage time, discounted over its distribution
+	lambda := math.Sqrt(mu*mu + 2*rate/(vol*vol))
+	z := math.Log(barrier/spot)/stdDev + lambda*stdDev
+	return price + rebate*(math.Pow(ratio, mu+lambda)*Phi(eta*z)+math.Pow(ratio, mu-lambda)*Phi(eta*(z-2*lambda*stdDev))), nil
+}
+
+// DoubleBarrierKind is whether leaving the corridor between two barriers knocks an option in or out
+type DoubleBarrierKind int
+
+const (
+	DoubleKnockOut DoubleBarrierKind = iota // Knocked out if the underlying reaches either barrier
+	DoubleKnockIn                           // Knocked in if the underlying reaches either barrier
+)
+
+// doubleBarrierTerms is the default number of terms either side of the central one of the Ikeda-Kunitomo series
+const doubleBarrierTerms = 10
+
+// DoubleBarrierPrice prices a European option with two flat barriers monitored continuously, by the Ikeda-Kunitomo
+// (1992) series of images reflected in both barriers, summed from −terms to terms. The series converges very fast,
+// five terms being plenty unless the corridor is narrow next to the volatility over the life of the option. A
+// knock-in option is priced as the vanilla option less the knock-out one. If the underlying price is not strictly
+// inside the corridor, a barrier has been hit: a knock-out option is worth 0 and a knock-in option the vanilla
+// price. Otherwise, at expiration a knock-out option is worth its intrinsic value and a knock-in option 0. Discrete
+// dividends are escrowed, the barriers then applying to the escrowed underlying price. NaN for invalid barriers, not
+// positive and increasing, or an unknown kind
+// option: the option
+// vol: the volatility
+// lower: the lower barrier
+// upper: the upper barrier
+// kind: the kind of barrier
+// terms: the number of terms either side of the central one, 10 if not positive
+func DoubleBarrierPrice(option Option, vol, lower, upper float64, kind DoubleBarrierKind, terms int) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration < 0 || !(lower > 0 && lower < upper) || math.IsInf(upper, 1) ||
+		(kind != DoubleKnockOut && kind != DoubleKnockIn) {
+		return math.NaN()
+	}
+	if terms <= 0 {
+		terms = doubleBarrierTerms
+	}
+	spot := option.UnderlyingPrice
+	if spot <= lower || spot >= upper {
+		if kind == DoubleKnockOut {
+			return 0
+		}
+		return BlackScholesOptionPrice(option, vol)
+	}
+	if option.DaysToExpiration == 0 {
+		if kind == DoubleKnockOut {
+			return option.IntrinsicValue()
+		}
+		return 0
+	}
+
+	knockOut := doubleKnockOutPrice(option, vol, lower, upper, terms)
+	if kind == DoubleKnockIn {
+		return BlackScholesOptionPrice(option, vol) - knockOut
+	}
+	return knockOut
+}
+
+// doubleKnockOutPrice sums the Ikeda-Kunitomo series for a double knock-out option on an underlying strictly inside
+// the corridor. The option pays off where the underlying ends between the strike and the far barrier, the nearer of
+// the strike and the near barrier bounding the payoff region
+// option: the option, with any discrete dividends already escrowed and positive days to expiration
+// vol: the volatility
+// lower: the lower barrier
+// upper: the upper barrier
+// terms: the number of terms either side of the central one
+func doubleKnockOutPrice(option Option, vol, lower, upper float64, terms int) float64 {
+	timeToExpiration := option.DaysToExpiration / 365.0
+	strike, carry := option.Strike, option.RiskFreeRate-option.DividendYield
+	// the payoff region [from, to] in the underlying price at expiration
+	from, to := math.Max(strike, lower), upper
+	if option.OptionType == Put {
+		from, to = lower, math.Min(strike, upper)
+	}
+	if from >= to {
+		return 0
+	}
+
+	stdDev := vol * math.Sqrt(timeToExpiration)
+	mu := 2*carry/(vol*vol) + 1
+	logSpot, logLower, logUpper := math.Log(option.UnderlyingPrice), math.Log(lower), math.Log(upper)
+	logFrom, logTo := math.Log(from), math.Log(to)
+	d := func(logMoneyness float64) float64 {
+		return (logMoneyness + (carry+0.5*vol*vol)*timeToExpiration) / stdDev
+	}
+
+	// assetSum and cashSum are the probabilities, under the share and money-market measures, of ending in the payoff
+	// region without touching either barrier
+	var assetSum, cashSum float64
+	for n := -terms; n <= terms; n++ {
+		k := float64(n)
+		shift := 2 * k * (logUpper - logLower)                    // log of (U/L)^{2n}
+		reflection := 2*(k+1)*logLower - 2*k*logUpper - 2*logSpot // log of (L^{n+1}/(U^n·S))²
+		d1, d2 := d(logSpot+shift-logFrom), d(logSpot+shift-logTo)
+		d3, d4 := d(reflection+logSpot-logFrom), d(reflection+logSpot-logTo)
+		direct := k * (logUpper - logLower)
+		image := reflection / 2
+		assetSum += math.Exp(mu*direct)*(Phi(d1)-Phi(d2)) - math.Exp(mu*image)*(Phi(d3)-Phi(d4))
+		cashSum += math.Exp((mu-2)*direct)*(Phi(d1-stdDev)-Phi(d2-stdDev)) - math.Exp((mu-2)*image)*(Phi(d3-stdDev)-Phi(d4-stdDev))
+	}
+	discountedSpot := option.UnderlyingPrice * math.Exp(-option.DividendYield*timeToExpiration)
+	discountedStrike := strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	if option.OptionType == Put {
+		return discountedStrike*cashSum - discountedSpot*assetSum
+	}
+	return discountedSpot*assetSum - discountedStrike*cashSum
+}
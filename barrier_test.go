@@ -0,0 +1,278 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBarrierOptionPriceHaug(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas, standard barrier options with a rebate of 3
+	tests := []struct {
+		kind       BarrierKind
+		optionType OptionType
+		barrier    float64
+		want       [3]float64 // at strikes 90, 100 and 110
+	}{
+		{DownAndOut, Call, 95.0, [3]float64{9.0246, 6.7924, 4.8759}},
+		{DownAndIn, Call, 95.0, [3]float64{7.7627, 4.0109, 2.0576}},
+		{UpAndIn, Call, 105.0, [3]float64{14.1112, 8.4482, 4.5910}},
+		{UpAndOut, Call, 105.0, [3]float64{2.6789, 2.3580, 2.3453}},
+		{DownAndIn, Put, 95.0, [3]float64{2.9586, 6.5677, 11.9752}},
+		{UpAndIn, Put, 105.0, [3]float64{1.4653, 3.3721, 7.0846}},
+		{DownAndOut, Put, 95.0, [3]float64{2.2798, 2.2947, 2.6252}},
+		{UpAndOut, Put, 105.0, [3]float64{3.7760, 5.4932, 7.5187}},
+	}
+
+	for _, test := range tests {
+		for i, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 0.5 * 365,
+				RiskFreeRate:     0.08,
+				UnderlyingPrice:  100.0,
+				OptionType:       test.optionType,
+				DividendYield:    0.04,
+			}
+			got, err := BarrierOptionPrice(option, 0.25, test.barrier, 3.0, test.kind)
+			if err != nil {
+				t.Fatalf("Unexpected error for kind %d type %v strike %v: %v", test.kind, test.optionType, strike, err)
+			}
+			if math.Abs(got-test.want[i]) > 5e-5 {
+				t.Errorf("Unexpected price for kind %d type %v strike %v: got %v, want %v", test.kind, test.optionType, strike, got, test.want[i])
+			}
+		}
+	}
+}
+
+func TestBarrierOptionPriceInOutParity(t *testing.T) {
+	const tolerance = 1e-10
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{80.0, 100.0, 120.0} {
+			for _, barrier := range []float64{85.0, 95.0, 105.0, 115.0} {
+				option := Option{
+					Strike:           strike,
+					DaysToExpiration: 270.0,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  100.0,
+					OptionType:       optionType,
+					DividendYield:    0.02,
+				}
+				in, out := DownAndIn, DownAndOut
+				if barrier > option.UnderlyingPrice {
+					in, out = UpAndIn, UpAndOut
+				}
+				knockIn, _ := BarrierOptionPrice(option, 0.3, barrier, 0, in)
+				knockOut, _ := BarrierOptionPrice(option, 0.3, barrier, 0, out)
+				if want := BlackScholesOptionPrice(option, 0.3); math.Abs(knockIn+knockOut-want) > tolerance {
+					t.Errorf("Unexpected in-out parity for type %v strike %v barrier %v: got %v + %v, want %v", optionType, strike, barrier, knockIn, knockOut, want)
+				}
+				if knockIn < 0 || knockOut < 0 {
+					t.Errorf("Unexpected negative price for type %v strike %v barrier %v: got %v and %v", optionType, strike, barrier, knockIn, knockOut)
+				}
+			}
+		}
+	}
+}
+
+func TestBarrierOptionPriceBreached(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	vanilla := BlackScholesOptionPrice(option, 0.2)
+
+	checks := []struct {
+		name    string
+		barrier float64
+		kind    BarrierKind
+		want    float64
+	}{
+		{"up-and-out below spot", 95.0, UpAndOut, 2.5},
+		{"down-and-out above spot", 105.0, DownAndOut, 2.5},
+		{"down-and-out at spot", 100.0, DownAndOut, 2.5},
+		{"up-and-in below spot", 95.0, UpAndIn, vanilla},
+		{"down-and-in above spot", 105.0, DownAndIn, vanilla},
+	}
+	for _, c := range checks {
+		if got, err := BarrierOptionPrice(option, 0.2, c.barrier, 2.5, c.kind); err != nil || got != c.want {
+			t.Errorf("Unexpected price for %s: got %v, %v, want %v", c.name, got, err, c.want)
+		}
+	}
+
+	// at expiration without a hit, a knock-out option pays its intrinsic value and a knock-in option the rebate
+	option.DaysToExpiration = 0
+	option.UnderlyingPrice = 104.0
+	if got, _ := BarrierOptionPrice(option, 0.2, 110.0, 2.5, UpAndOut); got != 4.0 {
+		t.Errorf("Unexpected up-and-out price at expiration: got %v, want 4", got)
+	}
+	if got, _ := BarrierOptionPrice(option, 0.2, 110.0, 2.5, UpAndIn); got != 2.5 {
+		t.Errorf("Unexpected up-and-in price at expiration: got %v, want 2.5", got)
+	}
+}
+
+func TestBarrierOptionPriceInvalid(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	for _, test := range []struct {
+		name            string
+		barrier, rebate float64
+		kind            BarrierKind
+	}{
+		{"zero barrier", 0, 0, DownAndOut},
+		{"NaN barrier", math.NaN(), 0, DownAndOut},
+		{"infinite barrier", math.Inf(1), 0, UpAndOut},
+		{"negative rebate", 90.0, -1, DownAndOut},
+		{"NaN rebate", 90.0, math.NaN(), DownAndOut},
+		{"unknown kind", 90.0, 0, BarrierKind(4)},
+	} {
+		if got, err := BarrierOptionPrice(option, 0.2, test.barrier, test.rebate, test.kind); !errors.Is(err, ErrInvalidBarrier) || !math.IsNaN(got) {
+			t.Errorf("Expected ErrInvalidBarrier for %s: got %v, %v", test.name, got, err)
+		}
+	}
+	if _, err := BarrierOptionPrice(option, 0, 90.0, 0, DownAndOut); !errors.Is(err, ErrNonPositiveVolatility) {
+		t.Errorf("Expected ErrNonPositiveVolatility: got %v", err)
+	}
+}
+
+func TestDoubleBarrierPriceHaug(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas, double knock-out calls with flat barriers
+	tests := []struct {
+		lower, upper float64
+		want         [2]float64 // at volatilities 0.15 and 0.25
+	}{
+		{50.0, 150.0, [2]float64{4.3515, 6.1644}},
+		{60.0, 140.0, [2]float64{4.3505, 5.8500}},
+		{70.0, 130.0, [2]float64{4.3139, 4.8293}},
+		{80.0, 120.0, [2]float64{3.7516, 2.6387}},
+		{90.0, 110.0, [2]float64{1.2055, 0.3098}},
+	}
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 0.25 * 365,
+		RiskFreeRate:     0.1,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	for _, test := range tests {
+		for i, vol := range []float64{0.15, 0.25} {
+			if got := DoubleBarrierPrice(option, vol, test.lower, test.upper, DoubleKnockOut, 0); math.Abs(got-test.want[i]) > 1e-4 {
+				t.Errorf("Unexpected price for corridor [%v, %v] vol %v: got %v, want %v", test.lower, test.upper, vol, got, test.want[i])
+			}
+		}
+	}
+}
+
+func TestDoubleBarrierPriceStrikes(t *testing.T) {
+	// reference values from a fine Crank-Nicolson grid between the barriers, with the strike inside and outside them
+	tests := []struct {
+		optionType OptionType
+		strike     float64
+		want       float64
+	}{
+		{Call, 100.0, 1.41636451},
+		{Call, 85.0, 6.54018166},
+		{Call, 75.0, 11.29814336},
+		{Call, 125.0, 0},
+		{Put, 100.0, 2.12008847},
+		{Put, 115.0, 7.92693425},
+		{Put, 125.0, 12.70559128},
+		{Put, 75.0, 0},
+	}
+
+	for _, test := range tests {
+		option := Option{
+			Strike:           test.strike,
+			DaysToExpiration: 0.5 * 365,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       test.optionType,
+			DividendYield:    0.02,
+		}
+		if got := DoubleBarrierPrice(option, 0.25, 80.0, 120.0, DoubleKnockOut, 0); math.Abs(got-test.want) > 5e-5 {
+			t.Errorf("Unexpected price for type %v strike %v: got %v, want %v", test.optionType, test.strike, got, test.want)
+		}
+	}
+}
+
+func TestDoubleBarrierPriceConvergence(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 180.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       optionType,
+			DividendYield:    0.02,
+		}
+
+		// the series is stable after a handful of terms
+		converged := DoubleBarrierPrice(option, 0.25, 80.0, 120.0, DoubleKnockOut, 50)
+		for terms := 5; terms <= 10; terms++ {
+			if got := DoubleBarrierPrice(option, 0.25, 80.0, 120.0, DoubleKnockOut, terms); math.Abs(got-converged) > 1e-12 {
+				t.Errorf("Unexpected price for type %v with %d terms: got %v, want %v", optionType, terms, got, converged)
+			}
+		}
+
+		// a very wide corridor is never reached, and knock-in and knock-out options add up to the vanilla option
+		vanilla := BlackScholesOptionPrice(option, 0.25)
+		if got := DoubleBarrierPrice(option, 0.25, 1.0, 10000.0, DoubleKnockOut, 0); math.Abs(got-vanilla) > 1e-10 {
+			t.Errorf("Unexpected price for type %v in a wide corridor: got %v, want %v", optionType, got, vanilla)
+		}
+		knockIn := DoubleBarrierPrice(option, 0.25, 80.0, 120.0, DoubleKnockIn, 0)
+		if math.Abs(knockIn+converged-vanilla) > 1e-10 {
+			t.Errorf("Unexpected in-out parity for type %v: got %v + %v, want %v", optionType, knockIn, converged, vanilla)
+		}
+	}
+}
+
+func TestDoubleBarrierPriceEdgeCases(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	vanilla := BlackScholesOptionPrice(option, 0.2)
+
+	if got := DoubleBarrierPrice(option, 0.2, 100.0, 120.0, DoubleKnockOut, 0); got != 0 {
+		t.Errorf("Unexpected knock-out price on the lower barrier: got %v, want 0", got)
+	}
+	if got := DoubleBarrierPrice(option, 0.2, 80.0, 95.0, DoubleKnockIn, 0); got != vanilla {
+		t.Errorf("Unexpected knock-in price above the upper barrier: got %v, want %v", got, vanilla)
+	}
+	for name, corridor := range map[string][2]float64{
+		"inverted":       {120.0, 80.0},
+		"zero lower":     {0, 120.0},
+		"infinite upper": {80.0, math.Inf(1)},
+		"NaN lower":      {math.NaN(), 120.0},
+	} {
+		if got := DoubleBarrierPrice(option, 0.2, corridor[0], corridor[1], DoubleKnockOut, 0); !math.IsNaN(got) {
+			t.Errorf("Unexpected price for a %s corridor: got %v, want NaN", name, got)
+		}
+	}
+	if got := DoubleBarrierPrice(option, 0.2, 80.0, 120.0, DoubleBarrierKind(2), 0); !math.IsNaN(got) {
+		t.Errorf("Unexpected price for an unknown kind: got %v, want NaN", got)
+	}
+
+	option.DaysToExpiration = 0
+	option.UnderlyingPrice = 110.0
+	if got := DoubleBarrierPrice(option, 0.2, 80.0, 120.0, DoubleKnockOut, 0); got != 10.0 {
+		t.Errorf("Unexpected knock-out price at expiration: got %v, want 10", got)
+	}
+	if got := DoubleBarrierPrice(option, 0.2, 80.0, 120.0, DoubleKnockIn, 0); got != 0 {
+		t.Errorf("Unexpected knock-in price at expiration: got %v, want 0", got)
+	}
+}
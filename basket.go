@@ -0,0 +1,177 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+var (
+	// ErrInvalidBasket is returned for a basket whose weights, spots and volatilities differ in number or are
+	// not finite, with spots or volatilities not positive, or priced with an invalid strike, time or method
+	ErrInvalidBasket = errors.New("finance: invalid basket")
+	// ErrInvalidCorrelation is returned for a correlation matrix that does not match the basket, is not symmetric
+	// with a unit diagonal and entries in [-1, 1], or is not positive semi-definite
+	ErrInvalidCorrelation = errors.New("finance: invalid correlation matrix")
+)
+
+// BasketMethod selects how BasketOptionPrice prices a basket option
+type BasketMethod int
+
+const (
+	BasketMomentMatching BasketMethod = iota // Levy's lognormal approximation with the basket's first two moments; the default
+	BasketMonteCarlo                         // Simulation of the correlated assets at expiration
+)
+
+const (
+	basketPaths = 1 << 18 // Number of paths, in antithetic pairs, simulated by BasketMonteCarlo
+	basketSeed  = 1       // Seed of the generator of BasketMonteCarlo, fixed so that prices are reproducible
+
+	correlationTolerance = 1e-10 // Tolerance on the symmetry and the eigenvalues of a correlation matrix
+)
+
+// BasketOptionPrice prices a European option on a weighted sum of assets with correlated lognormal prices, with
+// payoff (Σ wᵢ·Sᵢ − K)⁺ for a call and (K − Σ wᵢ·Sᵢ)⁺ for a put. BasketMomentMatching takes the basket to be
+// lognormal with its exact mean Σ wᵢ·Fᵢ and second moment Σ wᵢ·wⱼ·Fᵢ·Fⱼ·e^{ρᵢⱼσᵢσⱼT}, Fᵢ being the forwards, and
+// prices it with the Black formula; it is exact for a single asset or perfectly correlated assets of equal
+// volatility, and within about 1% of the price for typical equity baskets, less accurate as the assets' volatilities
+// grow apart. BasketMonteCarlo simulates the assets at expiration, correlated through the Cholesky factor of the
+// correlation matrix, with antithetic pairs from a fixed seed, to a standard error of a few thousandths of the price
+// for checking the approximation; it also prices baskets with negative weights, which the lognormal approximation
+// cannot when the basket's forward is not positive. At expiration the option is worth its payoff
+// Returns ErrInvalidBasket for inconsistent or invalid inputs and ErrInvalidCorrelation for an invalid correlation
+// matrix, naming the most negative eigenvalue if it is not positive semi-definite
+// weights: the quantity of each asset in the basket
+// spots: the price of each asset
+// vols: the volatility of each asset
+// corr: the correlation matrix of the returns of the assets
+// strike: the strike price
+// r: the risk-free interest rate
+// q: the continuous dividend yield, common to every asset
+// timeYears: the time to expiration in years
+// typ: the type of the option
+// method: the pricing method
+func BasketOptionPrice(weights, spots, vols []float64, corr [][]float64, strike, r, q, timeYears float64, typ OptionType, method BasketMethod) (float64, error) {
+	if err := validateBasket(weights, spots, vols, corr); err != nil {
+		return math.NaN(), err
+	}
+	switch {
+	case !(strike > 0) || math.IsInf(strike, 1):
+		return math.NaN(), fmt.Errorf("%w: strike %v", ErrInvalidBasket, strike)
+	case !(timeYears >= 0) || math.IsInf(timeYears, 1):
+		return math.NaN(), fmt.Errorf("%w: time to expiration %v", ErrInvalidBasket, timeYears)
+	case math.IsNaN(r) || math.IsInf(r, 0) || math.IsNaN(q) || math.IsInf(q, 0):
+		return math.NaN(), ErrInvalidRate
+	case method != BasketMomentMatching && method != BasketMonteCarlo:
+		return math.NaN(), fmt.Errorf("%w: unknown method %d", ErrInvalidBasket, method)
+	}
+
+	payoff := func(basket float64) float64 {
+		if typ == Call {
+			return math.Max(basket-strike, 0)
+		}
+		return math.Max(strike-basket, 0)
+	}
+	if timeYears == 0 {
+		var basket float64
+		for i, weight := range weights {
+			basket += weight * spots[i]
+		}
+		return payoff(basket), nil
+	}
+
+	growth := math.Exp((r - q) * timeYears)
+	if method == BasketMomentMatching {
+		var first, second float64
+		for i, weight := range weights {
+			first += weight * spots[i] * growth
+			for j, other := range weights {
+				second += weight * other * spots[i] * spots[j] * growth * growth * math.Exp(corr[i][j]*vols[i]*vols[j]*timeYears)
+			}
+		}
+		if !(first > 0) {
+			return math.NaN(), fmt.Errorf("%w: basket forward %v not positive for moment matching", ErrInvalidBasket, first)
+		}
+		vol := math.Sqrt(math.Max(math.Log(second/(first*first)), 0) / timeYears)
+		return BlackScholesForwardPrice(first, strike, timeYears, r, vol, typ), nil
+	}
+
+	// each path and its mirror image, driven by the negated draws
+	n := len(weights)
+	lower := cholesky(corr)
+	drift := make([]float64, n)
+	for i, vol := range vols {
+		drift[i] = (r - q - 0.5*vol*vol) * timeYears
+	}
+	rng := rand.New(rand.NewPCG(basketSeed, splitMix64(basketSeed)))
+	draws, correlated := make([]float64, n), make([]float64, n)
+	var sum float64
+	for range basketPaths / 2 {
+		for i := range draws {
+			draws[i] = rng.NormFloat64()
+		}
+		for i, row := range lower {
+			correlated[i] = 0
+			for k := 0; k <= i; k++ {
+				correlated[i] += row[k] * draws[k]
+			}
+		}
+		for _, sign := range []float64{1, -1} {
+			var basket float64
+			for i, weight := range weights {
+				basket += weight * spots[i] * math.Exp(drift[i]+sign*vols[i]*math.Sqrt(timeYears)*correlated[i])
+			}
+			sum += payoff(basket)
+		}
+	}
+	return math.Exp(-r*timeYears) * sum / basketPaths, nil
+}
+
+// validateBasket checks that the weights, spots and volatilities of a basket agree in number and are valid, and that
+// the correlation matrix matches them and is a correlation matrix
+// weights: the quantity of each asset in the basket
+// spots: the price of each asset
+// vols: the volatility of each asset
+// corr: the correlation matrix
+func validateBasket(weights, spots, vols []float64, corr [][]float64) error {
+	n := len(weights)
+	switch {
+	case n == 0:
+		return fmt.Errorf("%w: no assets", ErrInvalidBasket)
+	case len(spots) != n || len(vols) != n:
+		return fmt.Errorf("%w: %d weights, %d spots and %d volatilities", ErrInvalidBasket, n, len(spots), len(vols))
+	case len(corr) != n:
+		return fmt.Errorf("%w: %d rows for %d assets", ErrInvalidCorrelation, len(corr), n)
+	}
+	for i := range n {
+		switch {
+		case math.IsNaN(weights[i]) || math.IsInf(weights[i], 0):
+			return fmt.Errorf("%w: weight %v of asset %d", ErrInvalidBasket, weights[i], i)
+		case !(spots[i] > 0) || math.IsInf(spots[i], 1):
+			return fmt.Errorf("%w: spot %v of asset %d", ErrInvalidBasket, spots[i], i)
+		case !(vols[i] > 0) || math.IsInf(vols[i], 1):
+			return fmt.Errorf("%w: volatility %v of asset %d", ErrInvalidBasket, vols[i], i)
+		case len(corr[i]) != n:
+			return fmt.Errorf("%w: %d entries in row %d for %d assets", ErrInvalidCorrelation, len(corr[i]), i, n)
+		}
+	}
+	for i := range n {
+		if corr[i][i] != 1 {
+			return fmt.Errorf("%w: diagonal entry %v in row %d", ErrInvalidCorrelation, corr[i][i], i)
+		}
+		for j := range i {
+			if !(math.Abs(corr[i][j]) <= 1) || math.Abs(corr[i][j]-corr[j][i]) > correlationTolerance {
+				return fmt.Errorf("%w: entries %v and %v at (%d, %d)", ErrInvalidCorrelation, corr[i][j], corr[j][i], i, j)
+			}
+		}
+	}
+	eigenvalue := math.Inf(1)
+	for _, value := range symmetricEigenvalues(corr) {
+		eigenvalue = math.Min(eigenvalue, value)
+	}
+	if eigenvalue < -correlationTolerance {
+		return fmt.Errorf("%w: not positive semi-definite, with eigenvalue %v", ErrInvalidCorrelation, eigenvalue)
+	}
+	return nil
+}
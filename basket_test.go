@@ -0,0 +1,123 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBasketOptionPrice(t *testing.T) {
+	weights := []float64{0.5, 0.3, 0.2}
+	spots := []float64{100.0, 50.0, 80.0}
+	vols := []float64{0.2, 0.3, 0.25}
+	corr := [][]float64{
+		{1, 0.6, 0.4},
+		{0.6, 1, 0.5},
+		{0.4, 0.5, 1},
+	}
+	// the basket is worth 81 today
+	for _, typ := range []OptionType{Call, Put} {
+		for _, strike := range []float64{75.0, 81.0, 90.0} {
+			approximate, err := BasketOptionPrice(weights, spots, vols, corr, strike, 0.04, 0.01, 1, typ, BasketMomentMatching)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			simulated, err := BasketOptionPrice(weights, spots, vols, corr, strike, 0.04, 0.01, 1, typ, BasketMonteCarlo)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if math.Abs(approximate-simulated) > 0.01*simulated {
+				t.Errorf("Unexpected moment-matching price for type %v strike %v: got %v, simulated %v", typ, strike, approximate, simulated)
+			}
+		}
+	}
+
+	// a single asset, or perfectly correlated assets of equal volatility, are exactly lognormal
+	option := Option{Strike: 100.0, DaysToExpiration: 365.0, RiskFreeRate: 0.04, UnderlyingPrice: 120.0, OptionType: Call, DividendYield: 0.01}
+	want := BlackScholesOptionPrice(option, 0.3)
+	checks := []struct {
+		name    string
+		weights []float64
+		spots   []float64
+		vols    []float64
+		corr    [][]float64
+	}{
+		{"single asset", []float64{2}, []float64{60.0}, []float64{0.3}, [][]float64{{1}}},
+		{"comonotonic assets", []float64{1, 2}, []float64{40.0, 40.0}, []float64{0.3, 0.3}, [][]float64{{1, 1}, {1, 1}}},
+	}
+	for _, c := range checks {
+		got, err := BasketOptionPrice(c.weights, c.spots, c.vols, c.corr, 100.0, 0.04, 0.01, 1, Call, BasketMomentMatching)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", c.name, err)
+		}
+		if math.Abs(got-want) > 1e-10 {
+			t.Errorf("Unexpected moment-matching price for %s: got %v, want %v", c.name, got, want)
+		}
+		got, err = BasketOptionPrice(c.weights, c.spots, c.vols, c.corr, 100.0, 0.04, 0.01, 1, Call, BasketMonteCarlo)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", c.name, err)
+		}
+		if math.Abs(got-want) > 0.005*want {
+			t.Errorf("Unexpected simulated price for %s: got %v, want %v", c.name, got, want)
+		}
+	}
+
+	// a long-short basket has no lognormal approximation but can be simulated
+	dispersion := []float64{1, -1}
+	pair := [][]float64{{1, 0.8}, {0.8, 1}}
+	if _, err := BasketOptionPrice(dispersion, []float64{100.0, 100.0}, []float64{0.2, 0.3}, pair, 5.0, 0.04, 0.01, 1, Call, BasketMomentMatching); !errors.Is(err, ErrInvalidBasket) {
+		t.Errorf("Unexpected error for a long-short basket: got %v, want %v", err, ErrInvalidBasket)
+	}
+	got, err := BasketOptionPrice(dispersion, []float64{100.0, 100.0}, []float64{0.2, 0.3}, pair, 5.0, 0.04, 0.01, 1, Call, BasketMonteCarlo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := KirkSpreadOptionPrice(100.0*math.Exp(0.03), 100.0*math.Exp(0.03), 5.0, 0.2, 0.3, 0.8, 0.04, 1, Call); math.Abs(got-want) > 0.02*want {
+		t.Errorf("Unexpected simulated spread price: got %v, Kirk %v", got, want)
+	}
+
+	if got, err := BasketOptionPrice(weights, spots, vols, corr, 80.0, 0.04, 0.01, 0, Call, BasketMonteCarlo); err != nil || math.Abs(got-1.0) > 1e-12 {
+		t.Errorf("Unexpected price at expiration: got %v, %v; want 1", got, err)
+	}
+}
+
+func TestBasketOptionPriceErrors(t *testing.T) {
+	valid := [][]float64{{1, 0.5}, {0.5, 1}}
+	tests := []struct {
+		name    string
+		weights []float64
+		vols    []float64
+		corr    [][]float64
+		strike  float64
+		err     error
+	}{
+		{"no assets", nil, nil, nil, 100.0, ErrInvalidBasket},
+		{"too few volatilities", []float64{1, 1}, []float64{0.2}, valid, 100.0, ErrInvalidBasket},
+		{"zero volatility", []float64{1, 1}, []float64{0.2, 0}, valid, 100.0, ErrInvalidBasket},
+		{"too few rows", []float64{1, 1}, []float64{0.2, 0.2}, valid[:1], 100.0, ErrInvalidCorrelation},
+		{"asymmetric", []float64{1, 1}, []float64{0.2, 0.2}, [][]float64{{1, 0.5}, {0.4, 1}}, 100.0, ErrInvalidCorrelation},
+		{"diagonal not one", []float64{1, 1}, []float64{0.2, 0.2}, [][]float64{{1, 0.5}, {0.5, 0.9}}, 100.0, ErrInvalidCorrelation},
+		{"zero strike", []float64{1, 1}, []float64{0.2, 0.2}, valid, 0, ErrInvalidBasket},
+	}
+	for _, test := range tests {
+		spots := make([]float64, len(test.weights))
+		for i := range spots {
+			spots[i] = 50.0
+		}
+		if got, err := BasketOptionPrice(test.weights, spots, test.vols, test.corr, test.strike, 0.04, 0, 1, Call, BasketMomentMatching); !errors.Is(err, test.err) || !math.IsNaN(got) {
+			t.Errorf("Unexpected result for %s: got %v, %v; want NaN, %v", test.name, got, err, test.err)
+		}
+	}
+
+	// pairwise plausible correlations that no three assets can have together
+	indefinite := [][]float64{
+		{1, 0.9, -0.9},
+		{0.9, 1, 0.9},
+		{-0.9, 0.9, 1},
+	}
+	_, err := BasketOptionPrice([]float64{1, 1, 1}, []float64{50.0, 50.0, 50.0}, []float64{0.2, 0.2, 0.2}, indefinite, 100.0, 0.04, 0, 1, Call, BasketMonteCarlo)
+	if !errors.Is(err, ErrInvalidCorrelation) || !strings.Contains(err.Error(), "eigenvalue -0.8") {
+		t.Errorf("Unexpected error for an indefinite correlation matrix: got %v, want %v with eigenvalue -0.8", err, ErrInvalidCorrelation)
+	}
+}
@@ -0,0 +1,114 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBAWAmericanPriceAgainstTree(t *testing.T) {
+	const tolerance = 0.006 // relative
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, spot := range []float64{90.0, 95.0, 100.0, 105.0, 110.0} {
+			for _, dividendYield := range []float64{0.03, 0.06} {
+				option := Option{
+					Strike:           100.0,
+					DaysToExpiration: 90.0,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  spot,
+					OptionType:       optionType,
+					DividendYield:    dividendYield,
+				}
+
+				got := BAWAmericanPrice(option, 0.25)
+				if want := LeisenReimerPrice(option, 0.25, 1001, American); math.Abs(got-want) > tolerance*want {
+					t.Errorf("Unexpected price for type %v spot %v yield %v: got %v, want %v", optionType, spot, dividendYield, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestBAWAmericanPriceEarlyExercise(t *testing.T) {
+	put := Option{
+		Strike:           100.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  70.0,
+		OptionType:       Put,
+	}
+
+	if got := BAWAmericanPrice(put, 0.25); got != 30.0 {
+		t.Errorf("Unexpected deep in-the-money put price: got %v, want %v", got, 30.0)
+	}
+	put.UnderlyingPrice = 90.0
+	if got, european := BAWAmericanPrice(put, 0.25), BlackScholesOptionPrice(put, 0.25); got <= european {
+		t.Errorf("Unexpected put price: got %v, want above European %v", got, european)
+	}
+}
+
+func TestBAWAmericanPriceEuropeanFallback(t *testing.T) {
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  120.0,
+		OptionType:       Call,
+	}
+	if got, want := BAWAmericanPrice(call, 0.25), BlackScholesOptionPrice(call, 0.25); got != want {
+		t.Errorf("Unexpected non-dividend call price: got %v, want %v", got, want)
+	}
+
+	put := Option{
+		Strike:           100.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.0,
+		UnderlyingPrice:  80.0,
+		OptionType:       Put,
+		DividendYield:    0.02,
+	}
+	if got, want := BAWAmericanPrice(put, 0.25), BlackScholesOptionPrice(put, 0.25); got != want {
+		t.Errorf("Unexpected zero-rate put price: got %v, want %v", got, want)
+	}
+}
+
+func TestBAWAmericanPriceDividends(t *testing.T) {
+	// the escrowed approximation misses exercise ahead of the dividend, leaving the call at least at its intrinsic value
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  110.0,
+		OptionType:       Call,
+		Dividends:        []Dividend{{Amount: 8.0, DaysToExDate: 80.0}},
+	}
+	if got, want := BAWAmericanPrice(call, 0.2), call.IntrinsicValue(); got != want {
+		t.Errorf("Unexpected call price ahead of a large dividend: got %v, want intrinsic value %v", got, want)
+	}
+
+	// a small dividend leaves the escrowed price above the intrinsic value
+	call.Dividends = []Dividend{{Amount: 0.5, DaysToExDate: 30.0}}
+	call.DividendYield = 0.02
+	escrowedCall := escrowed(call)
+	if got, want := BAWAmericanPrice(call, 0.2), BAWAmericanPrice(escrowedCall, 0.2); got != want {
+		t.Errorf("Unexpected call price with a small dividend: got %v, want escrowed price %v", got, want)
+	}
+}
+
+func TestBAWAmericanPriceEdgeCases(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 0.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  90.0,
+		OptionType:       Put,
+	}
+
+	if got := BAWAmericanPrice(option, 0.25); got != 10.0 {
+		t.Errorf("Unexpected price at expiration: got %v, want %v", got, 10.0)
+	}
+	option.DaysToExpiration = -1.0
+	if got := BAWAmericanPrice(option, 0.25); !math.IsNaN(got) {
+		t.Errorf("Unexpected price with negative expiry: got %v, want NaN", got)
+	}
+}
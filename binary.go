@@ -0,0 +1,157 @@
+package finance
+
+import (
+	"math"
+)
+
+// Binary (digital) options pay a fixed amount of cash, or one unit of the underlying, at expiration if they finish
+// in the money, and nothing otherwise. A call pays when the underlying ends above the strike and a put when it ends
+// below; at expiration the payout is made only when the option is strictly in the money. Discrete dividends are
+// escrowed as in BlackScholesOptionPrice, and every price and Greek is NaN with negative days to expiration
+
+// BinaryCashOrNothingPrice computes the price of a cash-or-nothing option, cash·e^{−rT}·N(d2) for a call and
+// cash·e^{−rT}·N(−d2) for a put
+// option: the option
+// vol: the volatility
+// cashPayout: the cash paid if the option finishes in the money
+func BinaryCashOrNothingPrice(option Option, vol, cashPayout float64) float64 {
+	option = escrowed(option)
+	switch {
+	case option.DaysToExpiration < 0:
+		return math.NaN()
+	case option.DaysToExpiration == 0:
+		if expirationDelta(option) == 0 {
+			return 0
+		}
+		return cashPayout
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	_, d2 := blackScholesD1D2(option, vol)
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	if option.OptionType == Call {
+		return cashPayout * discount * Phi(d2)
+	}
+	return cashPayout * discount * PhiC(d2)
+}
+
+// BinaryAssetOrNothingPrice computes the price of an asset-or-nothing option, S·e^{−qT}·N(d1) for a call and
+// S·e^{−qT}·N(−d1) for a put. A call less strike cash-or-nothing calls paying 1 is a vanilla call
+// option: the option
+// vol: the volatility
+func BinaryAssetOrNothingPrice(option Option, vol float64) float64 {
+	option = escrowed(option)
+	switch {
+	case option.DaysToExpiration < 0:
+		return math.NaN()
+	case option.DaysToExpiration == 0:
+		if expirationDelta(option) == 0 {
+			return 0
+		}
+		return option.UnderlyingPrice
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, _ := blackScholesD1D2(option, vol)
+	discountedSpot := option.UnderlyingPrice * math.Exp(-option.DividendYield*timeToExpiration)
+	if option.OptionType == Call {
+		return discountedSpot * Phi(d1)
+	}
+	return discountedSpot * PhiC(d1)
+}
+
+// BinaryCashOrNothingDelta computes the delta of a cash-or-nothing option, ±cash·e^{−rT}·n(d2)/(S·σ·√T). It peaks at
+// the strike, where it grows without bound as expiration approaches, and is 0 at expiration
+// option: the option
+// vol: the volatility
+// cashPayout: the cash paid if the option finishes in the money
+func BinaryCashOrNothingDelta(option Option, vol, cashPayout float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	_, d2 := blackScholesD1D2(option, vol)
+	delta := cashPayout * math.Exp(-option.RiskFreeRate*timeToExpiration) * NormalDistributionDerivative(d2) /
+		(option.UnderlyingPrice * vol * math.Sqrt(timeToExpiration))
+	if option.OptionType == Put {
+		return -delta
+	}
+	return delta
+}
+
+// BinaryCashOrNothingVega computes the vega of a cash-or-nothing option per unit (1.00) change in volatility,
+// ∓cash·e^{−rT}·n(d2)·d1/σ. It changes sign where d1 is 0, just below the strike for a call, and is 0 at expiration
+// option: the option
+// vol: the volatility
+// cashPayout: the cash paid if the option finishes in the money
+func BinaryCashOrNothingVega(option Option, vol, cashPayout float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, d2 := blackScholesD1D2(option, vol)
+	vega := -cashPayout * math.Exp(-option.RiskFreeRate*timeToExpiration) * NormalDistributionDerivative(d2) * d1 / vol
+	if option.OptionType == Put {
+		return -vega
+	}
+	return vega
+}
+
+// BinaryAssetOrNothingDelta computes the delta of an asset-or-nothing option, e^{−qT}·(N(d1) + n(d1)/(σ·√T)) for a
+// call and e^{−qT}·(N(−d1) − n(d1)/(σ·√T)) for a put. At expiration it is 1 in the money and 0 otherwise
+// option: the option
+// vol: the volatility
+func BinaryAssetOrNothingDelta(option Option, vol float64) float64 {
+	option = escrowed(option)
+	switch {
+	case option.DaysToExpiration < 0:
+		return math.NaN()
+	case option.DaysToExpiration == 0:
+		return math.Abs(expirationDelta(option))
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	stdDev := vol * math.Sqrt(timeToExpiration)
+	d1, _ := blackScholesD1D2(option, vol)
+	dividendDiscount := math.Exp(-option.DividendYield * timeToExpiration)
+	if option.OptionType == Call {
+		return dividendDiscount * (Phi(d1) + NormalDistributionDerivative(d1)/stdDev)
+	}
+	return dividendDiscount * (PhiC(d1) - NormalDistributionDerivative(d1)/stdDev)
+}
+
+// BinaryAssetOrNothingVega computes the vega of an asset-or-nothing option per unit (1.00) change in volatility,
+// ∓S·e^{−qT}·n(d1)·d2/σ. It is 0 at expiration
+// option: the option
+// vol: the volatility
+func BinaryAssetOrNothingVega(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, d2 := blackScholesD1D2(option, vol)
+	vega := -option.UnderlyingPrice * math.Exp(-option.DividendYield*timeToExpiration) * NormalDistributionDerivative(d1) * d2 / vol
+	if option.OptionType == Put {
+		return -vega
+	}
+	return vega
+}
+
+// GapOptionPrice computes the price of a gap option, which pays S − K for a call when the underlying ends above the
+// trigger and K − S for a put when it ends below, K being the option's strike. The price is the Black-Scholes formula
+// with d1 and d2 computed at the trigger: for a call, an asset-or-nothing call less K cash-or-nothing calls struck at
+// the trigger, and for a put the reverse. With the strike beyond the trigger the payoff can be negative, and so can
+// the price, which is not clamped. At expiration the payoff is made only when the option is strictly beyond the
+// trigger
+// option: the option, whose strike sets the payoff
+// vol: the volatility
+// triggerStrike: the strike that decides whether the payoff is made
+func GapOptionPrice(option Option, vol, triggerStrike float64) float64 {
+	trigger := option
+	trigger.Strike = triggerStrike
+	price := BinaryAssetOrNothingPrice(trigger, vol) - BinaryCashOrNothingPrice(trigger, vol, option.Strike)
+	if option.OptionType == Put {
+		return -price
+	}
+	return price
+}
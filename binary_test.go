@@ -0,0 +1,182 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBinaryHaugReferenceValues(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas, cash-or-nothing and asset-or-nothing put examples
+	cash := Option{
+		Strike:           80.0,
+		DaysToExpiration: 0.75 * 365,
+		RiskFreeRate:     0.06,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+		DividendYield:    0.06,
+	}
+	if got := BinaryCashOrNothingPrice(cash, 0.35, 10.0); math.Abs(got-2.6710) > 5e-5 {
+		t.Errorf("Unexpected cash-or-nothing price: got %v, want 2.6710", got)
+	}
+	asset := Option{
+		Strike:           65.0,
+		DaysToExpiration: 0.5 * 365,
+		RiskFreeRate:     0.07,
+		UnderlyingPrice:  70.0,
+		OptionType:       Put,
+		DividendYield:    0.05,
+	}
+	if got := BinaryAssetOrNothingPrice(asset, 0.27); math.Abs(got-20.2069) > 5e-5 {
+		t.Errorf("Unexpected asset-or-nothing price: got %v, want 20.2069", got)
+	}
+}
+
+func TestBinaryParity(t *testing.T) {
+	const tolerance = 1e-12
+
+	for _, strike := range []float64{80.0, 100.0, 120.0} {
+		call := Option{
+			Strike:           strike,
+			DaysToExpiration: 90.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       Call,
+			DividendYield:    0.02,
+		}
+		put := call
+		put.OptionType = Put
+		timeToExpiration := call.DaysToExpiration / 365.0
+
+		checks := []struct {
+			name      string
+			got, want float64
+		}{
+			{"cash call plus put", BinaryCashOrNothingPrice(call, 0.25, 3.0) + BinaryCashOrNothingPrice(put, 0.25, 3.0), 3.0 * math.Exp(-call.RiskFreeRate*timeToExpiration)},
+			{"asset call plus put", BinaryAssetOrNothingPrice(call, 0.25) + BinaryAssetOrNothingPrice(put, 0.25), call.UnderlyingPrice * math.Exp(-call.DividendYield*timeToExpiration)},
+			{"vanilla call", BinaryAssetOrNothingPrice(call, 0.25) - BinaryCashOrNothingPrice(call, 0.25, strike), BlackScholesOptionPrice(call, 0.25)},
+			{"vanilla put", BinaryCashOrNothingPrice(put, 0.25, strike) - BinaryAssetOrNothingPrice(put, 0.25), BlackScholesOptionPrice(put, 0.25)},
+		}
+		for _, c := range checks {
+			if math.Abs(c.got-c.want) > tolerance {
+				t.Errorf("Unexpected %s for strike %v: got %v, want %v", c.name, strike, c.got, c.want)
+			}
+		}
+	}
+}
+
+func TestBinaryGreeks(t *testing.T) {
+	const (
+		bump      = 1e-4
+		tolerance = 1e-6
+	)
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 10.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.02,
+			}
+			up, down := option, option
+			up.UnderlyingPrice += bump
+			down.UnderlyingPrice -= bump
+
+			checks := []struct {
+				name      string
+				got, want float64
+			}{
+				{"cash delta", BinaryCashOrNothingDelta(option, 0.3, 5.0), (BinaryCashOrNothingPrice(up, 0.3, 5.0) - BinaryCashOrNothingPrice(down, 0.3, 5.0)) / (2 * bump)},
+				{"cash vega", BinaryCashOrNothingVega(option, 0.3, 5.0), (BinaryCashOrNothingPrice(option, 0.3+bump, 5.0) - BinaryCashOrNothingPrice(option, 0.3-bump, 5.0)) / (2 * bump)},
+				{"asset delta", BinaryAssetOrNothingDelta(option, 0.3), (BinaryAssetOrNothingPrice(up, 0.3) - BinaryAssetOrNothingPrice(down, 0.3)) / (2 * bump)},
+				{"asset vega", BinaryAssetOrNothingVega(option, 0.3), (BinaryAssetOrNothingPrice(option, 0.3+bump) - BinaryAssetOrNothingPrice(option, 0.3-bump)) / (2 * bump)},
+			}
+			for _, c := range checks {
+				if math.Abs(c.got-c.want) > tolerance*math.Max(1, math.Abs(c.want)) {
+					t.Errorf("Unexpected %s for type %v strike %v: got %v, want %v", c.name, optionType, strike, c.got, c.want)
+				}
+			}
+		}
+	}
+}
+
+func TestBinaryExpiration(t *testing.T) {
+	option := Option{
+		Strike:          100.0,
+		RiskFreeRate:    0.05,
+		UnderlyingPrice: 105.0,
+		OptionType:      Call,
+	}
+	put := option
+	put.OptionType = Put
+	atTheMoney := option
+	atTheMoney.UnderlyingPrice = 100.0
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"cash call", BinaryCashOrNothingPrice(option, 0.2, 5.0), 5.0},
+		{"cash put", BinaryCashOrNothingPrice(put, 0.2, 5.0), 0},
+		{"cash at the money", BinaryCashOrNothingPrice(atTheMoney, 0.2, 5.0), 0},
+		{"asset call", BinaryAssetOrNothingPrice(option, 0.2), 105.0},
+		{"asset put", BinaryAssetOrNothingPrice(put, 0.2), 0},
+		{"cash delta", BinaryCashOrNothingDelta(option, 0.2, 5.0), 0},
+		{"cash vega", BinaryCashOrNothingVega(option, 0.2, 5.0), 0},
+		{"asset delta", BinaryAssetOrNothingDelta(option, 0.2), 1},
+		{"asset put delta", BinaryAssetOrNothingDelta(put, 0.2), 0},
+		{"asset vega", BinaryAssetOrNothingVega(option, 0.2), 0},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("Unexpected %s at expiration: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	option.DaysToExpiration = -1
+	for name, got := range map[string]float64{
+		"cash price":  BinaryCashOrNothingPrice(option, 0.2, 5.0),
+		"asset price": BinaryAssetOrNothingPrice(option, 0.2),
+		"cash delta":  BinaryCashOrNothingDelta(option, 0.2, 5.0),
+		"asset delta": BinaryAssetOrNothingDelta(option, 0.2),
+		"cash vega":   BinaryCashOrNothingVega(option, 0.2, 5.0),
+		"asset vega":  BinaryAssetOrNothingVega(option, 0.2),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected %s for negative days to expiration: got %v, want NaN", name, got)
+		}
+	}
+}
+
+func TestGapOptionPrice(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas: a gap call paying S − 57 above 50 is worth less than nothing
+	option := Option{
+		Strike:           57.0,
+		DaysToExpiration: 0.5 * 365,
+		RiskFreeRate:     0.09,
+		UnderlyingPrice:  50.0,
+		OptionType:       Call,
+	}
+	if got := GapOptionPrice(option, 0.2, 50.0); math.Abs(got-(-0.0053)) > 5e-5 {
+		t.Errorf("Unexpected gap call price: got %v, want -0.0053", got)
+	}
+
+	// with the trigger at the strike it is a vanilla option, and at expiration its payoff may be negative
+	for _, optionType := range []OptionType{Call, Put} {
+		option.OptionType = optionType
+		if got, want := GapOptionPrice(option, 0.2, option.Strike), BlackScholesOptionPrice(option, 0.2); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Unexpected gap price for type %v at the strike: got %v, want %v", optionType, got, want)
+		}
+	}
+	option.OptionType = Put
+	option.DaysToExpiration = 0
+	option.Strike = 48.0
+	if got := GapOptionPrice(option, 0.2, 52.0); got != -2.0 {
+		t.Errorf("Unexpected gap put payoff at expiration: got %v, want -2", got)
+	}
+	if got := GapOptionPrice(option, 0.2, 49.0); got != 0 {
+		t.Errorf("Unexpected gap put payoff above the trigger at expiration: got %v, want 0", got)
+	}
+}
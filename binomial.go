@@ -0,0 +1,242 @@
+package finance
+
+import (
+	"math"
+)
+
+// ExerciseStyle is the exercise style of an option, either European or American
+type ExerciseStyle int
+
+const (
+	European ExerciseStyle = iota // Exercisable only at expiration
+	American                      // Exercisable at any time up to expiration
+)
+
+// BinomialOptionPrice prices an option on a Cox-Ross-Rubinstein binomial tree. American options are checked for
+// early exercise at every node, against the underlying price with the discrete dividends still to be paid added
+// back to the escrowed one. European prices converge to BlackScholesOptionPrice as steps grow, with an error
+// that oscillates between odd and even step counts. At expiration the price is the intrinsic value; with fewer than
+// one step or negative days to expiration it is NaN
+// option: the option
+// vol: the volatility
+// steps: the number of time steps in the tree
+// style: the exercise style
+func BinomialOptionPrice(option Option, vol float64, steps int, style ExerciseStyle) float64 {
+	dividends := option.Dividends
+	option = escrowed(option)
+	if steps < 1 || option.DaysToExpiration < 0 {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		return option.IntrinsicValue()
+	}
+
+	up, down, probability := crrParameters(option, vol, steps)
+	lattice := newBinomialLattice(steps)
+	lattice.dividends = dividends
+	return lattice.roll(option, steps, up, down, probability, style).value
+}
+
+// Binomial Greek bump sizes, for the Greeks that cannot be read off the lattice
+const (
+	binomialVolBump  = 0.01   // Absolute bump of the volatility, wide enough to smooth over nodes crossing the strike
+	binomialRateBump = 0.0001 // Absolute bump of the risk-free rate
+)
+
+// BinomialGreeks computes the price and Greeks of an option on a Cox-Ross-Rubinstein binomial tree, using the same
+// units as BlackScholesGreeks. Delta and gamma come from the nodes one and two steps into the tree, and theta from
+// the middle node two steps in, which has the same underlying price as the root. Being read off a single lattice,
+// they are free of the noise that bumping introduces near the early-exercise boundary. Vega and rho are central
+// differences of prices from trees of the same size, reusing its storage.
+// Every value is NaN with fewer than two steps or no time to expiration
+// option: the option
+// vol: the volatility
+// steps: the number of time steps in the tree
+// style: the exercise style
+func BinomialGreeks(option Option, vol float64, steps int, style ExerciseStyle) Greeks {
+	dividends := option.Dividends
+	option = escrowed(option)
+	if steps < 2 || math.IsNaN(option.UnderlyingPrice) || !(option.DaysToExpiration > 0) {
+		nan := math.NaN()
+		return Greeks{Price: nan, Delta: nan, Gamma: nan, Vega: nan, Theta: nan, Rho: nan}
+	}
+
+	lattice := newBinomialLattice(steps)
+	lattice.dividends = dividends
+	reprice := func(option Option, vol float64) float64 {
+		up, down, probability := crrParameters(option, vol, steps)
+		return lattice.roll(option, steps, up, down, probability, style).value
+	}
+
+	up, down, probability := crrParameters(option, vol, steps)
+	nodes := lattice.roll(option, steps, up, down, probability, style)
+	spot := option.UnderlyingPrice
+	spotUp, spotDown := spot*up, spot*down
+	spotUpUp, spotDownDown := spotUp*up, spotDown*down
+	dt := option.DaysToExpiration / 365.0 / float64(steps)
+
+	upperDelta := (nodes.upUp - nodes.upDown) / (spotUpUp - spot)
+	lowerDelta := (nodes.upDown - nodes.downDown) / (spot - spotDownDown)
+
+	greeks := Greeks{
+		Price: nodes.value,
+		Delta: (nodes.up - nodes.down) / (spotUp - spotDown),
+		Gamma: (upperDelta - lowerDelta) / (0.5 * (spotUpUp - spotDownDown)),
+		Vega:  (reprice(option, vol+binomialVolBump) - reprice(option, vol-binomialVolBump)) / (2 * binomialVolBump),
+		Theta: (nodes.upDown - nodes.value) / (2 * dt),
+	}
+	rateUp, rateDown := option, option
+	rateUp.RiskFreeRate += binomialRateBump
+	rateDown.RiskFreeRate -= binomialRateBump
+	greeks.Rho = (reprice(rateUp, vol) - reprice(rateDown, vol)) / (2 * binomialRateBump)
+	return greeks
+}
+
+// LeisenReimerPrice prices an option on a Leisen-Reimer binomial tree, whose moves are chosen by Peizer-Pratt
+// inversion so that the tree matches the Black-Scholes d1 and d2. European prices converge to
+// BlackScholesOptionPrice far faster than on a Cox-Ross-Rubinstein tree and without oscillating, but only for odd
+// step counts, so an even count is rounded up to the next odd one. American options are checked for early exercise
+// at every node, as for BinomialOptionPrice. At expiration the price is the intrinsic value; with fewer than one
+// step or negative days to expiration it is NaN
+// option: the option
+// vol: the volatility
+// steps: the number of time steps in the tree, rounded up to an odd number
+// style: the exercise style
+func LeisenReimerPrice(option Option, vol float64, steps int, style ExerciseStyle) float64 {
+	dividends := option.Dividends
+	option = escrowed(option)
+	if steps < 1 || option.DaysToExpiration < 0 {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		return option.IntrinsicValue()
+	}
+
+	steps |= 1
+	up, down, probability := leisenReimerParameters(option, vol, steps)
+	lattice := newBinomialLattice(steps)
+	lattice.dividends = dividends
+	return lattice.roll(option, steps, up, down, probability, style).value
+}
+
+// leisenReimerParameters returns the up and down factors and the risk-neutral probability of an up move of a
+// Leisen-Reimer tree
+// option: the option, with any discrete dividends already escrowed
+// vol: the volatility
+// steps: the number of time steps in the tree, which must be odd
+func leisenReimerParameters(option Option, vol float64, steps int) (up, down, probability float64) {
+	dt := option.DaysToExpiration / 365.0 / float64(steps)
+	d1, d2 := blackScholesD1D2(option, vol)
+	growth := math.Exp((option.RiskFreeRate - option.DividendYield) * dt)
+	probability = peizerPratt(d2, steps)
+	up = growth * peizerPratt(d1, steps) / probability
+	down = (growth - probability*up) / (1 - probability)
+	return up, down, probability
+}
+
+// peizerPratt approximates, by the second Peizer-Pratt inversion, the per-step probability of a binomial tree with
+// steps steps whose probability of finishing above its middle node is N(z)
+// z: the standard normal quantile
+// steps: the number of steps, which must be odd
+func peizerPratt(z float64, steps int) float64 {
+	n := float64(steps)
+	x := z / (n + 1.0/3.0 + 0.1/(n+1))
+	return 0.5 + math.Copysign(0.5*math.Sqrt(1-math.Exp(-x*x*(n+1.0/6.0))), z)
+}
+
+// crrParameters returns the up and down factors and the risk-neutral probability of an up move of a
+// Cox-Ross-Rubinstein tree, whose down factor is the reciprocal of its up factor
+// option: the option, with any discrete dividends already escrowed
+// vol: the volatility
+// steps: the number of time steps in the tree
+func crrParameters(option Option, vol float64, steps int) (up, down, probability float64) {
+	dt := option.DaysToExpiration / 365.0 / float64(steps)
+	up = math.Exp(vol * math.Sqrt(dt))
+	down = 1 / up
+	return up, down, (math.Exp((option.RiskFreeRate-option.DividendYield)*dt) - down) / (up - down)
+}
+
+// binomialLattice holds the working storage of a recombining binomial tree, so that it can be rolled back
+// repeatedly without allocating
+type binomialLattice struct {
+	powers     []float64                                  // Powers 0 to steps of the up factor, followed by those of the down factor
+	values     []float64                                  // Option values at the nodes of the current layer
+	onExercise func(step, ups int)                        // If set, called for each node, by step and number of up moves, that is exercised early
+	dividends  []Dividend                                 // Discrete dividends escrowed out of the option, whose value still to be paid is added back to the underlying price of a node exercised early or passed to node
+	node       func(step int, spot, held float64) float64 // If set, gives the value of each node before expiration from the real underlying price and the value of holding on, in place of the exercise style
+}
+
+// binomialNodes holds the option values at the root of a binomial tree and at the nodes of its first two layers
+type binomialNodes struct {
+	value                  float64 // Value at the root
+	up, down               float64 // Values after one step
+	upUp, upDown, downDown float64 // Values after two steps
+}
+
+// newBinomialLattice allocates the storage for a binomial tree with the given number of steps
+// steps: the number of time steps in the tree
+func newBinomialLattice(steps int) binomialLattice {
+	return binomialLattice{
+		powers: make([]float64, 2*(steps+1)),
+		values: make([]float64, steps+1),
+	}
+}
+
+// roll rolls an option back through a recombining binomial tree in which the underlying moves up by a factor
+// of up, with probability probability, or down by a factor of down at each step. Early exercise is valued at the
+// real underlying price, the escrowed one of the node with the lattice's dividends still to be paid added back
+// option: the option, with any discrete dividends already escrowed
+// steps: the number of time steps in the tree, at most the number the lattice was allocated for
+// up: the up factor
+// down: the down factor
+// probability: the risk-neutral probability of an up move
+// style: the exercise style
+func (lattice binomialLattice) roll(option Option, steps int, up, down, probability float64, style ExerciseStyle) binomialNodes {
+	dtDays := option.DaysToExpiration / float64(steps)
+	discount := math.Exp(-option.RiskFreeRate * dtDays / 365.0)
+	upValue, downValue := discount*probability, discount*(1-probability)
+	sign := 1.0
+	if option.OptionType == Put {
+		sign = -1.0
+	}
+
+	// the underlying price after i up and j down moves is S·ups[i]·downs[j]
+	ups, downs := lattice.powers[:steps+1], lattice.powers[steps+1:2*(steps+1)]
+	for k := range ups {
+		ups[k] = math.Pow(up, float64(k))
+		downs[k] = math.Pow(down, float64(k))
+	}
+	values := lattice.values[:steps+1]
+	payoff := func(i, j int, carry float64) float64 {
+		return math.Max(sign*(option.UnderlyingPrice*ups[i]*downs[j]+carry-option.Strike), 0)
+	}
+
+	var nodes binomialNodes
+	for i := range values {
+		values[i] = payoff(i, steps-i, 0)
+	}
+	for step := steps - 1; step >= 0; step-- {
+		switch step {
+		case 1:
+			nodes.upUp, nodes.upDown, nodes.downDown = values[2], values[1], values[0]
+		case 0:
+			nodes.up, nodes.down = values[1], values[0]
+		}
+		carry := outstandingDividends(lattice.dividends, option.RiskFreeRate, float64(step)*dtDays, option.DaysToExpiration)
+		for i := 0; i <= step; i++ {
+			values[i] = upValue*values[i+1] + downValue*values[i]
+			if lattice.node != nil {
+				values[i] = lattice.node(step, option.UnderlyingPrice*ups[i]*downs[step-i]+carry, values[i])
+			} else if style == American {
+				if exercise := payoff(i, step-i, carry); exercise > values[i] {
+					values[i] = exercise
+					if lattice.onExercise != nil {
+						lattice.onExercise(step, i)
+					}
+				}
+			}
+		}
+	}
+	nodes.value = values[0]
+	return nodes
+}
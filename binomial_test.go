@@ -0,0 +1,334 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBinomialOptionPriceConvergence(t *testing.T) {
+	const tolerance = 1e-3
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			for _, dividendYield := range []float64{0.0, 0.03} {
+				option := Option{
+					Strike:           strike,
+					DaysToExpiration: 90.0,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  100.0,
+					OptionType:       optionType,
+					DividendYield:    dividendYield,
+				}
+
+				got := BinomialOptionPrice(option, 0.2, 1000, European)
+				if want := BlackScholesOptionPrice(option, 0.2); math.Abs(got-want) > tolerance {
+					t.Errorf("Unexpected price for type %v strike %v yield %v: got %v, want %v", optionType, strike, dividendYield, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestBinomialOptionPriceAmerican(t *testing.T) {
+	for _, spot := range []float64{60.0, 80.0, 95.0, 100.0, 105.0, 120.0} {
+		put := Option{
+			Strike:           100.0,
+			DaysToExpiration: 365.0,
+			RiskFreeRate:     0.06,
+			UnderlyingPrice:  spot,
+			OptionType:       Put,
+		}
+
+		american := BinomialOptionPrice(put, 0.3, 500, American)
+		european := BinomialOptionPrice(put, 0.3, 500, European)
+		if american < put.IntrinsicValue() {
+			t.Errorf("Unexpected American put price for spot %v: got %v, want at least intrinsic value %v", spot, american, put.IntrinsicValue())
+		}
+		if american < european {
+			t.Errorf("Unexpected American put price for spot %v: got %v, want at least European price %v", spot, american, european)
+		}
+
+		// with no dividends an American call is never exercised early
+		call := put
+		call.OptionType = Call
+		if american, european := BinomialOptionPrice(call, 0.3, 500, American), BinomialOptionPrice(call, 0.3, 500, European); math.Abs(american-european) > 1e-12 {
+			t.Errorf("Unexpected American call price for spot %v: got %v, want %v", spot, american, european)
+		}
+	}
+
+	// ahead of a large dividend a deep in-the-money call is exercised and a deep in-the-money put is held
+	for _, tt := range []struct {
+		optionType OptionType
+		spot       float64
+	}{
+		{Call, 110.0}, {Put, 90.0},
+	} {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 90.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  tt.spot,
+			OptionType:       tt.optionType,
+			Dividends:        []Dividend{{Amount: 8.0, DaysToExDate: 80.0}},
+		}
+		intrinsic := option.IntrinsicValue()
+		for name, american := range map[string]float64{
+			"binomial":      BinomialOptionPrice(option, 0.2, 500, American),
+			"Leisen-Reimer": LeisenReimerPrice(option, 0.2, 501, American),
+			"lattice":       BinomialGreeks(option, 0.2, 500, American).Price,
+		} {
+			if american < intrinsic {
+				t.Errorf("Unexpected %s American price for type %v with a dividend: got %v, want at least intrinsic value %v", name, tt.optionType, american, intrinsic)
+			}
+			if european := BinomialOptionPrice(option, 0.2, 500, European); american < european {
+				t.Errorf("Unexpected %s American price for type %v with a dividend: got %v, want at least European price %v", name, tt.optionType, american, european)
+			}
+		}
+	}
+
+	// deep in the money the American put is worth exactly its intrinsic value
+	put := Option{
+		Strike:           140.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+	if got := BinomialOptionPrice(put, 0.2, 200, American); got != 40.0 {
+		t.Errorf("Unexpected deep in-the-money American put price: got %v, want 40", got)
+	}
+}
+
+func TestBinomialOptionPriceAmericanDividends(t *testing.T) {
+	// just before a large dividend a deep in-the-money call is worth exercising, which the escrowed price alone misses
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  110.0,
+		OptionType:       Call,
+		Dividends:        []Dividend{{Amount: 8.0, DaysToExDate: 80.0}},
+	}
+	american := BinomialOptionPrice(call, 0.2, 500, American)
+	if intrinsic := call.IntrinsicValue(); american < intrinsic {
+		t.Errorf("Unexpected American call price with a dividend: got %v, want at least intrinsic value %v", american, intrinsic)
+	}
+	if european := BinomialOptionPrice(call, 0.2, 500, European); !(american > european) {
+		t.Errorf("Unexpected American call price with a dividend: got %v, want above European price %v", american, european)
+	}
+}
+
+func TestBinomialOptionPriceEdgeCases(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 0.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  110.0,
+		OptionType:       Call,
+	}
+
+	if got := BinomialOptionPrice(option, 0.2, 100, American); got != 10.0 {
+		t.Errorf("Unexpected price at expiration: got %v, want 10", got)
+	}
+	option.DaysToExpiration = 30.0
+	if got := BinomialOptionPrice(option, 0.2, 0, American); !math.IsNaN(got) {
+		t.Errorf("Unexpected price for zero steps: got %v, want NaN", got)
+	}
+	option.DaysToExpiration = -1.0
+	if got := BinomialOptionPrice(option, 0.2, 100, American); !math.IsNaN(got) {
+		t.Errorf("Unexpected price for negative days: got %v, want NaN", got)
+	}
+}
+
+func TestBinomialOptionPriceAllocations(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		BinomialOptionPrice(option, 0.25, 500, American)
+	})
+	if max := 2.0; allocs > max {
+		t.Errorf("Unexpected allocations: got %v, want at most %v", allocs, max)
+	}
+}
+
+func BenchmarkBinomialOptionPrice(b *testing.B) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		BinomialOptionPrice(option, 0.25, 1000, American)
+	}
+}
+
+func TestBinomialGreeksEuropeanLimit(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 120.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.01,
+			}
+
+			got := BinomialGreeks(option, 0.25, 1000, European)
+			want := BlackScholesGreeks(option, 0.25)
+			checks := []struct {
+				name      string
+				got, want float64
+				tolerance float64
+			}{
+				{"price", got.Price, want.Price, 2e-3},
+				{"delta", got.Delta, want.Delta, 1e-3},
+				{"gamma", got.Gamma, want.Gamma, 1e-4},
+				{"vega", got.Vega, want.Vega, 0.05},
+				{"theta", got.Theta, want.Theta, 0.02},
+				{"rho", got.Rho, want.Rho, 0.01},
+			}
+			for _, c := range checks {
+				if diff := math.Abs(c.got - c.want); diff > c.tolerance {
+					t.Errorf("Unexpected %s for type %v strike %v: got %v, want %v", c.name, optionType, strike, c.got, c.want)
+				}
+			}
+		}
+	}
+}
+
+func TestBinomialGreeksAmericanPut(t *testing.T) {
+	previous := math.Inf(-1)
+	for spot := 70.0; spot <= 130.0; spot += 2.5 {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 365.0,
+			RiskFreeRate:     0.08,
+			UnderlyingPrice:  spot,
+			OptionType:       Put,
+		}
+
+		greeks := BinomialGreeks(option, 0.25, 500, American)
+		if greeks.Delta < -1 || greeks.Delta > 0 {
+			t.Errorf("Unexpected delta for spot %v: got %v, want within [-1, 0]", spot, greeks.Delta)
+		}
+		// put delta rises towards zero as the underlying price rises, including across the exercise boundary
+		if greeks.Delta < previous-1e-9 {
+			t.Errorf("Unexpected delta for spot %v: got %v, want at least %v", spot, greeks.Delta, previous)
+		}
+		previous = greeks.Delta
+		if greeks.Gamma < -1e-9 {
+			t.Errorf("Unexpected gamma for spot %v: got %v, want non-negative", spot, greeks.Gamma)
+		}
+	}
+
+	// deep in the exercise region the put behaves like short stock
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.08,
+		UnderlyingPrice:  60.0,
+		OptionType:       Put,
+	}
+	if greeks := BinomialGreeks(option, 0.25, 500, American); math.Abs(greeks.Delta+1) > 1e-12 || math.Abs(greeks.Gamma) > 1e-12 {
+		t.Errorf("Unexpected Greeks in the exercise region: got delta %v gamma %v, want -1 and 0", greeks.Delta, greeks.Gamma)
+	}
+
+	if greeks := BinomialGreeks(option, 0.25, 1, American); !math.IsNaN(greeks.Delta) {
+		t.Errorf("Unexpected delta for one step: got %v, want NaN", greeks.Delta)
+	}
+}
+
+func TestLeisenReimerPriceConvergence(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 90.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.03,
+			}
+
+			want := BlackScholesOptionPrice(option, 0.2)
+			lr := math.Abs(LeisenReimerPrice(option, 0.2, 101, European) - want)
+			crr := math.Abs(BinomialOptionPrice(option, 0.2, 1001, European) - want)
+			if lr >= crr {
+				t.Errorf("Unexpected Leisen-Reimer error for type %v strike %v: got %v, want below Cox-Ross-Rubinstein %v", optionType, strike, lr, crr)
+			}
+		}
+	}
+}
+
+func TestLeisenReimerPriceOddSteps(t *testing.T) {
+	option := Option{
+		Strike:           105.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	for _, style := range []ExerciseStyle{European, American} {
+		for _, steps := range []int{2, 50, 100} {
+			if got, want := LeisenReimerPrice(option, 0.25, steps, style), LeisenReimerPrice(option, 0.25, steps+1, style); got != want {
+				t.Errorf("Unexpected price for style %v steps %v: got %v, want %v", style, steps, got, want)
+			}
+		}
+	}
+}
+
+func TestLeisenReimerPriceAmerican(t *testing.T) {
+	for _, spot := range []float64{60.0, 80.0, 100.0, 120.0} {
+		put := Option{
+			Strike:           100.0,
+			DaysToExpiration: 365.0,
+			RiskFreeRate:     0.06,
+			UnderlyingPrice:  spot,
+			OptionType:       Put,
+		}
+
+		american := LeisenReimerPrice(put, 0.2, 201, American)
+		if intrinsic := put.IntrinsicValue(); american < intrinsic {
+			t.Errorf("Unexpected American put price for spot %v: got %v, want at least intrinsic %v", spot, american, intrinsic)
+		}
+		if european := LeisenReimerPrice(put, 0.2, 201, European); american < european {
+			t.Errorf("Unexpected American put price for spot %v: got %v, want at least European %v", spot, american, european)
+		}
+		if crr := BinomialOptionPrice(put, 0.2, 2000, American); math.Abs(american-crr) > 1e-2 {
+			t.Errorf("Unexpected American put price for spot %v: got %v, want %v", spot, american, crr)
+		}
+	}
+}
+
+func TestLeisenReimerPriceEdgeCases(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 0.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  110.0,
+		OptionType:       Call,
+	}
+
+	if got := LeisenReimerPrice(option, 0.2, 101, American); got != 10.0 {
+		t.Errorf("Unexpected price at expiration: got %v, want %v", got, 10.0)
+	}
+	option.DaysToExpiration = 30.0
+	if got := LeisenReimerPrice(option, 0.2, 0, European); !math.IsNaN(got) {
+		t.Errorf("Unexpected price with no steps: got %v, want NaN", got)
+	}
+	option.DaysToExpiration = -1.0
+	if got := LeisenReimerPrice(option, 0.2, 101, European); !math.IsNaN(got) {
+		t.Errorf("Unexpected price with negative expiry: got %v, want NaN", got)
+	}
+}
@@ -0,0 +1,114 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPhi2(t *testing.T) {
+	const tolerance = 1e-12
+
+	// reference values from numerical integration of n(x)·N((b − ρx)/√(1 − ρ²)) over x up to a
+	tests := []struct {
+		a, b, rho float64
+		want      float64
+	}{
+		{0.0, 0.0, 0.5, 1.0 / 3.0},
+		{0.3, -0.4, 0.2, 0.24080801509764757},
+		{1.2, 0.7, -0.6, 0.6452358404500909},
+		{-1.5, 2.0, 0.95, 0.06680720126885818},
+		{0.5, 0.5, -0.97, 0.38292540880010845},
+		{-2.0, -1.0, 0.8, 0.02085958393225548},
+		{2.5, -0.3, -0.4, 0.3770268093501934},
+	}
+
+	for _, test := range tests {
+		if got := Phi2(test.a, test.b, test.rho); math.Abs(got-test.want) > tolerance {
+			t.Errorf("Unexpected Phi2(%v, %v, %v): got %v, want %v", test.a, test.b, test.rho, got, test.want)
+		}
+	}
+}
+
+func TestPhi2Limits(t *testing.T) {
+	const tolerance = 1e-15
+
+	for _, a := range []float64{-1.5, 0.0, 0.8} {
+		for _, b := range []float64{-0.5, 0.3, 2.0} {
+			tests := []struct {
+				name      string
+				got, want float64
+			}{
+				{"independent", Phi2(a, b, 0), Phi(a) * Phi(b)},
+				{"perfectly correlated", Phi2(a, b, 1), Phi(math.Min(a, b))},
+				{"perfectly anticorrelated", Phi2(a, b, -1), math.Max(0, Phi(a)-Phi(-b))},
+				{"symmetric", Phi2(a, b, 0.6), Phi2(b, a, 0.6)},
+			}
+			for _, test := range tests {
+				if math.Abs(test.got-test.want) > tolerance {
+					t.Errorf("Unexpected %s Phi2(%v, %v): got %v, want %v", test.name, a, b, test.got, test.want)
+				}
+			}
+		}
+	}
+}
+
+func TestPhi2Accuracy(t *testing.T) {
+	// at the origin, 1/4 + arcsin(ρ)/(2π), up to the degenerate limits
+	for _, rho := range []float64{-1, -0.999999, -0.93, -0.5, 0, 0.2, 0.8, 0.93, 0.999999, 1} {
+		if got, want := Phi2(0, 0, rho), 0.25+math.Asin(rho)/(2*math.Pi); math.Abs(got-want) > 1e-14 {
+			t.Errorf("Unexpected Phi2(0, 0, %v): got %v, want %v", rho, got, want)
+		}
+	}
+
+	// reference values from Simpson's rule on n(x)·N((b − ρx)/√(1 − ρ²)) over x up to a, at 400000 points, with
+	// relative accuracy in the tails
+	tests := []struct {
+		a, b, rho float64
+		want      float64
+	}{
+		{-1, -1, -0.5, 3.7823020728542499e-03},
+		{-1, -1, 0.5, 6.2514094709663862e-02},
+		{1, 1, -0.5, 6.8647179420993820e-01},
+		{1, -1, 0.9, 1.5865510863301832e-01},
+		{2, -0.1, 0.95, 4.6017216272279132e-01},
+		{-3, -3, 0.999, 1.2708810536105329e-03},
+		{4, 4, -0.8, 9.9993665751633110e-01},
+		{-6, -5, 0.3, 4.7631224786701907e-13},
+		{-8, -8, 0.7, 4.0748655055758971e-19},
+		{0.5, -2, -0.99, 3.3187838209833917e-29},
+	}
+	for _, test := range tests {
+		got := Phi2(test.a, test.b, test.rho)
+		if math.Abs(got-test.want) > 1e-14 || math.Abs(got/test.want-1) > 1e-10 {
+			t.Errorf("Unexpected Phi2(%v, %v, %v): got %v, want %v", test.a, test.b, test.rho, got, test.want)
+		}
+	}
+
+	// continuous into the degenerate limits
+	for _, rho := range []float64{1 - 1e-12, -1 + 1e-12} {
+		want := Phi2(0.4, -0.7, math.Copysign(1, rho))
+		if got := Phi2(0.4, -0.7, rho); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Unexpected Phi2(0.4, -0.7, %v): got %v, want %v", rho, got, want)
+		}
+	}
+
+	for name, test := range map[string]struct{ got, want float64 }{
+		"a infinite":          {Phi2(math.Inf(1), 0.3, 0.5), Phi(0.3)},
+		"b infinite":          {Phi2(-1.2, math.Inf(1), -0.5), Phi(-1.2)},
+		"a minus infinite":    {Phi2(math.Inf(-1), 0.3, 0.5), 0},
+		"both infinite":       {Phi2(math.Inf(1), math.Inf(1), 0.99), 1},
+		"far beyond the mean": {Phi2(40, 40, 0.3), 1},
+	} {
+		if test.got != test.want {
+			t.Errorf("Unexpected Phi2 with %s: got %v, want %v", name, test.got, test.want)
+		}
+	}
+	for _, rho := range []float64{-1.01, 1.5, math.NaN()} {
+		if got := Phi2(0.1, 0.2, rho); !math.IsNaN(got) {
+			t.Errorf("Unexpected Phi2(0.1, 0.2, %v): got %v, want NaN", rho, got)
+		}
+	}
+	if got := Phi2(math.NaN(), 0.2, 0.3); !math.IsNaN(got) {
+		t.Errorf("Unexpected Phi2(NaN, 0.2, 0.3): got %v, want NaN", got)
+	}
+}
@@ -0,0 +1,132 @@
+package finance
+
+import (
+	"math"
+)
+
+// BjerksundStenslandPrice prices an American option with the Bjerksund-Stensland (2002) approximation, which
+// splits the life of the option at the golden ratio and applies a flat early-exercise boundary in each part. It is
+// more accurate than BAWAmericanPrice for long-dated options. Puts are priced as calls through the
+// Bjerksund-Stensland put-call transformation, which swaps the underlying price with the strike and the risk-free
+// rate with the dividend yield. Where early exercise is never optimal, a call whose dividend yield is not positive
+// or a put whose risk-free rate is not positive, the price is the European one. Discrete dividends are escrowed,
+// which misses exercise just ahead of a dividend, so the price is then floored at the intrinsic value of the real
+// underlying price. At expiration the price is the intrinsic value; with negative days to expiration it is NaN
+// option: the option
+// vol: the volatility
+func BjerksundStenslandPrice(option Option, vol float64) float64 {
+	if len(option.Dividends) > 0 {
+		return math.Max(BjerksundStenslandPrice(escrowed(option), vol), option.IntrinsicValue())
+	}
+	if option.DaysToExpiration < 0 {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		return option.IntrinsicValue()
+	}
+	if (option.OptionType == Call && option.DividendYield <= 0) || (option.OptionType == Put && option.RiskFreeRate <= 0) {
+		return BlackScholesOptionPrice(option, vol)
+	}
+
+	timeToExpiration := option.DaysToExpiration / 365.0
+	spot, strike, rate, dividendYield := option.UnderlyingPrice, option.Strike, option.RiskFreeRate, option.DividendYield
+	if option.OptionType == Put {
+		spot, strike, rate, dividendYield = strike, spot, dividendYield, rate
+	}
+	return bjerksundStenslandCall(spot, strike, timeToExpiration, rate, rate-dividendYield, vol)
+}
+
+// bjerksundStenslandCall computes the Bjerksund-Stensland (2002) price of an American call on an underlying whose
+// cost of carry is below the risk-free rate
+// spot: the underlying price
+// strike: the strike price
+// timeYears: the time to expiration in years, positive
+// rate: the risk-free interest rate
+// costOfCarry: the cost of carry, below rate
+// vol: the volatility
+func bjerksundStenslandCall(spot, strike, timeYears, rate, costOfCarry, vol float64) float64 {
+	variance := vol * vol
+	beta := (0.5 - costOfCarry/variance) + math.Sqrt(math.Pow(costOfCarry/variance-0.5, 2)+2*rate/variance)
+	perpetual := beta / (beta - 1) * strike
+	floor := math.Max(strike, rate/(rate-costOfCarry)*strike)
+
+	// flat exercise boundaries over [0, split] and [split, T], interpolated between floor and perpetual
+	split := 0.5 * (math.Sqrt(5) - 1) * timeYears
+	boundary := func(t float64) float64 {
+		h := -(costOfCarry*t + 2*vol*math.Sqrt(t)) * strike * strike / ((perpetual - floor) * floor)
+		return floor + (perpetual-floor)*(1-math.Exp(h))
+	}
+	early, late := boundary(split), boundary(timeYears)
+	if spot >= late {
+		return spot - strike
+	}
+
+	alphaEarly := (early - strike) * math.Pow(early, -beta)
+	alphaLate := (late - strike) * math.Pow(late, -beta)
+	phi := func(t, gamma, trigger, barrier float64) float64 {
+		return bjerksundStenslandPhi(spot, t, gamma, trigger, barrier, rate, costOfCarry, vol)
+	}
+	psi := func(gamma, trigger float64) float64 {
+		return bjerksundStenslandPsi(spot, timeYears, gamma, trigger, late, early, split, rate, costOfCarry, vol)
+	}
+	return alphaLate*math.Pow(spot, beta) - alphaLate*phi(split, beta, late, late) +
+		phi(split, 1, late, late) - phi(split, 1, early, late) -
+		strike*phi(split, 0, late, late) + strike*phi(split, 0, early, late) +
+		alphaEarly*phi(split, beta, early, late) - alphaEarly*psi(beta, early) +
+		psi(1, early) - psi(1, strike) -
+		strike*psi(0, early) + strike*psi(0, strike)
+}
+
+// bjerksundStenslandPhi computes the value of a claim paying spot^gamma at t if the underlying ends above trigger
+// without touching barrier first, expressed with the univariate normal distribution
+// spot: the underlying price
+// t: the time horizon in years
+// gamma: the power of the payoff
+// trigger: the level the underlying must end above
+// barrier: the knock-out barrier, at least trigger
+// rate: the risk-free interest rate
+// costOfCarry: the cost of carry
+// vol: the volatility
+func bjerksundStenslandPhi(spot, t, gamma, trigger, barrier, rate, costOfCarry, vol float64) float64 {
+	variance := vol * vol
+	stdDev := vol * math.Sqrt(t)
+	lambda := (-rate + gamma*costOfCarry + 0.5*gamma*(gamma-1)*variance) * t
+	d := -(math.Log(spot/trigger) + (costOfCarry+(gamma-0.5)*variance)*t) / stdDev
+	kappa := 2*costOfCarry/variance + (2*gamma - 1)
+	return math.Exp(lambda) * math.Pow(spot, gamma) *
+		(Phi(d) - math.Pow(barrier/spot, kappa)*Phi(d-2*math.Log(barrier/spot)/stdDev))
+}
+
+// bjerksundStenslandPsi computes the counterpart of bjerksundStenslandPhi over two periods, with barrier early up
+// to split and barrier late from split to t, expressed with the bivariate normal distribution
+// spot: the underlying price
+// t: the time horizon in years
+// gamma: the power of the payoff
+// trigger: the level the underlying must end above
+// late: the knock-out barrier from split to t
+// early: the knock-out barrier up to split
+// split: the time in years at which the barrier changes
+// rate: the risk-free interest rate
+// costOfCarry: the cost of carry
+// vol: the volatility
+func bjerksundStenslandPsi(spot, t, gamma, trigger, late, early, split, rate, costOfCarry, vol float64) float64 {
+	variance := vol * vol
+	drift := costOfCarry + (gamma-0.5)*variance
+	splitStdDev, stdDev := vol*math.Sqrt(split), vol*math.Sqrt(t)
+	e1 := (math.Log(spot/early) + drift*split) / splitStdDev
+	e2 := (math.Log(late*late/(spot*early)) + drift*split) / splitStdDev
+	e3 := (math.Log(spot/early) - drift*split) / splitStdDev
+	e4 := (math.Log(late*late/(spot*early)) - drift*split) / splitStdDev
+	f1 := (math.Log(spot/trigger) + drift*t) / stdDev
+	f2 := (math.Log(late*late/(spot*trigger)) + drift*t) / stdDev
+	f3 := (math.Log(early*early/(spot*trigger)) + drift*t) / stdDev
+	f4 := (math.Log(spot*early*early/(trigger*late*late)) + drift*t) / stdDev
+	rho := math.Sqrt(split / t)
+	lambda := -rate + gamma*costOfCarry + 0.5*gamma*(gamma-1)*variance
+	kappa := 2*costOfCarry/variance + (2*gamma - 1)
+	return math.Exp(lambda*t) * math.Pow(spot, gamma) *
+		(Phi2(-e1, -f1, rho) -
+			math.Pow(late/spot, kappa)*Phi2(-e2, -f2, rho) -
+			math.Pow(early/spot, kappa)*Phi2(-e3, -f3, -rho) +
+			math.Pow(early/late, kappa)*Phi2(-e4, -f4, -rho))
+}
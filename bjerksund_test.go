@@ -0,0 +1,128 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBjerksundStenslandPriceAgainstTree(t *testing.T) {
+	const tolerance = 0.01 // relative
+
+	var bjerksundError, bawError float64
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, days := range []float64{365.0, 1095.0} {
+			for _, spot := range []float64{80.0, 90.0, 100.0, 110.0, 120.0} {
+				for _, dividendYield := range []float64{0.02, 0.04} {
+					option := Option{
+						Strike:           100.0,
+						DaysToExpiration: days,
+						RiskFreeRate:     0.05,
+						UnderlyingPrice:  spot,
+						OptionType:       optionType,
+						DividendYield:    dividendYield,
+					}
+
+					got := BjerksundStenslandPrice(option, 0.25)
+					want := LeisenReimerPrice(option, 0.25, 1001, American)
+					if math.Abs(got-want) > tolerance*want {
+						t.Errorf("Unexpected price for type %v days %v spot %v yield %v: got %v, want %v", optionType, days, spot, dividendYield, got, want)
+					}
+					if days == 1095.0 {
+						bjerksundError += math.Abs(got - want)
+						bawError += math.Abs(BAWAmericanPrice(option, 0.25) - want)
+					}
+				}
+			}
+		}
+	}
+
+	if bjerksundError >= bawError {
+		t.Errorf("Unexpected total 3-year error: got %v, want below Barone-Adesi-Whaley %v", bjerksundError, bawError)
+	}
+}
+
+func TestBjerksundStenslandPriceEarlyExercise(t *testing.T) {
+	put := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  50.0,
+		OptionType:       Put,
+		DividendYield:    0.02,
+	}
+
+	if got := BjerksundStenslandPrice(put, 0.25); got != 50.0 {
+		t.Errorf("Unexpected deep in-the-money put price: got %v, want %v", got, 50.0)
+	}
+	put.UnderlyingPrice = 95.0
+	if got, european := BjerksundStenslandPrice(put, 0.25), BlackScholesOptionPrice(put, 0.25); got <= european {
+		t.Errorf("Unexpected put price: got %v, want above European %v", got, european)
+	}
+}
+
+func TestBjerksundStenslandPriceEuropeanFallback(t *testing.T) {
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  110.0,
+		OptionType:       Call,
+	}
+	if got, want := BjerksundStenslandPrice(call, 0.25), BlackScholesOptionPrice(call, 0.25); got != want {
+		t.Errorf("Unexpected non-dividend call price: got %v, want %v", got, want)
+	}
+
+	put := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.0,
+		UnderlyingPrice:  90.0,
+		OptionType:       Put,
+		DividendYield:    0.02,
+	}
+	if got, want := BjerksundStenslandPrice(put, 0.25), BlackScholesOptionPrice(put, 0.25); got != want {
+		t.Errorf("Unexpected zero-rate put price: got %v, want %v", got, want)
+	}
+}
+
+func TestBjerksundStenslandPriceDividends(t *testing.T) {
+	// the escrowed approximation misses exercise ahead of the dividend, leaving the call at least at its intrinsic value
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  110.0,
+		OptionType:       Call,
+		Dividends:        []Dividend{{Amount: 8.0, DaysToExDate: 80.0}},
+	}
+	if got, want := BjerksundStenslandPrice(call, 0.2), call.IntrinsicValue(); got != want {
+		t.Errorf("Unexpected call price ahead of a large dividend: got %v, want intrinsic value %v", got, want)
+	}
+
+	// a small dividend leaves the escrowed price above the intrinsic value
+	call.Dividends = []Dividend{{Amount: 0.5, DaysToExDate: 30.0}}
+	call.DividendYield = 0.02
+	escrowedCall := escrowed(call)
+	if got, want := BjerksundStenslandPrice(call, 0.2), BjerksundStenslandPrice(escrowedCall, 0.2); got != want {
+		t.Errorf("Unexpected call price with a small dividend: got %v, want escrowed price %v", got, want)
+	}
+}
+
+func TestBjerksundStenslandPriceEdgeCases(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 0.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  112.0,
+		OptionType:       Call,
+		DividendYield:    0.03,
+	}
+
+	if got := BjerksundStenslandPrice(option, 0.25); got != 12.0 {
+		t.Errorf("Unexpected price at expiration: got %v, want %v", got, 12.0)
+	}
+	option.DaysToExpiration = -1.0
+	if got := BjerksundStenslandPrice(option, 0.25); !math.IsNaN(got) {
+		t.Errorf("Unexpected price with negative expiry: got %v, want NaN", got)
+	}
+}
@@ -0,0 +1,68 @@
+package finance
+
+import "math"
+
+// The Black-76 model prices options on futures and forwards. UnderlyingPrice is the futures price, which has no drift
+// under the risk-neutral measure, and RiskFreeRate is used purely to discount the payoff. DividendYield and Dividends
+// do not apply to futures and are ignored
+
+// Black76OptionPrice calculates the Black-76 price of an option on a futures contract
+// option: the option, with UnderlyingPrice holding the futures price
+// vol: the volatility of the futures price
+func Black76OptionPrice(option Option, vol float64) float64 {
+	return GeneralizedBlackScholes(black76(option), vol, 0)
+}
+
+// Black76Delta computes the delta of an option on a futures contract with respect to the futures price
+// option: the option, with UnderlyingPrice holding the futures price
+// vol: the volatility of the futures price
+func Black76Delta(option Option, vol float64) float64 {
+	return GeneralizedBlackScholesDelta(black76(option), vol, 0)
+}
+
+// Black76Gamma computes the gamma of an option on a futures contract with respect to the futures price
+// option: the option, with UnderlyingPrice holding the futures price
+// vol: the volatility of the futures price
+func Black76Gamma(option Option, vol float64) float64 {
+	return GeneralizedBlackScholesGamma(black76(option), vol, 0)
+}
+
+// Black76Vega computes the vega of an option on a futures contract
+// option: the option, with UnderlyingPrice holding the futures price
+// vol: the volatility of the futures price
+func Black76Vega(option Option, vol float64) float64 {
+	return GeneralizedBlackScholesVega(black76(option), vol, 0)
+}
+
+// Black76Theta computes the theta of an option on a futures contract, expressed per year, holding the futures price fixed
+// option: the option, with UnderlyingPrice holding the futures price
+// vol: the volatility of the futures price
+func Black76Theta(option Option, vol float64) float64 {
+	return GeneralizedBlackScholesTheta(black76(option), vol, 0)
+}
+
+// Black76Rho computes the rho of an option on a futures contract, expressed per unit (1.00) change in the risk-free rate.
+// Since the rate only discounts the payoff, rho is −T times the option price
+// option: the option, with UnderlyingPrice holding the futures price
+// vol: the volatility of the futures price
+func Black76Rho(option Option, vol float64) float64 {
+	return -option.DaysToExpiration / 365.0 * Black76OptionPrice(option, vol)
+}
+
+// Black76ImpliedVolatility computes the Black-76 implied volatility of an option on a futures contract from its price.
+// An option on a futures price F is priced by Black-Scholes on a spot of F·e^{−rT}, so this delegates to
+// ImpliedVolatility and returns the same errors
+// option: the option, with UnderlyingPrice holding the futures price
+func Black76ImpliedVolatility(option Option) (IVResult, error) {
+	option = black76(option)
+	option.UnderlyingPrice *= math.Exp(-option.RiskFreeRate * option.DaysToExpiration / 365.0)
+	return ImpliedVolatility(option)
+}
+
+// black76 returns a copy of option without the dividend inputs that do not apply to futures
+// option: the option
+func black76(option Option) Option {
+	option.DividendYield = 0
+	option.Dividends = nil
+	return option
+}
@@ -0,0 +1,114 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBlack76OptionPrice(t *testing.T) {
+	const tolerance = 1e-10
+
+	tests := []struct {
+		optionType OptionType
+		expected   float64
+	}{
+		{Call, 10.703499385548087},
+		{Put, 5.826949825406423},
+	}
+
+	for _, test := range tests {
+		option := Option{
+			Strike:           95.0,
+			DaysToExpiration: 182.5,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       test.optionType,
+		}
+
+		if price := Black76OptionPrice(option, 0.3); math.Abs(price-test.expected) > tolerance {
+			t.Errorf("Unexpected price for type %v: got %v, want %v", test.optionType, price, test.expected)
+		}
+	}
+}
+
+func TestBlack76MatchesBlackScholes(t *testing.T) {
+	const tolerance = 1e-10
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{80.0, 100.0, 120.0} {
+			spot := Option{
+				Strike:           strike,
+				DaysToExpiration: 120.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+			}
+			futures := spot
+			futures.UnderlyingPrice = spot.UnderlyingPrice * math.Exp(spot.RiskFreeRate*spot.DaysToExpiration/365.0)
+
+			if got, want := Black76OptionPrice(futures, 0.25), BlackScholesOptionPrice(spot, 0.25); math.Abs(got-want) > tolerance {
+				t.Errorf("Unexpected price for type %v strike %v: got %v, want %v", optionType, strike, got, want)
+			}
+			if got, want := Black76Vega(futures, 0.25), BlackScholesVega(spot, 0.25); math.Abs(got-want) > tolerance {
+				t.Errorf("Unexpected vega for type %v strike %v: got %v, want %v", optionType, strike, got, want)
+			}
+			// delta and gamma are with respect to the futures price, so they rescale by dF/dS = e^{rT}
+			growth := futures.UnderlyingPrice / spot.UnderlyingPrice
+			if got, want := Black76Delta(futures, 0.25)*growth, BlackScholesDelta(spot, 0.25); math.Abs(got-want) > tolerance {
+				t.Errorf("Unexpected delta for type %v strike %v: got %v, want %v", optionType, strike, got, want)
+			}
+			if got, want := Black76Gamma(futures, 0.25)*growth*growth, BlackScholesGamma(spot, 0.25); math.Abs(got-want) > tolerance {
+				t.Errorf("Unexpected gamma for type %v strike %v: got %v, want %v", optionType, strike, got, want)
+			}
+		}
+	}
+}
+
+func TestBlack76Greeks(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.04,
+		UnderlyingPrice:  98.0,
+		OptionType:       Put,
+	}
+	const h = 1e-5
+
+	up, down := option, option
+	up.DaysToExpiration -= h
+	down.DaysToExpiration += h
+	theta := (Black76OptionPrice(up, 0.3) - Black76OptionPrice(down, 0.3)) / (2 * h / 365.0)
+	if got := Black76Theta(option, 0.3); math.Abs(got-theta) > 1e-4 {
+		t.Errorf("Unexpected theta: got %v, want %v", got, theta)
+	}
+
+	up, down = option, option
+	up.RiskFreeRate += h
+	down.RiskFreeRate -= h
+	rho := (Black76OptionPrice(up, 0.3) - Black76OptionPrice(down, 0.3)) / (2 * h)
+	if got := Black76Rho(option, 0.3); math.Abs(got-rho) > 1e-6 {
+		t.Errorf("Unexpected rho: got %v, want %v", got, rho)
+	}
+}
+
+func TestBlack76ImpliedVolatility(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           75.0,
+			DaysToExpiration: 60.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  72.5,
+			OptionType:       optionType,
+		}
+		option.Price = Black76OptionPrice(option, 0.35)
+
+		result, err := Black76ImpliedVolatility(option)
+		if err != nil {
+			t.Errorf("Unexpected error for type %v: %v", optionType, err)
+			continue
+		}
+		if diff := math.Abs(result.Volatility - 0.35); diff > 1e-4 {
+			t.Errorf("Unexpected implied volatility for type %v: got %v, want %v", optionType, result.Volatility, 0.35)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package finance
+
+import (
+	"math"
+)
+
+// BoundaryPoint is a point on the early-exercise boundary of an American option
+type BoundaryPoint struct {
+	DaysToExpiration float64 `json:"days_to_expiration"` // Days to expiration remaining at the point
+	UnderlyingPrice  float64 `json:"underlying_price"`   // Critical underlying price, below which a put or above which a call is exercised
+}
+
+// EarlyExerciseBoundary computes the early-exercise boundary of an American option on a Cox-Ross-Rubinstein
+// binomial tree, ordered from today to expiration. At each time step the critical price is that of the exercised
+// node nearest the money, so it is accurate to one node spacing; steps at which no node is exercised are omitted.
+// With discrete dividends the critical prices are real underlying prices, the escrowed price of the node with the
+// dividends still to be paid added back, so that a call shows where it is exercised ahead of a dividend. The last
+// point is the limit of the boundary at expiration, the strike scaled by the ratio of the risk-free rate to the
+// dividend yield where that brings it nearer the money, omitted for a call whose dividend yield is not positive,
+// which is never exercised at expiration's approach. The boundary is empty when early exercise is never optimal, for
+// a call without a positive dividend yield or discrete dividends or a put whose risk-free rate is not positive, and
+// with fewer than one step or no time to expiration
+// option: the option
+// vol: the volatility
+// steps: the number of time steps in the tree
+func EarlyExerciseBoundary(option Option, vol float64, steps int) []BoundaryPoint {
+	dividends := option.Dividends
+	option = escrowed(option)
+	if steps < 1 || math.IsNaN(option.UnderlyingPrice) || !(option.DaysToExpiration > 0) {
+		return nil
+	}
+	r, q := option.RiskFreeRate, option.DividendYield
+	if (option.OptionType == Call && q <= 0 && len(dividends) == 0) || (option.OptionType == Put && r <= 0) {
+		return nil
+	}
+
+	// record, per step, the number of up moves of the exercised node nearest the money: the most for a put, whose
+	// exercised nodes lie below the boundary, and the fewest for a call
+	const none = -1
+	critical := make([]int, steps)
+	for step := range critical {
+		critical[step] = none
+	}
+	lattice := newBinomialLattice(steps)
+	lattice.dividends = dividends
+	lattice.onExercise = func(step, ups int) {
+		if critical[step] == none || (option.OptionType == Put && ups > critical[step]) {
+			critical[step] = ups
+		}
+	}
+	up, down, probability := crrParameters(option, vol, steps)
+	lattice.roll(option, steps, up, down, probability, American)
+
+	dt := option.DaysToExpiration / float64(steps)
+	boundary := make([]BoundaryPoint, 0, steps+1)
+	for step, ups := range critical {
+		if ups == none {
+			continue
+		}
+		spot := option.UnderlyingPrice * math.Pow(up, float64(ups)) * math.Pow(down, float64(step-ups))
+		boundary = append(boundary, BoundaryPoint{
+			DaysToExpiration: option.DaysToExpiration - float64(step)*dt,
+			UnderlyingPrice:  spot + outstandingDividends(dividends, r, float64(step)*dt, option.DaysToExpiration),
+		})
+	}
+	if option.OptionType == Call && q <= 0 {
+		return boundary
+	}
+
+	expiry := option.Strike
+	switch {
+	case option.OptionType == Call && r > q:
+		expiry *= r / q
+	case option.OptionType == Put && q > r:
+		expiry *= r / q
+	}
+	return append(boundary, BoundaryPoint{UnderlyingPrice: expiry})
+}
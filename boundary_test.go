@@ -0,0 +1,114 @@
+package finance
+
+import (
+	"testing"
+)
+
+func TestEarlyExerciseBoundaryPut(t *testing.T) {
+	put := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	boundary := EarlyExerciseBoundary(put, 0.25, 200)
+	if len(boundary) < 2 || len(boundary) > 201 {
+		t.Fatalf("Unexpected boundary length: got %v, want between 2 and 201", len(boundary))
+	}
+	for i, point := range boundary[:len(boundary)-1] {
+		if point.UnderlyingPrice >= put.Strike {
+			t.Errorf("Unexpected critical price at %v days: got %v, want below strike", point.DaysToExpiration, point.UnderlyingPrice)
+		}
+		if next := boundary[i+1]; next.DaysToExpiration >= point.DaysToExpiration {
+			t.Errorf("Unexpected order at %v days: got %v days next", point.DaysToExpiration, next.DaysToExpiration)
+		}
+	}
+	if first, last := boundary[0], boundary[len(boundary)-2]; first.UnderlyingPrice >= last.UnderlyingPrice {
+		t.Errorf("Unexpected boundary trend: got %v at %v days, want below %v at %v days", first.UnderlyingPrice, first.DaysToExpiration, last.UnderlyingPrice, last.DaysToExpiration)
+	}
+	if got := boundary[len(boundary)-1]; got != (BoundaryPoint{UnderlyingPrice: 100.0}) {
+		t.Errorf("Unexpected boundary at expiration: got %v, want %v", got, BoundaryPoint{UnderlyingPrice: 100.0})
+	}
+
+	// spots below the critical price today are exercised, so the tree prices them at intrinsic value
+	today := boundary[0]
+	put.DaysToExpiration = today.DaysToExpiration
+	put.UnderlyingPrice = today.UnderlyingPrice - 1
+	if got, want := BinomialOptionPrice(put, 0.25, 200, American), put.IntrinsicValue(); got != want {
+		t.Errorf("Unexpected price below the boundary: got %v, want %v", got, want)
+	}
+	put.UnderlyingPrice = today.UnderlyingPrice + 5
+	if got, intrinsic := BinomialOptionPrice(put, 0.25, 200, American), put.IntrinsicValue(); got <= intrinsic {
+		t.Errorf("Unexpected price above the boundary: got %v, want above %v", got, intrinsic)
+	}
+}
+
+func TestEarlyExerciseBoundaryCall(t *testing.T) {
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.04,
+	}
+
+	boundary := EarlyExerciseBoundary(call, 0.25, 200)
+	if len(boundary) < 2 {
+		t.Fatalf("Unexpected boundary length: got %v, want at least 2", len(boundary))
+	}
+	for _, point := range boundary {
+		if point.UnderlyingPrice <= call.Strike {
+			t.Errorf("Unexpected critical price at %v days: got %v, want above strike", point.DaysToExpiration, point.UnderlyingPrice)
+		}
+	}
+	if got := boundary[len(boundary)-1]; got != (BoundaryPoint{UnderlyingPrice: 125.0}) {
+		t.Errorf("Unexpected boundary at expiration: got %v, want %v", got, BoundaryPoint{UnderlyingPrice: 125.0})
+	}
+}
+
+func TestEarlyExerciseBoundaryCallDividend(t *testing.T) {
+	// a call with no dividend yield is exercised only just before its dividend, above the strike in real prices
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  110.0,
+		OptionType:       Call,
+		Dividends:        []Dividend{{Amount: 8.0, DaysToExDate: 80.0}},
+	}
+
+	boundary := EarlyExerciseBoundary(call, 0.2, 500)
+	if len(boundary) == 0 {
+		t.Fatalf("Unexpected boundary length: got 0, want at least 1")
+	}
+	for _, point := range boundary {
+		if point.DaysToExpiration < 10.0 {
+			t.Errorf("Unexpected critical price after the dividend: got %v at %v days", point.UnderlyingPrice, point.DaysToExpiration)
+		}
+		if point.UnderlyingPrice <= call.Strike {
+			t.Errorf("Unexpected critical price at %v days: got %v, want above strike", point.DaysToExpiration, point.UnderlyingPrice)
+		}
+	}
+}
+
+func TestEarlyExerciseBoundaryNeverExercised(t *testing.T) {
+	tests := []struct {
+		name   string
+		option Option
+		steps  int
+	}{
+		{"non-dividend call", Option{Strike: 100.0, DaysToExpiration: 365.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Call}, 200},
+		{"zero-rate put", Option{Strike: 100.0, DaysToExpiration: 365.0, UnderlyingPrice: 100.0, OptionType: Put, DividendYield: 0.02}, 200},
+		{"at expiration", Option{Strike: 100.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Put}, 200},
+		{"no steps", Option{Strike: 100.0, DaysToExpiration: 365.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Put}, 0},
+	}
+
+	for _, test := range tests {
+		if got := EarlyExerciseBoundary(test.option, 0.25, test.steps); len(got) != 0 {
+			t.Errorf("Unexpected boundary for %s: got %v, want empty", test.name, got)
+		}
+	}
+}
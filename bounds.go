@@ -0,0 +1,51 @@
+package finance
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrBelowLowerBound is returned when an option price is below its no-arbitrage lower bound.
+	// It wraps ErrPriceOutOfBounds
+	ErrBelowLowerBound = fmt.Errorf("%w: below lower bound", ErrPriceOutOfBounds)
+	// ErrAboveUpperBound is returned when an option price is above its no-arbitrage upper bound.
+	// It wraps ErrPriceOutOfBounds
+	ErrAboveUpperBound = fmt.Errorf("%w: above upper bound", ErrPriceOutOfBounds)
+)
+
+// PriceBounds returns the model-free no-arbitrage bounds on the price of a European option:
+// max(0, S·e^{−qT} − K·e^{−rT}) ≤ C ≤ S·e^{−qT} for calls and max(0, K·e^{−rT} − S·e^{−qT}) ≤ P ≤ K·e^{−rT} for puts,
+// with discrete dividends taken out of the underlying price
+// option: the option
+func PriceBounds(option Option) (low, high float64) {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	discountedSpot := option.UnderlyingPrice * math.Exp(-option.DividendYield*timeToExpiration)
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	if option.OptionType == Call {
+		return math.Max(0, discountedSpot-discountedStrike), discountedSpot
+	}
+	return math.Max(0, discountedStrike-discountedSpot), discountedStrike
+}
+
+// CheckArbitrage checks that option.Price lies within PriceBounds, inclusive. Returns the error from Validate
+// for invalid inputs, ErrNegativeAdjustedSpot when discrete dividends exceed the underlying price, and an error
+// wrapping ErrBelowLowerBound or ErrAboveUpperBound that reports the violated bound otherwise
+// option: the option
+func CheckArbitrage(option Option) error {
+	if err := option.Validate(); err != nil {
+		return err
+	}
+	if err := checkDividends(option); err != nil {
+		return err
+	}
+	low, high := PriceBounds(option)
+	switch {
+	case option.Price < low:
+		return fmt.Errorf("%w: price %v, bound %v", ErrBelowLowerBound, option.Price, low)
+	case option.Price > high:
+		return fmt.Errorf("%w: price %v, bound %v", ErrAboveUpperBound, option.Price, high)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPriceBounds(t *testing.T) {
+	const tolerance = 1e-12
+
+	for _, dividendYield := range []float64{0.0, 0.03} {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 365.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  110.0,
+			OptionType:       Call,
+			DividendYield:    dividendYield,
+		}
+		discountedSpot := 110.0 * math.Exp(-dividendYield)
+		discountedStrike := 100.0 * math.Exp(-0.05)
+
+		low, high := PriceBounds(option)
+		if math.Abs(low-(discountedSpot-discountedStrike)) > tolerance || math.Abs(high-discountedSpot) > tolerance {
+			t.Errorf("Unexpected call bounds for yield %v: got [%v, %v], want [%v, %v]", dividendYield, low, high, discountedSpot-discountedStrike, discountedSpot)
+		}
+
+		option.OptionType = Put
+		low, high = PriceBounds(option)
+		if low != 0 || math.Abs(high-discountedStrike) > tolerance {
+			t.Errorf("Unexpected put bounds for yield %v: got [%v, %v], want [%v, %v]", dividendYield, low, high, 0, discountedStrike)
+		}
+
+		// every model price lies within the bounds
+		for _, vol := range []float64{0.01, 0.2, 3.0} {
+			for _, optionType := range []OptionType{Call, Put} {
+				option.OptionType = optionType
+				low, high := PriceBounds(option)
+				if price := BlackScholesOptionPrice(option, vol); price < low-tolerance || price > high+tolerance {
+					t.Errorf("Unexpected price for type %v vol %v: got %v, want within [%v, %v]", optionType, vol, price, low, high)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckArbitrage(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  120.0,
+		OptionType:       Call,
+	}
+
+	tests := []struct {
+		optionType OptionType
+		price      float64
+		expected   error
+	}{
+		{Call, 25.0, nil},
+		{Call, 15.0, ErrBelowLowerBound},
+		{Call, 121.0, ErrAboveUpperBound},
+		{Put, 0.5, nil},
+		{Put, 0.0, nil},
+		{Put, 100.0, ErrAboveUpperBound},
+		{Put, -1.0, ErrNegativePrice},
+	}
+
+	for _, test := range tests {
+		option.OptionType = test.optionType
+		option.Price = test.price
+		err := CheckArbitrage(option)
+		if !errors.Is(err, test.expected) {
+			t.Errorf("Unexpected error for type %v price %v: got %v, want %v", test.optionType, test.price, err, test.expected)
+		}
+		if test.expected == ErrBelowLowerBound || test.expected == ErrAboveUpperBound {
+			if !errors.Is(err, ErrPriceOutOfBounds) {
+				t.Errorf("Unexpected error for type %v price %v: got %v, want it to wrap %v", test.optionType, test.price, err, ErrPriceOutOfBounds)
+			}
+		}
+	}
+
+	// a dividend yield lowers the call's upper bound
+	option.OptionType = Call
+	option.Price = 119.5
+	if err := CheckArbitrage(option); err != nil {
+		t.Errorf("Unexpected error without yield: %v", err)
+	}
+	option.DividendYield = 0.05
+	if err := CheckArbitrage(option); !errors.Is(err, ErrAboveUpperBound) {
+		t.Errorf("Unexpected error with yield: got %v, want %v", err, ErrAboveUpperBound)
+	}
+}
@@ -0,0 +1,82 @@
+package finance
+
+import (
+	"math"
+)
+
+// brownianBridge builds Brownian motion paths on equally spaced dates from independent normal draws, fixing the
+// terminal value first and then filling in the midpoints of ever shorter intervals. The first draws then carry most
+// of the variance of the path, which concentrates the effective dimension of a path payoff in the leading, best
+// distributed coordinates of a low-discrepancy sequence
+type brownianBridge struct {
+	bridge, left, right     []int     // Date set by each draw and the dates bracketing it, left being one past its date so that 0 is the origin
+	leftWeight, rightWeight []float64 // Weights of the bracketing values in the conditional mean
+	stdDev                  []float64 // Conditional standard deviation
+	path                    []float64 // Working storage for the Brownian motion on the dates
+}
+
+// newBrownianBridge lays out a Brownian bridge over dates 1 to steps, in units of the time step
+// steps: the number of dates
+func newBrownianBridge(steps int) *brownianBridge {
+	b := &brownianBridge{
+		bridge:      make([]int, steps),
+		left:        make([]int, steps),
+		right:       make([]int, steps),
+		leftWeight:  make([]float64, steps),
+		rightWeight: make([]float64, steps),
+		stdDev:      make([]float64, steps),
+		path:        make([]float64, steps),
+	}
+
+	// set records the draw that sets each date, 0 for a date not yet set, with the last date set by draw 0
+	set := make([]int, steps)
+	set[steps-1] = 1
+	b.bridge[0], b.stdDev[0] = steps-1, math.Sqrt(float64(steps))
+	j := 0
+	for i := 1; i < steps; i++ {
+		// dates j to k − 1 are not yet set, and date k is
+		for set[j] != 0 {
+			j++
+		}
+		k := j
+		for set[k] == 0 {
+			k++
+		}
+		l := j + (k-1-j)/2
+		set[l] = i + 1
+		b.bridge[i], b.left[i], b.right[i] = l, j, k
+
+		// date d lies at time d + 1, and the motion is 0 at time 0, to the left of date 0
+		tl, tm, tr := float64(j), float64(l+1), float64(k+1)
+		b.leftWeight[i] = (tr - tm) / (tr - tl)
+		b.rightWeight[i] = (tm - tl) / (tr - tl)
+		b.stdDev[i] = math.Sqrt((tm - tl) * (tr - tm) / (tr - tl))
+
+		j = k + 1
+		if j >= steps {
+			j = 0
+		}
+	}
+	return b
+}
+
+// increments turns independent standard normal draws into the independent standard normal increments of the
+// Brownian motion between successive dates
+// draws: the draws, one per date
+// increments: the destination, one per date; it may be draws itself
+func (b *brownianBridge) increments(draws, increments []float64) {
+	path := b.path
+	path[len(path)-1] = b.stdDev[0] * draws[0]
+	for i := 1; i < len(path); i++ {
+		value := b.rightWeight[i]*path[b.right[i]] + b.stdDev[i]*draws[i]
+		if j := b.left[i]; j > 0 {
+			value += b.leftWeight[i] * path[j-1]
+		}
+		path[b.bridge[i]] = value
+	}
+
+	previous := 0.0
+	for i, w := range path {
+		increments[i], previous = w-previous, w
+	}
+}
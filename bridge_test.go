@@ -0,0 +1,51 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBrownianBridgeIncrements(t *testing.T) {
+	for _, steps := range []int{1, 2, 5, 12, 33} {
+		// the increments are linear in the draws; with independent unit draws they must be independent unit
+		// normals, so the matrix of increments per unit draw must be orthogonal
+		bridge := newBrownianBridge(steps)
+		columns := make([][]float64, steps)
+		for i := range columns {
+			draws := make([]float64, steps)
+			draws[i] = 1
+			columns[i] = make([]float64, steps)
+			bridge.increments(draws, columns[i])
+		}
+
+		for a := range steps {
+			for b := range steps {
+				var covariance float64
+				for _, column := range columns {
+					covariance += column[a] * column[b]
+				}
+				want := 0.0
+				if a == b {
+					want = 1.0
+				}
+				if math.Abs(covariance-want) > 1e-12 {
+					t.Errorf("Unexpected covariance of increments %d and %d over %d steps: got %v, want %v", a, b, steps, covariance, want)
+				}
+			}
+		}
+	}
+}
+
+func TestBrownianBridgeTerminalFirst(t *testing.T) {
+	bridge := newBrownianBridge(8)
+	draws := []float64{1, 0, 0, 0, 0, 0, 0, 0}
+	increments := make([]float64, 8)
+	bridge.increments(draws, increments)
+
+	// the first draw alone sets the terminal value, spread evenly along the path
+	for i, increment := range increments {
+		if want := math.Sqrt(8) / 8; math.Abs(increment-want) > 1e-15 {
+			t.Errorf("Unexpected increment %d: got %v, want %v", i, increment, want)
+		}
+	}
+}
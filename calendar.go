@@ -0,0 +1,74 @@
+package finance
+
+import "time"
+
+// Calendar is a trading calendar in the style of the NYSE: Monday to Friday are trading days, except for a list
+// of holidays. The zero value has no holidays
+type Calendar struct {
+	holidays map[civilDate]struct{}
+}
+
+// civilDate is a calendar date without a time or location
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// NewCalendar returns a weekday calendar that also excludes the given holidays. Only the date of each holiday
+// in its own location is used
+// holidays: the dates on which the market is closed
+func NewCalendar(holidays ...time.Time) Calendar {
+	calendar := Calendar{holidays: make(map[civilDate]struct{}, len(holidays))}
+	for _, holiday := range holidays {
+		calendar.holidays[dateOf(holiday)] = struct{}{}
+	}
+	return calendar
+}
+
+// IsTradingDay reports whether the date of t, in t's location, is a trading day
+// t: the date
+func (calendar Calendar) IsTradingDay(t time.Time) bool {
+	if !isWeekday(t) {
+		return false
+	}
+	_, holiday := calendar.holidays[dateOf(t)]
+	return !holiday
+}
+
+// TradingDaysBetween counts the trading days after the date of from up to and including the date of to, in the
+// location of from, so that an expiry later the same day counts zero days and one on the next trading day counts one.
+// The count is negative when to is before from
+// from: the start
+// to: the end
+// cal: the trading calendar
+func TradingDaysBetween(from, to time.Time, cal Calendar) int {
+	if to.Before(from) {
+		return -TradingDaysBetween(to, from, cal)
+	}
+	from, to = wallClock(from, to)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	count := 0
+	for day := time.Date(from.Year(), from.Month(), from.Day()+1, 0, 0, 0, 0, time.UTC); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if cal.IsTradingDay(day) {
+			count++
+		}
+	}
+	return count
+}
+
+// YearFractionBusiness252 computes the time from one instant to another in trading years of 252 trading days
+// (BUS/252). Partial trading days at either end count in proportion to the time elapsed within them, so a same-day
+// expiry has a positive year fraction before it expires
+// from: the start
+// to: the end
+// cal: the trading calendar
+func YearFractionBusiness252(from, to time.Time, cal Calendar) float64 {
+	return businessDays(from, to, cal.IsTradingDay) / 252.0
+}
+
+// dateOf returns the date of t in its own location
+func dateOf(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{year, month, day}
+}
@@ -0,0 +1,79 @@
+package finance
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTradingDaysBetween(t *testing.T) {
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	holidays := NewCalendar(date(2024, time.December, 25, 0), date(2025, time.January, 1, 0))
+
+	tests := []struct {
+		description string
+		from, to    time.Time
+		cal         Calendar
+		expected    int
+	}{
+		{"same day", date(2024, time.June, 14, 10), date(2024, time.June, 14, 16), Calendar{}, 0},
+		{"over a weekend", date(2024, time.June, 14, 16), date(2024, time.June, 17, 16), Calendar{}, 1},
+		{"to a Saturday", date(2024, time.June, 14, 16), date(2024, time.June, 15, 16), Calendar{}, 0},
+		{"two weeks", date(2024, time.June, 3, 9), date(2024, time.June, 17, 9), Calendar{}, 10},
+		{"year boundary without holidays", date(2024, time.December, 23, 16), date(2025, time.January, 3, 16), Calendar{}, 9},
+		{"year boundary with holidays", date(2024, time.December, 23, 16), date(2025, time.January, 3, 16), holidays, 7},
+		{"backwards", date(2025, time.January, 3, 16), date(2024, time.December, 23, 16), holidays, -7},
+	}
+
+	for _, test := range tests {
+		if got := TradingDaysBetween(test.from, test.to, test.cal); got != test.expected {
+			t.Errorf("Unexpected trading days for %v: got %v, want %v", test.description, got, test.expected)
+		}
+	}
+}
+
+func TestYearFractionBusiness252(t *testing.T) {
+	const tolerance = 1e-12
+	holidays := NewCalendar(time.Date(2024, time.July, 4, 0, 0, 0, 0, time.UTC))
+
+	// Wednesday 3 July 16:00 to Friday 5 July 16:00 skips the 4 July holiday
+	from := time.Date(2024, time.July, 3, 16, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.July, 5, 16, 0, 0, 0, time.UTC)
+	if got, want := YearFractionBusiness252(from, to, holidays), 1.0/252.0; math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected year fraction with holiday: got %v, want %v", got, want)
+	}
+	if got, want := YearFractionBusiness252(from, to, Calendar{}), 2.0/252.0; math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected year fraction without holiday: got %v, want %v", got, want)
+	}
+
+	// a same-day expiry still has trading time left
+	open := time.Date(2024, time.July, 5, 10, 0, 0, 0, time.UTC)
+	if got, want := YearFractionBusiness252(open, to, holidays), 0.25/252.0; math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected same-day year fraction: got %v, want %v", got, want)
+	}
+
+	option := NewOption(Call, 100.0, 100.0, 0.05, to, from, Business252{Calendar: holidays})
+	if want := 365.0 / 252.0; math.Abs(option.DaysToExpiration-want) > tolerance {
+		t.Errorf("Unexpected days to expiration: got %v, want %v", option.DaysToExpiration, want)
+	}
+}
+
+func TestCalendarIsTradingDay(t *testing.T) {
+	calendar := NewCalendar(time.Date(2024, time.November, 28, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		date     time.Time
+		expected bool
+	}{
+		{time.Date(2024, time.November, 27, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, time.November, 28, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, time.November, 30, 12, 0, 0, 0, time.UTC), false},
+	}
+	for _, test := range tests {
+		if got := calendar.IsTradingDay(test.date); got != test.expected {
+			t.Errorf("Unexpected trading day for %v: got %v, want %v", test.date, got, test.expected)
+		}
+	}
+}
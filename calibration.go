@@ -0,0 +1,355 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidCalibration is returned for a calibration with no quotes, with weights that do not match the quotes or
+// are negative, or with no positive weight
+var ErrInvalidCalibration = errors.New("finance: invalid calibration")
+
+const (
+	calibrationMaxIterations = 100   // Maximum number of Levenberg-Marquardt iterations, unless set by WithCalibrationMaxIterations
+	calibrationTolerance     = 1e-10 // Relative decrease of the objective, or change of the parameters, below which the search stops
+	calibrationMaxDamping    = 1e12  // Damping above which no step decreases the objective and the search stops
+)
+
+// Lower and upper bounds on the Heston parameters searched by CalibrateHeston
+var (
+	hestonLowerBounds = HestonParams{V0: 1e-6, Kappa: 1e-3, Theta: 1e-6, Sigma: 1e-3, Rho: -0.999}
+	hestonUpperBounds = HestonParams{V0: 4, Kappa: 50, Theta: 4, Sigma: 5, Rho: 0.999}
+)
+
+// hestonMinFellerRatio is the lowest ratio σ/√(2κθ) searched by CalibrateHeston with the Feller condition enforced
+const hestonMinFellerRatio = 1e-3
+
+// CalibrationReport holds the fit of a calibrated model to its quotes
+type CalibrationReport struct {
+	Residuals  []float64 // Model price less the quoted price of each quote
+	Objective  float64   // Weighted sum of the squared residuals
+	Iterations int       // Number of iterations used
+}
+
+// hestonCalibrator holds the settings of CalibrateHeston
+type hestonCalibrator struct {
+	feller        bool      // Whether the Feller condition 2κθ ≥ σ² is enforced
+	maxIterations int       // Maximum number of iterations
+	lower, upper  []float64 // Bounds on the parameters in the coordinates of vector
+}
+
+// CalibrationOption configures CalibrateHeston
+type CalibrationOption func(*hestonCalibrator)
+
+// WithFellerCondition enforces the Feller condition 2κθ ≥ σ², under which the variance never reaches zero, by
+// searching the volatility of variance as a ratio σ/√(2κθ) in [1e-3, 1] instead of in its bounds (default not
+// enforced)
+func WithFellerCondition() CalibrationOption {
+	return func(c *hestonCalibrator) {
+		c.feller = true
+	}
+}
+
+// WithCalibrationMaxIterations sets the maximum number of iterations (default 100)
+func WithCalibrationMaxIterations(maxIterations int) CalibrationOption {
+	return func(c *hestonCalibrator) {
+		c.maxIterations = maxIterations
+	}
+}
+
+// CalibrateHeston fits the Heston model to quoted option prices, minimizing the weighted sum of the squared
+// differences between HestonPrice and each quote's Price by the Levenberg-Marquardt method, with Marquardt's scaling
+// of the damping by the diagonal of the normal equations, Nielsen's update of the damping and a forward-difference
+// Jacobian. The search runs over the logarithms of v0, κ, θ and σ, along whose valleys it moves in straight lines,
+// within the bounds v0, θ ∈ [1e-6, 4], κ ∈ [1e-3, 50], σ ∈ [1e-3, 5] and ρ ∈ [−0.999, 0.999]: each step is
+// projected onto them, with the parameters the gradient holds at a bound fixed, and the initial parameters are
+// projected likewise. Quotes sharing an expiration are priced together, with one evaluation of the characteristic
+// function for all their strikes. Iteration stops when the objective decreases, or the parameters change, by less
+// than 1e-10 relative, or when no damped step decreases the objective. Weighting each quote by the inverse square of
+// its vega approximates a fit to implied volatilities. From a reasonable start, twenty quotes on one expiration
+// calibrate in a few tens of iterations and a few tenths of a second at most; a single expiration determines κ and
+// θ poorly, and a start far from the fit can drift along the valley in which their product is fixed
+// Returns the errors of HestonPrice for an invalid quote or initial parameters, ErrInvalidCalibration for no quotes
+// or invalid weights, and ErrNotConverged with the best parameters found and their report if the iterations run out
+// quotes: the quoted options, with their prices
+// weights: the weight of each quote, all equal if nil
+// initial: the parameters the search starts from
+// opts: the settings of the calibration
+func CalibrateHeston(quotes []Option, weights []float64, initial HestonParams, opts ...CalibrationOption) (HestonParams, CalibrationReport, error) {
+	c := hestonCalibrator{maxIterations: calibrationMaxIterations}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	c.lower, c.upper = c.vector(hestonLowerBounds), c.vector(hestonUpperBounds)
+	if c.feller {
+		c.lower[3], c.upper[3] = math.Log(hestonMinFellerRatio), 0
+	}
+	if err := validateCalibration(quotes, weights); err != nil {
+		return HestonParams{}, CalibrationReport{}, err
+	}
+	if err := initial.validate(); err != nil {
+		return HestonParams{}, CalibrationReport{}, err
+	}
+	for _, quote := range quotes {
+		if err := quote.Validate(); err != nil {
+			return HestonParams{}, CalibrationReport{}, err
+		}
+		if err := validateMarket(quote); err != nil {
+			return HestonParams{}, CalibrationReport{}, err
+		}
+	}
+	if weights == nil {
+		weights = make([]float64, len(quotes))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+
+	// quotes sharing an expiration and market are priced together, with one characteristic function
+	type slice struct {
+		indices []int
+		options []Option
+	}
+	type market struct{ days, rate, yield, spot float64 }
+	var slices []*slice
+	byMarket := make(map[market]*slice)
+	for i, quote := range quotes {
+		escrow := escrowed(quote)
+		key := market{escrow.DaysToExpiration, escrow.RiskFreeRate, escrow.DividendYield, escrow.UnderlyingPrice}
+		if byMarket[key] == nil {
+			byMarket[key] = &slice{}
+			slices = append(slices, byMarket[key])
+		}
+		byMarket[key].indices = append(byMarket[key].indices, i)
+		byMarket[key].options = append(byMarket[key].options, quote)
+	}
+	prices := func(x []float64) ([]float64, error) {
+		result := make([]float64, len(quotes))
+		for _, s := range slices {
+			slicePrices, err := hestonSlicePrices(s.options, c.params(x))
+			if err != nil {
+				return nil, err
+			}
+			for k, i := range s.indices {
+				result[i] = slicePrices[k]
+			}
+		}
+		return result, nil
+	}
+
+	// the residuals scaled by the square roots of the weights, whose sum of squares is the objective
+	residuals := func(x []float64) ([]float64, error) {
+		model, err := prices(x)
+		if err != nil {
+			return nil, err
+		}
+		for i, quote := range quotes {
+			model[i] = math.Sqrt(weights[i]) * (model[i] - quote.Price)
+		}
+		return model, nil
+	}
+	x, iterations, converged, err := leastSquares(residuals, c.project(c.vector(initial)), c.lower, c.upper, c.maxIterations)
+	if iterations == 0 && err != nil {
+		return HestonParams{}, CalibrationReport{}, err
+	}
+	report := CalibrationReport{Iterations: iterations}
+	report.Residuals, _ = prices(x)
+	for i, quote := range quotes {
+		report.Residuals[i] -= quote.Price
+		report.Objective += weights[i] * report.Residuals[i] * report.Residuals[i]
+	}
+	if err == nil && !converged {
+		err = fmt.Errorf("%w: Heston calibration after %d iterations", ErrNotConverged, iterations)
+	}
+	return c.params(x), report, err
+}
+
+// leastSquares minimizes the sum of the squares of the residuals of its parameters within bounds by the
+// Levenberg-Marquardt method, with Marquardt's scaling of the damping by the diagonal of the normal equations,
+// Nielsen's update of the damping and a forward-difference Jacobian. Each step is projected onto the bounds, with
+// the parameters that the gradient holds at a bound fixed. It stops when the objective decreases, or the parameters
+// change, by less than calibrationTolerance, relative for the objective, or when no damped step decreases the
+// objective, and reports whether it stopped so within the maximum number of iterations. Returns the parameters
+// reached, the number of iterations used and any error of the residuals at the initial parameters or of the
+// Jacobian, a failed trial step being rejected instead
+// residuals: the residuals of the parameters
+// x: the initial parameters, within the bounds
+// lower: the lower bound of each parameter
+// upper: the upper bound of each parameter, above the lower bound
+// maxIterations: the maximum number of iterations
+func leastSquares(residuals func([]float64) ([]float64, error), x, lower, upper []float64, maxIterations int) ([]float64, int, bool, error) {
+	n := len(x)
+	r, err := residuals(x)
+	if err != nil {
+		return x, 0, false, err
+	}
+	objective := sumOfSquares(r)
+
+	damping, growth := 1e-3, 2.0
+	for iteration := 1; iteration <= maxIterations; iteration++ {
+		// the Jacobian by forward differences, stepping back from an upper bound
+		jacobian := make([][]float64, len(r))
+		for i := range jacobian {
+			jacobian[i] = make([]float64, n)
+		}
+		for j := range x {
+			h := 1e-6 * math.Max(1, math.Abs(x[j]))
+			if x[j]+h > upper[j] {
+				h = -h
+			}
+			shifted := append([]float64(nil), x...)
+			shifted[j] += h
+			rShifted, err := residuals(shifted)
+			if err != nil {
+				return x, iteration, false, err
+			}
+			for i := range r {
+				jacobian[i][j] = (rShifted[i] - r[i]) / h
+			}
+		}
+		normal := make([][]float64, n)
+		gradient := make([]float64, n)
+		for j := range normal {
+			normal[j] = make([]float64, n)
+			for k := range normal[j] {
+				for i := range r {
+					normal[j][k] += jacobian[i][j] * jacobian[i][k]
+				}
+			}
+			for i := range r {
+				gradient[j] -= jacobian[i][j] * r[i]
+			}
+		}
+		// a parameter held at a bound by the gradient is fixed for the step, which would otherwise be cut short at it
+		for j := range x {
+			if (x[j] <= lower[j] && gradient[j] < 0) || (x[j] >= upper[j] && gradient[j] > 0) {
+				for k := range x {
+					normal[j][k], normal[k][j] = 0, 0
+				}
+				normal[j][j], gradient[j] = 1, 0
+			}
+		}
+
+		// raise the damping until a step decreases the objective, then lower it by how well the linear model predicted
+		// the decrease, as in Nielsen (1999)
+		for {
+			if damping > calibrationMaxDamping {
+				return x, iteration, true, nil
+			}
+			damped := make([][]float64, n)
+			for j := range damped {
+				damped[j] = append([]float64(nil), normal[j]...)
+				damped[j][j] += damping * math.Max(normal[j][j], 1e-12)
+			}
+			step, ok := solveLinear(damped, gradient)
+			if !ok {
+				damping, growth = damping*growth, 2*growth
+				continue
+			}
+			trial := make([]float64, n)
+			for j := range trial {
+				trial[j] = math.Min(math.Max(x[j]+step[j], lower[j]), upper[j])
+			}
+			rTrial, err := residuals(trial)
+			trialObjective := sumOfSquares(rTrial)
+			if err != nil || !(trialObjective < objective) {
+				damping, growth = damping*growth, 2*growth
+				continue
+			}
+
+			// the decrease 2δᵀg − δᵀAδ predicted for the projected step δ, with g = −Jᵀr and A = JᵀJ
+			var predicted, change float64
+			for j := range x {
+				moved := trial[j] - x[j]
+				predicted += 2 * moved * gradient[j]
+				for k := range x {
+					predicted -= moved * normal[j][k] * (trial[k] - x[k])
+				}
+				change = math.Max(change, math.Abs(moved)/math.Max(1, math.Abs(x[j])))
+			}
+			decrease := (objective - trialObjective) / objective
+			if predicted > 0 {
+				gain := (objective - trialObjective) / predicted
+				damping *= math.Max(1.0/3, 1-math.Pow(2*gain-1, 3))
+			}
+			damping, growth = math.Max(damping, 1e-15), 2
+			x, r, objective = trial, rTrial, trialObjective
+			if decrease < calibrationTolerance || change < calibrationTolerance {
+				return x, iteration, true, nil
+			}
+			break
+		}
+	}
+	return x, maxIterations, false, nil
+}
+
+// sumOfSquares returns the sum of the squares of the residuals, NaN for none
+// r: the residuals
+func sumOfSquares(r []float64) float64 {
+	if r == nil {
+		return math.NaN()
+	}
+	var sum float64
+	for _, x := range r {
+		sum += x * x
+	}
+	return sum
+}
+
+// project clamps Heston parameters, in the coordinates of vector, into the bounds of the calibration
+// x: the parameters
+func (c hestonCalibrator) project(x []float64) []float64 {
+	for j := range x {
+		x[j] = math.Min(math.Max(x[j], c.lower[j]), c.upper[j])
+	}
+	return x
+}
+
+// validateCalibration checks that there are quotes and that their weights are valid
+// quotes: the quoted options
+// weights: the weight of each quote, or nil
+func validateCalibration(quotes []Option, weights []float64) error {
+	if len(quotes) == 0 {
+		return fmt.Errorf("%w: no quotes", ErrInvalidCalibration)
+	}
+	if weights == nil {
+		return nil
+	}
+	if len(weights) != len(quotes) {
+		return fmt.Errorf("%w: %d weights for %d quotes", ErrInvalidCalibration, len(weights), len(quotes))
+	}
+	var total float64
+	for i, weight := range weights {
+		if !(weight >= 0) || math.IsInf(weight, 1) {
+			return fmt.Errorf("%w: weight %v of quote %d", ErrInvalidCalibration, weight, i)
+		}
+		total += weight
+	}
+	if !(total > 0) {
+		return fmt.Errorf("%w: no positive weight", ErrInvalidCalibration)
+	}
+	return nil
+}
+
+// vector returns the Heston parameters in the coordinates of the calibration, the logarithms of v0, κ, θ and σ, or
+// of σ/√(2κθ) with the Feller condition enforced, and the correlation ρ. In them the valleys of the objective
+// along which κθ or the variances trade off against each other are straight, and the Feller condition is a bound
+// p: the parameters
+func (c hestonCalibrator) vector(p HestonParams) []float64 {
+	x := []float64{math.Log(p.V0), math.Log(p.Kappa), math.Log(p.Theta), math.Log(p.Sigma), p.Rho}
+	if c.feller {
+		x[3] -= 0.5 * (math.Ln2 + x[1] + x[2])
+	}
+	return x
+}
+
+// params returns the Heston parameters in the coordinates of vector as HestonParams
+// x: the parameters
+func (c hestonCalibrator) params(x []float64) HestonParams {
+	logSigma := x[3]
+	if c.feller {
+		logSigma += 0.5 * (math.Ln2 + x[1] + x[2])
+	}
+	return HestonParams{V0: math.Exp(x[0]), Kappa: math.Exp(x[1]), Theta: math.Exp(x[2]), Sigma: math.Exp(logSigma), Rho: x[4]}
+}
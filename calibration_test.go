@@ -0,0 +1,139 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// hestonQuotes prices calls on a grid of strikes and expirations under the Heston model
+func hestonQuotes(t *testing.T, p HestonParams, strikes, days []float64) []Option {
+	var quotes []Option
+	for _, expiry := range days {
+		for _, strike := range strikes {
+			quote := Option{
+				Strike:           strike,
+				DaysToExpiration: expiry,
+				RiskFreeRate:     0.03,
+				UnderlyingPrice:  100.0,
+				OptionType:       Call,
+				DividendYield:    0.01,
+			}
+			if strike < 100.0 {
+				quote.OptionType = Put
+			}
+			price, err := HestonPrice(quote, p)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			quote.Price = price
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes
+}
+
+func TestCalibrateHeston(t *testing.T) {
+	want := HestonParams{V0: 0.03, Kappa: 2.5, Theta: 0.05, Sigma: 0.6, Rho: -0.7}
+	strikes := []float64{70, 75, 80, 85, 90, 95, 100, 105, 110, 115, 120, 125, 130}
+	quotes := hestonQuotes(t, want, strikes, []float64{30.0, 91.0, 182.0, 365.0, 730.0})
+	got, report, err := CalibrateHeston(quotes, nil, HestonParams{V0: 0.04, Kappa: 1.0, Theta: 0.04, Sigma: 0.3, Rho: -0.3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"initial variance", got.V0, want.V0},
+		{"speed of mean reversion", got.Kappa, want.Kappa},
+		{"long-run variance", got.Theta, want.Theta},
+		{"volatility of variance", got.Sigma, want.Sigma},
+		{"correlation", got.Rho, want.Rho},
+	} {
+		if math.Abs(test.got-test.want) > 1e-4*math.Max(1, math.Abs(test.want)) {
+			t.Errorf("Unexpected calibrated %s: got %v, want %v", test.name, test.got, test.want)
+		}
+	}
+	if len(report.Residuals) != len(quotes) || report.Objective > 1e-14 {
+		t.Errorf("Unexpected report: got %d residuals and objective %v", len(report.Residuals), report.Objective)
+	}
+}
+
+func TestCalibrateHestonSlice(t *testing.T) {
+	// twenty quotes on a single expiry, weighted by inverse vega squared to fit implied volatilities
+	var strikes []float64
+	for i := range 20 {
+		strikes = append(strikes, 80.0+2.0*float64(i))
+	}
+	weigh := func(quotes []Option) []float64 {
+		weights := make([]float64, len(quotes))
+		for i, quote := range quotes {
+			vega := BlackScholesVega(quote, ImpliedVolGuess(quote))
+			weights[i] = 1 / (vega * vega)
+		}
+		return weights
+	}
+	initial := HestonParams{V0: 0.04, Kappa: 1.0, Theta: 0.04, Sigma: 0.5, Rho: -0.5}
+
+	truth := HestonParams{V0: 0.04, Kappa: 1.5, Theta: 0.06, Sigma: 0.8, Rho: -0.6}
+	quotes := hestonQuotes(t, truth, strikes, []float64{91.0})
+	start := time.Now()
+	got, report, err := CalibrateHeston(quotes, weigh(quotes), initial)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Logf("calibrated %+v in %v and %d iterations", got, time.Since(start), report.Iterations)
+	if math.Abs(got.V0-truth.V0) > 1e-6 || math.Abs(got.Sigma-truth.Sigma) > 1e-6 || math.Abs(got.Rho-truth.Rho) > 1e-6 {
+		t.Errorf("Unexpected calibrated parameters: got %+v, want %+v", got, truth)
+	}
+
+	// with the Feller condition enforced, parameters that satisfy it are still recovered
+	truth = HestonParams{V0: 0.03, Kappa: 2.0, Theta: 0.05, Sigma: 0.4, Rho: -0.7}
+	quotes = hestonQuotes(t, truth, strikes, []float64{91.0})
+	got, _, err = CalibrateHeston(quotes, weigh(quotes), initial, WithFellerCondition())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(got.V0-truth.V0) > 1e-6 || math.Abs(got.Sigma-truth.Sigma) > 1e-6 || math.Abs(got.Rho-truth.Rho) > 1e-6 {
+		t.Errorf("Unexpected calibrated parameters with the Feller condition: got %+v, want %+v", got, truth)
+	}
+	// and those that break it are fitted within it
+	truth = HestonParams{V0: 0.04, Kappa: 1.5, Theta: 0.06, Sigma: 0.8, Rho: -0.6}
+	quotes = hestonQuotes(t, truth, strikes, []float64{91.0})
+	got, report, err = CalibrateHeston(quotes, weigh(quotes), initial, WithFellerCondition(), WithCalibrationMaxIterations(20))
+	if err != nil && !errors.Is(err, ErrNotConverged) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.Sigma*got.Sigma > 2*got.Kappa*got.Theta*(1+1e-12) || !(report.Objective > 0) {
+		t.Errorf("Unexpected fit with the Feller condition: got %+v with objective %v", got, report.Objective)
+	}
+}
+
+func TestCalibrateHestonErrors(t *testing.T) {
+	initial := HestonParams{V0: 0.04, Kappa: 1.0, Theta: 0.04, Sigma: 0.3, Rho: -0.5}
+	quotes := hestonQuotes(t, initial, []float64{90, 100, 110}, []float64{91.0})
+	tests := []struct {
+		name    string
+		quotes  []Option
+		weights []float64
+		initial HestonParams
+		err     error
+	}{
+		{"no quotes", nil, nil, initial, ErrInvalidCalibration},
+		{"too few weights", quotes, []float64{1, 1}, initial, ErrInvalidCalibration},
+		{"negative weight", quotes, []float64{1, -1, 1}, initial, ErrInvalidCalibration},
+		{"zero weights", quotes, []float64{0, 0, 0}, initial, ErrInvalidCalibration},
+		{"invalid initial parameters", quotes, nil, HestonParams{V0: 0.04, Theta: 0.04}, ErrInvalidHeston},
+		{"invalid quote", append([]Option{{Strike: -1, DaysToExpiration: 30.0, UnderlyingPrice: 100.0}}, quotes...), nil, initial, ErrNonPositiveStrike},
+	}
+	for _, test := range tests {
+		if _, _, err := CalibrateHeston(test.quotes, test.weights, test.initial); !errors.Is(err, test.err) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", test.name, err, test.err)
+		}
+	}
+	if _, report, err := CalibrateHeston(quotes, nil, HestonParams{V0: 0.01, Kappa: 5.0, Theta: 0.1, Sigma: 1.0, Rho: 0.5}, WithCalibrationMaxIterations(1)); !errors.Is(err, ErrNotConverged) || report.Iterations != 1 {
+		t.Errorf("Unexpected result of a single iteration: got %v after %d iterations, want %v", err, report.Iterations, ErrNotConverged)
+	}
+}
@@ -0,0 +1,101 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCEVPriceBlackScholesLimit(t *testing.T) {
+	for _, typ := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           105.0,
+			DaysToExpiration: 180.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       typ,
+			DividendYield:    0.02,
+		}
+		want := BlackScholesOptionPrice(option, 0.25)
+		if got, err := CEVPrice(option, 0.25, 1); err != nil || got != want {
+			t.Errorf("Unexpected price with unit elasticity for %v: got %v (%v), want %v", typ, got, err, want)
+		}
+		// either side of one, with the same local volatility at the current price
+		for _, beta := range []float64{0.9999, 1.0001} {
+			got, err := CEVPrice(option, 0.25*math.Pow(100.0, 1-beta), beta)
+			if err != nil || math.Abs(got-want) > 1e-4 {
+				t.Errorf("Unexpected price with elasticity %v for %v: got %v (%v), want %v", beta, typ, got, err, want)
+			}
+		}
+	}
+}
+
+func TestCEVPrice(t *testing.T) {
+	// against a Crank-Nicolson solution of the pricing equation, absorbing at zero
+	tests := []struct {
+		option      Option
+		sigma, beta float64
+		want        float64
+	}{
+		{Option{Strike: 100.0, DaysToExpiration: 365.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Call, DividendYield: 0.02}, 2.0, 0.5, 9.23025},
+		{Option{Strike: 110.0, DaysToExpiration: 365.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Call, DividendYield: 0.02}, 2.0, 0.5, 5.01206},
+		{Option{Strike: 90.0, DaysToExpiration: 365.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Put, DividendYield: 0.02}, 2.0, 0.5, 2.87134},
+		{Option{Strike: 100.0, DaysToExpiration: 365.0, UnderlyingPrice: 100.0, OptionType: Call}, 2.0, 0.5, 7.96902},
+		{Option{Strike: 100.0, DaysToExpiration: 182.5, RiskFreeRate: 0.03, UnderlyingPrice: 100.0, OptionType: Put}, 0.002, 1.5, 0.10402},
+	}
+	for _, test := range tests {
+		got, err := CEVPrice(test.option, test.sigma, test.beta)
+		if err != nil || math.Abs(got-test.want) > 1e-3 {
+			t.Errorf("Unexpected price for %+v with elasticity %v: got %v (%v), want %v", test.option, test.beta, got, err, test.want)
+		}
+	}
+
+	// the call and put satisfy put-call parity on both sides of unit elasticity
+	for _, beta := range []float64{0, 0.5, 1.5} {
+		call := Option{Strike: 95.0, DaysToExpiration: 270.0, RiskFreeRate: 0.04, UnderlyingPrice: 100.0, OptionType: Call, DividendYield: 0.01}
+		put := call
+		put.OptionType = Put
+		sigma := 0.3 * math.Pow(100.0, 1-beta)
+		callPrice, _ := CEVPrice(call, sigma, beta)
+		putPrice, _ := CEVPrice(put, sigma, beta)
+		timeToExpiration := 270.0 / 365.0
+		parity := 100.0*math.Exp(-0.01*timeToExpiration) - 95.0*math.Exp(-0.04*timeToExpiration)
+		if got := callPrice - putPrice; math.Abs(got-parity) > 1e-10 {
+			t.Errorf("Unexpected put-call parity with elasticity %v: got %v, want %v", beta, got, parity)
+		}
+	}
+
+	// the skew: below unit elasticity out-of-the-money puts are dearer than Black-Scholes at the same local volatility
+	put := Option{Strike: 80.0, DaysToExpiration: 365.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Put}
+	got, _ := CEVPrice(put, 0.2*math.Pow(100.0, 0.5), 0.5)
+	if want := BlackScholesOptionPrice(put, 0.2); !(got > want) {
+		t.Errorf("Unexpected out-of-the-money put: got %v, not above %v", got, want)
+	}
+
+	expired := Option{Strike: 100.0, UnderlyingPrice: 110.0, OptionType: Call}
+	if got, err := CEVPrice(expired, 2.0, 0.5); err != nil || got != 10.0 {
+		t.Errorf("Unexpected price at expiration: got %v (%v), want 10", got, err)
+	}
+}
+
+func TestCEVPriceErrors(t *testing.T) {
+	option := Option{Strike: 100.0, DaysToExpiration: 90.0, UnderlyingPrice: 90.0, OptionType: Call}
+	tests := []struct {
+		name        string
+		option      Option
+		sigma, beta float64
+		err         error
+	}{
+		{"negative elasticity", option, 2.0, -0.5, ErrInvalidElasticity},
+		{"infinite elasticity", option, 2.0, math.Inf(1), ErrInvalidElasticity},
+		{"NaN elasticity", option, 2.0, math.NaN(), ErrInvalidElasticity},
+		{"zero volatility", option, 0, 0.5, ErrNonPositiveVolatility},
+		{"negative days", Option{Strike: 100.0, DaysToExpiration: -1, UnderlyingPrice: 90.0}, 2.0, 0.5, ErrNegativeExpiry},
+		{"infinite rate", Option{Strike: 100.0, DaysToExpiration: 90.0, RiskFreeRate: math.Inf(1), UnderlyingPrice: 90.0}, 2.0, 0.5, ErrInvalidRate},
+	}
+	for _, test := range tests {
+		if got, err := CEVPrice(test.option, test.sigma, test.beta); !errors.Is(err, test.err) || !math.IsNaN(got) {
+			t.Errorf("Unexpected result for %s: got %v (%v), want NaN (%v)", test.name, got, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package finance
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrNonPositiveVolatility is returned when a volatility is zero, negative, infinite or NaN
+	ErrNonPositiveVolatility = errors.New("finance: volatility must be positive and finite")
+	// ErrInvalidRate is returned when an option's risk-free rate or dividend yield is infinite or NaN
+	ErrInvalidRate = errors.New("finance: rate must be finite")
+	// ErrUndefinedLambda is returned when an option is too cheap for its lambda to be meaningful
+	ErrUndefinedLambda = errors.New("finance: lambda undefined for worthless option")
+)
+
+// The functions in this file are error-returning variants of the Black-Scholes pricing functions. Each validates its
+// inputs, returning the error from Validate, ErrNonPositiveVolatility, ErrInvalidRate or ErrNegativeAdjustedSpot
+// where the plain function would return NaN, and otherwise returns exactly what the plain function returns
+
+// BlackScholesOptionPriceE calculates the Black-Scholes option price like BlackScholesOptionPrice, returning an error
+// for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesOptionPriceE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesOptionPrice, option, vol)
+}
+
+// BlackScholesDeltaE computes the delta of an option like BlackScholesDelta, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesDeltaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesDelta, option, vol)
+}
+
+// BlackScholesGammaE computes the gamma of an option like BlackScholesGamma, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesGammaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesGamma, option, vol)
+}
+
+// BlackScholesVegaE computes the vega of an option like BlackScholesVega, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesVegaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesVega, option, vol)
+}
+
+// BlackScholesThetaE computes the theta of an option like BlackScholesTheta, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesThetaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesTheta, option, vol)
+}
+
+// BlackScholesRhoE computes the rho of an option like BlackScholesRho, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesRhoE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesRho, option, vol)
+}
+
+// BlackScholesVannaE computes the vanna of an option like BlackScholesVanna, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesVannaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesVanna, option, vol)
+}
+
+// BlackScholesVommaE computes the vomma of an option like BlackScholesVomma, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesVommaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesVomma, option, vol)
+}
+
+// BlackScholesCharmE computes the charm of an option like BlackScholesCharm, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesCharmE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesCharm, option, vol)
+}
+
+// BlackScholesSpeedE computes the speed of an option like BlackScholesSpeed, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesSpeedE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesSpeed, option, vol)
+}
+
+// BlackScholesZommaE computes the zomma of an option like BlackScholesZomma, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesZommaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesZomma, option, vol)
+}
+
+// BlackScholesColorE computes the color of an option like BlackScholesColor, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesColorE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesColor, option, vol)
+}
+
+// BlackScholesDualDeltaE computes the dual delta of an option like BlackScholesDualDelta, returning an error
+// for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesDualDeltaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesDualDelta, option, vol)
+}
+
+// BlackScholesDualGammaE computes the dual gamma of an option like BlackScholesDualGamma, returning an error
+// for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesDualGammaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesDualGamma, option, vol)
+}
+
+// BlackScholesEpsilonE computes the epsilon of an option like BlackScholesEpsilon, returning an error
+// for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesEpsilonE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesEpsilon, option, vol)
+}
+
+// BlackScholesUltimaE computes the ultima of an option like BlackScholesUltima, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesUltimaE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesUltima, option, vol)
+}
+
+// BlackScholesVeraE computes the vera of an option like BlackScholesVera, returning an error for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesVeraE(option Option, vol float64) (float64, error) {
+	return checked(BlackScholesVera, option, vol)
+}
+
+// BlackScholesLambdaE computes the lambda of an option like BlackScholesLambda, returning an error for invalid inputs
+// and ErrUndefinedLambda when the option price is below 1e-10
+// option: the option
+// vol: the volatility
+func BlackScholesLambdaE(option Option, vol float64) (float64, error) {
+	lambda, err := checked(BlackScholesLambda, option, vol)
+	if err == nil && math.IsNaN(lambda) {
+		return lambda, ErrUndefinedLambda
+	}
+	return lambda, err
+}
+
+// BlackScholesGreeksE computes the price and Greeks of an option like BlackScholesGreeks, returning an error
+// for invalid inputs
+// option: the option
+// vol: the volatility
+func BlackScholesGreeksE(option Option, vol float64) (Greeks, error) {
+	if err := validatePricing(option, vol); err != nil {
+		nan := math.NaN()
+		return Greeks{Price: nan, Delta: nan, Gamma: nan, Vega: nan, Theta: nan, Rho: nan}, err
+	}
+	return BlackScholesGreeks(option, vol), nil
+}
+
+// checked validates the inputs of a pricing function before calling it, returning NaN and the error
+// when they are invalid
+// f: the pricing function
+// option: the option
+// vol: the volatility
+func checked(f func(Option, float64) float64, option Option, vol float64) (float64, error) {
+	if err := validatePricing(option, vol); err != nil {
+		return math.NaN(), err
+	}
+	return f(option, vol), nil
+}
+
+// validatePricing checks that an option can be priced at the given volatility without producing NaN
+// option: the option
+// vol: the volatility
+func validatePricing(option Option, vol float64) error {
+	if err := option.Validate(); err != nil {
+		return err
+	}
+	if !(vol > 0) || math.IsInf(vol, 1) {
+		return ErrNonPositiveVolatility
+	}
+	return validateMarket(option)
+}
+
+// validateMarket checks that an option's rates and discrete dividends can be priced without producing NaN, for the
+// models that take no single volatility
+// option: the option
+func validateMarket(option Option) error {
+	if math.IsNaN(option.RiskFreeRate) || math.IsInf(option.RiskFreeRate, 0) ||
+		math.IsNaN(option.DividendYield) || math.IsInf(option.DividendYield, 0) {
+		return ErrInvalidRate
+	}
+	return checkDividends(option)
+}
@@ -0,0 +1,138 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+var checkedFunctions = []struct {
+	name    string
+	checked func(Option, float64) (float64, error)
+	plain   func(Option, float64) float64
+}{
+	{"price", BlackScholesOptionPriceE, BlackScholesOptionPrice},
+	{"delta", BlackScholesDeltaE, BlackScholesDelta},
+	{"gamma", BlackScholesGammaE, BlackScholesGamma},
+	{"vega", BlackScholesVegaE, BlackScholesVega},
+	{"theta", BlackScholesThetaE, BlackScholesTheta},
+	{"rho", BlackScholesRhoE, BlackScholesRho},
+	{"vanna", BlackScholesVannaE, BlackScholesVanna},
+	{"vomma", BlackScholesVommaE, BlackScholesVomma},
+	{"charm", BlackScholesCharmE, BlackScholesCharm},
+	{"speed", BlackScholesSpeedE, BlackScholesSpeed},
+	{"zomma", BlackScholesZommaE, BlackScholesZomma},
+	{"color", BlackScholesColorE, BlackScholesColor},
+	{"dual delta", BlackScholesDualDeltaE, BlackScholesDualDelta},
+	{"dual gamma", BlackScholesDualGammaE, BlackScholesDualGamma},
+	{"epsilon", BlackScholesEpsilonE, BlackScholesEpsilon},
+	{"ultima", BlackScholesUltimaE, BlackScholesUltima},
+	{"vera", BlackScholesVeraE, BlackScholesVera},
+}
+
+func TestCheckedFunctionsMatchPlain(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{80.0, 100.0, 120.0} {
+			for _, days := range []float64{0.0, 1.0, 30.0, 365.0} {
+				option := Option{
+					Strike:           strike,
+					DaysToExpiration: days,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  100.0,
+					OptionType:       optionType,
+					DividendYield:    0.01,
+				}
+
+				for _, f := range checkedFunctions {
+					got, err := f.checked(option, 0.25)
+					if err != nil {
+						t.Errorf("Unexpected %s error for type %v strike %v days %v: %v", f.name, optionType, strike, days, err)
+						continue
+					}
+					if math.IsNaN(got) {
+						t.Errorf("Unexpected NaN %s for type %v strike %v days %v", f.name, optionType, strike, days)
+					}
+					if want := f.plain(option, 0.25); got != want {
+						t.Errorf("Unexpected %s for type %v strike %v days %v: got %v, want %v", f.name, optionType, strike, days, got, want)
+					}
+				}
+
+				greeks, err := BlackScholesGreeksE(option, 0.25)
+				if err != nil {
+					t.Errorf("Unexpected Greeks error for type %v strike %v days %v: %v", optionType, strike, days, err)
+				} else if want := BlackScholesGreeks(option, 0.25); greeks != want {
+					t.Errorf("Unexpected Greeks for type %v strike %v days %v: got %+v, want %+v", optionType, strike, days, greeks, want)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckedFunctionsInvalidInputs(t *testing.T) {
+	valid := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	tests := []struct {
+		description string
+		modify      func(*Option)
+		vol         float64
+		expected    error
+	}{
+		{"zero strike", func(o *Option) { o.Strike = 0 }, 0.2, ErrNonPositiveStrike},
+		{"negative underlying", func(o *Option) { o.UnderlyingPrice = -1.0 }, 0.2, ErrNonPositiveUnderlying},
+		{"negative expiry", func(o *Option) { o.DaysToExpiration = -1.0 }, 0.2, ErrNegativeExpiry},
+		{"invalid option type", func(o *Option) { o.OptionType = OptionType(7) }, 0.2, ErrInvalidOptionType},
+		{"zero volatility", func(o *Option) {}, 0, ErrNonPositiveVolatility},
+		{"infinite volatility", func(o *Option) {}, math.Inf(1), ErrNonPositiveVolatility},
+		{"NaN volatility", func(o *Option) {}, math.NaN(), ErrNonPositiveVolatility},
+		{"NaN rate", func(o *Option) { o.RiskFreeRate = math.NaN() }, 0.2, ErrInvalidRate},
+		{"infinite dividend yield", func(o *Option) { o.DividendYield = math.Inf(1) }, 0.2, ErrInvalidRate},
+		{"excessive dividends", func(o *Option) { o.Dividends = []Dividend{{Amount: 150.0, DaysToExDate: 10.0}} }, 0.2, ErrNegativeAdjustedSpot},
+	}
+
+	for _, test := range tests {
+		option := valid
+		test.modify(&option)
+
+		for _, f := range checkedFunctions {
+			value, err := f.checked(option, test.vol)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Unexpected %s error for %v: got %v, want %v", f.name, test.description, err, test.expected)
+			}
+			if !math.IsNaN(value) {
+				t.Errorf("Unexpected %s for %v: got %v, want NaN", f.name, test.description, value)
+			}
+		}
+		if _, err := BlackScholesGreeksE(option, test.vol); !errors.Is(err, test.expected) {
+			t.Errorf("Unexpected Greeks error for %v: got %v, want %v", test.description, err, test.expected)
+		}
+	}
+}
+
+func TestBlackScholesLambdaE(t *testing.T) {
+	option := Option{
+		Strike:           200.0,
+		DaysToExpiration: 1.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	if _, err := BlackScholesLambdaE(option, 0.2); !errors.Is(err, ErrUndefinedLambda) {
+		t.Errorf("Unexpected error for worthless option: got %v, want %v", err, ErrUndefinedLambda)
+	}
+
+	option.Strike = 100.0
+	lambda, err := BlackScholesLambdaE(option, 0.2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := BlackScholesLambda(option, 0.2); lambda != want {
+		t.Errorf("Unexpected lambda: got %v, want %v", lambda, want)
+	}
+}
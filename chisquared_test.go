@@ -0,0 +1,49 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNoncentralChiSquaredCDF(t *testing.T) {
+	// with one degree of freedom it is the distribution of (Z + √λ)², N(√x − √λ) − N(−√x − √λ)
+	for _, x := range []float64{0.01, 0.3, 1, 5, 20, 100} {
+		for _, lambda := range []float64{0, 0.5, 4, 10, 90} {
+			want := Phi(math.Sqrt(x)-math.Sqrt(lambda)) - Phi(-math.Sqrt(x)-math.Sqrt(lambda))
+			if got := NoncentralChiSquaredCDF(x, 1, lambda); math.Abs(got-want) > 1e-14 {
+				t.Errorf("Unexpected distribution at %v with noncentrality %v: got %v, want %v", x, lambda, got, want)
+			}
+			// the complement is summed separately, and still makes up one
+			p, q := noncentralChiSquared(x, 3, lambda)
+			if math.Abs(p+q-1) > 1e-14 {
+				t.Errorf("Unexpected complement at %v with noncentrality %v: got %v + %v", x, lambda, p, q)
+			}
+		}
+		// the central distribution with two degrees of freedom is exponential
+		if got, want := NoncentralChiSquaredCDF(x, 2, 0), -math.Expm1(-0.5*x); math.Abs(got-want) > 1e-15 {
+			t.Errorf("Unexpected central distribution at %v: got %v, want %v", x, got, want)
+		}
+	}
+
+	// far in the upper tail the complement keeps its precision, e^{−x/2} for two degrees of freedom
+	if _, got := noncentralChiSquared(200, 2, 0); math.Abs(got-math.Exp(-100))/math.Exp(-100) > 1e-12 {
+		t.Errorf("Unexpected upper tail: got %v, want %v", got, math.Exp(-100))
+	}
+	// a large noncentrality needs no more than a few hundred terms
+	if got := NoncentralChiSquaredCDF(20000, 0, 20000); math.Abs(got-0.5) > 0.01 {
+		t.Errorf("Unexpected distribution at its mean: got %v, want about 0.5", got)
+	}
+	if got := NoncentralChiSquaredCDF(-1, 2, 1); got != 0 {
+		t.Errorf("Unexpected distribution below zero: got %v, want 0", got)
+	}
+
+	for name, got := range map[string]float64{
+		"negative degrees of freedom": NoncentralChiSquaredCDF(1, -1, 1),
+		"negative noncentrality":      NoncentralChiSquaredCDF(1, 1, -1),
+		"NaN value":                   NoncentralChiSquaredCDF(math.NaN(), 1, 1),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected distribution with %s: got %v, want NaN", name, got)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package finance
+
+import (
+	"math"
+)
+
+// ChooserOptionPrice computes the Rubinstein (1991) price of a simple chooser option, whose holder decides at the
+// choice date whether it is a call or a put with the option's strike and expiration. It decomposes into a call
+// expiring at T and e^{−q(T−t)} puts expiring at the choice date t struck at K·e^{−(r−q)(T−t)}: choosing today it is
+// worth the dearer of the call and the put, and choosing at expiration a straddle. The option's type is ignored.
+// Discrete dividends are escrowed. NaN with negative days to expiration or a choice date outside the option's life
+// option: the option
+// vol: the volatility
+// chooseTimeDays: the days until the holder must choose
+func ChooserOptionPrice(option Option, vol, chooseTimeDays float64) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration < 0 || !(chooseTimeDays >= 0 && chooseTimeDays <= option.DaysToExpiration) {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		return math.Abs(option.UnderlyingPrice - option.Strike)
+	}
+
+	remaining := (option.DaysToExpiration - chooseTimeDays) / 365.0
+	call, put := option, option
+	call.OptionType = Call
+	put.OptionType = Put
+	put.DaysToExpiration = chooseTimeDays
+	put.Strike = option.Strike * math.Exp(-(option.RiskFreeRate-option.DividendYield)*remaining)
+	return BlackScholesOptionPrice(call, vol) + math.Exp(-option.DividendYield*remaining)*BlackScholesOptionPrice(put, vol)
+}
@@ -0,0 +1,67 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChooserOptionPrice(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas, simple chooser example
+	option := Option{
+		Strike:           50.0,
+		DaysToExpiration: 0.5 * 365,
+		RiskFreeRate:     0.08,
+		UnderlyingPrice:  50.0,
+		OptionType:       Call,
+	}
+	if got := ChooserOptionPrice(option, 0.25, 0.25*365); math.Abs(got-6.1071) > 5e-5 {
+		t.Errorf("Unexpected chooser price: got %v, want 6.1071", got)
+	}
+
+	for _, strike := range []float64{40.0, 50.0, 60.0} {
+		option.Strike = strike
+		option.DividendYield = 0.03
+		call, put := option, option
+		put.OptionType = Put
+		callPrice, putPrice := BlackScholesOptionPrice(call, 0.25), BlackScholesOptionPrice(put, 0.25)
+
+		checks := []struct {
+			name      string
+			got, want float64
+		}{
+			{"choosing today", ChooserOptionPrice(option, 0.25, 0), math.Max(callPrice, putPrice)},
+			{"choosing at expiration", ChooserOptionPrice(option, 0.25, option.DaysToExpiration), callPrice + putPrice},
+			{"type ignored", ChooserOptionPrice(put, 0.25, 30.0), ChooserOptionPrice(call, 0.25, 30.0)},
+		}
+		for _, c := range checks {
+			if math.Abs(c.got-c.want) > 1e-12 {
+				t.Errorf("Unexpected chooser price %s for strike %v: got %v, want %v", c.name, strike, c.got, c.want)
+			}
+		}
+
+		// the right to choose later is worth more
+		previous := ChooserOptionPrice(option, 0.25, 0)
+		for days := 10.0; days <= option.DaysToExpiration; days += 10 {
+			if got := ChooserOptionPrice(option, 0.25, days); got < previous {
+				t.Errorf("Unexpected chooser price for strike %v choosing in %v days: got %v, below %v", strike, days, got, previous)
+			}
+			previous = ChooserOptionPrice(option, 0.25, days)
+		}
+	}
+
+	option.DaysToExpiration = 0
+	option.Strike = 55.0
+	if got := ChooserOptionPrice(option, 0.25, 0); got != 5.0 {
+		t.Errorf("Unexpected chooser price at expiration: got %v, want 5", got)
+	}
+	option.DaysToExpiration = 90.0
+	for name, got := range map[string]float64{
+		"negative choice":         ChooserOptionPrice(option, 0.25, -1),
+		"choice after expiration": ChooserOptionPrice(option, 0.25, 91),
+		"negative days":           ChooserOptionPrice(Option{Strike: 50.0, DaysToExpiration: -1, UnderlyingPrice: 50.0}, 0.25, 0),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected chooser price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
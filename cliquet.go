@@ -0,0 +1,186 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidCliquet is returned for a cliquet whose reset dates are not positive and increasing, whose caps and floors
+// do not match its periods or are crossed, or whose reset dates fall on no grid the Monte Carlo engine can observe
+var ErrInvalidCliquet = errors.New("finance: invalid cliquet")
+
+const (
+	cliquetPaths           = 100000 // Number of paths simulated with a global cap or floor if the configuration sets none
+	cliquetMaxObservations = 3660   // Largest number of equally spaced observation dates searched for the reset dates
+)
+
+// CliquetSpec describes a cliquet, which pays at expiration, per unit of notional, the sum over its periods of the
+// return of the underlying over each period clamped to that period's floor and cap, the sum itself clamped to the
+// global floor and cap. A cap or floor left unset is none
+type CliquetSpec struct {
+	ResetDays      []float64 // Days from today to the end of each period, increasing; the first period starts today and the last ends at expiration
+	LocalCaps      []float64 // Cap on the return of each period, +Inf for none; empty for no caps
+	LocalFloors    []float64 // Floor on the return of each period, −Inf for none; empty for no floors
+	GlobalCap      float64   // Cap on the sum of the clamped returns if HasGlobalCap, +Inf for none
+	GlobalFloor    float64   // Floor on the sum of the clamped returns if HasGlobalFloor, −Inf for none
+	HasGlobalCap   bool      // Whether the sum is capped at GlobalCap; false for no global cap
+	HasGlobalFloor bool      // Whether the sum is floored at GlobalFloor; false for no global floor
+	MC             MCConfig  // Monte Carlo configuration used with a global cap or floor; 100000 paths if Paths is 0
+}
+
+// CliquetPrice prices a cliquet under Black-Scholes dynamics. Each period's return is independent of the others and
+// lognormal, so with no global cap or floor the cliquet is a strip of forward-start call spreads, struck at the
+// period's floor and cap, and is priced in closed form. With a global cap or floor the payoff no longer separates
+// and the cliquet is priced with MonteCarloPathPrice, observing the underlying at the coarsest equally spaced dates
+// that include every reset date
+// Returns the errors of MonteCarloPathPrice for an invalid volatility, rates or simulation configuration and
+// ErrInvalidCliquet for an invalid spec
+// spec: the cliquet
+// vol: the volatility
+// r: the risk-free interest rate
+// q: the continuous dividend yield
+func CliquetPrice(spec CliquetSpec, vol float64, r, q float64) (float64, error) {
+	if err := spec.validate(); err != nil {
+		return math.NaN(), err
+	}
+	periods := len(spec.ResetDays)
+	unit := Option{
+		Strike:           1,
+		DaysToExpiration: spec.ResetDays[periods-1],
+		RiskFreeRate:     r,
+		UnderlyingPrice:  1,
+		OptionType:       Call,
+		DividendYield:    q,
+	}
+	if err := validatePricing(unit, vol); err != nil {
+		return math.NaN(), err
+	}
+
+	if math.IsInf(spec.globalCap(), 1) && math.IsInf(spec.globalFloor(), -1) {
+		// E[min(max(R, f), c)] = f + E[(R − f)⁺] − E[(R − c)⁺], each a forward-start call on a unit spot grown to
+		// expiration, e^{rτ}·C(1, 1 + k, τ), and a floor below −1 never binds
+		var sum float64
+		start := 0.0
+		for i, end := range spec.ResetDays {
+			period := unit
+			period.DaysToExpiration = end - start
+			growth := math.Exp(r * period.DaysToExpiration / 365.0)
+			floor, ceiling := spec.localFloor(i), spec.localCap(i)
+			period.Strike = 1 + floor
+			sum += floor + growth*BlackScholesOptionPrice(period, vol)
+			if !math.IsInf(ceiling, 1) {
+				period.Strike = 1 + ceiling
+				sum -= growth * BlackScholesOptionPrice(period, vol)
+			}
+			start = end
+		}
+		return math.Exp(-r*unit.DaysToExpiration/365.0) * sum, nil
+	}
+
+	observations, indices, ok := spec.observationGrid()
+	if !ok {
+		return math.NaN(), fmt.Errorf("%w: reset dates on no grid of up to %d observations", ErrInvalidCliquet, cliquetMaxObservations)
+	}
+	payoff := func(_ Option, path []float64) float64 {
+		var sum float64
+		previous := 1.0
+		for i, index := range indices {
+			sum += math.Min(math.Max(path[index]/previous-1, spec.localFloor(i)), spec.localCap(i))
+			previous = path[index]
+		}
+		return math.Min(math.Max(sum, spec.globalFloor()), spec.globalCap())
+	}
+	cfg := spec.MC
+	if cfg.Paths == 0 {
+		cfg.Paths = cliquetPaths
+	}
+	result, err := MonteCarloPathPrice(unit, vol, payoff, observations, cfg)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return result.Price, nil
+}
+
+// validate checks the reset dates, caps and floors of a cliquet
+func (spec CliquetSpec) validate() error {
+	periods := len(spec.ResetDays)
+	switch {
+	case periods == 0:
+		return fmt.Errorf("%w: no reset dates", ErrInvalidCliquet)
+	case len(spec.LocalCaps) != 0 && len(spec.LocalCaps) != periods:
+		return fmt.Errorf("%w: %d local caps for %d periods", ErrInvalidCliquet, len(spec.LocalCaps), periods)
+	case len(spec.LocalFloors) != 0 && len(spec.LocalFloors) != periods:
+		return fmt.Errorf("%w: %d local floors for %d periods", ErrInvalidCliquet, len(spec.LocalFloors), periods)
+	case !(spec.globalFloor() <= spec.globalCap()):
+		return fmt.Errorf("%w: global floor %v above cap %v", ErrInvalidCliquet, spec.globalFloor(), spec.globalCap())
+	}
+	start := 0.0
+	for i, end := range spec.ResetDays {
+		if !(end > start) || math.IsInf(end, 1) {
+			return fmt.Errorf("%w: reset date %v after %v", ErrInvalidCliquet, end, start)
+		}
+		if floor, ceiling := spec.localFloor(i), spec.localCap(i); !(floor <= ceiling) || math.IsInf(floor, 1) || math.IsInf(ceiling, -1) {
+			return fmt.Errorf("%w: period %d floor %v and cap %v", ErrInvalidCliquet, i, floor, ceiling)
+		}
+		start = end
+	}
+	return nil
+}
+
+// localCap returns the cap on the return of a period, +Inf if none
+// period: the index of the period
+func (spec CliquetSpec) localCap(period int) float64 {
+	if len(spec.LocalCaps) == 0 {
+		return math.Inf(1)
+	}
+	return spec.LocalCaps[period]
+}
+
+// localFloor returns the floor on the return of a period, at least −1, the lowest return possible
+// period: the index of the period
+func (spec CliquetSpec) localFloor(period int) float64 {
+	if len(spec.LocalFloors) == 0 {
+		return -1
+	}
+	return math.Max(spec.LocalFloors[period], -1)
+}
+
+// globalCap returns the cap on the sum of the clamped returns, +Inf if none
+func (spec CliquetSpec) globalCap() float64 {
+	if !spec.HasGlobalCap {
+		return math.Inf(1)
+	}
+	return spec.GlobalCap
+}
+
+// globalFloor returns the floor on the sum of the clamped returns, −Inf if none
+func (spec CliquetSpec) globalFloor() float64 {
+	if !spec.HasGlobalFloor {
+		return math.Inf(-1)
+	}
+	return spec.GlobalFloor
+}
+
+// observationGrid finds the fewest equally spaced observation dates up to expiration that include every reset date,
+// and the index in the path of each reset date. Reports false if there are more than cliquetMaxObservations
+func (spec CliquetSpec) observationGrid() (int, []int, bool) {
+	expiration := spec.ResetDays[len(spec.ResetDays)-1]
+	indices := make([]int, len(spec.ResetDays))
+	for observations := len(spec.ResetDays); observations <= cliquetMaxObservations; observations++ {
+		aligned := true
+		for i, days := range spec.ResetDays {
+			position := days / expiration * float64(observations)
+			index := math.Round(position)
+			if math.Abs(position-index) > 1e-9*float64(observations) {
+				aligned = false
+				break
+			}
+			indices[i] = int(index) - 1
+		}
+		if aligned {
+			return observations, indices, true
+		}
+	}
+	return 0, nil, false
+}
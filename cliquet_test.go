@@ -0,0 +1,165 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCliquetPriceSinglePeriod(t *testing.T) {
+	// a single period floored at 0 and capped at 10% is a call spread on a unit spot
+	call := Option{
+		Strike:           1.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  1.0,
+		OptionType:       Call,
+		DividendYield:    0.02,
+	}
+	capped := call
+	capped.Strike = 1.1
+	want := BlackScholesOptionPrice(call, 0.2) - BlackScholesOptionPrice(capped, 0.2)
+
+	spec := CliquetSpec{
+		ResetDays:   []float64{365.0},
+		LocalCaps:   []float64{0.1},
+		LocalFloors: []float64{0},
+	}
+	got, err := CliquetPrice(spec, 0.2, 0.05, 0.02)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected single-period cliquet price: got %v, want %v", got, want)
+	}
+
+	// a global cap above the local one changes nothing, but is priced by simulation
+	spec.GlobalCap, spec.HasGlobalCap = 1, true
+	spec.MC = MCConfig{Paths: 1 << 14, Sampler: QuasiRandom}
+	got, err = CliquetPrice(spec, 0.2, 0.05, 0.02)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-3 {
+		t.Errorf("Unexpected simulated single-period cliquet price: got %v, want %v", got, want)
+	}
+}
+
+func TestCliquetPrice(t *testing.T) {
+	// quarterly resets, each return floored at −2% and capped at 3%
+	spec := CliquetSpec{
+		ResetDays:   []float64{91.25, 182.5, 273.75, 365.0},
+		LocalCaps:   []float64{0.03, 0.03, 0.03, 0.03},
+		LocalFloors: []float64{-0.02, -0.02, -0.02, -0.02},
+	}
+	strip, err := CliquetPrice(spec, 0.25, 0.04, 0.01)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the strip agrees with simulation of the same payoff, forced by a global floor that never binds
+	spec.GlobalFloor, spec.HasGlobalFloor = -1, true
+	spec.MC = MCConfig{Paths: 200000, Seed: 5, Antithetic: true}
+	simulated, err := CliquetPrice(spec, 0.25, 0.04, 0.01)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(simulated-strip) > 3e-4 {
+		t.Errorf("Unexpected simulated cliquet price: got %v, want %v", simulated, strip)
+	}
+
+	// a binding global floor adds value and a binding global cap removes it
+	spec.GlobalFloor = 0
+	floored, err := CliquetPrice(spec, 0.25, 0.04, 0.01)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	spec.HasGlobalFloor = false
+	spec.GlobalCap, spec.HasGlobalCap = 0.05, true
+	capped, err := CliquetPrice(spec, 0.25, 0.04, 0.01)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !(floored > strip && capped < strip) {
+		t.Errorf("Unexpected globally floored and capped prices: got %v and %v around %v", floored, capped, strip)
+	}
+
+	// with no caps or floors at all, each period is worth its expected return
+	uncapped := CliquetSpec{ResetDays: []float64{180.0, 365.0}}
+	got, err := CliquetPrice(uncapped, 0.25, 0.04, 0.01)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := math.Exp(-0.04) * (math.Expm1(0.03*180.0/365.0) + math.Expm1(0.03*185.0/365.0))
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected uncapped cliquet price: got %v, want %v", got, want)
+	}
+}
+
+func TestCliquetPriceLocalBounds(t *testing.T) {
+	// a spec setting only local caps and floors leaves the sum unclamped, and is the strip of call spreads
+	spec := CliquetSpec{
+		ResetDays:   []float64{182.5, 365.0},
+		LocalCaps:   []float64{0.05, 0.05},
+		LocalFloors: []float64{0, 0},
+	}
+	got, err := CliquetPrice(spec, 0.2, 0.05, 0.02)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	period := Option{
+		Strike:           1.0,
+		DaysToExpiration: 182.5,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  1.0,
+		OptionType:       Call,
+		DividendYield:    0.02,
+	}
+	capped := period
+	capped.Strike = 1.05
+	spread := math.Exp(0.05*0.5) * (BlackScholesOptionPrice(period, 0.2) - BlackScholesOptionPrice(capped, 0.2))
+	want := math.Exp(-0.05) * 2 * spread
+	if !(got > 0) || math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected cliquet price with only local bounds: got %v, want %v", got, want)
+	}
+
+	// a global cap and floor that are set but never bind agree with the strip
+	spec.GlobalCap, spec.GlobalFloor, spec.HasGlobalCap, spec.HasGlobalFloor = 1, -1, true, true
+	spec.MC = MCConfig{Paths: 1 << 14, Sampler: QuasiRandom}
+	simulated, err := CliquetPrice(spec, 0.2, 0.05, 0.02)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(simulated-want) > 1e-3 {
+		t.Errorf("Unexpected simulated cliquet price with only local bounds: got %v, want %v", simulated, want)
+	}
+}
+
+func TestCliquetPriceErrors(t *testing.T) {
+	valid := CliquetSpec{ResetDays: []float64{90.0, 180.0}}
+	tests := []struct {
+		name string
+		spec func(*CliquetSpec)
+		err  error
+	}{
+		{"no reset dates", func(s *CliquetSpec) { s.ResetDays = nil }, ErrInvalidCliquet},
+		{"decreasing reset dates", func(s *CliquetSpec) { s.ResetDays = []float64{180.0, 90.0} }, ErrInvalidCliquet},
+		{"caps for too few periods", func(s *CliquetSpec) { s.LocalCaps = []float64{0.1} }, ErrInvalidCliquet},
+		{"crossed local cap", func(s *CliquetSpec) { s.LocalCaps, s.LocalFloors = []float64{0.1, 0.1}, []float64{0.2, 0} }, ErrInvalidCliquet},
+		{"crossed global cap", func(s *CliquetSpec) {
+			s.GlobalCap, s.GlobalFloor, s.HasGlobalCap, s.HasGlobalFloor = 0, 0.1, true, true
+		}, ErrInvalidCliquet},
+		{"no observation grid", func(s *CliquetSpec) { s.ResetDays, s.HasGlobalFloor = []float64{math.Pi, 10.0}, true }, ErrInvalidCliquet},
+		{"no paths", func(s *CliquetSpec) { s.HasGlobalFloor, s.MC.Paths = true, -1 }, ErrInvalidPaths},
+	}
+	for _, test := range tests {
+		spec := valid
+		test.spec(&spec)
+		if got, err := CliquetPrice(spec, 0.2, 0.05, 0); !errors.Is(err, test.err) || !math.IsNaN(got) {
+			t.Errorf("Unexpected result for %s: got %v, %v; want NaN, %v", test.name, got, err, test.err)
+		}
+	}
+	if _, err := CliquetPrice(valid, 0, 0.05, 0); !errors.Is(err, ErrNonPositiveVolatility) {
+		t.Errorf("Unexpected error for zero volatility: got %v, want %v", err, ErrNonPositiveVolatility)
+	}
+}
@@ -0,0 +1,121 @@
+package finance
+
+import (
+	"math"
+)
+
+// CompoundKind is the types of the outer option and of the inner option it is written on
+type CompoundKind int
+
+const (
+	CallOnCall CompoundKind = iota // Right to buy a call
+	CallOnPut                      // Right to buy a put
+	PutOnCall                      // Right to sell a call
+	PutOnPut                       // Right to sell a put
+)
+
+// CompoundOptionPrice computes the Geske (1979) price of a compound option, an option expiring at t1 to buy or sell
+// for the outer strike a European option on the underlying expiring at T2 > t1. The outer option is exercised when
+// the inner one is worth more than its strike at t1, above a critical underlying price for an inner call and below
+// it for an inner put, which is found by Brent's method inside bounds that follow from the inner option's intrinsic
+// value and so hold however deep in the money it is. The price is then a combination of bivariate normal
+// probabilities with correlation √(t1/T2). The outer option's type is ignored in favour of the kind. Discrete
+// dividends paid until T2 are escrowed. NaN for negative days to expiration, an inner option expiring before the
+// outer one, non-positive strikes or an unknown kind
+// outer: the outer option, whose strike is paid for the inner option and whose expiration is the decision date
+// vol: the volatility
+// innerStrike: the strike of the inner option
+// innerDays: the days to expiration of the inner option
+// kind: the types of the outer and inner options
+func CompoundOptionPrice(outer Option, vol, innerStrike, innerDays float64, kind CompoundKind) float64 {
+	if outer.DaysToExpiration < 0 || !(innerDays >= outer.DaysToExpiration) || !(outer.Strike > 0) || !(innerStrike > 0) ||
+		kind < CallOnCall || kind > PutOnPut {
+		return math.NaN()
+	}
+	inner := outer
+	inner.Strike, inner.DaysToExpiration, inner.OptionType = innerStrike, innerDays, Call
+	if kind == CallOnPut || kind == PutOnPut {
+		inner.OptionType = Put
+	}
+	inner = escrowed(inner)
+	outerIsCall := kind == CallOnCall || kind == CallOnPut
+	if outer.DaysToExpiration == 0 {
+		value := BlackScholesOptionPrice(inner, vol) - outer.Strike
+		if !outerIsCall {
+			value = -value
+		}
+		return math.Max(value, 0)
+	}
+
+	// the inner option's value at the decision date as a function of the underlying price then
+	decision := outer.DaysToExpiration / 365.0
+	remaining := inner
+	remaining.DaysToExpiration = innerDays - outer.DaysToExpiration
+	innerValue := func(spot float64) float64 {
+		remaining.UnderlyingPrice = spot
+		return BlackScholesOptionPrice(remaining, vol)
+	}
+	timeToExpiration := innerDays / 365.0
+	discount := math.Exp(-inner.RiskFreeRate * timeToExpiration)
+	outerDiscount := math.Exp(-inner.RiskFreeRate * decision)
+	if inner.OptionType == Put && innerValue(0) <= outer.Strike {
+		// the inner put can never be worth more than the outer strike: the call on it is never exercised and the put
+		// on it always is
+		if kind == CallOnPut {
+			return 0
+		}
+		return outer.Strike*outerDiscount - BlackScholesOptionPrice(inner, vol)
+	}
+	critical, ok := compoundCriticalPrice(innerValue, remaining, outer.Strike)
+	if !ok {
+		return math.NaN()
+	}
+
+	stdDev, innerStdDev := vol*math.Sqrt(decision), vol*math.Sqrt(timeToExpiration)
+	carry := inner.RiskFreeRate - inner.DividendYield
+	y1 := (math.Log(inner.UnderlyingPrice/critical) + (carry+0.5*vol*vol)*decision) / stdDev
+	y2 := y1 - stdDev
+	z1 := (math.Log(inner.UnderlyingPrice/innerStrike) + (carry+0.5*vol*vol)*timeToExpiration) / innerStdDev
+	z2 := z1 - innerStdDev
+	rho := math.Sqrt(decision / timeToExpiration)
+	discountedSpot := inner.UnderlyingPrice * math.Exp(-inner.DividendYield*timeToExpiration)
+	discountedStrike := innerStrike * discount
+	outerStrike := outer.Strike * outerDiscount
+
+	switch kind {
+	case CallOnCall:
+		return discountedSpot*Phi2(z1, y1, rho) - discountedStrike*Phi2(z2, y2, rho) - outerStrike*Phi(y2)
+	case PutOnCall:
+		return discountedStrike*Phi2(z2, -y2, -rho) - discountedSpot*Phi2(z1, -y1, -rho) + outerStrike*PhiC(y2)
+	case CallOnPut:
+		return discountedStrike*Phi2(-z2, -y2, rho) - discountedSpot*Phi2(-z1, -y1, rho) - outerStrike*PhiC(y2)
+	default: // put on put
+		return discountedSpot*Phi2(-z1, y1, -rho) - discountedStrike*Phi2(-z2, y2, -rho) + outerStrike*Phi(y2)
+	}
+}
+
+// compoundCriticalPrice finds the underlying price at the decision date at which the inner option of a compound
+// option is worth the outer strike. The inner option's value lies within its discounted intrinsic value and the
+// discounted underlying price or strike, which bound the critical price; the upper bound for a put is found by
+// doubling. Reports false if the root finder fails
+// innerValue: the inner option's value at the decision date as a function of the underlying price then
+// remaining: the inner option as seen from the decision date
+// outerStrike: the strike of the outer option
+func compoundCriticalPrice(innerValue func(float64) float64, remaining Option, outerStrike float64) (float64, bool) {
+	timeToExpiration := remaining.DaysToExpiration / 365.0
+	discountedStrike := remaining.Strike * math.Exp(-remaining.RiskFreeRate*timeToExpiration)
+	growth := math.Exp(remaining.DividendYield * timeToExpiration)
+	objective := func(spot float64) float64 { return innerValue(spot) - outerStrike }
+
+	// a call is worth at least S·e^{−qτ} − K·e^{−rτ} and less than S·e^{−qτ}; a put at least K·e^{−rτ} − S·e^{−qτ}
+	lower, upper := outerStrike*growth, (outerStrike+discountedStrike)*growth
+	if remaining.OptionType == Put {
+		lower = math.Max((discountedStrike-outerStrike)*growth, 0)
+		upper = math.Max(remaining.Strike, lower)
+		for i := 0; objective(upper) > 0 && i < 64; i++ {
+			upper *= 2
+		}
+	}
+	critical, _, err := brent(objective, lower, upper, impliedTolerance*upper, impliedMaxIterations)
+	return critical, err == nil
+}
@@ -0,0 +1,126 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompoundOptionPriceHaug(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas, put on call example, printed with the error of the book's
+	// bivariate normal approximation; quadrature gives 21.19635
+	outer := Option{
+		Strike:           50.0,
+		DaysToExpiration: 0.25 * 365,
+		RiskFreeRate:     0.08,
+		UnderlyingPrice:  500.0,
+		DividendYield:    0.03,
+	}
+	if got := CompoundOptionPrice(outer, 0.35, 520.0, 0.5*365, PutOnCall); math.Abs(got-21.1965) > 2e-4 {
+		t.Errorf("Unexpected put on call price: got %v, want 21.1965", got)
+	}
+}
+
+// compoundByQuadrature integrates the outer payoff on the inner option's value at the decision date over the
+// lognormal distribution of the underlying price then
+func compoundByQuadrature(outer Option, vol, innerStrike, innerDays float64, kind CompoundKind) float64 {
+	decision := outer.DaysToExpiration / 365.0
+	inner := outer
+	inner.Strike, inner.DaysToExpiration, inner.OptionType = innerStrike, innerDays-outer.DaysToExpiration, Call
+	if kind == CallOnPut || kind == PutOnPut {
+		inner.OptionType = Put
+	}
+	const steps = 40000
+	var sum float64
+	for i := 0; i <= steps; i++ {
+		z := -10 + 20*float64(i)/steps
+		inner.UnderlyingPrice = outer.UnderlyingPrice * math.Exp((outer.RiskFreeRate-outer.DividendYield-0.5*vol*vol)*decision+vol*math.Sqrt(decision)*z)
+		payoff := BlackScholesOptionPrice(inner, vol) - outer.Strike
+		if kind == PutOnCall || kind == PutOnPut {
+			payoff = -payoff
+		}
+		weight := 20.0 / steps
+		if i == 0 || i == steps {
+			weight /= 2
+		}
+		sum += weight * NormalDistributionDerivative(z) * math.Max(payoff, 0)
+	}
+	return math.Exp(-outer.RiskFreeRate*decision) * sum
+}
+
+func TestCompoundOptionPrice(t *testing.T) {
+	kinds := []CompoundKind{CallOnCall, CallOnPut, PutOnCall, PutOnPut}
+	for _, spot := range []float64{50.0, 100.0, 200.0, 400.0} {
+		for _, outerStrike := range []float64{1.0, 5.0, 20.0} {
+			outer := Option{
+				Strike:           outerStrike,
+				DaysToExpiration: 90.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  spot,
+				DividendYield:    0.02,
+			}
+			prices := make(map[CompoundKind]float64)
+			for _, kind := range kinds {
+				prices[kind] = CompoundOptionPrice(outer, 0.3, 100.0, 270.0, kind)
+				if want := compoundByQuadrature(outer, 0.3, 100.0, 270.0, kind); math.Abs(prices[kind]-want) > 1e-7*math.Max(1, want) {
+					t.Errorf("Unexpected price for kind %v spot %v outer strike %v: got %v, want %v", kind, spot, outerStrike, prices[kind], want)
+				}
+			}
+
+			// a call less a put on the same inner option is a forward contract on it
+			call, put := outer, outer
+			call.Strike, call.DaysToExpiration, call.OptionType = 100.0, 270.0, Call
+			put.Strike, put.DaysToExpiration, put.OptionType = 100.0, 270.0, Put
+			outerStrikeValue := outerStrike * math.Exp(-0.05*90.0/365.0)
+			if got, want := prices[CallOnCall]-prices[PutOnCall], BlackScholesOptionPrice(call, 0.3)-outerStrikeValue; math.Abs(got-want) > 1e-10 {
+				t.Errorf("Unexpected parity on calls for spot %v outer strike %v: got %v, want %v", spot, outerStrike, got, want)
+			}
+			if got, want := prices[CallOnPut]-prices[PutOnPut], BlackScholesOptionPrice(put, 0.3)-outerStrikeValue; math.Abs(got-want) > 1e-10 {
+				t.Errorf("Unexpected parity on puts for spot %v outer strike %v: got %v, want %v", spot, outerStrike, got, want)
+			}
+		}
+	}
+}
+
+func TestCompoundOptionPriceEdgeCases(t *testing.T) {
+	outer := Option{
+		Strike:           5.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+	}
+	inner := outer
+	inner.Strike, inner.DaysToExpiration, inner.OptionType = 100.0, 180.0, Put
+
+	// an inner put that can never be worth the outer strike
+	if got := CompoundOptionPrice(outer, 0.3, 4.0, 180.0, CallOnPut); got != 0 {
+		t.Errorf("Unexpected call on an unreachable put: got %v, want 0", got)
+	}
+	small := inner
+	small.Strike = 4.0
+	if got, want := CompoundOptionPrice(outer, 0.3, 4.0, 180.0, PutOnPut), 5.0*math.Exp(-0.05*90.0/365.0)-BlackScholesOptionPrice(small, 0.3); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected put on an unreachable put: got %v, want %v", got, want)
+	}
+
+	// at the decision date, the payoff on the inner option's value
+	outer.DaysToExpiration = 0
+	inner.DaysToExpiration = 90.0
+	if got, want := CompoundOptionPrice(outer, 0.3, 100.0, 90.0, CallOnPut), math.Max(BlackScholesOptionPrice(inner, 0.3)-5.0, 0); got != want {
+		t.Errorf("Unexpected call on put at the decision date: got %v, want %v", got, want)
+	}
+	// with both expiring together, the inner option's intrinsic value
+	if got := CompoundOptionPrice(outer, 0.3, 90.0, 0, CallOnCall); got != 5.0 {
+		t.Errorf("Unexpected call on call at expiration: got %v, want 5", got)
+	}
+
+	outer.DaysToExpiration = 90.0
+	for name, got := range map[string]float64{
+		"inner expiring first": CompoundOptionPrice(outer, 0.3, 100.0, 60.0, CallOnCall),
+		"zero inner strike":    CompoundOptionPrice(outer, 0.3, 0, 180.0, CallOnCall),
+		"unknown kind":         CompoundOptionPrice(outer, 0.3, 100.0, 180.0, CompoundKind(4)),
+		"negative days":        CompoundOptionPrice(Option{Strike: 5.0, DaysToExpiration: -1, UnderlyingPrice: 100.0}, 0.3, 100.0, 180.0, CallOnCall),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package finance
+
+import "time"
+
+// DayCount is a day-count convention, which measures the time between two instants as a fraction of a year.
+// Fractions include the time of day, so an option expiring at 16:00 still has time value at 10:00 the same day.
+// Time is measured on the wall clock of the first instant's location, so daylight saving transitions do not
+// shift whole-day counts
+type DayCount interface {
+	// YearFraction returns the time from one instant to another in years, negative when to is before from
+	YearFraction(from, to time.Time) float64
+}
+
+var (
+	// Actual365Fixed counts actual elapsed time over a 365-day year (ACT/365 Fixed), the convention used by DaysToExpiration
+	Actual365Fixed DayCount = actualFixed(365)
+	// Actual360 counts actual elapsed time over a 360-day year (ACT/360), common for money-market rates
+	Actual360 DayCount = actualFixed(360)
+	// ActualActual counts actual elapsed time, dividing the part falling in each calendar year by that year's
+	// length of 365 or 366 days (ACT/ACT ISDA)
+	ActualActual DayCount = actualActual{}
+)
+
+// Business252 counts the business days of a calendar over a 252-day year (BUS/252), measuring volatility in
+// trading time. Partial days at either end count in proportion to the time elapsed within them.
+// The zero value counts every weekday as a business day
+type Business252 struct {
+	Calendar Calendar // Trading calendar
+}
+
+// hoursPerDay is the number of hours in a calendar day
+const hoursPerDay = 24.0
+
+// NewOption constructs an option whose time to expiration is measured from asOf to expiry under the day-count
+// convention dc; use Business252 with a Calendar to measure it in trading days. The year fraction is stored as
+// DaysToExpiration in 365-day years, so that every pricing function sees the year fraction of dc
+// optionType: the type of the option
+// strike: the strike price
+// underlyingPrice: the current price of the underlying asset
+// riskFreeRate: the risk-free interest rate
+// expiry: the expiration time of the option
+// asOf: the valuation time
+// dc: the day-count convention
+func NewOption(optionType OptionType, strike, underlyingPrice, riskFreeRate float64, expiry, asOf time.Time, dc DayCount) Option {
+	return Option{
+		Strike:           strike,
+		DaysToExpiration: dc.YearFraction(asOf, expiry) * 365.0,
+		RiskFreeRate:     riskFreeRate,
+		UnderlyingPrice:  underlyingPrice,
+		OptionType:       optionType,
+	}
+}
+
+// YearsToExpiration returns the time to expiration of an option in years
+func (option Option) YearsToExpiration() float64 {
+	return option.DaysToExpiration / 365.0
+}
+
+// actualFixed is an actual/fixed day count with the given number of days per year
+type actualFixed float64
+
+// YearFraction returns the actual time from one instant to another over a fixed-length year
+func (basis actualFixed) YearFraction(from, to time.Time) float64 {
+	from, to = wallClock(from, to)
+	return to.Sub(from).Hours() / hoursPerDay / float64(basis)
+}
+
+// actualActual is the ACT/ACT ISDA day count
+type actualActual struct{}
+
+// YearFraction returns the actual time from one instant to another, with the part in each calendar year
+// divided by the length of that year
+func (actualActual) YearFraction(from, to time.Time) float64 {
+	if to.Before(from) {
+		return -actualActual{}.YearFraction(to, from)
+	}
+	from, to = wallClock(from, to)
+	var fraction float64
+	for start := from; start.Before(to); {
+		nextYear := time.Date(start.Year()+1, time.January, 1, 0, 0, 0, 0, start.Location())
+		end := to
+		if nextYear.Before(to) {
+			end = nextYear
+		}
+		daysInYear := nextYear.Sub(time.Date(start.Year(), time.January, 1, 0, 0, 0, 0, start.Location())).Hours() / hoursPerDay
+		fraction += end.Sub(start).Hours() / hoursPerDay / daysInYear
+		start = end
+	}
+	return fraction
+}
+
+// YearFraction returns the business time from one instant to another over a 252-day year
+func (dc Business252) YearFraction(from, to time.Time) float64 {
+	return YearFractionBusiness252(from, to, dc.Calendar)
+}
+
+// businessDays returns the number of business days from one instant to another, counting partial days in
+// proportion to the time elapsed within them, and negative when to is before from. The days are those of
+// from's location
+// from: the start
+// to: the end
+// isBusinessDay: reports whether the calendar day starting at the given midnight is a business day
+func businessDays(from, to time.Time, isBusinessDay func(time.Time) bool) float64 {
+	if to.Before(from) {
+		return -businessDays(to, from, isBusinessDay)
+	}
+	from, to = wallClock(from, to)
+	var days float64
+	dayStart := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for dayStart.Before(to) {
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		if isBusinessDay(dayStart) {
+			start, end := dayStart, dayEnd
+			if from.After(start) {
+				start = from
+			}
+			if to.Before(end) {
+				end = to
+			}
+			days += end.Sub(start).Hours() / hoursPerDay
+		}
+		dayStart = dayEnd
+	}
+	return days
+}
+
+// wallClock returns two instants as UTC times with the wall-clock readings they have in the location of from,
+// so that every calendar day between them lasts exactly 24 hours
+// from: the first instant
+// to: the second instant
+func wallClock(from, to time.Time) (time.Time, time.Time) {
+	to = to.In(from.Location())
+	utc := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	}
+	return utc(from), utc(to)
+}
+
+// isWeekday reports whether a time falls on Monday to Friday
+func isWeekday(t time.Time) bool {
+	weekday := t.Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
@@ -0,0 +1,65 @@
+package finance
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDayCountYearFraction(t *testing.T) {
+	const tolerance = 1e-12
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		newYork = time.UTC
+	}
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, newYork)
+	}
+
+	tests := []struct {
+		description string
+		dc          DayCount
+		from, to    time.Time
+		expected    float64
+	}{
+		{"ACT/365 thirty days", Actual365Fixed, date(2024, time.March, 1, 12), date(2024, time.March, 31, 12), 30.0 / 365.0},
+		{"ACT/360 thirty days", Actual360, date(2024, time.March, 1, 12), date(2024, time.March, 31, 12), 30.0 / 360.0},
+		{"ACT/365 intraday", Actual365Fixed, date(2024, time.June, 14, 10), date(2024, time.June, 14, 16), 6.0 / 24.0 / 365.0},
+		{"ACT/365 backwards", Actual365Fixed, date(2024, time.March, 31, 12), date(2024, time.March, 1, 12), -30.0 / 365.0},
+		{"ACT/ACT across leap year", ActualActual, date(2023, time.July, 1, 0), date(2024, time.July, 1, 0), 184.0/365.0 + 182.0/366.0},
+		{"ACT/ACT within leap year", ActualActual, date(2024, time.January, 1, 0), date(2024, time.January, 2, 12), 1.5 / 366.0},
+		{"BUS/252 weekend", Business252{}, date(2024, time.June, 14, 16), date(2024, time.June, 17, 16), 1.0 / 252.0},
+		{"BUS/252 intraday", Business252{}, date(2024, time.June, 14, 10), date(2024, time.June, 14, 16), 0.25 / 252.0},
+		{"BUS/252 two weeks", Business252{}, date(2024, time.June, 3, 0), date(2024, time.June, 17, 0), 10.0 / 252.0},
+		{"BUS/252 backwards", Business252{}, date(2024, time.June, 17, 16), date(2024, time.June, 14, 16), -1.0 / 252.0},
+	}
+
+	for _, test := range tests {
+		if got := test.dc.YearFraction(test.from, test.to); math.Abs(got-test.expected) > tolerance {
+			t.Errorf("Unexpected year fraction for %v: got %v, want %v", test.description, got, test.expected)
+		}
+	}
+}
+
+func TestNewOption(t *testing.T) {
+	asOf := time.Date(2024, time.June, 14, 10, 0, 0, 0, time.UTC)
+	expiry := time.Date(2024, time.June, 14, 16, 0, 0, 0, time.UTC)
+
+	option := NewOption(Call, 100.0, 100.0, 0.05, expiry, asOf, Actual365Fixed)
+	if want := 0.25; math.Abs(option.DaysToExpiration-want) > 1e-12 {
+		t.Errorf("Unexpected days to expiration: got %v, want %v", option.DaysToExpiration, want)
+	}
+	// an option expiring later today still has time value
+	if price := BlackScholesOptionPrice(option, 0.2); !(price > 0) {
+		t.Errorf("Invalid price: got %v, expected a value greater than 0", price)
+	}
+
+	expiry = asOf.AddDate(0, 0, 90)
+	option = NewOption(Put, 95.0, 100.0, 0.05, expiry, asOf, Actual360)
+	if want := 90.0 / 360.0; math.Abs(option.YearsToExpiration()-want) > 1e-12 {
+		t.Errorf("Unexpected years to expiration: got %v, want %v", option.YearsToExpiration(), want)
+	}
+	if option.OptionType != Put || option.Strike != 95.0 || option.UnderlyingPrice != 100.0 || option.RiskFreeRate != 0.05 {
+		t.Errorf("Unexpected option fields: got %+v", option)
+	}
+}
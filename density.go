@@ -0,0 +1,61 @@
+package finance
+
+import "math"
+
+// ImpliedDensity computes the risk-neutral density of the underlying price at expiration implied by a smile, by
+// Breeden and Litzenberger (1978) the second derivative in strike of the call price compounded at the risk-free
+// rate. The call price is differentiated analytically through the smile's interpolant and extrapolation,
+// p(K) = ∂²c/∂K² + 2·∂²c/∂K∂σ·σ′ + ∂²c/∂σ²·σ′² + ∂c/∂σ·σ″ for the undiscounted Black price c, rather than by finite
+// differences of the quotes, so that it is as smooth as the interpolant, and negative where the smile has butterfly
+// arbitrage. FlatExtrapolation of a smile that slopes at its outermost quotes kinks the call price there, whose
+// point mass the density leaves out; the density integrates to one and has the forward as its mean where the smile
+// is continuously differentiable, as it is with StickyDeltaExtrapolation. The density does not depend on the rate,
+// which discounts the call price and then compounds it back. NaN for a strike that is not positive
+// smile: the smile
+// forward: the forward price, usually smile.Forward()
+// rate: the risk-free interest rate
+// timeYears: the time to expiration in years, usually smile.TimeYears()
+// strikes: the prices at which to compute the density
+func ImpliedDensity(smile VolSmile, forward, rate, timeYears float64, strikes []float64) []float64 {
+	density := make([]float64, len(strikes))
+	for i, strike := range strikes {
+		vol, slope, curvature := smile.volDerivatives(strike)
+		d1, d2, vega := impliedDensityTerms(forward, strike, vol, timeYears)
+		stdDev := vol * math.Sqrt(timeYears)
+		n2 := NormalDistributionDerivative(d2)
+		density[i] = n2/(strike*stdDev) + 2*n2*d1/vol*slope + vega*d1*d2/vol*slope*slope + vega*curvature
+	}
+	return density
+}
+
+// ImpliedCDF computes the risk-neutral probability that the underlying price at expiration is below each strike,
+// implied by a smile as one plus the compounded first derivative in strike of the call price,
+// P(K) = 1 + ∂c/∂K + ∂c/∂σ·σ′ = N(−d2) + K·n(d2)·√T·σ′, the integral of ImpliedDensity with any point masses at
+// kinks of the smile. Quantiles of the underlying price, the moves at given probabilities, can be read off it. The
+// probability does not depend on the rate. NaN for a strike that is not positive
+// smile: the smile
+// forward: the forward price, usually smile.Forward()
+// rate: the risk-free interest rate
+// timeYears: the time to expiration in years, usually smile.TimeYears()
+// strikes: the prices at which to compute the probability
+func ImpliedCDF(smile VolSmile, forward, rate, timeYears float64, strikes []float64) []float64 {
+	cdf := make([]float64, len(strikes))
+	for i, strike := range strikes {
+		vol, slope, _ := smile.volDerivatives(strike)
+		_, d2, vega := impliedDensityTerms(forward, strike, vol, timeYears)
+		cdf[i] = PhiC(d2) + vega*slope
+	}
+	return cdf
+}
+
+// impliedDensityTerms computes d1, d2 and the undiscounted vega K·n(d2)·√T of the Black call price
+// forward: the forward price
+// strike: the strike price
+// vol: the implied volatility
+// timeYears: the time to expiration in years
+func impliedDensityTerms(forward, strike, vol, timeYears float64) (d1, d2, vega float64) {
+	stdDev := vol * math.Sqrt(timeYears)
+	d1 = (math.Log(forward/strike) + 0.5*stdDev*stdDev) / stdDev
+	d2 = d1 - stdDev
+	return d1, d2, strike * NormalDistributionDerivative(d2) * math.Sqrt(timeYears)
+}
@@ -0,0 +1,71 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestImpliedDensity(t *testing.T) {
+	// a flat smile implies the lognormal density
+	strikes := []float64{60.0, 80.0, 100.0, 120.0, 150.0}
+	flat, err := NewVolSmile(105.0, 0.5, strikes, []float64{0.25, 0.25, 0.25, 0.25, 0.25}, SplineInterpolation, FlatExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	at := []float64{40.0, 70.0, 100.0, 105.0, 130.0, 200.0}
+	density := ImpliedDensity(flat, 105.0, 0.05, 0.5, at)
+	cdf := ImpliedCDF(flat, 105.0, 0.05, 0.5, at)
+	stdDev := 0.25 * math.Sqrt(0.5)
+	for i, strike := range at {
+		z := (math.Log(strike/105.0) + 0.5*stdDev*stdDev) / stdDev
+		if want := NormalDistributionDerivative(z) / (strike * stdDev); math.Abs(density[i]-want) > 1e-12 {
+			t.Errorf("Unexpected lognormal density at %v: got %v, want %v", strike, density[i], want)
+		}
+		if want := Phi(z); math.Abs(cdf[i]-want) > 1e-12 {
+			t.Errorf("Unexpected lognormal probability at %v: got %v, want %v", strike, cdf[i], want)
+		}
+	}
+
+	// a skewed smile, extrapolated smoothly, implies a density that integrates to one with the forward as its mean
+	vols := []float64{0.34, 0.28, 0.22, 0.19, 0.18}
+	for _, extrapolation := range []SmileExtrapolation{StickyDeltaExtrapolation} {
+		smile, err := NewVolSmile(105.0, 0.5, strikes, vols, SplineInterpolation, extrapolation)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		pdf := func(strike float64) float64 { return ImpliedDensity(smile, 105.0, 0.05, 0.5, []float64{strike})[0] }
+		var mass, mean float64
+		// piecewise, as the density has kinks at the outermost quotes
+		bounds := []float64{1e-6, 60.0, 150.0, 1000.0}
+		for j := 1; j < len(bounds); j++ {
+			m, _ := integrate(pdf, bounds[j-1], bounds[j], 1e-12)
+			first, _ := integrate(func(strike float64) float64 { return strike * pdf(strike) }, bounds[j-1], bounds[j], 1e-12)
+			mass, mean = mass+m, mean+first
+		}
+		if math.Abs(mass-1) > 1e-6 {
+			t.Errorf("Unexpected total probability with extrapolation %d: got %v, want 1", extrapolation, mass)
+		}
+		if math.Abs(mean-105.0) > 1e-4 {
+			t.Errorf("Unexpected mean with extrapolation %d: got %v, want 105", extrapolation, mean)
+		}
+		// the CDF is the integral of the density
+		below, _ := integrate(pdf, 1e-6, 60.0, 1e-12)
+		more, _ := integrate(pdf, 60.0, 95.0, 1e-12)
+		probabilities := ImpliedCDF(smile, 105.0, 0.05, 0.5, []float64{95.0})
+		if math.Abs(probabilities[0]-(below+more)) > 1e-6 {
+			t.Errorf("Unexpected probability below 95 with extrapolation %d: got %v, want %v", extrapolation, probabilities[0], below+more)
+		}
+	}
+
+	// flat extrapolation of a sloping smile kinks the call price at the outermost quotes, where the CDF jumps
+	kinked, _ := NewVolSmile(105.0, 0.5, strikes, vols, SplineInterpolation, FlatExtrapolation)
+	if jump := ImpliedCDF(kinked, 105.0, 0.05, 0.5, []float64{60.0 - 1e-9, 60.0 + 1e-9}); !(jump[0]-jump[1] > 1e-3) {
+		t.Errorf("Unexpected probabilities either side of the lowest quote: got %v, want a fall", jump)
+	}
+
+	for _, got := range append(ImpliedDensity(flat, 105.0, 0.05, 0.5, []float64{0, -1}), ImpliedCDF(flat, 105.0, 0.05, 0.5, []float64{math.NaN()})...) {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected density or probability at an invalid strike: got %v, want NaN", got)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrInvalidIndex is returned for an index whose weights and component volatilities differ in number, with
+	// fewer than two components of positive weight, with a weight that is negative or weights that do not sum to one,
+	// or with a volatility that is not positive and finite
+	ErrInvalidIndex = errors.New("finance: invalid index")
+	// ErrNoConsistentCorrelation is returned when no single pairwise correlation reconciles the index volatility with
+	// its components'
+	ErrNoConsistentCorrelation = errors.New("finance: no correlation consistent with index volatility")
+)
+
+// indexWeightTolerance is how far the weights of an index may sum from one, for rounded published weights
+const indexWeightTolerance = 1e-3
+
+// ImpliedCorrelation computes the single pairwise correlation ρ of an index's components that reconciles their
+// volatilities with the index's, from σI² = Σwi²σi² + ρ·Σ_{i≠j} wi·wj·σi·σj, so that
+// ρ = (σI² − Σwi²σi²)/((Σwiσi)² − Σwi²σi²), the implied correlation a dispersion trade is long or short of. A common
+// correlation below −1/(n − 1) among n components does not make a correlation matrix, and the highest consistent
+// index volatility is Σwiσi, at ρ = 1
+// Returns ErrInvalidIndex for invalid weights or component volatilities, ErrNonPositiveVolatility for an index
+// volatility that is not positive and finite, and ErrNoConsistentCorrelation if ρ is above one or below −1/(n − 1)
+// indexVol: the implied volatility of the index
+// componentVols: the implied volatility of each component
+// weights: the weight of each component in the index, summing to one
+func ImpliedCorrelation(indexVol float64, componentVols, weights []float64) (float64, error) {
+	if !(indexVol > 0) || math.IsInf(indexVol, 1) {
+		return math.NaN(), fmt.Errorf("%w: index volatility %v", ErrNonPositiveVolatility, indexVol)
+	}
+	own, cross, lowest, err := indexVariances(componentVols, weights)
+	if err != nil {
+		return math.NaN(), err
+	}
+	rho := (indexVol*indexVol - own) / cross
+	switch {
+	case rho > 1:
+		return math.NaN(), fmt.Errorf("%w: index volatility %v above %v, that of perfectly correlated components, for a correlation of %v", ErrNoConsistentCorrelation, indexVol, math.Sqrt(own+cross), rho)
+	case rho < lowest:
+		return math.NaN(), fmt.Errorf("%w: index volatility %v below %v, that of the lowest common correlation %v, for a correlation of %v", ErrNoConsistentCorrelation, indexVol, math.Sqrt(math.Max(own+lowest*cross, 0)), lowest, rho)
+	}
+	return rho, nil
+}
+
+// IndexVolFromCorrelation computes the volatility of an index whose components have a single pairwise correlation,
+// √(Σwi²σi² + ρ·Σ_{i≠j} wi·wj·σi·σj), the inverse of ImpliedCorrelation
+// Returns ErrInvalidIndex for invalid weights or component volatilities, and ErrInvalidCorrelation for a correlation
+// above one or below −1/(n − 1)
+// rho: the pairwise correlation of the components
+// componentVols: the implied volatility of each component
+// weights: the weight of each component in the index, summing to one
+func IndexVolFromCorrelation(rho float64, componentVols, weights []float64) (float64, error) {
+	own, cross, lowest, err := indexVariances(componentVols, weights)
+	if err != nil {
+		return math.NaN(), err
+	}
+	if !(rho >= lowest && rho <= 1) {
+		return math.NaN(), fmt.Errorf("%w: common correlation %v outside [%v, 1]", ErrInvalidCorrelation, rho, lowest)
+	}
+	return math.Sqrt(math.Max(own+rho*cross, 0)), nil
+}
+
+// indexVariances computes the variance Σwi²σi² of an index's components on their own and the sum
+// Σ_{i≠j} wi·wj·σi·σj that the common correlation multiplies, and the lowest common correlation −1/(n − 1) of the n
+// components of positive weight. Returns ErrInvalidIndex for invalid inputs
+// componentVols: the volatility of each component
+// weights: the weight of each component
+func indexVariances(componentVols, weights []float64) (own, cross, lowest float64, err error) {
+	if len(componentVols) != len(weights) {
+		return 0, 0, 0, fmt.Errorf("%w: %d volatilities and %d weights", ErrInvalidIndex, len(componentVols), len(weights))
+	}
+	var total, linear float64
+	var n int
+	for i, weight := range weights {
+		vol := componentVols[i]
+		switch {
+		case !(weight >= 0) || math.IsInf(weight, 1):
+			return 0, 0, 0, fmt.Errorf("%w: weight %v of component %d", ErrInvalidIndex, weight, i)
+		case !(vol > 0) || math.IsInf(vol, 1):
+			return 0, 0, 0, fmt.Errorf("%w: volatility %v of component %d", ErrInvalidIndex, vol, i)
+		}
+		total += weight
+		linear += weight * vol
+		own += weight * weight * vol * vol
+		if weight > 0 {
+			n++
+		}
+	}
+	switch {
+	case math.Abs(total-1) > indexWeightTolerance:
+		return 0, 0, 0, fmt.Errorf("%w: weights sum to %v", ErrInvalidIndex, total)
+	case n < 2:
+		return 0, 0, 0, fmt.Errorf("%w: %d components of positive weight", ErrInvalidIndex, n)
+	}
+	return own, linear*linear - own, -1 / float64(n-1), nil
+}
@@ -0,0 +1,58 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestImpliedCorrelation(t *testing.T) {
+	vols := []float64{0.3, 0.25, 0.4, 0.2}
+	weights := []float64{0.4, 0.3, 0.2, 0.1}
+
+	// round trip through the index volatility
+	for _, rho := range []float64{-1.0 / 3, 0, 0.35, 0.8, 1} {
+		indexVol, err := IndexVolFromCorrelation(rho, vols, weights)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got, err := ImpliedCorrelation(indexVol, vols, weights); err != nil || math.Abs(got-rho) > 1e-12 {
+			t.Errorf("Unexpected implied correlation: got %v (%v), want %v", got, err, rho)
+		}
+	}
+
+	// perfectly correlated components add their volatilities, and two uncorrelated ones add their variances
+	if got, _ := IndexVolFromCorrelation(1, vols, weights); math.Abs(got-(0.12+0.075+0.08+0.02)) > 1e-15 {
+		t.Errorf("Unexpected index volatility at correlation one: got %v, want %v", got, 0.12+0.075+0.08+0.02)
+	}
+	if got, _ := IndexVolFromCorrelation(0, []float64{0.3, 0.4}, []float64{0.5, 0.5}); math.Abs(got-0.25) > 1e-15 {
+		t.Errorf("Unexpected index volatility of uncorrelated components: got %v, want 0.25", got)
+	}
+
+	tests := []struct {
+		name     string
+		indexVol float64
+		vols     []float64
+		weights  []float64
+		want     error
+	}{
+		{"above perfect correlation", 0.35, vols, weights, ErrNoConsistentCorrelation},
+		{"below the lowest correlation", 0.05, vols, weights, ErrNoConsistentCorrelation},
+		{"zero index vol", 0, vols, weights, ErrNonPositiveVolatility},
+		{"mismatched", 0.25, vols, weights[:3], ErrInvalidIndex},
+		{"weights not summing to one", 0.25, vols, []float64{0.4, 0.3, 0.2, 0.2}, ErrInvalidIndex},
+		{"negative weight", 0.25, vols, []float64{0.6, 0.3, 0.2, -0.1}, ErrInvalidIndex},
+		{"zero component vol", 0.25, []float64{0.3, 0, 0.4, 0.2}, weights, ErrInvalidIndex},
+		{"single component", 0.25, []float64{0.3, 0.25}, []float64{1, 0}, ErrInvalidIndex},
+	}
+	for _, tt := range tests {
+		if _, err := ImpliedCorrelation(tt.indexVol, tt.vols, tt.weights); !errors.Is(err, tt.want) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	for _, rho := range []float64{1.1, -0.5, math.NaN()} {
+		if _, err := IndexVolFromCorrelation(rho, vols, weights); !errors.Is(err, ErrInvalidCorrelation) {
+			t.Errorf("Unexpected error for correlation %v: got %v, want %v", rho, err, ErrInvalidCorrelation)
+		}
+	}
+}
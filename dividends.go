@@ -0,0 +1,73 @@
+package finance
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNegativeAdjustedSpot is returned when the present value of the discrete dividends paid before expiration
+// is at least the underlying price, leaving no escrowed spot to price the option with
+var ErrNegativeAdjustedSpot = errors.New("finance: dividends exceed underlying price")
+
+// Dividend represents a discrete cash dividend paid by the underlying asset
+type Dividend struct {
+	Amount       float64 `json:"amount"`          // Cash amount per share
+	DaysToExDate float64 `json:"days_to_ex_date"` // Days until the ex-dividend date
+}
+
+// EscrowedUnderlyingPrice computes the underlying price net of the present value of the discrete dividends
+// going ex on or before expiration (the escrowed dividend model). Dividends after expiration, or whose
+// ex-date has passed, are ignored. Returns ErrNegativeAdjustedSpot when the adjusted price is not positive
+func (option Option) EscrowedUnderlyingPrice() (float64, error) {
+	spot := option.UnderlyingPrice
+	for _, dividend := range option.Dividends {
+		if dividend.DaysToExDate < 0 || dividend.DaysToExDate > option.DaysToExpiration {
+			continue
+		}
+		spot -= dividend.Amount * math.Exp(-option.RiskFreeRate*dividend.DaysToExDate/365.0)
+	}
+	if !(spot > 0) {
+		return math.NaN(), ErrNegativeAdjustedSpot
+	}
+	return spot, nil
+}
+
+// checkDividends returns ErrNegativeAdjustedSpot when the discrete dividends of an option exceed its underlying price
+// option: the option
+func checkDividends(option Option) error {
+	if len(option.Dividends) == 0 {
+		return nil
+	}
+	_, err := option.EscrowedUnderlyingPrice()
+	return err
+}
+
+// escrowed returns a copy of option whose underlying price is net of the present value of its discrete
+// dividends and which carries no dividends. The underlying price is NaN when the dividends exceed it,
+// so that every price and Greek computed from the copy is NaN
+// option: the option
+func escrowed(option Option) Option {
+	if len(option.Dividends) == 0 {
+		return option
+	}
+	option.UnderlyingPrice, _ = option.EscrowedUnderlyingPrice()
+	option.Dividends = nil
+	return option
+}
+
+// outstandingDividends computes the value at a time before expiration of the discrete dividends going ex then or
+// later and on or before expiration, which the escrowed underlying price at that time leaves out of the real one
+// dividends: the dividends
+// rate: the risk-free rate
+// days: the days from now to the time
+// expiryDays: the days to expiration
+func outstandingDividends(dividends []Dividend, rate, days, expiryDays float64) float64 {
+	value := 0.0
+	for _, dividend := range dividends {
+		if dividend.DaysToExDate < days || dividend.DaysToExDate > expiryDays {
+			continue
+		}
+		value += dividend.Amount * math.Exp(-rate*(dividend.DaysToExDate-days)/365.0)
+	}
+	return value
+}
@@ -0,0 +1,107 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEscrowedUnderlyingPrice(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		Dividends: []Dividend{
+			{Amount: 2.0, DaysToExDate: 30.0},
+			{Amount: 5.0, DaysToExDate: 120.0}, // after expiration
+			{Amount: 5.0, DaysToExDate: -1.0},  // already paid
+		},
+	}
+
+	const expected = 98.0082023124716
+	spot, err := option.EscrowedUnderlyingPrice()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := math.Abs(spot - expected); diff > 1e-10 {
+		t.Errorf("Unexpected escrowed underlying price: got %v, want %v", spot, expected)
+	}
+}
+
+func TestDiscreteDividendPrice(t *testing.T) {
+	const tolerance = 1e-10
+	for _, optionType := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 90.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       optionType,
+			Dividends:        []Dividend{{Amount: 2.0, DaysToExDate: 30.0}},
+		}
+		spot, _ := option.EscrowedUnderlyingPrice()
+
+		adjusted := option
+		adjusted.Dividends = nil
+		adjusted.UnderlyingPrice = spot
+		if got, want := BlackScholesOptionPrice(option, 0.25), BlackScholesOptionPrice(adjusted, 0.25); math.Abs(got-want) > tolerance {
+			t.Errorf("Unexpected price for type %v: got %v, want %v", optionType, got, want)
+		}
+
+		// A single dividend is reproduced exactly by the continuous yield with S e^{-qT} equal to the escrowed spot
+		years := option.DaysToExpiration / 365.0
+		continuous := option
+		continuous.Dividends = nil
+		continuous.DividendYield = -math.Log(spot/option.UnderlyingPrice) / years
+		if got, want := BlackScholesOptionPrice(option, 0.25), BlackScholesOptionPrice(continuous, 0.25); math.Abs(got-want) > tolerance {
+			t.Errorf("Unexpected price against equivalent yield for type %v: got %v, want %v", optionType, got, want)
+		}
+
+		// The naive yield ignores the timing of the dividend and so differs slightly
+		continuous.DividendYield = 2.0 / option.UnderlyingPrice / years
+		if diff := math.Abs(BlackScholesOptionPrice(option, 0.25) - BlackScholesOptionPrice(continuous, 0.25)); diff < 1e-4 || diff > 0.1 {
+			t.Errorf("Unexpected difference against naive yield for type %v: got %v", optionType, diff)
+		}
+
+		if got, want := BlackScholesGamma(option, 0.25), BlackScholesGamma(adjusted, 0.25); math.Abs(got-want) > tolerance {
+			t.Errorf("Unexpected gamma for type %v: got %v, want %v", optionType, got, want)
+		}
+		if got, want := BlackScholesDelta(option, 0.25), BlackScholesDelta(adjusted, 0.25); math.Abs(got-want) > tolerance {
+			t.Errorf("Unexpected delta for type %v: got %v, want %v", optionType, got, want)
+		}
+
+		option.Price = BlackScholesOptionPrice(option, 0.25)
+		result, err := ImpliedVolatility(option)
+		if err != nil {
+			t.Errorf("Unexpected error for type %v: %v", optionType, err)
+		} else if diff := math.Abs(result.Volatility - 0.25); diff > 1e-4 {
+			t.Errorf("Unexpected implied volatility for type %v: got %v, want %v", optionType, result.Volatility, 0.25)
+		}
+	}
+}
+
+func TestDividendsExceedUnderlyingPrice(t *testing.T) {
+	option := Option{
+		Price:            1.0,
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  10.0,
+		OptionType:       Call,
+		Dividends:        []Dividend{{Amount: 12.0, DaysToExDate: 30.0}},
+	}
+
+	if _, err := option.EscrowedUnderlyingPrice(); !errors.Is(err, ErrNegativeAdjustedSpot) {
+		t.Errorf("Unexpected error: got %v, want %v", err, ErrNegativeAdjustedSpot)
+	}
+	if price := BlackScholesOptionPrice(option, 0.25); !math.IsNaN(price) {
+		t.Errorf("Unexpected price: got %v, want NaN", price)
+	}
+	if _, err := ImpliedVolatility(option); !errors.Is(err, ErrNegativeAdjustedSpot) {
+		t.Errorf("Unexpected implied volatility error: got %v, want %v", err, ErrNegativeAdjustedSpot)
+	}
+	if _, err := RationalImpliedVolatility(option); !errors.Is(err, ErrNegativeAdjustedSpot) {
+		t.Errorf("Unexpected rational implied volatility error: got %v, want %v", err, ErrNegativeAdjustedSpot)
+	}
+}
@@ -0,0 +1,55 @@
+package finance
+
+import (
+	"math"
+)
+
+// employeeStockOptionSteps is the number of time steps of the tree EmployeeStockOptionPrice rolls back
+const employeeStockOptionSteps = 1000
+
+// EmployeeStockOptionPrice computes the Hull-White (2004) price of an employee stock option, a call that can only
+// be exercised after vesting, on a Cox-Ross-Rubinstein tree. After vesting the employee exercises as soon as the
+// underlying price reaches the exercise multiple of the strike, and leaves the company at the exit rate, exercising
+// the option if it is in the money and forfeiting it otherwise; before vesting there are no exits. Early exercise
+// and exits cut the option's life short, so it is worth no more than the American call and, without dividends, less
+// than the European one; with no exits and no exercise multiple it is the European call. Discrete dividends are
+// escrowed, with exercise and exits valued at the underlying price with the dividends still to be paid added back.
+// NaN for a put, negative days to expiration, vesting after expiration, a negative exit rate or an exercise multiple
+// below 1
+// option: the option, a call
+// vol: the volatility
+// vestYears: the time until the option vests in years
+// exitRate: the annual rate at which the employee leaves after vesting
+// exerciseMultiple: the multiple of the strike at which the employee exercises, +Inf for never early
+func EmployeeStockOptionPrice(option Option, vol float64, vestYears float64, exitRate float64, exerciseMultiple float64) float64 {
+	dividends := option.Dividends
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	if option.OptionType != Call || option.DaysToExpiration < 0 || !(vestYears >= 0 && vestYears <= timeToExpiration) ||
+		!(exitRate >= 0) || math.IsInf(exitRate, 1) || !(exerciseMultiple >= 1) {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		return option.IntrinsicValue()
+	}
+
+	steps := employeeStockOptionSteps
+	dt := timeToExpiration / float64(steps)
+	stay := math.Exp(-exitRate * dt)
+	vestSteps := int(math.Ceil(vestYears/dt - 1e-9))
+	exercisePrice := exerciseMultiple * option.Strike
+	up, down, probability := crrParameters(option, vol, steps)
+	lattice := newBinomialLattice(steps)
+	lattice.dividends = dividends
+	lattice.node = func(step int, spot, held float64) float64 {
+		if step < vestSteps {
+			return held
+		}
+		exercise := math.Max(spot-option.Strike, 0)
+		if spot >= exercisePrice {
+			return exercise
+		}
+		return stay*held + (1-stay)*exercise
+	}
+	return lattice.roll(option, steps, up, down, probability, European).value
+}
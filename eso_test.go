@@ -0,0 +1,90 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEmployeeStockOptionPrice(t *testing.T) {
+	option := Option{
+		Strike:           50.0,
+		DaysToExpiration: 10 * 365,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  50.0,
+		OptionType:       Call,
+		DividendYield:    0.02,
+	}
+	european := BinomialOptionPrice(option, 0.3, employeeStockOptionSteps, European)
+	american := BinomialOptionPrice(option, 0.3, employeeStockOptionSteps, American)
+
+	// with no exits and no early exercise it is the European call on the same tree
+	if got := EmployeeStockOptionPrice(option, 0.3, 3, 0, math.Inf(1)); math.Abs(got-european) > 1e-10 {
+		t.Errorf("Unexpected price with no exits: got %v, want %v", got, european)
+	}
+
+	// exits only shorten its life: it is never worth more than the American call, and less the more employees leave
+	previous := math.Inf(1)
+	for _, exitRate := range []float64{0, 0.03, 0.1, 0.3} {
+		got := EmployeeStockOptionPrice(option, 0.3, 3, exitRate, 2.5)
+		if !(got <= american) {
+			t.Errorf("Unexpected price for exit rate %v: got %v, above the American %v", exitRate, got, american)
+		}
+		if !(got < previous) {
+			t.Errorf("Unexpected price for exit rate %v: got %v, not below %v", exitRate, got, previous)
+		}
+		previous = got
+	}
+
+	// with dividends, exercising at a high enough multiple is worth more than never exercising early
+	if got := EmployeeStockOptionPrice(option, 0.3, 3, 0, 3); !(got > european && got <= american) {
+		t.Errorf("Unexpected price with a multiple of 3: got %v, want between %v and %v", got, european, american)
+	}
+
+	// vested at once with a multiple of 1, an option in the money is exercised at once
+	deep := option
+	deep.UnderlyingPrice = 200.0
+	if got := EmployeeStockOptionPrice(deep, 0.3, 0, 0, 1); math.Abs(got-150.0) > 1e-12 {
+		t.Errorf("Unexpected price exercised at once: got %v, want 150", got)
+	}
+
+	// with a discrete dividend, exercise and exits are valued at the real underlying price: an employee leaving at
+	// once, or exercising at once, is paid the intrinsic value of the real spot
+	dividend := Option{
+		Strike:           80.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		Dividends:        []Dividend{{Amount: 10.0, DaysToExDate: 180.0}},
+	}
+	if got := EmployeeStockOptionPrice(dividend, 0.3, 0, 1e4, math.Inf(1)); math.Abs(got-20.0) > 1e-6 {
+		t.Errorf("Unexpected price leaving at once with a dividend: got %v, want 20", got)
+	}
+	if got := EmployeeStockOptionPrice(dividend, 0.3, 0, 0, 1.2); math.Abs(got-20.0) > 1e-12 {
+		t.Errorf("Unexpected price exercised at once with a dividend: got %v, want 20", got)
+	}
+	// never exercising early, it is the European call on the escrowed spot
+	europeanDividend := BinomialOptionPrice(dividend, 0.3, employeeStockOptionSteps, European)
+	if got := EmployeeStockOptionPrice(dividend, 0.3, 0, 0, math.Inf(1)); math.Abs(got-europeanDividend) > 1e-10 {
+		t.Errorf("Unexpected price with a dividend and no exits: got %v, want %v", got, europeanDividend)
+	}
+
+	option.DaysToExpiration = 0
+	option.UnderlyingPrice = 55.0
+	if got := EmployeeStockOptionPrice(option, 0.3, 0, 0.1, 2); got != 5.0 {
+		t.Errorf("Unexpected price at expiration: got %v, want 5", got)
+	}
+	option.DaysToExpiration = 365.0
+	put := option
+	put.OptionType = Put
+	for name, got := range map[string]float64{
+		"put":                  EmployeeStockOptionPrice(put, 0.3, 0.5, 0.1, 2),
+		"vesting after expiry": EmployeeStockOptionPrice(option, 0.3, 2, 0.1, 2),
+		"negative exit rate":   EmployeeStockOptionPrice(option, 0.3, 0.5, -0.1, 2),
+		"multiple below 1":     EmployeeStockOptionPrice(option, 0.3, 0.5, 0.1, 0.9),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
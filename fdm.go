@@ -0,0 +1,516 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrInvalidGrid is returned when a finite-difference grid or scheme cannot be solved on
+	ErrInvalidGrid = errors.New("finance: invalid finite-difference grid")
+	// ErrUnstableScheme is returned when the time step of the explicit scheme is too long for it to be stable
+	ErrUnstableScheme = errors.New("finance: explicit finite-difference scheme unstable")
+)
+
+// Scheme is a time-stepping scheme for the finite-difference pricers
+type Scheme int
+
+const (
+	CrankNicolson Scheme = iota // Average of the explicit and implicit schemes, second order in time; the default
+	Implicit                    // Fully implicit, first order in time and stable for any time step
+	Explicit                    // Fully explicit, first order in time and stable only for short time steps
+)
+
+// GridSpacing is the spacing of the underlying price nodes of a finite-difference grid
+type GridSpacing int
+
+const (
+	LogSpot    GridSpacing = iota // Uniform in the logarithm of the underlying price; the default
+	LinearSpot                    // Uniform in the underlying price, from zero
+)
+
+// Finite-difference defaults and bump sizes
+const (
+	fdmStdDevs      = 5.0    // Default half-width of the grid in standard deviations of the log underlying price
+	fdmRannacher    = 2      // Crank-Nicolson steps replaced by two implicit half steps each, to damp the payoff kink
+	fdmVolBump      = 0.001  // Absolute bump of the volatility, on a grid held fixed
+	fdmRateBump     = 0.0001 // Absolute bump of the risk-free rate
+	fdmMinSpotSteps = 2      // Fewest spot intervals that leave a node either side of the underlying price
+)
+
+// FDGrid describes the grid on which the finite-difference pricers solve the Black-Scholes PDE, the exercise style
+// solved for, and how FDMPriceCtx reports its progress
+type FDGrid struct {
+	SpotSteps  int           // Number of underlying price intervals, at least 2; rounded up to even for LogSpot
+	TimeSteps  int           // Number of time steps, at least 1
+	Spacing    GridSpacing   // Spacing of the underlying price nodes
+	StdDevs    float64       // Half-width of the grid in standard deviations of the log underlying price at expiration, 5 if zero
+	Style      ExerciseStyle // Exercise style; American options are projected onto their intrinsic value at every step
+	BatchSteps int           // Time steps taken between cancellation checks and progress reports, 1 if not positive
+	Progress   ProgressFunc  // Called with the time steps taken so far after each batch, if set
+}
+
+// FDMPrice prices an option by solving the Black-Scholes PDE on a finite-difference grid. The grid is centred on
+// the underlying price, which is always a node, and spans StdDevs standard deviations of the log underlying price
+// either side (from zero up, for LinearSpot), with the discounted forward intrinsic value as boundary condition.
+// The payoff is averaged over each cell so that the price converges at second order in the spot spacing wherever
+// the strike falls, and the first Crank-Nicolson steps are taken as implicit half steps to damp the oscillations
+// it would otherwise cause. American options are solved exactly on the grid at every step by the Brennan-Schwartz
+// algorithm, projected onto the intrinsic value of the real underlying price, the escrowed price of the node with
+// the discrete dividends still to be paid added back. Returns NaN whenever FDMPriceE returns an error
+// option: the option
+// vol: the volatility
+// grid: the grid
+// scheme: the time-stepping scheme
+func FDMPrice(option Option, vol float64, grid FDGrid, scheme Scheme) float64 {
+	price, _ := FDMPriceE(option, vol, grid, scheme)
+	return price
+}
+
+// FDMPriceE prices an option like FDMPrice, returning an error where FDMPrice returns NaN: the errors of
+// BlackScholesOptionPriceE, ErrInvalidGrid, and ErrUnstableScheme when the explicit scheme's time step is too long.
+// At expiration the price is the intrinsic value
+// option: the option
+// vol: the volatility
+// grid: the grid
+// scheme: the time-stepping scheme
+func FDMPriceE(option Option, vol float64, grid FDGrid, scheme Scheme) (float64, error) {
+	return FDMPriceCtx(context.Background(), option, vol, grid, scheme)
+}
+
+// FDMPriceCtx prices an option like FDMPriceE, checking ctx before each batch of BatchSteps time steps. If ctx is
+// done, it returns NaN and ctx.Err(), never the value of a partly solved grid
+// ctx: the context whose cancellation stops the solve
+// option: the option
+// vol: the volatility
+// grid: the grid
+// scheme: the time-stepping scheme
+func FDMPriceCtx(ctx context.Context, option Option, vol float64, grid FDGrid, scheme Scheme) (float64, error) {
+	if err := validatePricing(option, vol); err != nil {
+		return math.NaN(), err
+	}
+	if err := checkFDGrid(grid, scheme); err != nil {
+		return math.NaN(), err
+	}
+	dividends := option.Dividends
+	option = escrowed(option)
+	if option.DaysToExpiration == 0 {
+		return option.IntrinsicValue(), nil
+	}
+
+	fd := newFDMGrid(option, vol, grid)
+	fd.dividends = dividends
+	nodes, err := fd.solve(ctx, option, vol, scheme, false, grid.Progress)
+	return nodes.value, err
+}
+
+// FDMGreeks computes the price and Greeks of an option on a finite-difference grid, using the same units as
+// BlackScholesGreeks. Delta and gamma are differences across the nodes either side of the underlying price, and
+// theta a central difference over one time step either side of expiration, all from a single solve; vega and rho
+// are central differences of prices solved on the same grid. Every value is NaN with no time to expiration or
+// whenever FDMPriceE would return an error
+// option: the option
+// vol: the volatility
+// grid: the grid
+// scheme: the time-stepping scheme
+func FDMGreeks(option Option, vol float64, grid FDGrid, scheme Scheme) Greeks {
+	nan := math.NaN()
+	invalid := Greeks{Price: nan, Delta: nan, Gamma: nan, Vega: nan, Theta: nan, Rho: nan}
+	if validatePricing(option, vol) != nil || checkFDGrid(grid, scheme) != nil {
+		return invalid
+	}
+	dividends := option.Dividends
+	option = escrowed(option)
+	if option.DaysToExpiration == 0 {
+		return invalid
+	}
+
+	fd := newFDMGrid(option, vol, grid)
+	fd.dividends = dividends
+	nodes, err := fd.solve(context.Background(), option, vol, scheme, true, nil)
+	if err != nil {
+		return invalid
+	}
+	reprice := func(option Option, vol float64) float64 {
+		nodes, err := fd.solve(context.Background(), option, vol, scheme, false, nil)
+		if err != nil {
+			return nan
+		}
+		return nodes.value
+	}
+
+	greeks := Greeks{Price: nodes.value}
+	spot := option.UnderlyingPrice
+	switch grid.Spacing {
+	case LogSpot:
+		h := math.Log(fd.spots[fd.center+1] / spot)
+		first := (nodes.up - nodes.down) / (2 * h)
+		second := (nodes.up - 2*nodes.value + nodes.down) / (h * h)
+		greeks.Delta = first / spot
+		greeks.Gamma = (second - first) / (spot * spot)
+	case LinearSpot:
+		h := fd.spots[fd.center+1] - spot
+		greeks.Delta = (nodes.up - nodes.down) / (2 * h)
+		greeks.Gamma = (nodes.up - 2*nodes.value + nodes.down) / (h * h)
+	}
+	greeks.Theta = (nodes.before - nodes.after) / (2 * fd.dt)
+
+	greeks.Vega = (reprice(option, vol+fdmVolBump) - reprice(option, vol-fdmVolBump)) / (2 * fdmVolBump)
+	rateUp, rateDown := option, option
+	rateUp.RiskFreeRate += fdmRateBump
+	rateDown.RiskFreeRate -= fdmRateBump
+	greeks.Rho = (reprice(rateUp, vol) - reprice(rateDown, vol)) / (2 * fdmRateBump)
+	return greeks
+}
+
+// checkFDGrid returns ErrInvalidGrid if a grid or scheme cannot be solved on
+// grid: the grid
+// scheme: the time-stepping scheme
+func checkFDGrid(grid FDGrid, scheme Scheme) error {
+	switch {
+	case grid.SpotSteps < fdmMinSpotSteps:
+		return fmt.Errorf("%w: %d spot steps", ErrInvalidGrid, grid.SpotSteps)
+	case grid.TimeSteps < 1:
+		return fmt.Errorf("%w: %d time steps", ErrInvalidGrid, grid.TimeSteps)
+	case !(grid.StdDevs >= 0) || math.IsInf(grid.StdDevs, 1):
+		return fmt.Errorf("%w: width of %v standard deviations", ErrInvalidGrid, grid.StdDevs)
+	case grid.Spacing != LogSpot && grid.Spacing != LinearSpot:
+		return fmt.Errorf("%w: unknown spacing %d", ErrInvalidGrid, grid.Spacing)
+	case scheme != CrankNicolson && scheme != Implicit && scheme != Explicit:
+		return fmt.Errorf("%w: unknown scheme %d", ErrInvalidGrid, scheme)
+	}
+	return nil
+}
+
+// fdmGrid holds the nodes of a finite-difference grid and its working storage
+type fdmGrid struct {
+	grid   FDGrid
+	spots  []float64 // Underlying prices at the nodes, ascending
+	center int       // Index of the node at the underlying price
+	dt     float64   // Time step in years
+	values []float64 // Option values at the nodes
+	// Local volatility at an underlying price and time, replacing the constant volatility if set, and the number
+	// of nodes and steps at which it was clamped
+	localVol LocalVolFunc
+	clamped  int
+	// Discrete dividends escrowed out of the option, whose value still to be paid is added back to the underlying
+	// price of a node to project American options onto its intrinsic value
+	dividends []Dividend
+	// Tridiagonal coefficients, right-hand side and elimination storage of the implicit solve
+	lower, diag, upper, rhs, ratio, reduced []float64
+}
+
+// fdmNodes holds the values read off a finite-difference solve
+type fdmNodes struct {
+	value, down, up float64 // Values at the underlying price and at the nodes either side of it
+	before, after   float64 // Values at the underlying price with one time step less and one more to expiration
+}
+
+// newFDMGrid lays out a finite-difference grid centred on the underlying price of an option
+// option: the option, with any discrete dividends already escrowed and positive days to expiration
+// vol: the volatility that sets the width of the grid
+// grid: the grid
+func newFDMGrid(option Option, vol float64, grid FDGrid) *fdmGrid {
+	stdDevs := grid.StdDevs
+	if stdDevs == 0 {
+		stdDevs = fdmStdDevs
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	width := stdDevs * vol * math.Sqrt(timeToExpiration)
+	spot := option.UnderlyingPrice
+
+	steps := grid.SpotSteps
+	var spots []float64
+	var center int
+	switch grid.Spacing {
+	case LogSpot:
+		steps += steps % 2
+		center = steps / 2
+		h := width / float64(center)
+		spots = make([]float64, steps+1)
+		for i := range spots {
+			spots[i] = spot * math.Exp(float64(i-center)*h)
+		}
+	case LinearSpot:
+		center = int(math.Round(float64(steps) * math.Exp(-width)))
+		center = max(1, min(center, steps-1))
+		h := spot / float64(center)
+		spots = make([]float64, steps+1)
+		for i := range spots {
+			spots[i] = float64(i) * h
+		}
+	}
+	spots[center] = spot
+
+	n := len(spots)
+	return &fdmGrid{
+		grid:    grid,
+		spots:   spots,
+		center:  center,
+		dt:      timeToExpiration / float64(grid.TimeSteps),
+		values:  make([]float64, n),
+		lower:   make([]float64, n),
+		diag:    make([]float64, n),
+		upper:   make([]float64, n),
+		rhs:     make([]float64, n),
+		ratio:   make([]float64, n),
+		reduced: make([]float64, n),
+	}
+}
+
+// solve steps an option back from expiration to today on the grid, and if extra is set one time step beyond, for
+// theta, checking ctx before each batch of time steps
+// ctx: the context whose cancellation stops the solve
+// option: the option, with any discrete dividends already escrowed
+// vol: the volatility, unless the grid has a local volatility
+// scheme: the time-stepping scheme
+// extra: whether to take the extra time step
+// progress: the progress callback, if any
+func (fd *fdmGrid) solve(ctx context.Context, option Option, vol float64, scheme Scheme, extra bool, progress ProgressFunc) (fdmNodes, error) {
+	spots, values := fd.spots, fd.values
+	sign := 1.0
+	if option.OptionType == Put {
+		sign = -1.0
+	}
+	// the value of the dividends still to be paid at the time level being solved for
+	carry := 0.0
+	intrinsic := func(spot float64) float64 {
+		return math.Max(sign*(spot+carry-option.Strike), 0)
+	}
+	boundary := func(spot, tau float64) float64 {
+		value := math.Max(sign*(spot*math.Exp(-option.DividendYield*tau)-option.Strike*math.Exp(-option.RiskFreeRate*tau)), 0)
+		if fd.grid.Style == American {
+			value = math.Max(value, intrinsic(spot))
+		}
+		return value
+	}
+
+	// the operator L of V_τ = L·V weighs each node and its neighbours below and above, constantly in time unless the
+	// volatility is local, when it is rebuilt at the middle of every step
+	timeToExpiration := fd.dt * float64(fd.grid.TimeSteps)
+	setOperator := func(tau float64) error {
+		variance := func(int) float64 { return vol * vol }
+		if fd.localVol != nil {
+			t := math.Max(timeToExpiration-tau, 0)
+			variance = func(i int) float64 {
+				local, clamped := fd.localVol(spots[i], t)
+				if clamped {
+					fd.clamped++
+				}
+				return local * local
+			}
+		}
+		if stiffest := fd.operator(option, variance); scheme == Explicit && fd.dt*stiffest > 1 {
+			return fmt.Errorf("%w: time step %.3g years exceeds %.3g", ErrUnstableScheme, fd.dt, 1/stiffest)
+		}
+		return nil
+	}
+	if fd.localVol == nil {
+		if err := setOperator(0); err != nil {
+			return fdmNodes{value: math.NaN()}, err
+		}
+	}
+	advance := func(theta, dt, tau float64) error {
+		carry = outstandingDividends(fd.dividends, option.RiskFreeRate, option.DaysToExpiration-365.0*tau, option.DaysToExpiration)
+		if fd.localVol != nil {
+			if err := setOperator(tau - dt/2); err != nil {
+				return err
+			}
+		}
+		if scheme == Explicit {
+			fd.explicitStep(tau, boundary, intrinsic)
+		} else {
+			fd.thetaStep(theta, dt, tau, sign, boundary, intrinsic)
+		}
+		return nil
+	}
+
+	fd.cellAverages(option, sign)
+	steps := fd.grid.TimeSteps
+	if extra {
+		steps++
+	}
+	batchSteps := max(1, fd.grid.BatchSteps)
+	var nodes fdmNodes
+	for step := range steps {
+		if step%batchSteps == 0 {
+			if err := ctx.Err(); err != nil {
+				return fdmNodes{value: math.NaN()}, err
+			}
+		}
+		switch step {
+		case fd.grid.TimeSteps - 1:
+			nodes.before = values[fd.center]
+		case fd.grid.TimeSteps:
+			nodes.value, nodes.down, nodes.up = values[fd.center], values[fd.center-1], values[fd.center+1]
+		}
+		tau := float64(step+1) * fd.dt
+		var err error
+		switch {
+		case scheme == Explicit:
+			err = advance(0, fd.dt, tau)
+		case scheme == Implicit:
+			err = advance(1, fd.dt, tau)
+		case step < fdmRannacher:
+			if err = advance(1, fd.dt/2, tau-fd.dt/2); err == nil {
+				err = advance(1, fd.dt/2, tau)
+			}
+		default:
+			err = advance(0.5, fd.dt, tau)
+		}
+		if err != nil {
+			return fdmNodes{value: math.NaN()}, err
+		}
+		if progress != nil && ((step+1)%batchSteps == 0 || step+1 == steps) {
+			progress(step+1, steps)
+		}
+	}
+	if extra {
+		nodes.after = values[fd.center]
+	} else {
+		nodes.value, nodes.down, nodes.up = values[fd.center], values[fd.center-1], values[fd.center+1]
+	}
+	return nodes, nil
+}
+
+// operator sets the tridiagonal coefficients of the operator L of V_τ = L·V at the interior nodes, returning the
+// largest magnitude of its diagonal, which bounds the stable explicit time step
+// option: the option
+// variance: the variance of the underlying's returns at each node
+func (fd *fdmGrid) operator(option Option, variance func(i int) float64) float64 {
+	spots, lower, diag, upper := fd.spots, fd.lower, fd.diag, fd.upper
+	r, carry := option.RiskFreeRate, option.RiskFreeRate-option.DividendYield
+	stiffest := 0.0
+	for i := 1; i < len(spots)-1; i++ {
+		v := variance(i)
+		switch fd.grid.Spacing {
+		case LogSpot:
+			h := math.Log(spots[i+1] / spots[i])
+			diffusion, drift := 0.5*v/(h*h), (carry-0.5*v)/(2*h)
+			lower[i], diag[i], upper[i] = diffusion-drift, -2*diffusion-r, diffusion+drift
+		case LinearSpot:
+			h := spots[i+1] - spots[i]
+			diffusion, drift := 0.5*v*spots[i]*spots[i]/(h*h), carry*spots[i]/(2*h)
+			lower[i], diag[i], upper[i] = diffusion-drift, -2*diffusion-r, diffusion+drift
+		}
+		stiffest = math.Max(stiffest, -diag[i])
+	}
+	return stiffest
+}
+
+// cellAverages sets the option values at expiration to the payoff averaged over the cell around each interior node,
+// between the midpoints to its neighbours in the grid coordinate, and to the payoff itself at the boundary nodes
+// option: the option
+// sign: 1 for a call, -1 for a put
+func (fd *fdmGrid) cellAverages(option Option, sign float64) {
+	spots, values := fd.spots, fd.values
+	last := len(spots) - 1
+	strike := option.Strike
+	values[0] = math.Max(sign*(spots[0]-strike), 0)
+	values[last] = math.Max(sign*(spots[last]-strike), 0)
+	for i := 1; i < last; i++ {
+		switch fd.grid.Spacing {
+		case LogSpot:
+			// average of (e^x − K)⁺ or (K − e^x)⁺ over x
+			lo, hi, k := math.Log(spots[i-1]*spots[i])/2, math.Log(spots[i]*spots[i+1])/2, math.Log(strike)
+			switch {
+			case sign > 0 && hi > k:
+				a := math.Max(lo, k)
+				values[i] = (math.Exp(hi) - math.Exp(a) - strike*(hi-a)) / (hi - lo)
+			case sign < 0 && lo < k:
+				b := math.Min(hi, k)
+				values[i] = (strike*(b-lo) - math.Exp(b) + math.Exp(lo)) / (hi - lo)
+			default:
+				values[i] = 0
+			}
+		case LinearSpot:
+			// average of (S − K)⁺ or (K − S)⁺ over S
+			lo, hi := (spots[i-1]+spots[i])/2, (spots[i]+spots[i+1])/2
+			switch {
+			case sign > 0 && hi > strike:
+				a := math.Max(lo, strike)
+				values[i] = (hi - a) * ((hi+a)/2 - strike) / (hi - lo)
+			case sign < 0 && lo < strike:
+				b := math.Min(hi, strike)
+				values[i] = (b - lo) * (strike - (b+lo)/2) / (hi - lo)
+			default:
+				values[i] = 0
+			}
+		}
+	}
+}
+
+// explicitStep takes one explicit time step to time to expiration tau, projecting American options onto their
+// intrinsic value
+// tau: the time to expiration in years after the step
+// boundary: the boundary condition at an underlying price and time to expiration
+// intrinsic: the intrinsic value at an underlying price
+func (fd *fdmGrid) explicitStep(tau float64, boundary func(spot, tau float64) float64, intrinsic func(float64) float64) {
+	spots, values, next := fd.spots, fd.values, fd.rhs
+	last := len(spots) - 1
+	for i := 1; i < last; i++ {
+		next[i] = values[i] + fd.dt*(fd.lower[i]*values[i-1]+fd.diag[i]*values[i]+fd.upper[i]*values[i+1])
+		if fd.grid.Style == American {
+			next[i] = math.Max(next[i], intrinsic(spots[i]))
+		}
+	}
+	copy(values[1:last], next[1:last])
+	values[0], values[last] = boundary(spots[0], tau), boundary(spots[last], tau)
+}
+
+// thetaStep takes one time step of the θ-scheme (I − θ·dt·L)·V' = (I + (1 − θ)·dt·L)·V to time to expiration tau.
+// American options are solved as a linear complementarity problem by the Brennan-Schwartz algorithm, which
+// eliminates towards the exercise region and projects onto the intrinsic value while substituting back out of it
+// theta: the implicitness, 1 for fully implicit and 0.5 for Crank-Nicolson
+// dt: the time step in years
+// tau: the time to expiration in years after the step
+// sign: 1 for a call, whose exercise region lies above the boundary, -1 for a put, whose exercise region lies below
+// boundary: the boundary condition at an underlying price and time to expiration
+// intrinsic: the intrinsic value at an underlying price
+func (fd *fdmGrid) thetaStep(theta, dt, tau, sign float64, boundary func(spot, tau float64) float64, intrinsic func(float64) float64) {
+	spots, values, rhs := fd.spots, fd.values, fd.rhs
+	lower, diag, upper := fd.lower, fd.diag, fd.upper
+	last := len(spots) - 1
+	explicit := (1 - theta) * dt
+	for i := 1; i < last; i++ {
+		rhs[i] = values[i] + explicit*(lower[i]*values[i-1]+diag[i]*values[i]+upper[i]*values[i+1])
+	}
+	values[0], values[last] = boundary(spots[0], tau), boundary(spots[last], tau)
+
+	// the system is a[i]·V'[i−1] + b[i]·V'[i] + c[i]·V'[i+1] = rhs[i], with a = −θ·dt·lower, b = 1 − θ·dt·diag
+	// and c = −θ·dt·upper
+	implicit := theta * dt
+	american := fd.grid.Style == American
+	ratio, reduced := fd.ratio, fd.reduced
+	if sign > 0 {
+		// eliminate upwards from the lower boundary, then substitute back down from the upper one
+		ratio[0], reduced[0] = 0, values[0]
+		for i := 1; i < last; i++ {
+			a, b, c := -implicit*lower[i], 1-implicit*diag[i], -implicit*upper[i]
+			denominator := b - a*ratio[i-1]
+			ratio[i], reduced[i] = c/denominator, (rhs[i]-a*reduced[i-1])/denominator
+		}
+		for i := last - 1; i > 0; i-- {
+			values[i] = reduced[i] - ratio[i]*values[i+1]
+			if american {
+				values[i] = math.Max(values[i], intrinsic(spots[i]))
+			}
+		}
+		return
+	}
+	// eliminate downwards from the upper boundary, then substitute back up from the lower one
+	ratio[last], reduced[last] = 0, values[last]
+	for i := last - 1; i > 0; i-- {
+		a, b, c := -implicit*lower[i], 1-implicit*diag[i], -implicit*upper[i]
+		denominator := b - c*ratio[i+1]
+		ratio[i], reduced[i] = a/denominator, (rhs[i]-c*reduced[i+1])/denominator
+	}
+	for i := 1; i < last; i++ {
+		values[i] = reduced[i] - ratio[i]*values[i-1]
+		if american {
+			values[i] = math.Max(values[i], intrinsic(spots[i]))
+		}
+	}
+}
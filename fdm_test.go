@@ -0,0 +1,255 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFDMPriceSecondOrderConvergence(t *testing.T) {
+	for _, spacing := range []GridSpacing{LogSpot, LinearSpot} {
+		for _, optionType := range []OptionType{Call, Put} {
+			option := Option{
+				Strike:           105.0,
+				DaysToExpiration: 180.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.02,
+			}
+			want := BlackScholesOptionPrice(option, 0.25)
+
+			// doubling the grid should cut the error about fourfold
+			var errs []float64
+			for _, steps := range []int{100, 200, 400} {
+				errs = append(errs, math.Abs(FDMPrice(option, 0.25, FDGrid{SpotSteps: steps, TimeSteps: steps, Spacing: spacing}, CrankNicolson)-want))
+			}
+			for i := 1; i < len(errs); i++ {
+				if ratio := errs[i-1] / errs[i]; ratio < 3 || ratio > 6 {
+					t.Errorf("Unexpected error ratio for spacing %v type %v: got %v, want about 4", spacing, optionType, ratio)
+				}
+			}
+			if errs[2] > 1e-4 {
+				t.Errorf("Unexpected error for spacing %v type %v: got %v, want at most %v", spacing, optionType, errs[2], 1e-4)
+			}
+		}
+	}
+}
+
+func TestFDMPriceSchemes(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	want := BlackScholesOptionPrice(option, 0.2)
+
+	tests := []struct {
+		scheme Scheme
+		grid   FDGrid
+	}{
+		{Implicit, FDGrid{SpotSteps: 200, TimeSteps: 400}},
+		{Explicit, FDGrid{SpotSteps: 100, TimeSteps: 1000}},
+		{Explicit, FDGrid{SpotSteps: 100, TimeSteps: 1000, Spacing: LinearSpot}},
+	}
+
+	for _, test := range tests {
+		got, err := FDMPriceE(option, 0.2, test.grid, test.scheme)
+		if err != nil {
+			t.Errorf("Unexpected error for scheme %v grid %+v: %v", test.scheme, test.grid, err)
+		}
+		if math.Abs(got-want) > 1e-2 {
+			t.Errorf("Unexpected price for scheme %v grid %+v: got %v, want %v", test.scheme, test.grid, got, want)
+		}
+	}
+}
+
+func TestFDMPriceExplicitInstability(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+	grid := FDGrid{SpotSteps: 400, TimeSteps: 50}
+
+	if _, err := FDMPriceE(option, 0.2, grid, Explicit); !errors.Is(err, ErrUnstableScheme) {
+		t.Errorf("Expected ErrUnstableScheme: got %v", err)
+	}
+	if got := FDMPrice(option, 0.2, grid, Explicit); !math.IsNaN(got) {
+		t.Errorf("Unexpected unstable price: got %v, want NaN", got)
+	}
+	if _, err := FDMPriceE(option, 0.2, grid, CrankNicolson); err != nil {
+		t.Errorf("Unexpected Crank-Nicolson error: %v", err)
+	}
+}
+
+func TestFDMPriceAmerican(t *testing.T) {
+	tests := []Option{
+		{Strike: 105.0, DaysToExpiration: 180.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Put, DividendYield: 0.02},
+		{Strike: 90.0, DaysToExpiration: 365.0, RiskFreeRate: 0.06, UnderlyingPrice: 100.0, OptionType: Put},
+		{Strike: 105.0, DaysToExpiration: 180.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Call, DividendYield: 0.06},
+	}
+
+	for _, spacing := range []GridSpacing{LogSpot, LinearSpot} {
+		for _, option := range tests {
+			got := FDMPrice(option, 0.25, FDGrid{SpotSteps: 400, TimeSteps: 400, Spacing: spacing, Style: American}, CrankNicolson)
+			if want := LeisenReimerPrice(option, 0.25, 2001, American); math.Abs(got-want) > 1e-3 {
+				t.Errorf("Unexpected American price for spacing %v option %+v: got %v, want %v", spacing, option, got, want)
+			}
+		}
+	}
+
+	// a call exercised ahead of its dividend and a put held through it; both grids place their last exercise before
+	// the ex-date at a slightly different time, so they agree less closely
+	for _, option := range []Option{
+		{Strike: 100.0, DaysToExpiration: 90.0, RiskFreeRate: 0.05, UnderlyingPrice: 110.0, OptionType: Call, Dividends: []Dividend{{Amount: 8.0, DaysToExDate: 80.0}}},
+		{Strike: 100.0, DaysToExpiration: 90.0, RiskFreeRate: 0.05, UnderlyingPrice: 90.0, OptionType: Put, Dividends: []Dividend{{Amount: 8.0, DaysToExDate: 80.0}}},
+	} {
+		got := FDMPrice(option, 0.25, FDGrid{SpotSteps: 400, TimeSteps: 400, Style: American}, CrankNicolson)
+		if intrinsic := option.IntrinsicValue(); got < intrinsic {
+			t.Errorf("Unexpected American price for option %+v: got %v, want at least intrinsic value %v", option, got, intrinsic)
+		}
+		if want := LeisenReimerPrice(option, 0.25, 2001, American); math.Abs(got-want) > 1e-2 {
+			t.Errorf("Unexpected American price for option %+v: got %v, want %v", option, got, want)
+		}
+	}
+
+	deep := Option{Strike: 100.0, DaysToExpiration: 180.0, RiskFreeRate: 0.05, UnderlyingPrice: 60.0, OptionType: Put}
+	if got := FDMPrice(deep, 0.25, FDGrid{SpotSteps: 200, TimeSteps: 200, Style: American}, CrankNicolson); math.Abs(got-40.0) > 1e-9 {
+		t.Errorf("Unexpected deep in-the-money put price: got %v, want %v", got, 40.0)
+	}
+}
+
+func TestFDMGreeks(t *testing.T) {
+	for _, spacing := range []GridSpacing{LogSpot, LinearSpot} {
+		for _, optionType := range []OptionType{Call, Put} {
+			option := Option{
+				Strike:           105.0,
+				DaysToExpiration: 180.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.02,
+			}
+
+			got := FDMGreeks(option, 0.25, FDGrid{SpotSteps: 400, TimeSteps: 400, Spacing: spacing}, CrankNicolson)
+			want := BlackScholesGreeks(option, 0.25)
+			tests := []struct {
+				name      string
+				got, want float64
+				tolerance float64
+			}{
+				{"price", got.Price, want.Price, 1e-4},
+				{"delta", got.Delta, want.Delta, 1e-4},
+				{"gamma", got.Gamma, want.Gamma, 1e-5},
+				{"vega", got.Vega, want.Vega, 1e-2},
+				{"theta", got.Theta, want.Theta, 1e-2},
+				{"rho", got.Rho, want.Rho, 1e-2},
+			}
+			for _, test := range tests {
+				if math.Abs(test.got-test.want) > test.tolerance {
+					t.Errorf("Unexpected %s for spacing %v type %v: got %v, want %v", test.name, spacing, optionType, test.got, test.want)
+				}
+			}
+		}
+	}
+}
+
+func TestFDMPriceInvalid(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	tests := []struct {
+		name   string
+		grid   FDGrid
+		scheme Scheme
+	}{
+		{"one spot step", FDGrid{SpotSteps: 1, TimeSteps: 100}, CrankNicolson},
+		{"no time steps", FDGrid{SpotSteps: 100}, CrankNicolson},
+		{"negative width", FDGrid{SpotSteps: 100, TimeSteps: 100, StdDevs: -1}, CrankNicolson},
+		{"unknown spacing", FDGrid{SpotSteps: 100, TimeSteps: 100, Spacing: GridSpacing(2)}, CrankNicolson},
+		{"unknown scheme", FDGrid{SpotSteps: 100, TimeSteps: 100}, Scheme(3)},
+	}
+	for _, test := range tests {
+		if _, err := FDMPriceE(option, 0.2, test.grid, test.scheme); !errors.Is(err, ErrInvalidGrid) {
+			t.Errorf("Expected ErrInvalidGrid for %s: got %v", test.name, err)
+		}
+		if got := FDMGreeks(option, 0.2, test.grid, test.scheme); !math.IsNaN(got.Price) {
+			t.Errorf("Unexpected Greeks for %s: got %+v, want NaN", test.name, got)
+		}
+	}
+
+	grid := FDGrid{SpotSteps: 100, TimeSteps: 100}
+	if _, err := FDMPriceE(option, 0, grid, CrankNicolson); !errors.Is(err, ErrNonPositiveVolatility) {
+		t.Errorf("Expected ErrNonPositiveVolatility: got %v", err)
+	}
+	option.DaysToExpiration = 0
+	if got, err := FDMPriceE(option, 0.2, grid, CrankNicolson); got != 0 || err != nil {
+		t.Errorf("Unexpected price at expiration: got %v, %v, want 0, nil", got, err)
+	}
+}
+
+func TestFDMPriceCtx(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+	grid := FDGrid{SpotSteps: 200, TimeSteps: 100, Style: American, BatchSteps: 30}
+
+	var reports [][2]int
+	grid.Progress = func(done, total int) { reports = append(reports, [2]int{done, total}) }
+	complete, err := FDMPriceCtx(context.Background(), option, 0.3, grid, CrankNicolson)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := [][2]int{{30, 100}, {60, 100}, {90, 100}, {100, 100}}
+	if len(reports) != len(want) {
+		t.Fatalf("Unexpected progress reports: got %v, want %v", reports, want)
+	}
+	for i := range want {
+		if reports[i] != want[i] {
+			t.Errorf("Unexpected progress reports: got %v, want %v", reports, want)
+			break
+		}
+	}
+	if plain := FDMPrice(option, 0.3, grid, CrankNicolson); plain != complete {
+		t.Errorf("Unexpected price without a context: got %v, want %v", plain, complete)
+	}
+
+	// cancelling during the first batch stops the solve before the second
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	grid.Progress = func(done, total int) {
+		calls++
+		cancel()
+	}
+	price, err := FDMPriceCtx(ctx, option, 0.3, grid, CrankNicolson)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled: got %v", err)
+	}
+	if !math.IsNaN(price) {
+		t.Errorf("Unexpected partial price after cancellation: got %v", price)
+	}
+	if calls != 1 {
+		t.Errorf("Unexpected batches after cancellation: got %d progress reports, want 1", calls)
+	}
+
+	calls = 0
+	if _, err := FDMPriceCtx(ctx, option, 0.3, grid, CrankNicolson); !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("Unexpected result for a cancelled context: got %v after %d progress reports", err, calls)
+	}
+}
@@ -0,0 +1,91 @@
+package finance
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestFFTPriceBlackScholes(t *testing.T) {
+	cf := BlackScholesCharacteristic(0.25)
+	for _, days := range []float64{7.0, 30.0, 365.0, 3650.0} {
+		for _, strike := range []float64{50.0, 80.0, 95.0, 100.0, 105.0, 120.0, 200.0} {
+			for _, typ := range []OptionType{Call, Put} {
+				option := Option{
+					Strike:           strike,
+					DaysToExpiration: days,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  100.0,
+					OptionType:       typ,
+					DividendYield:    0.02,
+				}
+				want := BlackScholesOptionPrice(option, 0.25)
+				if got := FFTPrice(cf, option, FFTConfig{}); math.Abs(got-want) > 1e-6 {
+					t.Errorf("Unexpected price for %v at %v struck at %v: got %v, want %v", typ, days, strike, got, want)
+				}
+			}
+		}
+	}
+
+	// other grids and dampings price alike
+	option := Option{Strike: 110.0, DaysToExpiration: 90.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Call}
+	want := BlackScholesOptionPrice(option, 0.25)
+	for _, cfg := range []FFTConfig{{Alpha: 3}, {Points: 1024}, {Spacing: 0.1, Points: 16384}} {
+		if got := FFTPrice(cf, option, cfg); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Unexpected price with %+v: got %v, want %v", cfg, got, want)
+		}
+	}
+
+	expired := Option{Strike: 100.0, UnderlyingPrice: 110.0, OptionType: Call}
+	if got := FFTPrice(cf, expired, FFTConfig{}); got != 10.0 {
+		t.Errorf("Unexpected price at expiration: got %v, want 10", got)
+	}
+	for name, got := range map[string]float64{
+		"negative days":         FFTPrice(cf, Option{Strike: 100.0, DaysToExpiration: -1, UnderlyingPrice: 100.0}, FFTConfig{}),
+		"negative damping":      FFTPrice(cf, option, FFTConfig{Alpha: -1}),
+		"points not power of 2": FFTPrice(cf, option, FFTConfig{Points: 1000}),
+		"negative spacing":      FFTPrice(cf, option, FFTConfig{Spacing: -0.25}),
+		"invalid model":         FFTPrice(HestonCharacteristic(HestonParams{}), option, FFTConfig{}),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected price with %s: got %v, want NaN", name, got)
+		}
+	}
+}
+
+func TestFFTPriceModels(t *testing.T) {
+	// the Lewis calls of TestHestonPriceLewis
+	heston := HestonCharacteristic(HestonParams{V0: 0.04, Kappa: 4.0, Theta: 0.25, Sigma: 1.0, Rho: -0.5})
+	option := Option{Strike: 80.0, DaysToExpiration: 365.0, RiskFreeRate: 0.01, UnderlyingPrice: 100.0, OptionType: Call, DividendYield: 0.02}
+	if got, want := FFTPrice(heston, option, FFTConfig{}), 26.774758743998854; math.Abs(got-want) > 1e-8 {
+		t.Errorf("Unexpected Heston price: got %v, want %v", got, want)
+	}
+
+	merton := MertonCharacteristic(0.2, 1.0, -0.1, 0.15)
+	for _, typ := range []OptionType{Call, Put} {
+		option := Option{Strike: 105.0, DaysToExpiration: 365.0, RiskFreeRate: 0.01, UnderlyingPrice: 100.0, OptionType: typ, DividendYield: 0.02}
+		want := MertonJumpDiffusionPrice(option, 0.2, 1.0, -0.1, 0.15, 0)
+		if got := FFTPrice(merton, option, FFTConfig{}); math.Abs(got-want) > 1e-8 {
+			t.Errorf("Unexpected Merton price for %v: got %v, want %v", typ, got, want)
+		}
+	}
+}
+
+func TestFFT(t *testing.T) {
+	x := make([]complex128, 16)
+	for i := range x {
+		x[i] = complex(math.Sin(float64(i)), float64(i%3))
+	}
+	want := make([]complex128, len(x))
+	for m := range want {
+		for j, value := range x {
+			want[m] += value * cmplx.Exp(complex(0, -2*math.Pi*float64(j*m)/float64(len(x))))
+		}
+	}
+	fft(x)
+	for m := range x {
+		if cmplx.Abs(x[m]-want[m]) > 1e-12 {
+			t.Errorf("Unexpected transform at %d: got %v, want %v", m, x[m], want[m])
+		}
+	}
+}
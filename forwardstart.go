@@ -0,0 +1,26 @@
+package finance
+
+import (
+	"math"
+)
+
+// ForwardStartOptionPrice computes the Rubinstein (1991) price of a forward-start option, whose strike is set at a
+// future date to a multiple of the underlying price then. As the Black-Scholes price is proportional to the spot for
+// a strike proportional to it, the option is worth e^{−qt} of a vanilla option struck at the multiple of today's
+// price and expiring T − t after the strike is set, and with the strike set today it is the vanilla option. The
+// option's strike is ignored. Discrete dividends are escrowed, the strike then being set on the escrowed underlying
+// price. NaN with negative days to expiration, a strike-set date outside the option's life or a non-positive multiple
+// option: the option, whose expiration is that of the forward-start option
+// vol: the volatility
+// strikeSetDays: the days until the strike is set
+// moneynessFactor: the strike as a multiple of the underlying price when it is set, 1 at the money
+func ForwardStartOptionPrice(option Option, vol, strikeSetDays, moneynessFactor float64) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration < 0 || !(strikeSetDays >= 0 && strikeSetDays <= option.DaysToExpiration) || !(moneynessFactor > 0) {
+		return math.NaN()
+	}
+	started := option
+	started.Strike = moneynessFactor * option.UnderlyingPrice
+	started.DaysToExpiration = option.DaysToExpiration - strikeSetDays
+	return math.Exp(-option.DividendYield*strikeSetDays/365.0) * BlackScholesOptionPrice(started, vol)
+}
@@ -0,0 +1,74 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestForwardStartOptionPrice(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas, forward-start call struck 10% out of the money
+	option := Option{
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.08,
+		UnderlyingPrice:  60.0,
+		OptionType:       Call,
+		DividendYield:    0.04,
+	}
+	if got := ForwardStartOptionPrice(option, 0.3, 0.25*365, 1.1); math.Abs(got-4.4064) > 1e-4 {
+		t.Errorf("Unexpected forward-start call price: got %v, want 4.4064", got)
+	}
+
+	for _, optionType := range []OptionType{Call, Put} {
+		option.OptionType = optionType
+		// with the strike set today it is the vanilla option
+		vanilla := option
+		vanilla.Strike = 66.0
+		if got, want := ForwardStartOptionPrice(option, 0.3, 0, 1.1), BlackScholesOptionPrice(vanilla, 0.3); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Unexpected forward-start price for type %v set today: got %v, want %v", optionType, got, want)
+		}
+
+		// an at-the-money forward start is e^{−qt}·S times the at-the-money option on a unit spot over the rest of its life
+		setDays := 90.0
+		unit := Option{
+			Strike:           1.0,
+			DaysToExpiration: option.DaysToExpiration - setDays,
+			RiskFreeRate:     option.RiskFreeRate,
+			UnderlyingPrice:  1.0,
+			OptionType:       optionType,
+			DividendYield:    option.DividendYield,
+		}
+		want := option.UnderlyingPrice * math.Exp(-option.DividendYield*setDays/365.0) * BlackScholesOptionPrice(unit, 0.3)
+		if got := ForwardStartOptionPrice(option, 0.3, setDays, 1.0); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Unexpected at-the-money forward-start price for type %v: got %v, want %v", optionType, got, want)
+		}
+	}
+
+	// the at-the-money forward start is worth the discounted expectation of the at-the-money option struck at the
+	// spot on the strike-set date, integrated here by the midpoint rule
+	option.OptionType = Call
+	var sum float64
+	const steps = 20000
+	setYears := 0.25
+	for i := 0; i < steps; i++ {
+		z := -10 + 20*(float64(i)+0.5)/steps
+		started := option
+		started.UnderlyingPrice = 60.0 * math.Exp((0.04-0.045)*setYears+0.3*math.Sqrt(setYears)*z)
+		started.Strike = started.UnderlyingPrice
+		started.DaysToExpiration = 365.0 - setYears*365
+		sum += 20.0 / steps * NormalDistributionDerivative(z) * BlackScholesOptionPrice(started, 0.3)
+	}
+	if got, want := ForwardStartOptionPrice(option, 0.3, setYears*365, 1.0), math.Exp(-0.08*setYears)*sum; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Unexpected at-the-money forward-start price: got %v, want %v", got, want)
+	}
+
+	for name, got := range map[string]float64{
+		"negative set date":    ForwardStartOptionPrice(option, 0.3, -1, 1.0),
+		"set after expiration": ForwardStartOptionPrice(option, 0.3, 366, 1.0),
+		"zero moneyness":       ForwardStartOptionPrice(option, 0.3, 90, 0),
+		"negative days":        ForwardStartOptionPrice(Option{DaysToExpiration: -1, UnderlyingPrice: 60.0}, 0.3, 0, 1.0),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected forward-start price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
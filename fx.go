@@ -0,0 +1,66 @@
+package finance
+
+// The Garman-Kohlhagen model prices currency options. UnderlyingPrice is the spot exchange rate in domestic units per
+// unit of foreign currency, RiskFreeRate is the domestic rate and the foreign rate plays the role of a continuous
+// dividend yield. DividendYield and Dividends do not apply to currencies and are ignored
+
+// GarmanKohlhagenPrice calculates the Garman-Kohlhagen price of a currency option, in domestic currency
+// option: the option, with UnderlyingPrice holding the spot exchange rate
+// vol: the volatility of the exchange rate
+// foreignRate: the foreign risk-free interest rate
+func GarmanKohlhagenPrice(option Option, vol, foreignRate float64) float64 {
+	return BlackScholesOptionPrice(garmanKohlhagen(option, foreignRate), vol)
+}
+
+// GarmanKohlhagenSpotDelta computes the spot delta of a currency option, the sensitivity of its price to the spot rate.
+// It includes the foreign discount factor e^{−rf·T}
+// option: the option, with UnderlyingPrice holding the spot exchange rate
+// vol: the volatility of the exchange rate
+// foreignRate: the foreign risk-free interest rate
+func GarmanKohlhagenSpotDelta(option Option, vol, foreignRate float64) float64 {
+	return BlackScholesDelta(garmanKohlhagen(option, foreignRate), vol)
+}
+
+// GarmanKohlhagenForwardDelta computes the forward delta of a currency option, the sensitivity of its forward value
+// to the outright forward rate: N(d1) for calls and N(d1) − 1 for puts. It equals the spot delta
+// divided by e^{−rf·T}, so the two diverge for long maturities and high foreign rates
+// option: the option, with UnderlyingPrice holding the spot exchange rate
+// vol: the volatility of the exchange rate
+// foreignRate: the foreign risk-free interest rate
+func GarmanKohlhagenForwardDelta(option Option, vol, foreignRate float64) float64 {
+	if option.DaysToExpiration == 0 {
+		return expirationDelta(option)
+	}
+	d1, _ := blackScholesD1D2(garmanKohlhagen(option, foreignRate), vol)
+	if option.OptionType == Call {
+		return Phi(d1)
+	}
+	return Phi(d1) - 1
+}
+
+// GarmanKohlhagenVega computes the vega of a currency option
+// option: the option, with UnderlyingPrice holding the spot exchange rate
+// vol: the volatility of the exchange rate
+// foreignRate: the foreign risk-free interest rate
+func GarmanKohlhagenVega(option Option, vol, foreignRate float64) float64 {
+	return BlackScholesVega(garmanKohlhagen(option, foreignRate), vol)
+}
+
+// GarmanKohlhagenImpliedVolatility computes the Garman-Kohlhagen implied volatility of a currency option from its
+// price with an IVSolver configured by opts, and returns the same errors as ImpliedVolatility. Currency option prices
+// are often small, so a tolerance tighter than the default 1e-4 is usually wanted
+// option: the option, with UnderlyingPrice holding the spot exchange rate
+// foreignRate: the foreign risk-free interest rate
+// opts: the solver settings
+func GarmanKohlhagenImpliedVolatility(option Option, foreignRate float64, opts ...IVOption) (IVResult, error) {
+	return NewIVSolver(opts...).Solve(garmanKohlhagen(option, foreignRate))
+}
+
+// garmanKohlhagen returns a copy of option with the foreign rate as its dividend yield and no discrete dividends
+// option: the option
+// foreignRate: the foreign risk-free interest rate
+func garmanKohlhagen(option Option, foreignRate float64) Option {
+	option.DividendYield = foreignRate
+	option.Dividends = nil
+	return option
+}
@@ -0,0 +1,91 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGarmanKohlhagenPrice(t *testing.T) {
+	const tolerance = 1e-10
+
+	// Haug, The Complete Guide to Option Pricing Formulas: S = 1.56, K = 1.60, T = 0.5, r = 6%, rf = 8%, vol = 12%
+	tests := []struct {
+		optionType OptionType
+		expected   float64
+	}{
+		{Call, 0.02909925314943973},
+		{Put, 0.08298058174942846},
+	}
+
+	for _, test := range tests {
+		option := Option{
+			Strike:           1.60,
+			DaysToExpiration: 182.5,
+			RiskFreeRate:     0.06,
+			UnderlyingPrice:  1.56,
+			OptionType:       test.optionType,
+		}
+
+		if price := GarmanKohlhagenPrice(option, 0.12, 0.08); math.Abs(price-test.expected) > tolerance {
+			t.Errorf("Unexpected price for type %v: got %v, want %v", test.optionType, price, test.expected)
+		}
+	}
+}
+
+func TestGarmanKohlhagenDelta(t *testing.T) {
+	const tolerance = 1e-10
+
+	// a high foreign rate and a long maturity pull the spot and forward deltas far apart
+	for _, optionType := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           20.0,
+			DaysToExpiration: 5 * 365.0,
+			RiskFreeRate:     0.04,
+			UnderlyingPrice:  18.5,
+			OptionType:       optionType,
+		}
+		const foreignRate = 0.35
+
+		spot := GarmanKohlhagenSpotDelta(option, 0.15, foreignRate)
+		forward := GarmanKohlhagenForwardDelta(option, 0.15, foreignRate)
+		if want := forward * math.Exp(-foreignRate*5); math.Abs(spot-want) > tolerance {
+			t.Errorf("Unexpected spot delta for type %v: got %v, want %v", optionType, spot, want)
+		}
+
+		const h = 1e-6
+		up, down := option, option
+		up.UnderlyingPrice += h
+		down.UnderlyingPrice -= h
+		want := (GarmanKohlhagenPrice(up, 0.15, foreignRate) - GarmanKohlhagenPrice(down, 0.15, foreignRate)) / (2 * h)
+		if math.Abs(spot-want) > 1e-6 {
+			t.Errorf("Unexpected finite difference spot delta for type %v: got %v, want %v", optionType, spot, want)
+		}
+	}
+}
+
+func TestGarmanKohlhagenImpliedVolatility(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, foreignRate := range []float64{0.0, 0.03, 0.40} {
+			for _, days := range []float64{30.0, 3650.0} {
+				// strike at the forward, which drifts far from spot for high foreign rates and long maturities
+				option := Option{
+					Strike:           1.08 * math.Exp((0.05-foreignRate)*days/365.0),
+					DaysToExpiration: days,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  1.08,
+					OptionType:       optionType,
+				}
+				option.Price = GarmanKohlhagenPrice(option, 0.1, foreignRate)
+
+				result, err := GarmanKohlhagenImpliedVolatility(option, foreignRate, WithTolerance(1e-10))
+				if err != nil {
+					t.Errorf("Unexpected error for type %v foreign rate %v days %v: %v", optionType, foreignRate, days, err)
+					continue
+				}
+				if diff := math.Abs(result.Volatility - 0.1); diff > 1e-4 {
+					t.Errorf("Unexpected implied volatility for type %v foreign rate %v days %v: got %v, want %v", optionType, foreignRate, days, result.Volatility, 0.1)
+				}
+			}
+		}
+	}
+}
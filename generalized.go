@@ -0,0 +1,299 @@
+package finance
+
+import "math"
+
+// The generalized Black-Scholes-Merton model (Haug) prices options on any underlying through its cost of carry b:
+// b = r gives Black-Scholes on a non-dividend stock, b = r − q the Merton model with a continuous dividend yield,
+// b = 0 the Black-76 model on futures and b = r − rf the Garman-Kohlhagen model on currencies.
+// The costOfCarry argument replaces option.DividendYield, which is ignored by every function in this file;
+// discrete dividends are still taken out of the underlying price with the escrowed dividend model.
+// Expiration is handled as in BlackScholesOptionPrice and its Greeks
+
+// GeneralizedBlackScholes calculates the option price under the generalized Black-Scholes-Merton model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholes(option Option, vol, costOfCarry float64) float64 {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, d2 := generalizedD1D2(option, vol, costOfCarry)
+	carriedSpot := option.UnderlyingPrice * math.Exp((costOfCarry-option.RiskFreeRate)*timeToExpiration)
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	if option.OptionType == Call {
+		return carriedSpot*Phi(d1) - discountedStrike*Phi(d2)
+	}
+	return discountedStrike*PhiC(d2) - carriedSpot*PhiC(d1)
+}
+
+// GeneralizedBlackScholesDelta computes the delta of an option under the generalized Black-Scholes-Merton model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesDelta(option Option, vol, costOfCarry float64) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration == 0 {
+		return expirationDelta(option)
+	}
+	d1, _ := generalizedD1D2(option, vol, costOfCarry)
+	carry := math.Exp((costOfCarry - option.RiskFreeRate) * option.DaysToExpiration / 365.0)
+	if option.OptionType == Call {
+		return carry * Phi(d1)
+	}
+	return carry * (Phi(d1) - 1)
+}
+
+// GeneralizedBlackScholesGamma computes the gamma of an option under the generalized Black-Scholes-Merton model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesGamma(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, _ := generalizedD1D2(option, vol, costOfCarry)
+	carry := math.Exp((costOfCarry - option.RiskFreeRate) * timeToExpiration)
+	return carry * NormalDistributionDerivative(d1) / (option.UnderlyingPrice * vol * math.Sqrt(timeToExpiration))
+}
+
+// GeneralizedBlackScholesVega computes the vega of an option under the generalized Black-Scholes-Merton model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesVega(option Option, vol, costOfCarry float64) float64 {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, _ := generalizedD1D2(option, vol, costOfCarry)
+	carriedSpot := option.UnderlyingPrice * math.Exp((costOfCarry-option.RiskFreeRate)*timeToExpiration)
+	return carriedSpot * math.Sqrt(timeToExpiration) * NormalDistributionDerivative(d1)
+}
+
+// GeneralizedBlackScholesTheta computes the theta of an option under the generalized Black-Scholes-Merton model,
+// expressed per year
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesTheta(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, d2 := generalizedD1D2(option, vol, costOfCarry)
+	carriedSpot := option.UnderlyingPrice * math.Exp((costOfCarry-option.RiskFreeRate)*timeToExpiration)
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	decay := -carriedSpot * NormalDistributionDerivative(d1) * vol / (2 * math.Sqrt(timeToExpiration))
+
+	if option.OptionType == Call {
+		return decay - (costOfCarry-option.RiskFreeRate)*carriedSpot*Phi(d1) - option.RiskFreeRate*discountedStrike*Phi(d2)
+	}
+	return decay + (costOfCarry-option.RiskFreeRate)*carriedSpot*PhiC(d1) + option.RiskFreeRate*discountedStrike*PhiC(d2)
+}
+
+// GeneralizedBlackScholesRho computes the rho of an option under the generalized Black-Scholes-Merton model,
+// expressed per unit (1.00) change in the risk-free rate. The cost of carry moves with the rate (r − b is held fixed),
+// as for stocks and currencies; when b itself is held fixed, as for futures, subtract GeneralizedBlackScholesCarryRho
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesRho(option Option, vol, costOfCarry float64) float64 {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	_, d2 := generalizedD1D2(option, vol, costOfCarry)
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+
+	if option.OptionType == Call {
+		return discountedStrike * timeToExpiration * Phi(d2)
+	}
+	return -discountedStrike * timeToExpiration * PhiC(d2)
+}
+
+// GeneralizedBlackScholesCarryRho computes the sensitivity of an option to the cost of carry b,
+// expressed per unit (1.00) change in b. With b = r − q it is the negative of the dividend yield sensitivity
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesCarryRho(option Option, vol, costOfCarry float64) float64 {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, _ := generalizedD1D2(option, vol, costOfCarry)
+	carriedSpot := option.UnderlyingPrice * math.Exp((costOfCarry-option.RiskFreeRate)*timeToExpiration)
+
+	if option.OptionType == Call {
+		return carriedSpot * timeToExpiration * Phi(d1)
+	}
+	return -carriedSpot * timeToExpiration * PhiC(d1)
+}
+
+// GeneralizedBlackScholesVanna computes the vanna of an option under the generalized Black-Scholes-Merton model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesVanna(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	d1, d2 := generalizedD1D2(option, vol, costOfCarry)
+	carry := math.Exp((costOfCarry - option.RiskFreeRate) * option.DaysToExpiration / 365.0)
+	return -carry * NormalDistributionDerivative(d1) * d2 / vol
+}
+
+// GeneralizedBlackScholesVomma computes the vomma of an option under the generalized Black-Scholes-Merton model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesVomma(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	d1, d2 := generalizedD1D2(escrowed(option), vol, costOfCarry)
+	return GeneralizedBlackScholesVega(option, vol, costOfCarry) * d1 * d2 / vol
+}
+
+// GeneralizedBlackScholesCharm computes the charm of an option under the generalized Black-Scholes-Merton model,
+// the rate at which delta changes as time passes, expressed per year
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesCharm(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	sqrtT := math.Sqrt(timeToExpiration)
+	d1, d2 := generalizedD1D2(option, vol, costOfCarry)
+	carry := math.Exp((costOfCarry - option.RiskFreeRate) * timeToExpiration)
+	drift := -carry * NormalDistributionDerivative(d1) * (costOfCarry/(vol*sqrtT) - d2/(2*timeToExpiration))
+	if option.OptionType == Call {
+		return drift - (costOfCarry-option.RiskFreeRate)*carry*Phi(d1)
+	}
+	return drift + (costOfCarry-option.RiskFreeRate)*carry*PhiC(d1)
+}
+
+// GeneralizedBlackScholesSpeed computes the speed of an option under the generalized Black-Scholes-Merton model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesSpeed(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, _ := generalizedD1D2(option, vol, costOfCarry)
+	return -GeneralizedBlackScholesGamma(option, vol, costOfCarry) / option.UnderlyingPrice * (d1/(vol*math.Sqrt(timeToExpiration)) + 1)
+}
+
+// GeneralizedBlackScholesZomma computes the zomma of an option under the generalized Black-Scholes-Merton model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesZomma(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	d1, d2 := generalizedD1D2(escrowed(option), vol, costOfCarry)
+	return GeneralizedBlackScholesGamma(option, vol, costOfCarry) * (d1*d2 - 1) / vol
+}
+
+// GeneralizedBlackScholesColor computes the color of an option under the generalized Black-Scholes-Merton model,
+// the rate at which gamma changes as time passes, expressed per year
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesColor(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	volSqrtT := vol * math.Sqrt(timeToExpiration)
+	d1, d2 := generalizedD1D2(option, vol, costOfCarry)
+	carry := math.Exp((costOfCarry - option.RiskFreeRate) * timeToExpiration)
+	return carry * NormalDistributionDerivative(d1) / (2 * option.UnderlyingPrice * timeToExpiration * volSqrtT) *
+		(2*(option.RiskFreeRate-costOfCarry)*timeToExpiration + 1 + (2*costOfCarry*timeToExpiration-d2*volSqrtT)*d1/volSqrtT)
+}
+
+// GeneralizedBlackScholesLambda computes the lambda of an option under the generalized Black-Scholes-Merton model,
+// NaN when the option price is below 1e-10
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesLambda(option Option, vol, costOfCarry float64) float64 {
+	price := GeneralizedBlackScholes(option, vol, costOfCarry)
+	if !(price >= lambdaMinPrice) {
+		return math.NaN()
+	}
+	return GeneralizedBlackScholesDelta(option, vol, costOfCarry) * option.UnderlyingPrice / price
+}
+
+// GeneralizedBlackScholesDualDelta computes the dual delta of an option under the generalized Black-Scholes-Merton
+// model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesDualDelta(option Option, vol, costOfCarry float64) float64 {
+	option = escrowed(option)
+	_, d2 := generalizedD1D2(option, vol, costOfCarry)
+	discount := math.Exp(-option.RiskFreeRate * option.DaysToExpiration / 365.0)
+
+	if option.OptionType == Call {
+		return -discount * Phi(d2)
+	}
+	return discount * PhiC(d2)
+}
+
+// GeneralizedBlackScholesDualGamma computes the dual gamma of an option under the generalized Black-Scholes-Merton
+// model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesDualGamma(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	_, d2 := generalizedD1D2(option, vol, costOfCarry)
+	return math.Exp(-option.RiskFreeRate*timeToExpiration) * NormalDistributionDerivative(d2) / (option.Strike * vol * math.Sqrt(timeToExpiration))
+}
+
+// GeneralizedBlackScholesUltima computes the ultima of an option under the generalized Black-Scholes-Merton model
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesUltima(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	d1, d2 := generalizedD1D2(escrowed(option), vol, costOfCarry)
+	return -GeneralizedBlackScholesVega(option, vol, costOfCarry) / (vol * vol) * (d1*d2*(1-d1*d2) + d1*d1 + d2*d2)
+}
+
+// GeneralizedBlackScholesVera computes the vera of an option under the generalized Black-Scholes-Merton model, the
+// sensitivity to volatility of GeneralizedBlackScholesRho
+// option: the option
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func GeneralizedBlackScholesVera(option Option, vol, costOfCarry float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, d2 := generalizedD1D2(option, vol, costOfCarry)
+	return -option.Strike * timeToExpiration * math.Exp(-option.RiskFreeRate*timeToExpiration) * NormalDistributionDerivative(d2) * d1 / vol
+}
+
+// generalizedD1D2 computes the d1 and d2 terms of the generalized Black-Scholes-Merton formula
+// option: the option, with any discrete dividends already escrowed
+// vol: the volatility
+// costOfCarry: the annualized cost of carry b
+func generalizedD1D2(option Option, vol, costOfCarry float64) (float64, float64) {
+	timeToExpiration := option.DaysToExpiration / 365.0
+	forward := option.UnderlyingPrice * math.Exp(costOfCarry*timeToExpiration)
+	return forwardD1D2(forward, option.Strike, timeToExpiration, vol)
+}
@@ -0,0 +1,107 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeneralizedBlackScholesSpecializations(t *testing.T) {
+	const tolerance = 1e-12
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{80.0, 100.0, 120.0} {
+			for _, dividendYield := range []float64{0.0, 0.03} {
+				option := Option{
+					Strike:           strike,
+					DaysToExpiration: 90.0,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  100.0,
+					OptionType:       optionType,
+					DividendYield:    dividendYield,
+				}
+				// b = r gives Black-Scholes and b = r − q gives Merton; with q playing the foreign rate this is also Garman-Kohlhagen
+				b := option.RiskFreeRate - dividendYield
+
+				checks := []struct {
+					name      string
+					got, want float64
+				}{
+					{"price", GeneralizedBlackScholes(option, 0.25, b), BlackScholesOptionPrice(option, 0.25)},
+					{"delta", GeneralizedBlackScholesDelta(option, 0.25, b), BlackScholesDelta(option, 0.25)},
+					{"gamma", GeneralizedBlackScholesGamma(option, 0.25, b), BlackScholesGamma(option, 0.25)},
+					{"vega", GeneralizedBlackScholesVega(option, 0.25, b), BlackScholesVega(option, 0.25)},
+					{"theta", GeneralizedBlackScholesTheta(option, 0.25, b), BlackScholesTheta(option, 0.25)},
+					{"rho", GeneralizedBlackScholesRho(option, 0.25, b), BlackScholesRho(option, 0.25)},
+					{"vanna", GeneralizedBlackScholesVanna(option, 0.25, b), BlackScholesVanna(option, 0.25)},
+					{"vomma", GeneralizedBlackScholesVomma(option, 0.25, b), BlackScholesVomma(option, 0.25)},
+					{"charm", GeneralizedBlackScholesCharm(option, 0.25, b), BlackScholesCharm(option, 0.25)},
+					{"speed", GeneralizedBlackScholesSpeed(option, 0.25, b), BlackScholesSpeed(option, 0.25)},
+					{"zomma", GeneralizedBlackScholesZomma(option, 0.25, b), BlackScholesZomma(option, 0.25)},
+					{"color", GeneralizedBlackScholesColor(option, 0.25, b), BlackScholesColor(option, 0.25)},
+					{"lambda", GeneralizedBlackScholesLambda(option, 0.25, b), BlackScholesLambda(option, 0.25)},
+					{"dual delta", GeneralizedBlackScholesDualDelta(option, 0.25, b), BlackScholesDualDelta(option, 0.25)},
+					{"dual gamma", GeneralizedBlackScholesDualGamma(option, 0.25, b), BlackScholesDualGamma(option, 0.25)},
+					{"ultima", GeneralizedBlackScholesUltima(option, 0.25, b), BlackScholesUltima(option, 0.25)},
+					{"vera", GeneralizedBlackScholesVera(option, 0.25, b), BlackScholesVera(option, 0.25)},
+				}
+				for _, c := range checks {
+					if diff := math.Abs(c.got - c.want); diff > tolerance {
+						t.Errorf("Unexpected %s for type %v strike %v yield %v: got %v, want %v", c.name, optionType, strike, dividendYield, c.got, c.want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGeneralizedBlackScholesFutures(t *testing.T) {
+	const tolerance = 1e-10
+
+	tests := []struct {
+		optionType OptionType
+		expected   float64
+	}{
+		{Call, 10.703499385548087},
+		{Put, 5.826949825406423},
+	}
+
+	for _, test := range tests {
+		option := Option{
+			Strike:           95.0,
+			DaysToExpiration: 182.5,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0, // futures price
+			OptionType:       test.optionType,
+		}
+
+		price := GeneralizedBlackScholes(option, 0.3, 0)
+		if diff := math.Abs(price - test.expected); diff > tolerance {
+			t.Errorf("Unexpected price for type %v: got %v, want %v", test.optionType, price, test.expected)
+		}
+
+		// with b = 0 held fixed, rho reduces to −T·V
+		rho := GeneralizedBlackScholesRho(option, 0.3, 0) - GeneralizedBlackScholesCarryRho(option, 0.3, 0)
+		if want := -0.5 * price; math.Abs(rho-want) > tolerance {
+			t.Errorf("Unexpected futures rho for type %v: got %v, want %v", test.optionType, rho, want)
+		}
+	}
+}
+
+func TestGeneralizedBlackScholesCarryRho(t *testing.T) {
+	const h = 1e-6
+
+	for _, optionType := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           105.0,
+			DaysToExpiration: 120.0,
+			RiskFreeRate:     0.04,
+			UnderlyingPrice:  100.0,
+			OptionType:       optionType,
+		}
+
+		want := (GeneralizedBlackScholes(option, 0.2, 0.01+h) - GeneralizedBlackScholes(option, 0.2, 0.01-h)) / (2 * h)
+		if got := GeneralizedBlackScholesCarryRho(option, 0.2, 0.01); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Unexpected carry rho for type %v: got %v, want %v", optionType, got, want)
+		}
+	}
+}
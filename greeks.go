@@ -0,0 +1,329 @@
+package finance
+
+import (
+	"math"
+)
+
+// Greeks holds an option's price together with its first-order sensitivities
+type Greeks struct {
+	Price float64 `json:"price"` // Option price
+	Delta float64 `json:"delta"` // Sensitivity to the underlying price
+	Gamma float64 `json:"gamma"` // Sensitivity of delta to the underlying price
+	Vega  float64 `json:"vega"`  // Sensitivity to volatility, per 1.00 change in volatility
+	Theta float64 `json:"theta"` // Sensitivity to the passage of time, per year
+	Rho   float64 `json:"rho"`   // Sensitivity to the risk-free rate, per 1.00 change in rate
+}
+
+// BlackScholesGreeks computes the Black-Scholes price and Greeks of an option in a single pass,
+// evaluating d1, d2 and the discount factor only once. At expiration gamma, vega and theta are 0
+// and delta follows BlackScholesDelta
+// option: the option
+// volatility: the volatility
+func BlackScholesGreeks(option Option, volatility float64) Greeks {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	sqrtT := math.Sqrt(timeToExpiration)
+	d1, d2 := blackScholesD1D2(option, volatility)
+	pdf := NormalDistributionDerivative(d1)
+	dividendDiscount := math.Exp(-option.DividendYield * timeToExpiration)
+	discountedSpot := option.UnderlyingPrice * dividendDiscount
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	decay := -discountedSpot * pdf * volatility / (2 * sqrtT)
+
+	greeks := Greeks{
+		Gamma: dividendDiscount * pdf / (option.UnderlyingPrice * volatility * sqrtT),
+		Vega:  discountedSpot * sqrtT * pdf,
+	}
+
+	if option.DaysToExpiration == 0 {
+		return Greeks{Price: BlackScholesOptionPrice(option, volatility), Delta: expirationDelta(option)}
+	}
+
+	if option.OptionType == Call {
+		nd1, nd2 := Phi(d1), Phi(d2)
+		greeks.Price = discountedSpot*nd1 - discountedStrike*nd2
+		greeks.Delta = dividendDiscount * nd1
+		greeks.Theta = decay - option.RiskFreeRate*discountedStrike*nd2 + option.DividendYield*discountedSpot*nd1
+		greeks.Rho = discountedStrike * timeToExpiration * nd2
+		return greeks
+	}
+
+	nd1, nd2 := PhiC(d1), PhiC(d2)
+	greeks.Price = discountedStrike*nd2 - discountedSpot*nd1
+	greeks.Delta = -dividendDiscount * nd1
+	greeks.Theta = decay + option.RiskFreeRate*discountedStrike*nd2 - option.DividendYield*discountedSpot*nd1
+	greeks.Rho = -discountedStrike * timeToExpiration * nd2
+	return greeks
+}
+
+// ScaledGreeks computes the Black-Scholes price and Greeks of an option in the units traders quote:
+// vega per 1 volatility point, theta per calendar day and rho per 1% change in rate, with every value
+// multiplied by the contract multiplier (e.g. 100 for US equity options). A multiplier of 1 gives per-share values
+// option: the option
+// volatility: the volatility
+// multiplier: the contract multiplier
+func ScaledGreeks(option Option, volatility float64, multiplier float64) Greeks {
+	greeks := BlackScholesGreeks(option, volatility)
+	return Greeks{
+		Price: greeks.Price * multiplier,
+		Delta: greeks.Delta * multiplier,
+		Gamma: greeks.Gamma * multiplier,
+		Vega:  greeks.Vega / 100.0 * multiplier,
+		Theta: greeks.Theta / 365.0 * multiplier,
+		Rho:   greeks.Rho / 100.0 * multiplier,
+	}
+}
+
+// BumpSizes holds the bump sizes used to compute Greeks by finite differences
+type BumpSizes struct {
+	Underlying float64 // Relative bump of the underlying price, as a fraction of the price
+	Volatility float64 // Absolute bump of the volatility
+	Days       float64 // Absolute bump of the days to expiration
+	Rate       float64 // Absolute bump of the risk-free rate
+}
+
+// DefaultBumpSizes returns bump sizes that balance truncation and rounding error for typical
+// equity option inputs
+func DefaultBumpSizes() BumpSizes {
+	return BumpSizes{
+		Underlying: 0.0001,
+		Volatility: 0.0001,
+		Days:       0.01,
+		Rate:       0.0001,
+	}
+}
+
+// NumericalGreeks computes the price and Greeks of an option under any pricer by central finite
+// differences (bump and reprice), using the same units as BlackScholesGreeks. When fewer days remain
+// than the time bump, theta falls back to a one-sided difference
+// pricer: the pricing function
+// option: the option
+// vol: the volatility
+// bumps: the bump sizes
+func NumericalGreeks(pricer func(Option, float64) float64, option Option, vol float64, bumps BumpSizes) Greeks {
+	price := pricer(option, vol)
+
+	spotBump := option.UnderlyingPrice * bumps.Underlying
+	up, down := option, option
+	up.UnderlyingPrice += spotBump
+	down.UnderlyingPrice -= spotBump
+	priceUp, priceDown := pricer(up, vol), pricer(down, vol)
+
+	rateUp, rateDown := option, option
+	rateUp.RiskFreeRate += bumps.Rate
+	rateDown.RiskFreeRate -= bumps.Rate
+
+	// theta is measured as time passes, i.e. as days to expiration shrink
+	earlier := option
+	earlier.DaysToExpiration += bumps.Days
+	var theta float64
+	if option.DaysToExpiration > bumps.Days {
+		later := option
+		later.DaysToExpiration -= bumps.Days
+		theta = (pricer(later, vol) - pricer(earlier, vol)) / (2 * bumps.Days / 365.0)
+	} else {
+		theta = (price - pricer(earlier, vol)) / (bumps.Days / 365.0)
+	}
+
+	return Greeks{
+		Price: price,
+		Delta: (priceUp - priceDown) / (2 * spotBump),
+		Gamma: (priceUp - 2*price + priceDown) / (spotBump * spotBump),
+		Vega:  (pricer(option, vol+bumps.Volatility) - pricer(option, vol-bumps.Volatility)) / (2 * bumps.Volatility),
+		Theta: theta,
+		Rho:   (pricer(rateUp, vol) - pricer(rateDown, vol)) / (2 * bumps.Rate),
+	}
+}
+
+// ThetaComponents splits theta into the rate-carry and volatility-decay components, both per year
+type ThetaComponents struct {
+	Carry      float64 // Funding cost of the discounted strike net of dividends (-r*K*e^{-rT}*N(d2) + q*S*e^{-qT}*N(d1) for calls)
+	Volatility float64 // Pure volatility decay (-S*e^{-qT}*N'(d1)*σ/(2*sqrt(T))), the rent paid for gamma
+}
+
+// ThetaDecomposition splits the Black-Scholes theta of an option into its rate-carry and volatility-decay
+// components, which sum to BlackScholesTheta
+// option: the option
+// vol: the volatility
+func ThetaDecomposition(option Option, vol float64) ThetaComponents {
+	if value, ok := atExpiration(option); ok {
+		return ThetaComponents{Carry: value, Volatility: value}
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, d2 := blackScholesD1D2(option, vol)
+	discountedSpot := option.UnderlyingPrice * math.Exp(-option.DividendYield*timeToExpiration)
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+
+	components := ThetaComponents{
+		Volatility: -discountedSpot * NormalDistributionDerivative(d1) * vol / (2 * math.Sqrt(timeToExpiration)),
+	}
+	if option.OptionType == Call {
+		components.Carry = -option.RiskFreeRate*discountedStrike*Phi(d2) + option.DividendYield*discountedSpot*Phi(d1)
+	} else {
+		components.Carry = option.RiskFreeRate*discountedStrike*PhiC(d2) - option.DividendYield*discountedSpot*PhiC(d1)
+	}
+	return components
+}
+
+// Side is the direction of a position, either Long or Short
+type Side int
+
+const (
+	Long Side = iota
+	Short
+)
+
+// Leg represents one leg of a multi-leg position
+type Leg struct {
+	Option     Option  // Option contract
+	Volatility float64 // Volatility used to price the leg
+	Quantity   float64 // Number of contracts, always positive; the direction is given by Side
+	Side       Side    // Side of the position, can be either Long or Short
+}
+
+// LegGreeks computes the net Black-Scholes price and Greeks of a multi-leg position, weighting each
+// leg by its quantity and adding long legs while subtracting short legs. Legs may have different strikes,
+// expirations and volatilities
+// legs: the legs of the position
+func LegGreeks(legs []Leg) Greeks {
+	var net Greeks
+	for _, leg := range legs {
+		greeks := BlackScholesGreeks(leg.Option, leg.Volatility)
+		weight := leg.Quantity
+		if leg.Side == Short {
+			weight = -weight
+		}
+		net.Price += weight * greeks.Price
+		net.Delta += weight * greeks.Delta
+		net.Gamma += weight * greeks.Gamma
+		net.Vega += weight * greeks.Vega
+		net.Theta += weight * greeks.Theta
+		net.Rho += weight * greeks.Rho
+	}
+	return net
+}
+
+// SpreadGreeks computes the net Black-Scholes price and Greeks of a one-by-one spread that is long one
+// option and short another, both priced at the same volatility
+// long: the option bought
+// short: the option sold
+// vol: the volatility
+func SpreadGreeks(long Option, short Option, vol float64) Greeks {
+	return LegGreeks([]Leg{
+		{Option: long, Volatility: vol, Quantity: 1, Side: Long},
+		{Option: short, Volatility: vol, Quantity: 1, Side: Short},
+	})
+}
+
+// GreekKind selects one of the values held by Greeks
+type GreekKind int
+
+const (
+	GreekPrice GreekKind = iota
+	GreekDelta
+	GreekGamma
+	GreekVega
+	GreekTheta
+	GreekRho
+)
+
+// value returns the field of greeks selected by kind
+func (kind GreekKind) value(greeks Greeks) float64 {
+	switch kind {
+	case GreekPrice:
+		return greeks.Price
+	case GreekDelta:
+		return greeks.Delta
+	case GreekGamma:
+		return greeks.Gamma
+	case GreekVega:
+		return greeks.Vega
+	case GreekTheta:
+		return greeks.Theta
+	case GreekRho:
+		return greeks.Rho
+	}
+	return math.NaN()
+}
+
+// GreekSurface evaluates one Greek of an option over a grid of underlying prices and volatilities.
+// The result is row-major: row i holds the values at spots[i], column j the values at vols[j]. The axes are taken in
+// the order given, neither sorted nor deduplicated, so that spots and vols label the rows and columns. The rows
+// share a single backing array, so the matrix is allocated in one piece. The discount factors are computed once, the
+// log moneyness and discounted spot once per row and σ√T once per column, so that each cell costs d1, d2 and the
+// terms of the one Greek requested. Cells at expiration or at a zero volatility, and rows whose discrete dividends
+// exceed the spot, are evaluated by BlackScholesGreeks
+// option: the option; its underlying price is replaced by each spot in turn
+// spots: the underlying prices along the rows
+// vols: the volatilities along the columns
+// greek: the Greek to evaluate
+func GreekSurface(option Option, spots []float64, vols []float64, greek GreekKind) [][]float64 {
+	values := make([]float64, len(spots)*len(vols))
+	surface := make([][]float64, len(spots))
+
+	timeToExpiration := option.DaysToExpiration / 365.0
+	sqrtT := math.Sqrt(timeToExpiration)
+	dividendDiscount := math.Exp(-option.DividendYield * timeToExpiration)
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	call := option.OptionType == Call
+	stdDevs := make([]float64, len(vols))
+	for j, vol := range vols {
+		stdDevs[j] = vol * sqrtT
+	}
+
+	for i, spot := range spots {
+		row := values[i*len(vols) : (i+1)*len(vols)]
+		surface[i] = row
+		bumped := option
+		bumped.UnderlyingPrice = spot
+		escrowedSpot := escrowed(bumped).UnderlyingPrice
+		forward := ForwardPrice(escrowedSpot, option.RiskFreeRate, option.DividendYield, timeToExpiration)
+		logMoneyness := math.Log(forward / option.Strike)
+		discountedSpot := escrowedSpot * dividendDiscount
+
+		for j, vol := range vols {
+			stdDev := stdDevs[j]
+			if stdDev == 0 || math.IsNaN(escrowedSpot) {
+				row[j] = greek.value(BlackScholesGreeks(bumped, vol))
+				continue
+			}
+			d1 := logMoneyness/stdDev + 0.5*stdDev
+			d2 := d1 - stdDev
+			switch greek {
+			case GreekPrice:
+				if call {
+					row[j] = discountedSpot*Phi(d1) - discountedStrike*Phi(d2)
+				} else {
+					row[j] = discountedStrike*PhiC(d2) - discountedSpot*PhiC(d1)
+				}
+			case GreekDelta:
+				if call {
+					row[j] = dividendDiscount * Phi(d1)
+				} else {
+					row[j] = -dividendDiscount * PhiC(d1)
+				}
+			case GreekGamma:
+				row[j] = dividendDiscount * NormalDistributionDerivative(d1) / (escrowedSpot * stdDev)
+			case GreekVega:
+				row[j] = discountedSpot * sqrtT * NormalDistributionDerivative(d1)
+			case GreekTheta:
+				decay := -discountedSpot * NormalDistributionDerivative(d1) * vol / (2 * sqrtT)
+				if call {
+					row[j] = decay - option.RiskFreeRate*discountedStrike*Phi(d2) + option.DividendYield*discountedSpot*Phi(d1)
+				} else {
+					row[j] = decay + option.RiskFreeRate*discountedStrike*PhiC(d2) - option.DividendYield*discountedSpot*PhiC(d1)
+				}
+			case GreekRho:
+				if call {
+					row[j] = discountedStrike * timeToExpiration * Phi(d2)
+				} else {
+					row[j] = -discountedStrike * timeToExpiration * PhiC(d2)
+				}
+			default:
+				row[j] = math.NaN()
+			}
+		}
+	}
+	return surface
+}
@@ -0,0 +1,348 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBlackScholesGreeks(t *testing.T) {
+	const tolerance = 1e-12
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{80.0, 100.0, 120.0} {
+			for _, dividendYield := range []float64{0.0, 0.02} {
+				option := Option{
+					Price:            10.0,
+					Strike:           strike,
+					DaysToExpiration: 30.0,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  100.0,
+					OptionType:       optionType,
+					DividendYield:    dividendYield,
+				}
+
+				greeks := BlackScholesGreeks(option, 0.2)
+
+				expected := Greeks{
+					Price: BlackScholesOptionPrice(option, 0.2),
+					Delta: BlackScholesDelta(option, 0.2),
+					Gamma: BlackScholesGamma(option, 0.2),
+					Vega:  BlackScholesVega(option, 0.2),
+					Theta: BlackScholesTheta(option, 0.2),
+					Rho:   BlackScholesRho(option, 0.2),
+				}
+
+				checks := []struct {
+					name      string
+					got, want float64
+				}{
+					{"price", greeks.Price, expected.Price},
+					{"delta", greeks.Delta, expected.Delta},
+					{"gamma", greeks.Gamma, expected.Gamma},
+					{"vega", greeks.Vega, expected.Vega},
+					{"theta", greeks.Theta, expected.Theta},
+					{"rho", greeks.Rho, expected.Rho},
+				}
+				for _, c := range checks {
+					if diff := math.Abs(c.got - c.want); diff > tolerance {
+						t.Errorf("Unexpected %s for type %v strike %v yield %v: got %v, want %v", c.name, optionType, strike, dividendYield, c.got, c.want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkBlackScholesGreeks(b *testing.B) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	for i := 0; i < b.N; i++ {
+		BlackScholesGreeks(option, 0.2)
+	}
+}
+
+func TestScaledGreeks(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	raw := BlackScholesGreeks(option, 0.2)
+	scaled := ScaledGreeks(option, 0.2, 1)
+
+	if scaled.Vega != raw.Vega/100.0 {
+		t.Errorf("Unexpected scaled vega: got %v, want %v", scaled.Vega, raw.Vega/100.0)
+	}
+	if scaled.Theta != raw.Theta/365.0 {
+		t.Errorf("Unexpected scaled theta: got %v, want %v", scaled.Theta, raw.Theta/365.0)
+	}
+	if scaled.Rho != raw.Rho/100.0 {
+		t.Errorf("Unexpected scaled rho: got %v, want %v", scaled.Rho, raw.Rho/100.0)
+	}
+	if scaled.Delta != raw.Delta || scaled.Gamma != raw.Gamma || scaled.Price != raw.Price {
+		t.Errorf("Expected price, delta and gamma to be unchanged with a unit multiplier: got %+v, want %+v", scaled, raw)
+	}
+
+	const tolerance = 1e-9
+	contract := ScaledGreeks(option, 0.2, 100)
+	if diff := math.Abs(contract.Delta - raw.Delta*100); diff > tolerance {
+		t.Errorf("Unexpected per-contract delta: got %v, want %v", contract.Delta, raw.Delta*100)
+	}
+	if diff := math.Abs(contract.Vega - raw.Vega); diff > tolerance {
+		t.Errorf("Unexpected per-contract vega: got %v, want %v", contract.Vega, raw.Vega)
+	}
+	if diff := math.Abs(contract.Theta - raw.Theta*100/365.0); diff > tolerance {
+		t.Errorf("Unexpected per-contract theta: got %v, want %v", contract.Theta, raw.Theta*100/365.0)
+	}
+}
+
+func TestNumericalGreeks(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 30.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+			}
+
+			numerical := NumericalGreeks(BlackScholesOptionPrice, option, 0.2, DefaultBumpSizes())
+			analytic := BlackScholesGreeks(option, 0.2)
+
+			checks := []struct {
+				name      string
+				got, want float64
+				tolerance float64
+			}{
+				{"price", numerical.Price, analytic.Price, 1e-12},
+				{"delta", numerical.Delta, analytic.Delta, 1e-6},
+				{"gamma", numerical.Gamma, analytic.Gamma, 1e-4},
+				{"vega", numerical.Vega, analytic.Vega, 1e-5},
+				{"theta", numerical.Theta, analytic.Theta, 1e-4},
+				{"rho", numerical.Rho, analytic.Rho, 1e-5},
+			}
+			for _, c := range checks {
+				if diff := math.Abs(c.got - c.want); diff > c.tolerance {
+					t.Errorf("Unexpected numerical %s for type %v strike %v: got %v, want %v", c.name, optionType, strike, c.got, c.want)
+				}
+			}
+		}
+	}
+}
+
+func TestNumericalGreeksOneSidedTheta(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 0.005,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	theta := NumericalGreeks(BlackScholesOptionPrice, option, 0.2, DefaultBumpSizes()).Theta
+	if math.IsNaN(theta) || theta >= 0 {
+		t.Errorf("Expected a finite negative theta close to expiration: got %v", theta)
+	}
+}
+
+func TestThetaDecomposition(t *testing.T) {
+	const tolerance = 1e-10
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 30.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+			}
+
+			components := ThetaDecomposition(option, 0.2)
+			theta := BlackScholesTheta(option, 0.2)
+			if diff := math.Abs(components.Carry + components.Volatility - theta); diff > tolerance {
+				t.Errorf("Theta components for type %v strike %v do not sum to theta: got %v + %v, want %v", optionType, strike, components.Carry, components.Volatility, theta)
+			}
+
+			if components.Volatility >= 0 {
+				t.Errorf("Expected negative volatility decay for type %v strike %v: got %v", optionType, strike, components.Volatility)
+			}
+			if optionType == Call && components.Carry >= 0 || optionType == Put && components.Carry <= 0 {
+				t.Errorf("Unexpected carry sign for type %v strike %v: got %v", optionType, strike, components.Carry)
+			}
+		}
+	}
+}
+
+func TestSpreadGreeks(t *testing.T) {
+	long := Option{
+		Strike:           95.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	short := long
+	short.Strike = 105.0
+
+	spread := SpreadGreeks(long, short, 0.2)
+
+	const tolerance = 1e-12
+	expectedPrice := BlackScholesOptionPrice(long, 0.2) - BlackScholesOptionPrice(short, 0.2)
+	if diff := math.Abs(spread.Price - expectedPrice); diff > tolerance {
+		t.Errorf("Unexpected spread price: got %v, want %v", spread.Price, expectedPrice)
+	}
+
+	longDelta, shortDelta := BlackScholesDelta(long, 0.2), BlackScholesDelta(short, 0.2)
+	if spread.Delta <= shortDelta || spread.Delta >= longDelta {
+		t.Errorf("Expected bull call spread delta between the leg deltas %v and %v: got %v", shortDelta, longDelta, spread.Delta)
+	}
+
+	// gamma is positive near the long strike and negative near the short strike
+	long.UnderlyingPrice, short.UnderlyingPrice = 95.0, 95.0
+	if gamma := SpreadGreeks(long, short, 0.2).Gamma; gamma <= 0 {
+		t.Errorf("Expected positive spread gamma below the midpoint: got %v", gamma)
+	}
+	long.UnderlyingPrice, short.UnderlyingPrice = 105.0, 105.0
+	if gamma := SpreadGreeks(long, short, 0.2).Gamma; gamma >= 0 {
+		t.Errorf("Expected negative spread gamma above the midpoint: got %v", gamma)
+	}
+}
+
+func TestLegGreeks(t *testing.T) {
+	near := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+	far := near
+	far.DaysToExpiration = 90.0
+
+	legs := []Leg{
+		{Option: near, Volatility: 0.25, Quantity: 2, Side: Short},
+		{Option: far, Volatility: 0.2, Quantity: 3, Side: Long},
+	}
+
+	net := LegGreeks(legs)
+	nearGreeks, farGreeks := BlackScholesGreeks(near, 0.25), BlackScholesGreeks(far, 0.2)
+
+	const tolerance = 1e-12
+	if diff := math.Abs(net.Vega - (3*farGreeks.Vega - 2*nearGreeks.Vega)); diff > tolerance {
+		t.Errorf("Unexpected net vega: got %v, want %v", net.Vega, 3*farGreeks.Vega-2*nearGreeks.Vega)
+	}
+	if diff := math.Abs(net.Theta - (3*farGreeks.Theta - 2*nearGreeks.Theta)); diff > tolerance {
+		t.Errorf("Unexpected net theta: got %v, want %v", net.Theta, 3*farGreeks.Theta-2*nearGreeks.Theta)
+	}
+
+	if empty := LegGreeks(nil); empty != (Greeks{}) {
+		t.Errorf("Expected zero Greeks for no legs: got %+v", empty)
+	}
+}
+
+func TestGreekSurface(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	spots := []float64{90.0, 95.0, 100.0, 105.0}
+	vols := []float64{0.1, 0.2, 0.3}
+
+	surface := GreekSurface(option, spots, vols, GreekGamma)
+
+	if len(surface) != len(spots) {
+		t.Fatalf("Unexpected number of rows: got %v, want %v", len(surface), len(spots))
+	}
+	for i, row := range surface {
+		if len(row) != len(vols) {
+			t.Fatalf("Unexpected number of columns in row %v: got %v, want %v", i, len(row), len(vols))
+		}
+	}
+
+	const tolerance = 1e-12
+	cells := []struct{ i, j int }{{0, 0}, {2, 1}, {3, 2}}
+	for _, cell := range cells {
+		bumped := option
+		bumped.UnderlyingPrice = spots[cell.i]
+		expected := BlackScholesGamma(bumped, vols[cell.j])
+		if diff := math.Abs(surface[cell.i][cell.j] - expected); diff > tolerance {
+			t.Errorf("Unexpected gamma at spot %v vol %v: got %v, want %v", spots[cell.i], vols[cell.j], surface[cell.i][cell.j], expected)
+		}
+	}
+
+	deltas := GreekSurface(option, spots, vols, GreekDelta)
+	bumped := option
+	bumped.UnderlyingPrice = spots[1]
+	if expected := BlackScholesDelta(bumped, vols[0]); math.Abs(deltas[1][0]-expected) > tolerance {
+		t.Errorf("Unexpected delta at spot %v vol %v: got %v, want %v", spots[1], vols[0], deltas[1][0], expected)
+	}
+}
+
+func TestGreekSurfaceAxisOrder(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 60.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+	// rows follow the spots and columns the vols in the order given, unsorted and with repeats
+	spots := []float64{110.0, 90.0, 100.0, 90.0}
+	vols := []float64{0.4, 0.1, 0.25, 0.1}
+	surface := GreekSurface(option, spots, vols, GreekPrice)
+	sorted := GreekSurface(option, []float64{90.0, 100.0, 110.0}, []float64{0.1, 0.25, 0.4}, GreekPrice)
+	spotIndex := map[float64]int{90.0: 0, 100.0: 1, 110.0: 2}
+	volIndex := map[float64]int{0.1: 0, 0.25: 1, 0.4: 2}
+	for i, spot := range spots {
+		for j, vol := range vols {
+			if got, want := surface[i][j], sorted[spotIndex[spot]][volIndex[vol]]; got != want {
+				t.Errorf("Unexpected price in row %v column %v at spot %v vol %v: got %v, want %v", i, j, spot, vol, got, want)
+			}
+		}
+	}
+}
+
+func TestGreekSurfaceMatchesGreeks(t *testing.T) {
+	spots := []float64{80.0, 100.0, 120.0}
+	vols := []float64{0, 0.15, 0.4}
+	kinds := []GreekKind{GreekPrice, GreekDelta, GreekGamma, GreekVega, GreekTheta, GreekRho}
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, days := range []float64{0.0, 45.0} {
+			option := Option{
+				Strike:           100.0,
+				DaysToExpiration: days,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.02,
+				Dividends:        []Dividend{{Amount: 1.5, DaysToExDate: 20.0}},
+			}
+			for _, kind := range kinds {
+				surface := GreekSurface(option, spots, vols, kind)
+				for i, spot := range spots {
+					bumped := option
+					bumped.UnderlyingPrice = spot
+					for j, vol := range vols {
+						want := kind.value(BlackScholesGreeks(bumped, vol))
+						if got := surface[i][j]; math.Abs(got-want) > 1e-12*math.Max(1, math.Abs(want)) {
+							t.Errorf("Unexpected Greek %v of a %v in %v days at spot %v vol %v: got %v, want %v", kind, optionType, days, spot, vol, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
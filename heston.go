@@ -0,0 +1,179 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// ErrInvalidHeston is returned for Heston parameters that are not finite, with a negative initial variance or
+// volatility of variance, a long-run variance or speed of mean reversion that is not positive, or a correlation
+// outside [-1, 1]
+var ErrInvalidHeston = errors.New("finance: invalid Heston parameters")
+
+// hestonTolerance is the absolute tolerance of the quadrature of the Heston pricing integral, whose value is of
+// the order of one, so that prices are accurate to about 1e-12 of the forward
+const hestonTolerance = 1e-12
+
+// HestonParams holds the parameters of the Heston (1993) model, in which the variance v of the underlying's returns
+// follows the mean-reverting square-root process dv = κ(θ − v)·dt + σ·√v·dW, correlated with the underlying
+type HestonParams struct {
+	V0    float64 `json:"v0"`    // Initial variance
+	Kappa float64 `json:"kappa"` // Speed of mean reversion of the variance κ
+	Theta float64 `json:"theta"` // Long-run variance θ
+	Sigma float64 `json:"sigma"` // Volatility of the variance σ
+	Rho   float64 `json:"rho"`   // Correlation of the variance with the underlying's returns ρ, in [-1, 1]
+}
+
+// HestonPrice computes the price of a European option under the Heston model from the characteristic function φ of
+// the logarithm of the underlying price at expiration relative to its forward F, by the Lewis (2000) single-integral
+// formula C = e^{−rT}·(F − √(FK)/π·∫₀^∞ Re[e^{iu·ln(F/K)}·φ(u − i/2)]/(u² + 1/4) du), the put following by
+// put-call parity. The characteristic function is taken in the form of Albrecher et al. (2007), the "little Heston
+// trap", which stays on the principal branch of the complex logarithm at any maturity, and with its differences
+// rewritten free of cancellation, so that it is exact as σ goes to zero, when the price is the Black-Scholes price
+// at the root mean variance v̄ = θ + (v0 − θ)·(1 − e^{−κT})/(κT). The integral is evaluated by adaptive
+// Gauss-Kronrod quadrature to within hestonTolerance. At expiration the option is worth its intrinsic value.
+// Discrete dividends are escrowed
+// Returns the errors of Validate for an invalid option, ErrInvalidRate for invalid rates, ErrInvalidHeston for
+// invalid parameters and ErrNotConverged if the quadrature does not reach its tolerance
+// option: the option
+// p: the parameters of the model
+func HestonPrice(option Option, p HestonParams) (float64, error) {
+	if err := option.Validate(); err != nil {
+		return math.NaN(), err
+	}
+	if err := p.validate(); err != nil {
+		return math.NaN(), err
+	}
+	if err := validateMarket(option); err != nil {
+		return math.NaN(), err
+	}
+	prices, err := hestonSlicePrices([]Option{option}, p)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return prices[0], nil
+}
+
+// hestonSlicePrices computes the Heston prices of options that share their expiration, rates and underlying price
+// once escrowed, evaluating the characteristic function once for every strike in a single vector quadrature. The
+// options and parameters must be valid
+// options: the options
+// p: the parameters of the model
+func hestonSlicePrices(options []Option, p HestonParams) ([]float64, error) {
+	prices := make([]float64, len(options))
+	first := escrowed(options[0])
+	if first.DaysToExpiration == 0 {
+		for i, option := range options {
+			prices[i] = escrowed(option).IntrinsicValue()
+		}
+		return prices, nil
+	}
+
+	timeToExpiration := first.DaysToExpiration / 365.0
+	forward := ForwardPrice(first.UnderlyingPrice, first.RiskFreeRate, first.DividendYield, timeToExpiration)
+	moneyness := make([]float64, len(options))
+	for i, option := range options {
+		moneyness[i] = math.Log(forward / option.Strike)
+	}
+	integrals, ok := integrateVectorToInfinity(func(u float64, values []float64) {
+		phi := p.characteristic(complex(u, -0.5), timeToExpiration)
+		scale := 1 / (u*u + 0.25)
+		for i, x := range moneyness {
+			sin, cos := math.Sincos(u * x)
+			values[i] = (cos*real(phi) - sin*imag(phi)) * scale
+		}
+	}, len(options), 0, hestonTolerance)
+	if !ok {
+		return nil, fmt.Errorf("%w: Heston integral to tolerance %v", ErrNotConverged, hestonTolerance)
+	}
+
+	discount := math.Exp(-first.RiskFreeRate * timeToExpiration)
+	for i, option := range options {
+		price := discount * (forward - math.Sqrt(forward*option.Strike)/math.Pi*integrals[i])
+		if option.OptionType == Put {
+			price -= discount * (forward - option.Strike)
+		}
+		// far out of the money the difference can round below zero
+		prices[i] = math.Max(price, 0)
+	}
+	return prices, nil
+}
+
+// HestonCharacteristic returns the characteristic function of the logarithm of the underlying price at expiration
+// relative to its forward under the Heston model, in the form used by HestonPrice, for FFTPrice. The function is NaN
+// everywhere for invalid parameters
+// p: the parameters of the model
+func HestonCharacteristic(p HestonParams) CharacteristicFunc {
+	if p.validate() != nil {
+		return func(complex128, float64) complex128 { return cmplx.NaN() }
+	}
+	return p.characteristic
+}
+
+// validate checks that the parameters of the Heston model are valid
+func (p HestonParams) validate() error {
+	finite := func(x float64) bool { return !math.IsNaN(x) && !math.IsInf(x, 0) }
+	switch {
+	case !finite(p.V0) || p.V0 < 0:
+		return fmt.Errorf("%w: initial variance %v", ErrInvalidHeston, p.V0)
+	case !finite(p.Kappa) || !(p.Kappa > 0):
+		return fmt.Errorf("%w: speed of mean reversion %v", ErrInvalidHeston, p.Kappa)
+	case !finite(p.Theta) || !(p.Theta > 0):
+		return fmt.Errorf("%w: long-run variance %v", ErrInvalidHeston, p.Theta)
+	case !finite(p.Sigma) || p.Sigma < 0:
+		return fmt.Errorf("%w: volatility of variance %v", ErrInvalidHeston, p.Sigma)
+	case !(p.Rho >= -1 && p.Rho <= 1):
+		return fmt.Errorf("%w: correlation %v", ErrInvalidHeston, p.Rho)
+	}
+	return nil
+}
+
+// characteristic computes the characteristic function E[e^{iu·X}] of the logarithm X of the underlying price at
+// expiration relative to its forward, φ(u) = exp(C + D·v0), with b = κ − ρσiu, d = √(b² + σ²(iu + u²)) and
+// g = (b − d)/(b + d). Since b − d = −σ²(iu + u²)/(b + d), the factors of 1/σ² in C and D cancel exactly
+// u: the argument, complex for the Lewis formula
+// timeToExpiration: the time to expiration in years
+func (p HestonParams) characteristic(u complex128, timeToExpiration float64) complex128 {
+	sigma := complex(p.Sigma, 0)
+	iu := complex(0, 1) * u
+	b := complex(p.Kappa, 0) - complex(p.Rho, 0)*sigma*iu
+	d := cmplx.Sqrt(b*b + sigma*sigma*(iu+u*u))
+	scaled := -(iu + u*u) / (b + d) // (b − d)/σ²
+	scaledG := scaled / (b + d)     // g/σ²
+	decay := cmplx.Exp(-d * complex(timeToExpiration, 0))
+
+	// ln((1 − g·e^{−dT})/(1 − g)) = ln(1 + h) with h = g·(1 − e^{−dT})/(1 − g), of the order of σ²
+	g := sigma * sigma * scaledG
+	oneLessDecay := -complexExpm1(-d * complex(timeToExpiration, 0))
+	h := g * oneLessDecay / (1 - g)
+	logRatio := complexLog1pRatio(h) * scaledG * oneLessDecay / (1 - g) // ln(1 + h)/σ²
+
+	c := complex(p.Kappa*p.Theta, 0) * (scaled*complex(timeToExpiration, 0) - 2*logRatio)
+	dv := scaled * oneLessDecay / (1 - g*decay)
+	return cmplx.Exp(c + dv*complex(p.V0, 0))
+}
+
+// complexExpm1 computes e^z − 1 accurately for small z
+// z: the exponent
+func complexExpm1(z complex128) complex128 {
+	if cmplx.Abs(z) > 0.5 {
+		return cmplx.Exp(z) - 1
+	}
+	// e^{x+iy} − 1 = (e^x − 1)·cos y + (cos y − 1) + i·e^x·sin y, with cos y − 1 = −2·sin²(y/2)
+	x, y := real(z), imag(z)
+	half := math.Sin(0.5 * y)
+	return complex(math.Expm1(x)*math.Cos(y)-2*half*half, math.Exp(x)*math.Sin(y))
+}
+
+// complexLog1pRatio computes ln(1 + z)/z, which is 1 at z = 0, accurately for small z, by Kahan's trick of
+// evaluating the ratio at the rounded w = 1 + z
+// z: the argument
+func complexLog1pRatio(z complex128) complex128 {
+	w := 1 + z
+	if w == 1 {
+		return 1
+	}
+	return cmplx.Log(w) / (w - 1)
+}
@@ -0,0 +1,134 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestHestonPriceLewis(t *testing.T) {
+	// Lewis, Option Valuation under Stochastic Volatility, calls with v0 = 0.04, κ = 4, θ = 0.25, σ = 1, ρ = −0.5
+	p := HestonParams{V0: 0.04, Kappa: 4.0, Theta: 0.25, Sigma: 1.0, Rho: -0.5}
+	for _, test := range []struct {
+		strike, want float64
+	}{
+		{80.0, 26.774758743998854},
+		{90.0, 20.933349000596710},
+		{100.0, 16.070154917028834},
+		{110.0, 12.132211516709845},
+		{120.0, 9.024913483457836},
+	} {
+		call := Option{
+			Strike:           test.strike,
+			DaysToExpiration: 365.0,
+			RiskFreeRate:     0.01,
+			UnderlyingPrice:  100.0,
+			OptionType:       Call,
+			DividendYield:    0.02,
+		}
+		got, err := HestonPrice(call, p)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if math.Abs(got-test.want) > 1e-10 {
+			t.Errorf("Unexpected call price for strike %v: got %v, want %v", test.strike, got, test.want)
+		}
+
+		put := call
+		put.OptionType = Put
+		putPrice, err := HestonPrice(put, p)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		parity := 100.0*math.Exp(-0.02) - test.strike*math.Exp(-0.01)
+		if math.Abs(got-putPrice-parity) > 1e-10 {
+			t.Errorf("Unexpected put-call parity for strike %v: got %v, want %v", test.strike, got-putPrice, parity)
+		}
+	}
+}
+
+func TestHestonPriceDeterministicVariance(t *testing.T) {
+	option := Option{
+		Strike:           95.0,
+		DaysToExpiration: 730.0,
+		RiskFreeRate:     0.03,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+		DividendYield:    0.01,
+	}
+	// with no volatility of variance, Black-Scholes at √θ when the variance starts there
+	got, err := HestonPrice(option, HestonParams{V0: 0.09, Kappa: 2.0, Theta: 0.09, Sigma: 0, Rho: -0.7})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := BlackScholesOptionPrice(option, 0.3); math.Abs(got-want) > 1e-11 {
+		t.Errorf("Unexpected price with zero volatility of variance: got %v, want %v", got, want)
+	}
+	// and at the root mean variance when it reverts from elsewhere
+	got, err = HestonPrice(option, HestonParams{V0: 0.01, Kappa: 1.5, Theta: 0.09, Sigma: 0, Rho: 0.3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mean := 0.09 + (0.01-0.09)*-math.Expm1(-3.0)/3.0
+	if want := BlackScholesOptionPrice(option, math.Sqrt(mean)); math.Abs(got-want) > 1e-11 {
+		t.Errorf("Unexpected price with reverting deterministic variance: got %v, want %v", got, want)
+	}
+	// the price approaches Black-Scholes as the volatility of variance vanishes
+	want := BlackScholesOptionPrice(option, 0.3)
+	previous := math.Inf(1)
+	for _, sigma := range []float64{1e-1, 1e-2, 1e-3, 1e-4} {
+		got, err := HestonPrice(option, HestonParams{V0: 0.09, Kappa: 2.0, Theta: 0.09, Sigma: sigma, Rho: -0.7})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if math.Abs(got-want) >= previous {
+			t.Errorf("Unexpected price for volatility of variance %v: got %v, no nearer %v", sigma, got, want)
+		}
+		previous = math.Abs(got - want)
+	}
+	if previous > 1e-4 {
+		t.Errorf("Unexpected distance from Black-Scholes for volatility of variance 1e-4: got %v", previous)
+	}
+}
+
+func TestHestonPriceEdgeCases(t *testing.T) {
+	p := HestonParams{V0: 0.04, Kappa: 2.0, Theta: 0.04, Sigma: 0.5, Rho: -0.7}
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  90.0,
+		OptionType:       Put,
+	}
+	if got, err := HestonPrice(option, p); err != nil || got != 10.0 {
+		t.Errorf("Unexpected price at expiration: got %v, %v; want 10", got, err)
+	}
+	// a thirty-year option with a volatile variance, where the original formulation leaves the principal branch
+	option.DaysToExpiration = 30 * 365.0
+	got, err := HestonPrice(option, HestonParams{V0: 0.04, Kappa: 0.5, Theta: 0.04, Sigma: 1.0, Rho: -0.9})
+	if err != nil || !(got > 0 && got < 100.0*math.Exp(-1.5)) {
+		t.Errorf("Unexpected thirty-year put price: got %v, %v", got, err)
+	}
+
+	option.DaysToExpiration = 90.0
+	tests := []struct {
+		name   string
+		option Option
+		p      HestonParams
+		err    error
+	}{
+		{"negative initial variance", option, HestonParams{V0: -0.01, Kappa: 2.0, Theta: 0.04, Sigma: 0.5}, ErrInvalidHeston},
+		{"zero speed of mean reversion", option, HestonParams{V0: 0.04, Theta: 0.04, Sigma: 0.5}, ErrInvalidHeston},
+		{"zero long-run variance", option, HestonParams{V0: 0.04, Kappa: 2.0, Sigma: 0.5}, ErrInvalidHeston},
+		{"negative volatility of variance", option, HestonParams{V0: 0.04, Kappa: 2.0, Theta: 0.04, Sigma: -0.5}, ErrInvalidHeston},
+		{"correlation above one", option, HestonParams{V0: 0.04, Kappa: 2.0, Theta: 0.04, Sigma: 0.5, Rho: 1.5}, ErrInvalidHeston},
+		{"infinite volatility of variance", option, HestonParams{V0: 0.04, Kappa: 2.0, Theta: 0.04, Sigma: math.Inf(1)}, ErrInvalidHeston},
+		{"negative days", Option{Strike: 100.0, DaysToExpiration: -1, UnderlyingPrice: 90.0}, p, ErrNegativeExpiry},
+		{"infinite rate", Option{Strike: 100.0, DaysToExpiration: 90.0, RiskFreeRate: math.Inf(1), UnderlyingPrice: 90.0}, p, ErrInvalidRate},
+	}
+	for _, test := range tests {
+		if got, err := HestonPrice(test.option, test.p); !errors.Is(err, test.err) || !math.IsNaN(got) {
+			t.Errorf("Unexpected result for %s: got %v, %v; want NaN, %v", test.name, got, err, test.err)
+		}
+	}
+}
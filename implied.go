@@ -0,0 +1,200 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	impliedBracketWidth  = 1e4   // Ratio between a reference price and either end of a search bracket
+	impliedTolerance     = 1e-12 // Tolerance on the solution, relative to the reference price
+	impliedMaxIterations = 200   // Maximum number of iterations
+)
+
+// ImpliedUnderlyingPrice computes the underlying price at which the Black-Scholes price of an option
+// at the given volatility matches option.Price, searching between 1e-4 and 1e4 times the strike.
+// Returns the errors of Validate for the option with its underlying price ignored (ErrNegativePrice for a negative
+// price, ErrNonPositiveStrike for a strike, the reference of the search, that is not positive),
+// ErrNonPositiveVolatility for a volatility that is not positive and finite, ErrInvalidRate for rates that are not
+// finite, and ErrNotBracketed when no underlying price in the search range reproduces the price
+// option: the option; its underlying price is ignored
+// vol: the volatility
+func ImpliedUnderlyingPrice(option Option, vol float64) (float64, error) {
+	// the underlying price is given a placeholder, and the dividends are checked against each candidate by the pricing
+	candidate := option
+	candidate.UnderlyingPrice, candidate.Dividends = 1, nil
+	if err := validatePricing(candidate, vol); err != nil {
+		return math.NaN(), err
+	}
+	objective := func(spot float64) float64 {
+		bumped := option
+		bumped.UnderlyingPrice = spot
+		return BlackScholesOptionPrice(bumped, vol) - option.Price
+	}
+	return impliedRoot(objective, option.Strike)
+}
+
+// ImpliedStrike computes the strike at which the Black-Scholes price of an option at the given volatility
+// matches option.Price, searching between 1e-4 and 1e4 times the underlying price.
+// Returns the errors of Validate for the option with its strike ignored (ErrNegativePrice for a negative price,
+// ErrNonPositiveUnderlying for an underlying price, the reference of the search, that is not positive),
+// ErrNonPositiveVolatility for a volatility that is not positive and finite, ErrInvalidRate and
+// ErrNegativeAdjustedSpot for the market, and ErrNotBracketed when no strike in the search range reproduces the price
+// option: the option; its strike is ignored
+// vol: the volatility
+func ImpliedStrike(option Option, vol float64) (float64, error) {
+	candidate := option
+	candidate.Strike = 1
+	if err := validatePricing(candidate, vol); err != nil {
+		return math.NaN(), err
+	}
+	objective := func(strike float64) float64 {
+		bumped := option
+		bumped.Strike = strike
+		return BlackScholesOptionPrice(bumped, vol) - option.Price
+	}
+	return impliedRoot(objective, option.UnderlyingPrice)
+}
+
+// impliedRoot finds a root of a monotonic objective within a bracket spanning four orders of magnitude
+// either side of a reference price
+// objective: the function
+// reference: the reference price
+func impliedRoot(objective func(float64) float64, reference float64) (float64, error) {
+	root, _, err := brent(objective, reference/impliedBracketWidth, reference*impliedBracketWidth, impliedTolerance*reference, impliedMaxIterations)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return root, nil
+}
+
+// ErrDeltaOutOfRange is returned when a target delta cannot be attained by an option of the given type
+var ErrDeltaOutOfRange = errors.New("finance: target delta out of range")
+
+// StrikeFromDelta computes the strike at which an option has the given Black-Scholes delta at the given
+// volatility. Delta is monotonic in d1, so the inversion is exact: d1 = N⁻¹(Δe^{qT}) for calls and N⁻¹(Δe^{qT}+1)
+// for puts, and K = S*exp(-d1*σ*sqrt(T) + (r-q+σ²/2)T), S being the escrowed underlying price net of the present
+// value of the discrete dividends. Returns the errors of Validate for the option with its strike ignored,
+// ErrNonPositiveVolatility for a volatility that is not positive and finite, ErrInvalidRate for rates that are not
+// finite, ErrNegativeAdjustedSpot when the discrete dividends exceed the underlying price, and ErrDeltaOutOfRange at
+// expiration, where delta jumps from 0 to ±e^{-qT} at the spot, or unless the target delta lies in (0, e^{-qT}) for
+// a call or (-e^{-qT}, 0) for a put
+// option: the option; its strike is ignored
+// vol: the volatility
+// targetDelta: the delta to attain
+func StrikeFromDelta(option Option, vol float64, targetDelta float64) (float64, error) {
+	candidate := option
+	candidate.Strike = 1
+	if err := validatePricing(candidate, vol); err != nil {
+		return math.NaN(), err
+	}
+	if option.DaysToExpiration == 0 {
+		return math.NaN(), fmt.Errorf("%w: no strike attains delta %v at expiration", ErrDeltaOutOfRange, targetDelta)
+	}
+	option = escrowed(option)
+
+	timeToExpiration := option.DaysToExpiration / 365.0
+	probability := targetDelta * math.Exp(option.DividendYield*timeToExpiration)
+	if option.OptionType == Put {
+		probability += 1
+	}
+	if !(probability > 0 && probability < 1) {
+		return math.NaN(), ErrDeltaOutOfRange
+	}
+
+	d1 := PhiInv(probability)
+	return option.UnderlyingPrice * math.Exp(-d1*vol*math.Sqrt(timeToExpiration)+(option.RiskFreeRate-option.DividendYield+0.5*vol*vol)*timeToExpiration), nil
+}
+
+// ErrMismatchedPair is returned when a call and a put do not form a put-call parity pair: the first option must
+// be a call, the second a put, and both must share strike, expiration and underlying price
+var ErrMismatchedPair = errors.New("finance: options are not a matched call/put pair")
+
+// checkParityPair verifies that call and put form a put-call parity pair
+// call: the call option
+// put: the put option
+func checkParityPair(call, put Option) error {
+	if call.OptionType != Call || put.OptionType != Put ||
+		call.Strike != put.Strike || call.DaysToExpiration != put.DaysToExpiration || call.UnderlyingPrice != put.UnderlyingPrice {
+		return ErrMismatchedPair
+	}
+	return nil
+}
+
+// ImpliedRateFromParity computes the risk-free rate implied by the prices of a call and a put with the same
+// strike and expiration through put-call parity, C − P = S·e^{−qT} − K·e^{−rT}, using the call's DividendYield.
+// Discrete dividends are escrowed, S being the underlying price net of their present value at the implied rate,
+// which is then found by Newton's method. The options' RiskFreeRate is ignored. Returns ErrMismatchedPair when the
+// options are not a matched pair, ErrPriceOutOfBounds when the prices imply no rate, ErrNegativeAdjustedSpot when
+// the discrete dividends exceed the underlying price at a rate tried, and ErrNotConverged if Newton's method does
+// not converge
+// call: the call option
+// put: the put option
+func ImpliedRateFromParity(call, put Option) (float64, error) {
+	if err := checkParityPair(call, put); err != nil {
+		return math.NaN(), err
+	}
+
+	timeToExpiration := call.DaysToExpiration / 365.0
+	dividendDiscount := math.Exp(-call.DividendYield * timeToExpiration)
+	discountedStrike := call.UnderlyingPrice*dividendDiscount - (call.Price - put.Price)
+	if !(discountedStrike > 0) || !(timeToExpiration > 0) {
+		return math.NaN(), ErrPriceOutOfBounds
+	}
+	rate := -math.Log(discountedStrike/call.Strike) / timeToExpiration
+	if len(call.Dividends) == 0 {
+		return rate, nil
+	}
+
+	// f(r) = (S − Σ D·e^{−r·t})·e^{−qT} − K·e^{−rT} − (C − P) rises and is concave in r, climbing from minus infinity
+	// to the positive S·e^{−qT} − (C − P), so that Newton's method converges to its single root
+	for range impliedMaxIterations {
+		pv, slope := 0.0, call.Strike*timeToExpiration*math.Exp(-rate*timeToExpiration)
+		for _, dividend := range call.Dividends {
+			if dividend.DaysToExDate < 0 || dividend.DaysToExDate > call.DaysToExpiration {
+				continue
+			}
+			discounted := dividend.Amount * math.Exp(-rate*dividend.DaysToExDate/365.0)
+			pv += discounted
+			slope += dividend.DaysToExDate / 365.0 * discounted * dividendDiscount
+		}
+		if !(call.UnderlyingPrice-pv > 0) {
+			return math.NaN(), fmt.Errorf("%w: at a rate of %v", ErrNegativeAdjustedSpot, rate)
+		}
+		residual := (call.UnderlyingPrice-pv)*dividendDiscount - call.Strike*math.Exp(-rate*timeToExpiration) - (call.Price - put.Price)
+		step := residual / slope
+		rate -= step
+		if math.Abs(step) <= impliedTolerance {
+			return rate, nil
+		}
+	}
+	return math.NaN(), fmt.Errorf("%w: parity rate after %d iterations", ErrNotConverged, impliedMaxIterations)
+}
+
+// ImpliedDividendYieldFromParity computes the continuous dividend yield implied by the prices of a call and a put
+// with the same strike and expiration through put-call parity, C − P = S·e^{−qT} − K·e^{−rT}, given the risk-free
+// rate. Discrete dividends are escrowed, S being the underlying price net of their present value, so that the yield
+// is that on top of them. The options' DividendYield is ignored. Returns ErrMismatchedPair when the options are not
+// a matched pair, ErrNegativeAdjustedSpot when the discrete dividends exceed the underlying price and
+// ErrPriceOutOfBounds when the prices imply no yield
+// call: the call option
+// put: the put option
+// r: the risk-free rate
+func ImpliedDividendYieldFromParity(call, put Option, r float64) (float64, error) {
+	if err := checkParityPair(call, put); err != nil {
+		return math.NaN(), err
+	}
+	call.RiskFreeRate = r
+	spot, err := call.EscrowedUnderlyingPrice()
+	if err != nil {
+		return math.NaN(), err
+	}
+
+	timeToExpiration := call.DaysToExpiration / 365.0
+	discountedSpot := call.Price - put.Price + call.Strike*math.Exp(-r*timeToExpiration)
+	if !(discountedSpot > 0) || !(timeToExpiration > 0) {
+		return math.NaN(), ErrPriceOutOfBounds
+	}
+	return -math.Log(discountedSpot/spot) / timeToExpiration, nil
+}
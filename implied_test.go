@@ -0,0 +1,371 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestImpliedUnderlyingPrice(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, spot := range []float64{80.0, 100.0, 125.0} {
+			option := Option{
+				Strike:           100.0,
+				DaysToExpiration: 60.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  spot,
+				OptionType:       optionType,
+			}
+			option.Price = BlackScholesOptionPrice(option, 0.25)
+			option.UnderlyingPrice = 1.0
+
+			implied, err := ImpliedUnderlyingPrice(option, 0.25)
+			if err != nil {
+				t.Errorf("Unexpected error for type %v spot %v: %v", optionType, spot, err)
+				continue
+			}
+			if diff := math.Abs(implied - spot); diff > 1e-8 {
+				t.Errorf("Unexpected underlying price for type %v: got %v, want %v", optionType, implied, spot)
+			}
+		}
+	}
+}
+
+func TestImpliedStrike(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{80.0, 100.0, 125.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 60.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+			}
+			option.Price = BlackScholesOptionPrice(option, 0.25)
+			option.Strike = 1.0
+
+			implied, err := ImpliedStrike(option, 0.25)
+			if err != nil {
+				t.Errorf("Unexpected error for type %v strike %v: %v", optionType, strike, err)
+				continue
+			}
+			if diff := math.Abs(implied - strike); diff > 1e-8 {
+				t.Errorf("Unexpected strike for type %v: got %v, want %v", optionType, implied, strike)
+			}
+		}
+	}
+}
+
+func TestImpliedUnderlyingAndStrikeErrors(t *testing.T) {
+	option := Option{
+		Price:            150.0,
+		Strike:           100.0,
+		DaysToExpiration: 60.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	// a put can never be worth more than the discounted strike
+	if _, err := ImpliedUnderlyingPrice(option, 0.25); !errors.Is(err, ErrNotBracketed) {
+		t.Errorf("Expected ErrNotBracketed for an unattainable put price: got %v", err)
+	}
+
+	option.Price = -1.0
+	if _, err := ImpliedStrike(option, 0.25); !errors.Is(err, ErrNegativePrice) {
+		t.Errorf("Expected ErrNegativePrice: got %v", err)
+	}
+
+	option.Price = 5.0
+	zeroStrike, zeroSpot, badType := option, option, option
+	zeroStrike.Strike = 0
+	zeroSpot.UnderlyingPrice = 0
+	badType.OptionType = OptionType(7)
+	for _, tc := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"underlying with zero vol", second(ImpliedUnderlyingPrice(option, 0)), ErrNonPositiveVolatility},
+		{"underlying with negative vol", second(ImpliedUnderlyingPrice(option, -0.2)), ErrNonPositiveVolatility},
+		{"underlying with infinite vol", second(ImpliedUnderlyingPrice(option, math.Inf(1))), ErrNonPositiveVolatility},
+		{"underlying with zero strike", second(ImpliedUnderlyingPrice(zeroStrike, 0.25)), ErrNonPositiveStrike},
+		{"underlying with invalid type", second(ImpliedUnderlyingPrice(badType, 0.25)), ErrInvalidOptionType},
+		{"strike with zero vol", second(ImpliedStrike(option, 0)), ErrNonPositiveVolatility},
+		{"strike with NaN vol", second(ImpliedStrike(option, math.NaN())), ErrNonPositiveVolatility},
+		{"strike with zero underlying", second(ImpliedStrike(zeroSpot, 0.25)), ErrNonPositiveUnderlying},
+		{"strike with invalid type", second(ImpliedStrike(badType, 0.25)), ErrInvalidOptionType},
+	} {
+		if !errors.Is(tc.err, tc.want) {
+			t.Errorf("Unexpected error for the implied %s: got %v, want %v", tc.name, tc.err, tc.want)
+		}
+	}
+
+	// the underlying price is unknown, so it may be a placeholder, and the strike likewise
+	zeroSpot.Price = BlackScholesOptionPrice(option, 0.25)
+	if spot, err := ImpliedUnderlyingPrice(zeroSpot, 0.25); err != nil || math.Abs(spot-100.0) > 1e-8 {
+		t.Errorf("Unexpected implied underlying price with a zero placeholder: got %v, %v, want 100", spot, err)
+	}
+	zeroStrike.Price = zeroSpot.Price
+	if strike, err := ImpliedStrike(zeroStrike, 0.25); err != nil || math.Abs(strike-100.0) > 1e-8 {
+		t.Errorf("Unexpected implied strike with a zero placeholder: got %v, %v, want 100", strike, err)
+	}
+}
+
+func TestStrikeFromDelta(t *testing.T) {
+	option := Option{
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	// 25-delta risk reversal: a 25-delta call against a 25-delta put
+	callStrike, err := StrikeFromDelta(option, 0.2, 0.25)
+	if err != nil {
+		t.Fatalf("Unexpected error for the call leg: %v", err)
+	}
+	option.OptionType = Put
+	putStrike, err := StrikeFromDelta(option, 0.2, -0.25)
+	if err != nil {
+		t.Fatalf("Unexpected error for the put leg: %v", err)
+	}
+
+	if putStrike >= option.UnderlyingPrice || callStrike <= option.UnderlyingPrice {
+		t.Errorf("Expected the put strike below and the call strike above spot: got %v and %v", putStrike, callStrike)
+	}
+
+	const tolerance = 1e-12
+	for _, tt := range []struct {
+		optionType OptionType
+		target     float64
+	}{
+		{Call, 0.1}, {Call, 0.25}, {Call, 0.5}, {Call, 0.9},
+		{Put, -0.1}, {Put, -0.25}, {Put, -0.5}, {Put, -0.9},
+	} {
+		option.OptionType = tt.optionType
+		strike, err := StrikeFromDelta(option, 0.2, tt.target)
+		if err != nil {
+			t.Errorf("Unexpected error for type %v delta %v: %v", tt.optionType, tt.target, err)
+			continue
+		}
+		option.Strike = strike
+		if delta := BlackScholesDelta(option, 0.2); math.Abs(delta-tt.target) > tolerance {
+			t.Errorf("Unexpected delta at the solved strike for type %v: got %v, want %v", tt.optionType, delta, tt.target)
+		}
+	}
+}
+
+func TestStrikeFromDeltaOutOfRange(t *testing.T) {
+	option := Option{
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	for _, target := range []float64{-0.25, 0.0, 1.0, 1.5} {
+		if _, err := StrikeFromDelta(option, 0.2, target); !errors.Is(err, ErrDeltaOutOfRange) {
+			t.Errorf("Expected ErrDeltaOutOfRange for call delta %v: got %v", target, err)
+		}
+	}
+
+	option.OptionType = Put
+	for _, target := range []float64{0.25, 0.0, -1.0} {
+		if _, err := StrikeFromDelta(option, 0.2, target); !errors.Is(err, ErrDeltaOutOfRange) {
+			t.Errorf("Expected ErrDeltaOutOfRange for put delta %v: got %v", target, err)
+		}
+	}
+
+	option.OptionType = Call
+	for _, vol := range []float64{0, -0.2, math.NaN(), math.Inf(1)} {
+		if _, err := StrikeFromDelta(option, vol, 0.25); !errors.Is(err, ErrNonPositiveVolatility) {
+			t.Errorf("Expected ErrNonPositiveVolatility for volatility %v: got %v", vol, err)
+		}
+	}
+	option.DaysToExpiration = 0
+	if _, err := StrikeFromDelta(option, 0.2, 0.25); !errors.Is(err, ErrDeltaOutOfRange) {
+		t.Errorf("Expected ErrDeltaOutOfRange at expiration: got %v", err)
+	}
+	option.DaysToExpiration = -1
+	if _, err := StrikeFromDelta(option, 0.2, 0.25); !errors.Is(err, ErrNegativeExpiry) {
+		t.Errorf("Expected ErrNegativeExpiry after expiration: got %v", err)
+	}
+}
+
+func TestImpliedRateFromParity(t *testing.T) {
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.043,
+		UnderlyingPrice:  105.0,
+		OptionType:       Call,
+	}
+	put := call
+	put.OptionType = Put
+	call.Price = BlackScholesOptionPrice(call, 0.3)
+	put.Price = BlackScholesOptionPrice(put, 0.3)
+
+	rate, err := ImpliedRateFromParity(call, put)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := math.Abs(rate - 0.043); diff > 1e-10 {
+		t.Errorf("Unexpected implied rate: got %v, want 0.043", rate)
+	}
+
+	const yield = 0.018
+	call.DividendYield, put.DividendYield = yield, yield
+	call.Price = BlackScholesOptionPrice(call, 0.3)
+	put.Price = BlackScholesOptionPrice(put, 0.3)
+
+	rate, err = ImpliedRateFromParity(call, put)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := math.Abs(rate - 0.043); diff > 1e-10 {
+		t.Errorf("Unexpected implied rate with a dividend yield: got %v, want 0.043", rate)
+	}
+
+	call.DividendYield, put.DividendYield = 0, 0
+	q, err := ImpliedDividendYieldFromParity(call, put, 0.043)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := math.Abs(q - yield); diff > 1e-10 {
+		t.Errorf("Unexpected implied dividend yield: got %v, want %v", q, yield)
+	}
+}
+
+func TestImpliedRateFromParityDividends(t *testing.T) {
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.043,
+		UnderlyingPrice:  105.0,
+		OptionType:       Call,
+		DividendYield:    0.01,
+		Dividends:        []Dividend{{Amount: 2.0, DaysToExDate: 30.0}, {Amount: 2.0, DaysToExDate: 120.0}},
+	}
+	put := call
+	put.OptionType = Put
+	call.Price = BlackScholesOptionPrice(call, 0.3)
+	put.Price = BlackScholesOptionPrice(put, 0.3)
+
+	rate, err := ImpliedRateFromParity(call, put)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := math.Abs(rate - 0.043); diff > 1e-10 {
+		t.Errorf("Unexpected implied rate with discrete dividends: got %v, want 0.043", rate)
+	}
+
+	q, err := ImpliedDividendYieldFromParity(call, put, 0.043)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := math.Abs(q - 0.01); diff > 1e-10 {
+		t.Errorf("Unexpected implied dividend yield with discrete dividends: got %v, want 0.01", q)
+	}
+
+	call.Dividends = []Dividend{{Amount: 110.0, DaysToExDate: 30.0}}
+	put.Dividends = call.Dividends
+	if _, err := ImpliedDividendYieldFromParity(call, put, 0.043); !errors.Is(err, ErrNegativeAdjustedSpot) {
+		t.Errorf("Expected ErrNegativeAdjustedSpot: got %v", err)
+	}
+	if _, err := ImpliedRateFromParity(call, put); !errors.Is(err, ErrNegativeAdjustedSpot) {
+		t.Errorf("Expected ErrNegativeAdjustedSpot for the implied rate: got %v", err)
+	}
+}
+
+func TestImpliedRateFromParityMismatchedPair(t *testing.T) {
+	call := Option{
+		Price:            7.0,
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		UnderlyingPrice:  105.0,
+		OptionType:       Call,
+	}
+	put := call
+	put.OptionType = Put
+	put.Price = 1.0
+
+	mismatched := []struct {
+		name      string
+		call, put Option
+	}{
+		{"strike", call, Option{Price: 1.0, Strike: 95.0, DaysToExpiration: 90.0, UnderlyingPrice: 105.0, OptionType: Put}},
+		{"expiration", call, Option{Price: 1.0, Strike: 100.0, DaysToExpiration: 60.0, UnderlyingPrice: 105.0, OptionType: Put}},
+		{"underlying", call, Option{Price: 1.0, Strike: 100.0, DaysToExpiration: 90.0, UnderlyingPrice: 104.0, OptionType: Put}},
+		{"types", put, call},
+	}
+	for _, m := range mismatched {
+		if _, err := ImpliedRateFromParity(m.call, m.put); !errors.Is(err, ErrMismatchedPair) {
+			t.Errorf("Expected ErrMismatchedPair for mismatched %s: got %v", m.name, err)
+		}
+		if _, err := ImpliedDividendYieldFromParity(m.call, m.put, 0.05); !errors.Is(err, ErrMismatchedPair) {
+			t.Errorf("Expected ErrMismatchedPair for mismatched %s: got %v", m.name, err)
+		}
+	}
+
+	// a call worth more than the underlying over the put implies no rate
+	call.Price = 110.0
+	if _, err := ImpliedRateFromParity(call, put); !errors.Is(err, ErrPriceOutOfBounds) {
+		t.Errorf("Expected ErrPriceOutOfBounds: got %v", err)
+	}
+}
+
+func TestStrikeFromDeltaDividends(t *testing.T) {
+	option := Option{
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		Dividends:        []Dividend{{Amount: 3.0, DaysToExDate: 30.0}},
+	}
+
+	for _, target := range []float64{0.25, -0.25} {
+		option.OptionType = Call
+		if target < 0 {
+			option.OptionType = Put
+		}
+		strike, err := StrikeFromDelta(option, 0.25, target)
+		if err != nil {
+			t.Fatalf("Unexpected error for delta %v: %v", target, err)
+		}
+		option.Strike = strike
+		if delta := BlackScholesDelta(option, 0.25); math.Abs(delta-target) > 1e-12 {
+			t.Errorf("Unexpected delta at the solved strike with a dividend: got %v, want %v", delta, target)
+		}
+	}
+
+	option.Dividends = []Dividend{{Amount: 120.0, DaysToExDate: 30.0}}
+	if _, err := StrikeFromDelta(option, 0.25, -0.25); !errors.Is(err, ErrNegativeAdjustedSpot) {
+		t.Errorf("Expected ErrNegativeAdjustedSpot: got %v", err)
+	}
+}
+
+func TestStrikeFromDeltaDividendYield(t *testing.T) {
+	option := Option{
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+		DividendYield:    0.03,
+	}
+
+	strike, err := StrikeFromDelta(option, 0.2, -0.25)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	option.Strike = strike
+	if delta := BlackScholesDelta(option, 0.2); math.Abs(delta+0.25) > 1e-12 {
+		t.Errorf("Unexpected delta at the solved strike: got %v, want -0.25", delta)
+	}
+
+	// with a dividend yield a call's delta cannot reach e^{-qT}
+	option.OptionType = Call
+	if _, err := StrikeFromDelta(option, 0.2, 0.99); !errors.Is(err, ErrDeltaOutOfRange) {
+		t.Errorf("Expected ErrDeltaOutOfRange above e^{-qT}: got %v", err)
+	}
+}
@@ -0,0 +1,309 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+)
+
+var (
+	// ErrPriceOutOfBounds is returned when an option price lies outside its no-arbitrage bounds,
+	// so no volatility can reproduce it
+	ErrPriceOutOfBounds = errors.New("finance: option price outside no-arbitrage bounds")
+	// ErrVolatilityNotBracketed is returned when the volatility that reproduces an option price
+	// lies outside the range searched by the solver
+	ErrVolatilityNotBracketed = errors.New("finance: implied volatility outside solver bracket")
+	// ErrNotConverged is returned when an iterative solver exhausts its iterations without converging
+	ErrNotConverged = errors.New("finance: solver did not converge")
+	// ErrNegativePrice is returned when an option price is negative
+	ErrNegativePrice = errors.New("finance: negative option price")
+)
+
+// IVResult holds an implied volatility together with the solver's convergence diagnostics
+type IVResult struct {
+	Volatility float64 // Implied volatility
+	Iterations int     // Number of iterations used
+	PriceError float64 // Model price at Volatility minus the target price
+}
+
+// IVSolver computes Black-Scholes implied volatilities with configurable convergence settings
+type IVSolver struct {
+	initialGuess  float64 // Initial guess for volatility, or 0 to use ImpliedVolGuess
+	tolerance     float64 // Tolerance on the price for convergence
+	maxIterations int     // Maximum number of iterations
+	lowerBound    float64 // Lowest volatility searched
+	upperBound    float64 // Highest volatility searched
+}
+
+// defaultIVSolver holds the settings used by ImpliedVolatility
+var defaultIVSolver = IVSolver{
+	tolerance:     0.0001,
+	maxIterations: 100,
+	lowerBound:    1e-4,
+	upperBound:    5.0,
+}
+
+// ivFallbackGuess is the initial guess used when no closed-form approximation is available
+const ivFallbackGuess = 0.2
+
+// ivMinVega is the vega below which Newton steps are not attempted
+const ivMinVega = 1e-8
+
+// ImpliedVolatility computes the Black-Scholes implied volatility of an option from its price, using a bracketed
+// Newton-Raphson method seeded with ImpliedVolGuess that falls back to bisection whenever a Newton step is unreliable.
+// Returns the error from Validate for invalid inputs (ErrNegativePrice for a negative price), ErrBelowLowerBound
+// or ErrAboveUpperBound (both wrapping ErrPriceOutOfBounds) for a price outside the open no-arbitrage bounds,
+// ErrVolatilityNotBracketed when the implied volatility lies outside [1e-4, 5], ErrNegativeAdjustedSpot when
+// discrete dividends exceed the underlying price, and ErrNotConverged when the price is not matched to within 1e-4
+// after 100 iterations. On ErrNotConverged the result holds the last iterate
+// option: the option
+func ImpliedVolatility(option Option) (IVResult, error) {
+	return defaultIVSolver.Solve(option)
+}
+
+// IVOption configures an IVSolver
+type IVOption func(*IVSolver)
+
+// WithTolerance sets the absolute tolerance on the price at which the solver stops (default 1e-4)
+func WithTolerance(tolerance float64) IVOption {
+	return func(s *IVSolver) {
+		s.tolerance = tolerance
+	}
+}
+
+// WithMaxIterations sets the maximum number of iterations (default 100)
+func WithMaxIterations(maxIterations int) IVOption {
+	return func(s *IVSolver) {
+		s.maxIterations = maxIterations
+	}
+}
+
+// WithInitialGuess sets the volatility the solver starts from (default ImpliedVolGuess)
+func WithInitialGuess(guess float64) IVOption {
+	return func(s *IVSolver) {
+		s.initialGuess = guess
+	}
+}
+
+// WithBounds sets the range of volatilities searched, which must satisfy 0 < lower < upper (default [1e-4, 5])
+func WithBounds(lower, upper float64) IVOption {
+	return func(s *IVSolver) {
+		s.lowerBound, s.upperBound = lower, upper
+	}
+}
+
+// NewIVSolver returns an implied volatility solver with the settings of ImpliedVolatility, modified by opts
+func NewIVSolver(opts ...IVOption) *IVSolver {
+	s := defaultIVSolver
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return &s
+}
+
+// ivBatchPerWorker is the smallest number of options worth handing to a separate goroutine
+const ivBatchPerWorker = 256
+
+// ImpliedVolatilities computes the implied volatility of every option in a chain using ImpliedVolatility,
+// spreading the work over up to GOMAXPROCS goroutines. A failure for one option does not affect the others:
+// its volatility is NaN and its error is recorded in the same slot of the returned error slice, which is
+// otherwise nil. No memory is allocated per option beyond the two result slices
+// options: the options
+func ImpliedVolatilities(options []Option) ([]float64, []error) {
+	vols := make([]float64, len(options))
+	errs := make([]error, len(options))
+
+	workers := runtime.GOMAXPROCS(0)
+	if max := len(options) / ivBatchPerWorker; workers > max {
+		workers = max
+	}
+	if workers <= 1 {
+		impliedVolatilityRange(options, vols, errs)
+		return vols, errs
+	}
+
+	var wg sync.WaitGroup
+	chunk := (len(options) + workers - 1) / workers
+	for start := 0; start < len(options); start += chunk {
+		end := start + chunk
+		if end > len(options) {
+			end = len(options)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			impliedVolatilityRange(options[start:end], vols[start:end], errs[start:end])
+		}(start, end)
+	}
+	wg.Wait()
+	return vols, errs
+}
+
+// impliedVolatilityRange fills vols and errs with the implied volatilities of options
+func impliedVolatilityRange(options []Option, vols []float64, errs []error) {
+	for i, option := range options {
+		result, err := ImpliedVolatility(option)
+		if err != nil {
+			vols[i], errs[i] = math.NaN(), err
+			continue
+		}
+		vols[i] = result.Volatility
+	}
+}
+
+// Solve finds the volatility at which the Black-Scholes price matches option.Price.
+// Newton-Raphson steps are taken while they stay inside a bracket known to contain the root;
+// when a step would leave the bracket, or vega is too small to trust, the solver bisects instead,
+// so each iteration shrinks the bracket and the solver cannot diverge. Errors are as for ImpliedVolatility,
+// with ErrVolatilityNotBracketed also returned when the configured bounds are invalid
+// option: the option
+func (s *IVSolver) Solve(option Option) (IVResult, error) {
+	targetPrice := option.Price
+	if err := option.Validate(); err != nil {
+		return IVResult{Volatility: math.NaN()}, err
+	}
+	if err := checkDividends(option); err != nil {
+		return IVResult{Volatility: math.NaN()}, err
+	}
+	low, high := PriceBounds(option)
+	// a price on a bound is reproduced only by zero or infinite volatility
+	if !(targetPrice > low) {
+		return IVResult{Volatility: math.NaN()}, ErrBelowLowerBound
+	}
+	if !(targetPrice < high) {
+		return IVResult{Volatility: math.NaN()}, ErrAboveUpperBound
+	}
+
+	lowVol, highVol := s.lowerBound, s.upperBound
+	if !(lowVol > 0 && lowVol < highVol) {
+		return IVResult{Volatility: math.NaN()}, ErrVolatilityNotBracketed
+	}
+	if BlackScholesOptionPrice(option, lowVol) > targetPrice || BlackScholesOptionPrice(option, highVol) < targetPrice {
+		return IVResult{Volatility: math.NaN()}, ErrVolatilityNotBracketed
+	}
+
+	currentVolatility := s.initialGuess
+	if currentVolatility == 0 {
+		currentVolatility = ImpliedVolGuess(option)
+	}
+	if !(currentVolatility > lowVol && currentVolatility < highVol) {
+		currentVolatility = 0.5 * (lowVol + highVol)
+	}
+	result := IVResult{}
+	for result.Iterations < s.maxIterations {
+		result.Iterations++
+		result.Volatility = currentVolatility
+		result.PriceError = BlackScholesOptionPrice(option, currentVolatility) - targetPrice
+		if math.Abs(result.PriceError) < s.tolerance {
+			return result, nil // Convergence achieved
+		}
+
+		// Price is increasing in volatility, so the sign of the error tells which side the root is on
+		if result.PriceError > 0 {
+			highVol = currentVolatility
+		} else {
+			lowVol = currentVolatility
+		}
+
+		vega := BlackScholesVega(option, currentVolatility)
+		next := currentVolatility - result.PriceError/vega
+		if vega < ivMinVega || !(next > lowVol && next < highVol) {
+			next = 0.5 * (lowVol + highVol)
+		}
+		currentVolatility = next
+	}
+	return result, ErrNotConverged
+}
+
+// ImpliedVolGuess computes a closed-form approximation to the implied volatility of an option, used to seed
+// the implied volatility solver. Near the money it is the Brenner-Subrahmanyam approximation
+// sqrt(2π/T)*C/S; away from the money it is the Corrado-Miller approximation, which corrects for moneyness.
+// Both are applied to the dividend-discounted underlying price.
+// Puts are converted to calls through put-call parity. Returns 0.2 when the approximation breaks down
+// option: the option
+func ImpliedVolGuess(option Option) float64 {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	spot := option.UnderlyingPrice * math.Exp(-option.DividendYield*timeToExpiration)
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+
+	callPrice := option.Price
+	if option.OptionType == Put {
+		callPrice += spot - discountedStrike
+	}
+
+	scale := math.Sqrt(2 * math.Pi / timeToExpiration)
+	var guess float64
+	if math.Abs(spot-discountedStrike) < 1e-3*spot {
+		// Brenner-Subrahmanyam
+		guess = scale * callPrice / spot
+	} else {
+		// Corrado-Miller
+		halfMoneyness := (spot - discountedStrike) / 2
+		adjusted := callPrice - halfMoneyness
+		discriminant := math.Max(0, adjusted*adjusted-4*halfMoneyness*halfMoneyness/math.Pi)
+		guess = scale / (spot + discountedStrike) * (adjusted + math.Sqrt(discriminant))
+	}
+
+	if !(guess > 0) || math.IsInf(guess, 0) {
+		return ivFallbackGuess
+	}
+	return guess
+}
+
+// ErrEarlyExerciseRegion is returned when an American option price equals its intrinsic value, so that
+// immediate exercise is optimal, the price is insensitive to volatility, and no implied volatility exists
+var ErrEarlyExerciseRegion = errors.New("finance: American option price in early exercise region")
+
+// AmericanImpliedVolatility computes the implied volatility of an American option from its price, by inverting
+// a Cox-Ross-Rubinstein binomial tree with the given number of steps using Brent's method.
+// Returns the error from Validate for invalid inputs and ErrNegativeAdjustedSpot when discrete dividends exceed
+// the underlying price.
+// Returns ErrEarlyExerciseRegion when the price is at intrinsic value and the tree prices the option in the money at
+// intrinsic value for every volatility in the search range, ErrPriceOutOfBounds when the price is below
+// intrinsic value or above its upper bound, or when the option is not in the money and the price is at or below
+// the tree price at the lowest volatility, and ErrVolatilityNotBracketed when the implied volatility lies
+// outside [1e-4, 5]
+// option: the option
+// steps: the number of time steps in the tree
+func AmericanImpliedVolatility(option Option, steps int) (float64, error) {
+	targetPrice := option.Price
+	if err := option.Validate(); err != nil {
+		return math.NaN(), err
+	}
+	if err := checkDividends(option); err != nil {
+		return math.NaN(), err
+	}
+
+	intrinsic, upper := math.Max(option.UnderlyingPrice-option.Strike, 0), option.UnderlyingPrice
+	if option.OptionType == Put {
+		intrinsic, upper = math.Max(option.Strike-option.UnderlyingPrice, 0), option.Strike
+	}
+	if targetPrice < intrinsic || targetPrice >= upper {
+		return math.NaN(), ErrPriceOutOfBounds
+	}
+
+	objective := func(vol float64) float64 {
+		return BinomialOptionPrice(option, vol, steps, American) - targetPrice
+	}
+	s := defaultIVSolver
+	if objective(s.lowerBound) >= 0 {
+		if intrinsic == 0 {
+			return math.NaN(), ErrPriceOutOfBounds
+		}
+		if BinomialOptionPrice(option, s.lowerBound, steps, American)-intrinsic < s.tolerance {
+			return math.NaN(), ErrEarlyExerciseRegion
+		}
+		return math.NaN(), ErrVolatilityNotBracketed
+	}
+
+	vol, _, err := brent(objective, s.lowerBound, s.upperBound, 1e-10, s.maxIterations)
+	if errors.Is(err, ErrNotBracketed) {
+		return math.NaN(), ErrVolatilityNotBracketed
+	}
+	if err != nil {
+		return math.NaN(), err
+	}
+	return vol, nil
+}
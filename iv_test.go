@@ -0,0 +1,459 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"testing"
+)
+
+func TestBlackScholesImpliedVolatilityDeepOTMShortDated(t *testing.T) {
+	// 1-DTE 20% OTM put, where vega at the initial guess is vanishingly small
+	option := Option{
+		Price:            0.05,
+		Strike:           80.0,
+		DaysToExpiration: 1.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	volatility := BlackScholesImpliedVolatility(option)
+
+	const expectedIV = 1.97190642
+	const tolerance = 0.0001
+	if diff := math.Abs(volatility - expectedIV); diff > tolerance {
+		t.Errorf("Unexpected volatility for deep OTM put: got %v, want %v", volatility, expectedIV)
+	}
+
+	if price := BlackScholesOptionPrice(option, volatility); math.Abs(price-option.Price) > defaultIVSolver.tolerance {
+		t.Errorf("Implied volatility does not reproduce the price: got %v, want %v", price, option.Price)
+	}
+}
+
+func TestBlackScholesImpliedVolatilityRoundTrip(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{50.0, 80.0, 100.0, 120.0, 200.0} {
+			for _, days := range []float64{1.0, 30.0, 365.0, 1825.0} {
+				for _, vol := range []float64{0.05, 0.2, 0.8, 2.0} {
+					option := Option{
+						Strike:           strike,
+						DaysToExpiration: days,
+						RiskFreeRate:     0.05,
+						UnderlyingPrice:  100.0,
+						OptionType:       optionType,
+					}
+					option.Price = BlackScholesOptionPrice(option, vol)
+
+					result, err := ImpliedVolatility(option)
+					if errors.Is(err, ErrPriceOutOfBounds) || errors.Is(err, ErrVolatilityNotBracketed) {
+						// prices indistinguishable from intrinsic value cannot be inverted
+						continue
+					}
+					volatility := result.Volatility
+					if err != nil || volatility < defaultIVSolver.lowerBound || volatility > defaultIVSolver.upperBound {
+						t.Errorf("Invalid volatility for type %v strike %v days %v vol %v: got %v, %v", optionType, strike, days, vol, volatility, err)
+						continue
+					}
+					if math.Abs(result.PriceError) > defaultIVSolver.tolerance {
+						t.Errorf("Unexpected price error for type %v strike %v days %v vol %v: got %v", optionType, strike, days, vol, result.PriceError)
+					}
+					if price := BlackScholesOptionPrice(option, volatility); math.Abs(price-option.Price) > defaultIVSolver.tolerance {
+						t.Errorf("Implied volatility does not reproduce the price for type %v strike %v days %v vol %v: got %v, want %v", optionType, strike, days, vol, price, option.Price)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBlackScholesImpliedVolatilityOutOfBounds(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	option.Price = -1.0
+	if _, err := ImpliedVolatility(option); !errors.Is(err, ErrNegativePrice) {
+		t.Errorf("Expected ErrNegativePrice for a negative price: got %v", err)
+	}
+
+	for _, price := range []float64{0.0, 100.0, 150.0} {
+		option.Price = price
+		if _, err := ImpliedVolatility(option); !errors.Is(err, ErrPriceOutOfBounds) {
+			t.Errorf("Expected ErrPriceOutOfBounds for price %v: got %v", price, err)
+		}
+		if volatility := BlackScholesImpliedVolatility(option); !math.IsNaN(volatility) {
+			t.Errorf("Expected NaN volatility for price %v: got %v", price, volatility)
+		}
+	}
+
+	option.Price = 99.0
+	if _, err := ImpliedVolatility(option); !errors.Is(err, ErrVolatilityNotBracketed) {
+		t.Errorf("Expected ErrVolatilityNotBracketed for a price implying extreme volatility: got %v", err)
+	}
+}
+
+func TestImpliedVolatility(t *testing.T) {
+	option := Option{
+		Price:            2.5,
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	result, err := ImpliedVolatility(option)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	const expectedIV = 0.20058200
+	const tolerance = 0.00001
+	if diff := math.Abs(result.Volatility - expectedIV); diff > tolerance {
+		t.Errorf("Unexpected volatility: got %v, want %v", result.Volatility, expectedIV)
+	}
+	if result.Iterations < 1 || result.Iterations > defaultIVSolver.maxIterations {
+		t.Errorf("Unexpected iteration count: got %v", result.Iterations)
+	}
+	if math.Abs(result.PriceError) >= defaultIVSolver.tolerance {
+		t.Errorf("Unexpected price error: got %v", result.PriceError)
+	}
+}
+
+func TestImpliedVolatilityNotConverged(t *testing.T) {
+	option := Option{
+		Price:            0.05,
+		Strike:           80.0,
+		DaysToExpiration: 1.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	solver := defaultIVSolver
+	solver.maxIterations = 3
+
+	result, err := solver.Solve(option)
+	if !errors.Is(err, ErrNotConverged) {
+		t.Fatalf("Expected ErrNotConverged: got %v", err)
+	}
+	if result.Iterations != 3 {
+		t.Errorf("Unexpected iteration count: got %v, want 3", result.Iterations)
+	}
+	if math.Abs(result.PriceError) < solver.tolerance {
+		t.Errorf("Expected the reported price error to exceed the tolerance: got %v", result.PriceError)
+	}
+}
+
+// ivGrid returns options priced at known volatilities across a grid of moneyness and expiries
+func ivGrid() []Option {
+	var options []Option
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{70.0, 85.0, 95.0, 100.0, 105.0, 115.0, 130.0} {
+			for _, days := range []float64{7.0, 30.0, 90.0, 365.0} {
+				for _, vol := range []float64{0.15, 0.3, 0.6} {
+					option := Option{
+						Strike:           strike,
+						DaysToExpiration: days,
+						RiskFreeRate:     0.05,
+						UnderlyingPrice:  100.0,
+						OptionType:       optionType,
+					}
+					option.Price = BlackScholesOptionPrice(option, vol)
+					if _, err := ImpliedVolatility(option); err == nil {
+						options = append(options, option)
+					}
+				}
+			}
+		}
+	}
+	return options
+}
+
+// ivIterations returns the total number of iterations the solver needs across options
+func ivIterations(solver IVSolver, options []Option) int {
+	total := 0
+	for _, option := range options {
+		result, _ := solver.Solve(option)
+		total += result.Iterations
+	}
+	return total
+}
+
+func TestImpliedVolGuess(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.0,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	option.Price = BlackScholesOptionPrice(option, 0.3)
+
+	// Brenner-Subrahmanyam is very accurate at the money
+	if guess := ImpliedVolGuess(option); math.Abs(guess-0.3) > 0.001 {
+		t.Errorf("Unexpected ATM guess: got %v, want close to 0.3", guess)
+	}
+
+	option.Strike = 105.0
+	option.RiskFreeRate = 0.05
+	option.Price = BlackScholesOptionPrice(option, 0.3)
+	if guess := ImpliedVolGuess(option); math.Abs(guess-0.3) > 0.005 {
+		t.Errorf("Unexpected OTM guess: got %v, want close to 0.3", guess)
+	}
+
+	option.OptionType = Put
+	option.Price = BlackScholesOptionPrice(option, 0.3)
+	if guess := ImpliedVolGuess(option); math.Abs(guess-0.3) > 0.005 {
+		t.Errorf("Unexpected ITM put guess: got %v, want close to 0.3", guess)
+	}
+}
+
+func TestImpliedVolGuessReducesIterations(t *testing.T) {
+	options := ivGrid()
+
+	fixed := defaultIVSolver
+	fixed.initialGuess = 0.2
+
+	seeded, unseeded := ivIterations(defaultIVSolver, options), ivIterations(fixed, options)
+	if seeded >= unseeded {
+		t.Errorf("Expected fewer iterations with ImpliedVolGuess: got %v seeded, %v with a fixed 0.2 seed", seeded, unseeded)
+	}
+}
+
+func BenchmarkImpliedVolatilityFixedSeed(b *testing.B) {
+	options := ivGrid()
+	fixed := defaultIVSolver
+	fixed.initialGuess = 0.2
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iterations := ivIterations(fixed, options)
+		b.ReportMetric(float64(iterations)/float64(len(options)), "iterations/solve")
+	}
+}
+
+func BenchmarkImpliedVolatilityGuessSeed(b *testing.B) {
+	options := ivGrid()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iterations := ivIterations(defaultIVSolver, options)
+		b.ReportMetric(float64(iterations)/float64(len(options)), "iterations/solve")
+	}
+}
+
+// ivChain returns a chain of options priced at a known volatility, of the given length
+func ivChain(length int) []Option {
+	options := make([]Option, length)
+	for i := range options {
+		option := Option{
+			Strike:           80.0 + 40.0*float64(i%41)/40.0,
+			DaysToExpiration: 7.0 + float64(i%53),
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       OptionType(i % 2),
+		}
+		option.Price = BlackScholesOptionPrice(option, 0.25)
+		options[i] = option
+	}
+	return options
+}
+
+func TestImpliedVolatilities(t *testing.T) {
+	options := ivChain(2000)
+	// a zero bid and a quote below intrinsic value
+	options[3].Price = 0
+	options[1500].OptionType = Call
+	options[1500].Strike = 80.0
+	options[1500].Price = 1.0
+
+	vols, errs := ImpliedVolatilities(options)
+	if len(vols) != len(options) || len(errs) != len(options) {
+		t.Fatalf("Unexpected result lengths: got %v and %v, want %v", len(vols), len(errs), len(options))
+	}
+
+	for i, option := range options {
+		if i == 3 || i == 1500 {
+			if !errors.Is(errs[i], ErrPriceOutOfBounds) || !math.IsNaN(vols[i]) {
+				t.Errorf("Expected ErrPriceOutOfBounds and NaN in slot %v: got %v, %v", i, vols[i], errs[i])
+			}
+			continue
+		}
+		result, err := ImpliedVolatility(option)
+		if errs[i] != err || vols[i] != result.Volatility {
+			t.Errorf("Unexpected result in slot %v: got %v, %v, want %v, %v", i, vols[i], errs[i], result.Volatility, err)
+		}
+	}
+}
+
+func TestImpliedVolatilitiesAllocations(t *testing.T) {
+	options := ivChain(10000)
+	allocs := testing.AllocsPerRun(10, func() {
+		ImpliedVolatilities(options)
+	})
+	// two result slices, plus a goroutine and wait group bookkeeping per worker
+	if max := float64(3 + 2*runtime.GOMAXPROCS(0)); allocs > max {
+		t.Errorf("Unexpected allocations: got %v, want at most %v", allocs, max)
+	}
+}
+
+func BenchmarkImpliedVolatilities(b *testing.B) {
+	options := ivChain(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ImpliedVolatilities(options)
+	}
+}
+
+func TestAmericanImpliedVolatility(t *testing.T) {
+	const steps = 200
+
+	for _, strike := range []float64{90.0, 100.0, 110.0} {
+		option := Option{
+			Strike:           strike,
+			DaysToExpiration: 180.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       Put,
+		}
+		option.Price = BinomialOptionPrice(option, 0.3, steps, American)
+
+		volatility, err := AmericanImpliedVolatility(option, steps)
+		if err != nil {
+			t.Errorf("Unexpected error for strike %v: %v", strike, err)
+			continue
+		}
+		if diff := math.Abs(volatility - 0.3); diff > 1e-6 {
+			t.Errorf("Unexpected volatility for strike %v: got %v, want 0.3", strike, volatility)
+		}
+
+		// the early exercise premium biases the European solver upwards
+		if european := BlackScholesImpliedVolatility(option); european <= volatility {
+			t.Errorf("Expected the European implied volatility to exceed the American one for strike %v: got %v vs %v", strike, european, volatility)
+		}
+	}
+}
+
+func TestAmericanImpliedVolatilityEarlyExercise(t *testing.T) {
+	option := Option{
+		Price:            40.0,
+		Strike:           140.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	if _, err := AmericanImpliedVolatility(option, 200); !errors.Is(err, ErrEarlyExerciseRegion) {
+		t.Errorf("Expected ErrEarlyExerciseRegion for a deep ITM put at intrinsic value: got %v", err)
+	}
+
+	option.Price = 39.0
+	if _, err := AmericanImpliedVolatility(option, 200); !errors.Is(err, ErrPriceOutOfBounds) {
+		t.Errorf("Expected ErrPriceOutOfBounds below intrinsic value: got %v", err)
+	}
+
+	// an out-of-the-money quote at or below the price at the lowest volatility involves no early exercise
+	option.Strike = 80.0
+	for _, price := range []float64{0, BinomialOptionPrice(option, 1e-4, 200, American)} {
+		option.Price = price
+		if _, err := AmericanImpliedVolatility(option, 200); !errors.Is(err, ErrPriceOutOfBounds) {
+			t.Errorf("Expected ErrPriceOutOfBounds for an out-of-the-money put priced at %v: got %v", price, err)
+		}
+	}
+}
+
+func TestIVSolverTolerance(t *testing.T) {
+	// a penny-priced option, where the default price tolerance is loose
+	option := Option{
+		Strike:           115.0,
+		DaysToExpiration: 14.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	option.Price = BlackScholesOptionPrice(option, 0.3)
+
+	loose, err := NewIVSolver(WithInitialGuess(0.2)).Solve(option)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tight, err := NewIVSolver(WithInitialGuess(0.2), WithTolerance(1e-12)).Solve(option)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if loose.Volatility == tight.Volatility {
+		t.Errorf("Expected a tighter tolerance to change the volatility: got %v for both", loose.Volatility)
+	}
+	if diff := math.Abs(tight.Volatility - 0.3); diff > 1e-9 {
+		t.Errorf("Unexpected volatility at a tight tolerance: got %v, want 0.3", tight.Volatility)
+	}
+	if math.Abs(tight.Volatility-0.3) >= math.Abs(loose.Volatility-0.3) {
+		t.Errorf("Expected a tighter tolerance to be more accurate: got %v vs %v", tight.Volatility, loose.Volatility)
+	}
+}
+
+func TestIVSolverOptions(t *testing.T) {
+	option := Option{
+		Price:            2.5,
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	if _, err := NewIVSolver(WithMaxIterations(1), WithInitialGuess(1.0)).Solve(option); !errors.Is(err, ErrNotConverged) {
+		t.Errorf("Expected ErrNotConverged with a single iteration: got %v", err)
+	}
+
+	if _, err := NewIVSolver(WithBounds(0.5, 1.0)).Solve(option); !errors.Is(err, ErrVolatilityNotBracketed) {
+		t.Errorf("Expected ErrVolatilityNotBracketed outside the bounds: got %v", err)
+	}
+
+	if _, err := NewIVSolver(WithBounds(1.0, 0.5)).Solve(option); !errors.Is(err, ErrVolatilityNotBracketed) {
+		t.Errorf("Expected ErrVolatilityNotBracketed for inverted bounds: got %v", err)
+	}
+
+	result, err := NewIVSolver().Solve(option)
+	expected, expectedErr := ImpliedVolatility(option)
+	if result != expected || err != expectedErr {
+		t.Errorf("Expected a default solver to match ImpliedVolatility: got %+v, %v, want %+v, %v", result, err, expected, expectedErr)
+	}
+}
+
+func TestImpliedVolatilityDividendYield(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{80.0, 100.0, 120.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 180.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.04,
+			}
+			option.Price = BlackScholesOptionPrice(option, 0.3)
+
+			result, err := NewIVSolver(WithTolerance(1e-10)).Solve(option)
+			if err != nil {
+				t.Errorf("Unexpected error for type %v strike %v: %v", optionType, strike, err)
+			} else if diff := math.Abs(result.Volatility - 0.3); diff > 1e-8 {
+				t.Errorf("Unexpected volatility for type %v strike %v: got %v, want 0.3", optionType, strike, result.Volatility)
+			}
+
+			rational, err := RationalImpliedVolatility(option)
+			if err != nil {
+				t.Errorf("Unexpected rational error for type %v strike %v: %v", optionType, strike, err)
+			} else if diff := math.Abs(rational - 0.3); diff > 1e-10 {
+				t.Errorf("Unexpected rational volatility for type %v strike %v: got %v, want 0.3", optionType, strike, rational)
+			}
+		}
+	}
+}
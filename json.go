@@ -0,0 +1,21 @@
+package finance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalJSON decodes an option, rejecting unknown option types with ErrInvalidOptionType and negative
+// strikes with ErrNonPositiveStrike. A missing option_type field decodes as Call
+func (option *Option) UnmarshalJSON(data []byte) error {
+	type plain Option // plain has the fields of Option but not its methods, so decoding it does not recurse
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Strike < 0 {
+		return fmt.Errorf("%w: %v", ErrNonPositiveStrike, decoded.Strike)
+	}
+	*option = Option(decoded)
+	return nil
+}
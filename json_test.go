@@ -0,0 +1,112 @@
+package finance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// checkGolden compares data with the golden file testdata/name, rewriting the file when -update is set
+func checkGolden(t *testing.T, name string, data []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("Unexpected error writing %v: %v", path, err)
+		}
+	}
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error reading %v: %v", path, err)
+	}
+	if !bytes.Equal(data, golden) {
+		t.Errorf("Unexpected JSON for %v: got\n%s\nwant\n%s", name, data, golden)
+	}
+}
+
+func TestOptionJSONGolden(t *testing.T) {
+	options := []Option{
+		{
+			Price:            2.5,
+			Strike:           100.0,
+			DaysToExpiration: 30.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  101.25,
+			OptionType:       Call,
+		},
+		{
+			Price:            4.75,
+			Strike:           95.0,
+			DaysToExpiration: 91.5,
+			RiskFreeRate:     0.04,
+			UnderlyingPrice:  90.0,
+			OptionType:       Put,
+			DividendYield:    0.015,
+			Dividends:        []Dividend{{Amount: 0.88, DaysToExDate: 21.0}},
+		},
+	}
+
+	data, err := json.MarshalIndent(options, "", "  ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkGolden(t, "options.json", append(data, '\n'))
+
+	var decoded []Option
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, options) {
+		t.Errorf("Unexpected round trip: got %+v, want %+v", decoded, options)
+	}
+}
+
+func TestGreeksJSONGolden(t *testing.T) {
+	greeks := Greeks{
+		Price: 2.493376,
+		Delta: 0.537979,
+		Gamma: 0.069464,
+		Vega:  11.419,
+		Theta: -17.5495,
+		Rho:   4.2153,
+	}
+
+	data, err := json.MarshalIndent(greeks, "", "  ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkGolden(t, "greeks.json", append(data, '\n'))
+
+	var decoded Greeks
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded != greeks {
+		t.Errorf("Unexpected round trip: got %+v, want %+v", decoded, greeks)
+	}
+}
+
+func TestOptionUnmarshalJSONValidation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected error
+	}{
+		{`{"strike": -5, "option_type": "call"}`, ErrNonPositiveStrike},
+		{`{"strike": 100, "option_type": "straddle"}`, ErrInvalidOptionType},
+		{`{"strike": 100, "option_type": 1}`, ErrInvalidOptionType},
+	}
+
+	for _, test := range tests {
+		var option Option
+		if err := json.Unmarshal([]byte(test.input), &option); !errors.Is(err, test.expected) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", test.input, err, test.expected)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package finance
+
+import "math"
+
+// Kelly sizing of option positions
+const (
+	kellyMaxIterations = 200   // Bisections of the number of options at which the growth rate peaks
+	kellyTailMass      = 1e-14 // Probability beyond the range of prices over which the growth rate is integrated
+	kellyTolerance     = 1e-12 // Absolute tolerance of the integrals of the growth rate and its slope
+)
+
+// SizingResult is the growth-optimal size of a long option position
+type SizingResult struct {
+	Contracts      float64 `json:"contracts"`       // Number of options to buy, each on one unit of the underlying, at the fraction of the Kelly size
+	Fraction       float64 `json:"fraction"`        // Fraction of the bankroll paid in premium
+	ExpectedValue  float64 `json:"expected_value"`  // Expected payoff of an option less its premium compounded at the risk-free rate, under the drift
+	ExpectedGrowth float64 `json:"expected_growth"` // Expected log growth of the bankroll to expiration at the size, compounding included
+}
+
+// KellyFraction computes the fraction of a bankroll to stake on a bet with two outcomes that maximizes the expected
+// log growth of the bankroll, p/a − (1 − p)/b for a bet that gains b per unit staked with probability p and loses a
+// per unit staked otherwise, a = 1 for a bet that loses its stake. A bet whose expected value p·b − (1 − p)·a is not
+// positive has a fraction of zero. NaN for a probability outside [0, 1] or payoffs that are not positive and finite
+// winProb: the probability p of winning
+// winPayoff: the gain b per unit staked on a win
+// lossPayoff: the loss a per unit staked on a loss
+func KellyFraction(winProb, winPayoff, lossPayoff float64) float64 {
+	if !(winProb >= 0 && winProb <= 1) || !(winPayoff > 0) || math.IsInf(winPayoff, 1) || !(lossPayoff > 0) || math.IsInf(lossPayoff, 1) {
+		return math.NaN()
+	}
+	if winProb*winPayoff <= (1-winProb)*lossPayoff {
+		return 0
+	}
+	return winProb/lossPayoff - (1-winProb)/winPayoff
+}
+
+// OptionKellySize computes the number of options to buy at their price that maximizes the expected log growth of a
+// bankroll to expiration, the unspent bankroll earning the risk-free rate, when the underlying grows at an expected
+// return rather than the risk-free rate. The payoff follows the terminal distribution with the drift in place of the
+// rate, as for RealWorldProbabilityITM, and the growth rate ln(W_T/B) is integrated over it. An option whose
+// expected payoff does not exceed its compounded premium has no size; otherwise the size is where the slope of the
+// growth rate in the number of options vanishes, below the bankroll's worth of premium, which would lose it all when
+// the option expires worthless. Fractional Kelly scales the size by the multiplier, which lowers the growth rate
+// little and its variance much more; beyond the bankroll's worth of premium the growth is minus infinity. NaN for an
+// option that cannot be priced at the volatility, at expiration or without a positive price, and for a drift that is
+// not finite or a bankroll or multiplier that is not positive and finite
+// option: the option, with its price
+// vol: the volatility of the underlying
+// realWorldDrift: the expected return of the underlying, continuously compounded
+// bankroll: the bankroll B
+// kellyMultiplier: the fraction of the Kelly size to buy, 0.5 for half Kelly
+func OptionKellySize(option Option, vol, realWorldDrift, bankroll, kellyMultiplier float64) SizingResult {
+	nan := SizingResult{Contracts: math.NaN(), Fraction: math.NaN(), ExpectedValue: math.NaN(), ExpectedGrowth: math.NaN()}
+	switch {
+	case !(option.Price > 0) || !(option.DaysToExpiration > 0):
+		return nan
+	case math.IsNaN(realWorldDrift) || math.IsInf(realWorldDrift, 0):
+		return nan
+	case !(bankroll > 0) || math.IsInf(bankroll, 1) || !(kellyMultiplier > 0) || math.IsInf(kellyMultiplier, 1):
+		return nan
+	}
+	if err := validatePricing(option, vol); err != nil {
+		return nan
+	}
+	world := option
+	world.RiskFreeRate = realWorldDrift
+	dist, err := TerminalDistribution(world, vol)
+	if err != nil {
+		return nan
+	}
+
+	timeYears := option.DaysToExpiration / 365.0
+	cost := option.Price * math.Exp(option.RiskFreeRate*timeYears)
+	cash := bankroll * math.Exp(option.RiskFreeRate*timeYears)
+	payoff := func(price float64) float64 {
+		if option.OptionType == Call {
+			return math.Max(price-option.Strike, 0)
+		}
+		return math.Max(option.Strike-price, 0)
+	}
+	// the options pay off between the strike and a far quantile of the price, and lose the premium elsewhere
+	lo, hi := option.Strike, dist.Quantile(1-kellyTailMass)
+	worthless := dist.CDF(option.Strike)
+	if option.OptionType == Put {
+		lo, hi = dist.Quantile(kellyTailMass), option.Strike
+		worthless = 1 - worthless
+	}
+	expectation := func(f func(price float64) float64) float64 {
+		if !(hi > lo) {
+			return 0
+		}
+		sum, _ := integrate(func(price float64) float64 { return f(price) * dist.PDF(price) }, lo, hi, kellyTolerance)
+		return sum
+	}
+
+	result := SizingResult{ExpectedValue: -worthless*cost + expectation(func(price float64) float64 { return payoff(price) - cost })}
+	if !(result.ExpectedValue > 0) {
+		result.ExpectedGrowth = option.RiskFreeRate * timeYears
+		return result
+	}
+
+	// the slope of the growth rate falls from the expected value over the compounded bankroll to minus infinity at
+	// the bankroll's worth of premium
+	slope := func(contracts float64) float64 {
+		left := cash - contracts*cost
+		return -worthless*cost/left + expectation(func(price float64) float64 { return (payoff(price) - cost) / (left + contracts*payoff(price)) })
+	}
+	lower, upper := 0.0, bankroll/option.Price
+	for range kellyMaxIterations {
+		middle := 0.5 * (lower + upper)
+		if middle == lower || middle == upper {
+			break
+		}
+		if slope(middle) > 0 {
+			lower = middle
+		} else {
+			upper = middle
+		}
+	}
+
+	contracts := kellyMultiplier * 0.5 * (lower + upper)
+	left := cash - contracts*cost
+	result.Contracts = contracts
+	result.Fraction = contracts * option.Price / bankroll
+	result.ExpectedGrowth = math.Inf(-1)
+	if left > 0 {
+		result.ExpectedGrowth = worthless*math.Log(left/bankroll) + expectation(func(price float64) float64 { return math.Log((left + contracts*payoff(price)) / bankroll) })
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKellyFraction(t *testing.T) {
+	for _, tc := range []struct {
+		name                        string
+		winProb, winPayoff, lossPay float64
+		want                        float64
+	}{
+		{"even money coin at 60%", 0.6, 1, 1, 0.2},
+		{"two to one on a fair coin", 0.5, 2, 1, 0.25},
+		{"losing half the stake", 0.5, 1, 0.5, 0.5},
+		{"fair bet", 0.5, 1, 1, 0},
+		{"losing bet", 0.4, 1, 1, 0},
+		{"certain win", 1, 1, 1, 1},
+	} {
+		if got := KellyFraction(tc.winProb, tc.winPayoff, tc.lossPay); math.Abs(got-tc.want) > 1e-15 {
+			t.Errorf("Unexpected Kelly fraction for %s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+	for name, got := range map[string]float64{
+		"probability above one": KellyFraction(1.2, 1, 1),
+		"no payoff":             KellyFraction(0.6, 0, 1),
+		"negative loss":         KellyFraction(0.6, 1, -1),
+		"infinite payoff":       KellyFraction(0.6, math.Inf(1), 1),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected Kelly fraction for %s: got %v, want NaN", name, got)
+		}
+	}
+}
+
+func TestOptionKellySize(t *testing.T) {
+	call := Option{
+		Strike:           105.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.04,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	call.Price = BlackScholesOptionPrice(call, 0.25)
+	const bankroll = 10000.0
+
+	// a call at its risk-neutral price is worth buying when the underlying is expected to beat the rate
+	full := OptionKellySize(call, 0.25, 0.2, bankroll, 1)
+	if !(full.Contracts > 0) || !(full.Fraction > 0 && full.Fraction < 1) {
+		t.Fatalf("Unexpected size: got %+v", full)
+	}
+	world := call
+	world.RiskFreeRate = 0.2
+	cost := call.Price * math.Exp(0.04*90.0/365)
+	if want := ExpectedPayoff(world, 0.25) - cost; math.Abs(full.ExpectedValue-want) > 1e-9 {
+		t.Errorf("Unexpected expected value: got %v, want %v", full.ExpectedValue, want)
+	}
+	if got, want := full.Fraction, full.Contracts*call.Price/bankroll; math.Abs(got-want) > 1e-15 {
+		t.Errorf("Unexpected fraction: got %v, want %v", got, want)
+	}
+	// the size maximizes the growth, which beats holding cash
+	for _, multiplier := range []float64{0.9, 1.1} {
+		if other := OptionKellySize(call, 0.25, 0.2, bankroll, multiplier); !(other.ExpectedGrowth < full.ExpectedGrowth) {
+			t.Errorf("Unexpected growth at %v Kelly: got %v, want below %v", multiplier, other.ExpectedGrowth, full.ExpectedGrowth)
+		}
+	}
+	if cash := 0.04 * 90.0 / 365; !(full.ExpectedGrowth > cash) {
+		t.Errorf("Unexpected growth: got %v, want above %v", full.ExpectedGrowth, cash)
+	}
+	half := OptionKellySize(call, 0.25, 0.2, bankroll, 0.5)
+	if math.Abs(half.Contracts-0.5*full.Contracts) > 1e-9*full.Contracts {
+		t.Errorf("Unexpected half Kelly size: got %v, want %v", half.Contracts, 0.5*full.Contracts)
+	}
+	// the size scales with the bankroll
+	if double := OptionKellySize(call, 0.25, 0.2, 2*bankroll, 1); math.Abs(double.Contracts-2*full.Contracts) > 1e-6*full.Contracts {
+		t.Errorf("Unexpected size for twice the bankroll: got %v, want %v", double.Contracts, 2*full.Contracts)
+	}
+	// a put gains from a falling underlying
+	put := call
+	put.OptionType, put.Strike = Put, 95.0
+	put.Price = BlackScholesOptionPrice(put, 0.25)
+	if size := OptionKellySize(put, 0.25, -0.3, bankroll, 1); !(size.Contracts > 0) {
+		t.Errorf("Unexpected put size with a falling underlying: got %+v", size)
+	}
+
+	// no edge, or a negative one, buys nothing
+	for name, size := range map[string]SizingResult{
+		"drift at the rate":    OptionKellySize(call, 0.25, 0.04, bankroll, 1),
+		"put with the drift":   OptionKellySize(put, 0.25, 0.2, bankroll, 1),
+		"overpriced call":      OptionKellySize(Option{Price: 2 * call.Price, Strike: 105.0, DaysToExpiration: 90.0, RiskFreeRate: 0.04, UnderlyingPrice: 100.0, OptionType: Call}, 0.25, 0.1, bankroll, 1),
+		"far out of the money": OptionKellySize(Option{Price: 1e-3, Strike: 1000.0, DaysToExpiration: 90.0, RiskFreeRate: 0.04, UnderlyingPrice: 100.0, OptionType: Call}, 0.25, 0.2, bankroll, 1),
+	} {
+		if size.Contracts != 0 || size.Fraction != 0 || !(size.ExpectedValue <= 1e-12) || math.IsNaN(size.ExpectedGrowth) {
+			t.Errorf("Unexpected size for %s: got %+v, want none", name, size)
+		}
+	}
+
+	free := call
+	free.Price = 0
+	expired := call
+	expired.DaysToExpiration = 0
+	for name, size := range map[string]SizingResult{
+		"free option":   OptionKellySize(free, 0.25, 0.2, bankroll, 1),
+		"expired":       OptionKellySize(expired, 0.25, 0.2, bankroll, 1),
+		"zero vol":      OptionKellySize(call, 0, 0.2, bankroll, 1),
+		"no bankroll":   OptionKellySize(call, 0.25, 0.2, 0, 1),
+		"no multiplier": OptionKellySize(call, 0.25, 0.2, bankroll, 0),
+		"NaN drift":     OptionKellySize(call, 0.25, math.NaN(), bankroll, 1),
+	} {
+		if !math.IsNaN(size.Contracts) {
+			t.Errorf("Unexpected size for %s: got %+v, want NaN", name, size)
+		}
+	}
+}
@@ -13,69 +13,144 @@ const (
 
 // Option represents an option contract
 type Option struct {
-	Price            float64    // Option price
-	Strike           float64    // Option strike price
-	DaysToExpiration float64    // Days to expiration
-	RiskFreeRate     float64    // Risk-free interest rate
-	UnderlyingPrice  float64    // Current price of the underlying asset
-	OptionType       OptionType // Option type, can be either Call or Put
+	Price            float64    `json:"price"`                    // Option price
+	Strike           float64    `json:"strike"`                   // Option strike price
+	DaysToExpiration float64    `json:"days_to_expiration"`       // Days to expiration
+	RiskFreeRate     float64    `json:"risk_free_rate"`           // Risk-free interest rate
+	UnderlyingPrice  float64    `json:"underlying_price"`         // Current price of the underlying asset
+	OptionType       OptionType `json:"option_type"`              // Option type, can be either Call or Put
+	DividendYield    float64    `json:"dividend_yield,omitempty"` // Continuous dividend yield of the underlying asset, zero if it pays none
+	Dividends        []Dividend `json:"dividends,omitempty"`      // Discrete cash dividends, priced with the escrowed dividend model
 }
 
-// BlackScholesImpliedVolatility computes implied volatility using the Newton-Raphson method
+// BlackScholesImpliedVolatility computes implied volatility using ImpliedVolatility.
+// Returns NaN whenever ImpliedVolatility returns an error
 func BlackScholesImpliedVolatility(option Option) float64 {
-	targetPrice := option.Price
-	currentVolatility := 0.2 // Initial guess for volatility
-	epsilon := 0.0001        // Tolerance for convergence
-	maxIterations := 100     // Maximum number of iterations
-	vega := 0.0
-	for i := 0; i < maxIterations; i++ {
-		price := BlackScholesOptionPrice(option, currentVolatility)
-		vega = BlackScholesVega(option, currentVolatility)
-		if math.Abs(price-targetPrice) < epsilon {
-			break // Convergence achieved
-		}
-		// Update volatility using Newton-Raphson iteration
-		currentVolatility -= (price - targetPrice) / vega
+	result, err := ImpliedVolatility(option)
+	if err != nil {
+		return math.NaN()
 	}
-	return currentVolatility
+	return result.Volatility
 }
 
-// BlackScholesOptionPrice calculates the Black-Scholes option price
+// BlackScholesOptionPrice calculates the Black-Scholes option price. At expiration (zero days) the price is the
+// intrinsic value; with negative days to expiration every price and Greek in this package is NaN
 // underlyingAssetPrice: the underlying asset price
 // strikePrice: the strike price
 // timeToExpirationInDays: the time to expiration in days
 // volatility: the volatility
 // riskFreeInterestRate: the risk-free interest rate
 // optionType: the type of the option ("call" or "put")
+// dividendYield: the continuous dividend yield (Merton 1973)
+// dividends: the discrete dividends, subtracted from the underlying price at their present value (escrowed dividend model);
+// the price is NaN when they exceed the underlying price
 func BlackScholesOptionPrice(option Option, volatility float64) float64 {
+	option = escrowed(option)
 	timeToExpiration := option.DaysToExpiration / 365.0 // convert days to years
-	d1 := (math.Log(option.UnderlyingPrice/option.Strike) + (option.RiskFreeRate+0.5*math.Pow(volatility, 2))*timeToExpiration) / (volatility * math.Sqrt(timeToExpiration))
-	d2 := d1 - volatility*math.Sqrt(timeToExpiration)
-	if option.OptionType == Call {
-		return option.UnderlyingPrice*Phi(d1) - option.Strike*math.Exp(-option.RiskFreeRate*timeToExpiration)*Phi(d2)
+	forward := ForwardPrice(option.UnderlyingPrice, option.RiskFreeRate, option.DividendYield, timeToExpiration)
+	return BlackScholesForwardPrice(forward, option.Strike, timeToExpiration, option.RiskFreeRate, volatility, option.OptionType)
+}
+
+// ForwardPrice computes the forward price of an asset with a continuous dividend yield (or, for currencies,
+// a foreign interest rate): spot·e^{(rate − dividendYield)·timeYears}
+// spot: the spot price
+// rate: the risk-free interest rate
+// dividendYield: the continuous dividend yield
+// timeYears: the time to delivery in years
+func ForwardPrice(spot, rate, dividendYield, timeYears float64) float64 {
+	return spot * math.Exp((rate-dividendYield)*timeYears)
+}
+
+// BlackScholesForwardPrice calculates the Black-Scholes option price from the forward price of the underlying
+// rather than its spot, which is the natural input when pricing off a forward curve
+// forward: the forward price of the underlying for delivery at expiration
+// strike: the strike price
+// timeYears: the time to expiration in years
+// rate: the risk-free interest rate, used to discount the payoff
+// vol: the volatility
+// typ: the type of the option
+func BlackScholesForwardPrice(forward, strike, timeYears, rate, vol float64, typ OptionType) float64 {
+	d1, d2 := forwardD1D2(forward, strike, timeYears, vol)
+	discount := math.Exp(-rate * timeYears)
+	if typ == Call {
+		return discount * (forward*Phi(d1) - strike*Phi(d2))
 	}
-	return option.Strike*math.Exp(-option.RiskFreeRate*timeToExpiration)*Phi(-d2) - option.UnderlyingPrice*Phi(-d1)
+	return discount * (strike*PhiC(d2) - forward*PhiC(d1))
 }
 
-// Phi calculates the cumulative distribution function of the standard normal distribution
+// Phi calculates the cumulative distribution function of the standard normal distribution, through the
+// complementary error function so that it keeps its full relative precision in the lower tail
+// x: the input value
 func Phi(x float64) float64 {
-	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// PhiC calculates the complementary cumulative distribution function of the standard normal distribution, 1 − Phi(x)
+// or Phi(−x), with full relative precision in the upper tail, where the probability is tiny
+// x: the input value
+func PhiC(x float64) float64 {
+	return 0.5 * math.Erfc(x/math.Sqrt2)
+}
+
+// PhiInv calculates the inverse of the cumulative distribution function of the standard normal distribution, the
+// quantile of a probability, by Wichura's algorithm AS241 (1988), whose rational approximations are accurate to about
+// 1e-16 relative. −Inf at 0, +Inf at 1, and NaN for a probability outside [0, 1]
+// p: the probability
+func PhiInv(p float64) float64 {
+	switch {
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	case !(p > 0 && p < 1):
+		return math.NaN()
+	}
+
+	q := p - 0.5
+	if math.Abs(q) <= 0.425 {
+		r := 0.180625 - q*q
+		return q * (((((((2.5090809287301226727e+3*r+3.3430575583588128105e+4)*r+6.7265770927008700853e+4)*r+4.5921953931549871457e+4)*r+1.3731693765509461125e+4)*r+1.9715909503065514427e+3)*r+1.3314166789178437745e+2)*r + 3.3871328727963666080e0) /
+			(((((((5.2264952788528545610e+3*r+2.8729085735721942674e+4)*r+3.9307895800092710610e+4)*r+2.1213794301586595867e+4)*r+5.3941960214247511077e+3)*r+6.8718700749205790830e+2)*r+4.2313330701600911252e+1)*r + 1)
+	}
+
+	// the tails, in the square root of minus the log of the smaller of the probability and its complement
+	r := math.Sqrt(-math.Log(math.Min(p, 1-p)))
+	var x float64
+	if r <= 5 {
+		r -= 1.6
+		x = (((((((7.74545014278341407640e-4*r+2.27238449892691845833e-2)*r+2.41780725177450611770e-1)*r+1.27045825245236838258e0)*r+3.64784832476320460504e0)*r+5.76949722146069140550e0)*r+4.63033784615654529590e0)*r + 1.42343711074968357734e0) /
+			(((((((1.05075007164441684324e-9*r+5.47593808499534494600e-4)*r+1.51986665636164571966e-2)*r+1.48103976427480074590e-1)*r+6.89767334985100004550e-1)*r+1.67638483018380384940e0)*r+2.05319162663775882187e0)*r + 1)
+	} else {
+		r -= 5
+		x = (((((((2.01033439929228813265e-7*r+2.71155556874348757815e-5)*r+1.24266094738807843860e-3)*r+2.65321895265761230930e-2)*r+2.96560571828504891230e-1)*r+1.78482653991729133580e0)*r+5.46378491116411436990e0)*r + 6.65790464350110377720e0) /
+			(((((((2.04426310338993978564e-15*r+1.42151175831644588870e-7)*r+1.84631831751005468180e-5)*r+7.86869131145613259100e-4)*r+1.48753612908506148525e-2)*r+1.36929880922735805310e-1)*r+5.99832206555887937690e-1)*r + 1)
+	}
+	if q < 0 {
+		return -x
+	}
+	return x
 }
 
 // BlackScholesVega calculates the vega of Black-Scholes option price
 // option: the option
 // volatility: the volatility
 func BlackScholesVega(option Option, volatility float64) float64 {
+	option = escrowed(option)
 	timeToExpiration := option.DaysToExpiration / 365.0
-	d1 := (math.Log(option.UnderlyingPrice/option.Strike) + (option.RiskFreeRate+0.5*math.Pow(volatility, 2))*timeToExpiration) / (volatility * math.Sqrt(timeToExpiration))
-	return option.UnderlyingPrice * math.Sqrt(timeToExpiration) * math.Exp(-0.5*d1*d1) / math.Sqrt(2*math.Pi)
+	d1, _ := blackScholesD1D2(option, volatility)
+	return option.UnderlyingPrice * math.Exp(-option.DividendYield*timeToExpiration) * math.Sqrt(timeToExpiration) * math.Exp(-0.5*d1*d1) / math.Sqrt(2*math.Pi)
 }
 
-// BlackScholesGamma computes the gamma of an option
+// BlackScholesGamma computes the gamma of an option. Gamma is 0 at expiration, although it grows without bound
+// for at-the-money options as expiration approaches
 // option: the option
 func BlackScholesGamma(option Option, vol float64) float64 {
-	d1 := (math.Log(option.UnderlyingPrice/option.Strike) + (option.RiskFreeRate+0.5*math.Pow(vol, 2))*(option.DaysToExpiration/365.0)) / (vol * math.Sqrt(option.DaysToExpiration/365.0))
-	return NormalDistributionDerivative(d1) / (option.UnderlyingPrice * vol * math.Sqrt(option.DaysToExpiration/365.0))
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	d1, _ := blackScholesD1D2(option, vol)
+	return math.Exp(-option.DividendYield*option.DaysToExpiration/365.0) * NormalDistributionDerivative(d1) / (option.UnderlyingPrice * vol * math.Sqrt(option.DaysToExpiration/365.0))
 }
 
 // NormalDistributionDerivative calculates the derivative of the standard normal cumulative distribution function
@@ -84,16 +159,322 @@ func NormalDistributionDerivative(x float64) float64 {
 	return math.Exp(-0.5*math.Pow(x, 2)) / math.Sqrt(2*math.Pi)
 }
 
-// BlackScholesDelta computes the delta of an option
+// BlackScholesDelta computes the delta of an option. At expiration delta is 1 (-1 for puts) in the money and 0 otherwise,
+// including exactly at the money where the option expires worthless
 // option: the option
 // volatility: the volatility
 func BlackScholesDelta(option Option, volatility float64) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration == 0 {
+		return expirationDelta(option)
+	}
 	timeToExpiration := option.DaysToExpiration / 365.0
-	d1 := (math.Log(option.UnderlyingPrice/option.Strike) + (option.RiskFreeRate+volatility*volatility/2)*timeToExpiration) / (volatility * math.Sqrt(timeToExpiration))
+	d1, _ := blackScholesD1D2(option, volatility)
+	dividendDiscount := math.Exp(-option.DividendYield * timeToExpiration)
 
 	if option.OptionType == Call {
-		return Phi(d1)
+		return dividendDiscount * Phi(d1)
 	} else {
-		return Phi(d1) - 1
+		return dividendDiscount * (Phi(d1) - 1)
+	}
+}
+
+// BlackScholesTheta computes the theta of an option, expressed per year. With discrete dividends
+// the escrowed underlying price is held fixed. Theta is 0 at expiration
+// option: the option
+// volatility: the volatility
+func BlackScholesTheta(option Option, volatility float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, d2 := blackScholesD1D2(option, volatility)
+	discountedSpot := option.UnderlyingPrice * math.Exp(-option.DividendYield*timeToExpiration)
+	decay := -discountedSpot * NormalDistributionDerivative(d1) * volatility / (2 * math.Sqrt(timeToExpiration))
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+
+	if option.OptionType == Call {
+		return decay - option.RiskFreeRate*discountedStrike*Phi(d2) + option.DividendYield*discountedSpot*Phi(d1)
+	}
+	return decay + option.RiskFreeRate*discountedStrike*PhiC(d2) - option.DividendYield*discountedSpot*PhiC(d1)
+}
+
+// BlackScholesThetaPerDay computes the theta of an option, expressed per calendar day
+// option: the option
+// volatility: the volatility
+func BlackScholesThetaPerDay(option Option, volatility float64) float64 {
+	return BlackScholesTheta(option, volatility) / 365.0
+}
+
+// BlackScholesRho computes the rho of an option, expressed per unit (1.00) change in the risk-free rate
+// option: the option
+// volatility: the volatility
+func BlackScholesRho(option Option, volatility float64) float64 {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	_, d2 := blackScholesD1D2(option, volatility)
+	discountedStrike := option.Strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+
+	if option.OptionType == Call {
+		return discountedStrike * timeToExpiration * Phi(d2)
+	}
+	return -discountedStrike * timeToExpiration * PhiC(d2)
+}
+
+// BlackScholesRhoPerPercent computes the rho of an option, expressed per 1% (0.01) change in the risk-free rate
+// option: the option
+// volatility: the volatility
+func BlackScholesRhoPerPercent(option Option, volatility float64) float64 {
+	return BlackScholesRho(option, volatility) / 100.0
+}
+
+// blackScholesD1D2 computes the d1 and d2 terms of the Black-Scholes formula
+// option: the option
+// volatility: the volatility
+func blackScholesD1D2(option Option, volatility float64) (float64, float64) {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	forward := ForwardPrice(option.UnderlyingPrice, option.RiskFreeRate, option.DividendYield, timeToExpiration)
+	return forwardD1D2(forward, option.Strike, timeToExpiration, volatility)
+}
+
+// forwardD1D2 computes the d1 and d2 terms of the Black-Scholes formula from the forward price
+// forward: the forward price
+// strike: the strike price
+// timeYears: the time to expiration in years
+// vol: the volatility
+func forwardD1D2(forward, strike, timeYears, vol float64) (float64, float64) {
+	stdDev := vol * math.Sqrt(timeYears)
+	if stdDev == 0 {
+		// at expiration, or with no volatility, the option finishes in the money with certainty or not at all
+		d := math.Inf(1)
+		if forward < strike {
+			d = math.Inf(-1)
+		} else if forward == strike {
+			d = 0
+		}
+		return d, d
+	}
+	d1 := math.Log(forward/strike)/stdDev + 0.5*stdDev
+	return d1, d1 - stdDev
+}
+
+// BlackScholesVanna computes the vanna of an option, the sensitivity of delta to volatility (d²V/dS dσ).
+// Vanna is the same for calls and puts and changes sign where d2 crosses zero (close to the money).
+// As expiration approaches it stays bounded by roughly 0.24/vol but concentrates in a narrowing band around the strike
+// option: the option
+// vol: the volatility
+func BlackScholesVanna(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	d1, d2 := blackScholesD1D2(option, vol)
+	return -math.Exp(-option.DividendYield*option.DaysToExpiration/365.0) * NormalDistributionDerivative(d1) * d2 / vol
+}
+
+// BlackScholesVomma computes the vomma (volga) of an option, the sensitivity of vega to volatility (d²V/dσ²).
+// Vomma is the same for calls and puts
+// option: the option
+// vol: the volatility
+func BlackScholesVomma(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	d1, d2 := blackScholesD1D2(option, vol)
+	return BlackScholesVega(option, vol) * d1 * d2 / vol
+}
+
+// BlackScholesCharm computes the charm (delta decay) of an option, the rate at which delta changes
+// as time passes, expressed per year. A positive charm means delta increases as expiration approaches.
+// The analytic value grows without bound for near-the-money options as expiration approaches;
+// BlackScholesCharmPerDay should be preferred for options with less than a day remaining
+// option: the option
+// vol: the volatility
+func BlackScholesCharm(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	sqrtT := math.Sqrt(timeToExpiration)
+	d1, d2 := blackScholesD1D2(option, vol)
+	dividendDiscount := math.Exp(-option.DividendYield * timeToExpiration)
+	drift := -dividendDiscount * NormalDistributionDerivative(d1) * (2*(option.RiskFreeRate-option.DividendYield)*timeToExpiration - d2*vol*sqrtT) / (2 * timeToExpiration * vol * sqrtT)
+	// with no dividend yield, call and put charm are identical
+	if option.OptionType == Call {
+		return drift + option.DividendYield*dividendDiscount*Phi(d1)
+	}
+	return drift - option.DividendYield*dividendDiscount*PhiC(d1)
+}
+
+// BlackScholesCharmPerDay computes the charm of an option, expressed as the change in delta over one calendar day.
+// When less than one day remains, the change in delta between now and expiration is returned instead,
+// which stays bounded where the analytic value explodes
+// option: the option
+// vol: the volatility
+func BlackScholesCharmPerDay(option Option, vol float64) float64 {
+	if option.DaysToExpiration < 1 {
+		return expirationDelta(option) - BlackScholesDelta(option, vol)
+	}
+	return BlackScholesCharm(option, vol) / 365.0
+}
+
+// atExpiration reports whether an option has no time left, in which case Greeks of second or higher order
+// and time decay are undefined in the limit; value is then 0 at expiration and NaN for negative days to expiration
+// option: the option
+func atExpiration(option Option) (value float64, ok bool) {
+	switch {
+	case option.DaysToExpiration < 0:
+		return math.NaN(), true
+	case option.DaysToExpiration == 0:
+		return 0, true
+	}
+	return 0, false
+}
+
+// expirationDelta returns the delta of an option at expiration, which depends only on moneyness
+// option: the option
+func expirationDelta(option Option) float64 {
+	if option.OptionType == Call {
+		if option.UnderlyingPrice > option.Strike {
+			return 1
+		}
+		return 0
+	}
+	if option.UnderlyingPrice < option.Strike {
+		return -1
+	}
+	return 0
+}
+
+// BlackScholesSpeed computes the speed of an option, the sensitivity of gamma to the underlying price (dGamma/dS).
+// Speed is the same for calls and puts
+// option: the option
+// vol: the volatility
+func BlackScholesSpeed(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, _ := blackScholesD1D2(option, vol)
+	return -BlackScholesGamma(option, vol) / option.UnderlyingPrice * (d1/(vol*math.Sqrt(timeToExpiration)) + 1)
+}
+
+// BlackScholesZomma computes the zomma of an option, the sensitivity of gamma to volatility (dGamma/dσ).
+// Zomma is the same for calls and puts
+// option: the option
+// vol: the volatility
+func BlackScholesZomma(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	d1, d2 := blackScholesD1D2(option, vol)
+	return BlackScholesGamma(option, vol) * (d1*d2 - 1) / vol
+}
+
+// BlackScholesColor computes the color of an option, the rate at which gamma changes as time passes,
+// expressed per year with the same sign convention as BlackScholesCharm. Color is the same for calls and puts
+// option: the option
+// vol: the volatility
+func BlackScholesColor(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	volSqrtT := vol * math.Sqrt(timeToExpiration)
+	d1, d2 := blackScholesD1D2(option, vol)
+	return math.Exp(-option.DividendYield*timeToExpiration) * NormalDistributionDerivative(d1) / (2 * option.UnderlyingPrice * timeToExpiration * volSqrtT) *
+		(2*option.DividendYield*timeToExpiration + 1 + (2*(option.RiskFreeRate-option.DividendYield)*timeToExpiration-d2*volSqrtT)*d1/volSqrtT)
+}
+
+// lambdaMinPrice is the option price below which lambda is considered undefined
+const lambdaMinPrice = 1e-10
+
+// BlackScholesLambda computes the lambda (elasticity) of an option, the percentage change in option price
+// for a one percent change in the underlying price (delta * S / V), i.e. the effective leverage of the position.
+// Returns NaN when the option price is below 1e-10 (deep out of the money or about to expire worthless),
+// where the ratio is meaningless
+// option: the option
+// vol: the volatility
+func BlackScholesLambda(option Option, vol float64) float64 {
+	price := BlackScholesOptionPrice(option, vol)
+	if !(price >= lambdaMinPrice) {
+		return math.NaN()
 	}
+	return BlackScholesDelta(option, vol) * option.UnderlyingPrice / price
+}
+
+// BlackScholesDualDelta computes the dual delta of an option, the sensitivity of its price to the strike (dV/dK)
+// option: the option
+// vol: the volatility
+func BlackScholesDualDelta(option Option, vol float64) float64 {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	_, d2 := blackScholesD1D2(option, vol)
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+
+	if option.OptionType == Call {
+		return -discount * Phi(d2)
+	}
+	return discount * PhiC(d2)
+}
+
+// BlackScholesDualGamma computes the dual gamma of an option, the second derivative of its price
+// with respect to the strike (d²V/dK²). Dual gamma is the same for calls and puts
+// option: the option
+// vol: the volatility
+func BlackScholesDualGamma(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	_, d2 := blackScholesD1D2(option, vol)
+	return math.Exp(-option.RiskFreeRate*timeToExpiration) * NormalDistributionDerivative(d2) / (option.Strike * vol * math.Sqrt(timeToExpiration))
+}
+
+// BlackScholesEpsilon computes the epsilon (psi) of an option, the sensitivity of its price to the
+// continuous dividend yield (dV/dq), per 1.00 change in yield
+// option: the option
+// vol: the volatility
+func BlackScholesEpsilon(option Option, vol float64) float64 {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, _ := blackScholesD1D2(option, vol)
+	discountedSpot := option.UnderlyingPrice * math.Exp(-option.DividendYield*timeToExpiration)
+
+	if option.OptionType == Call {
+		return -timeToExpiration * discountedSpot * Phi(d1)
+	}
+	return timeToExpiration * discountedSpot * PhiC(d1)
+}
+
+// BlackScholesUltima computes the ultima of an option, the sensitivity of vomma to volatility (dVomma/dσ).
+// Ultima is the same for calls and puts
+// option: the option
+// vol: the volatility
+func BlackScholesUltima(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	d1, d2 := blackScholesD1D2(option, vol)
+	return -BlackScholesVega(option, vol) / (vol * vol) * (d1*d2*(1-d1*d2) + d1*d1 + d2*d2)
+}
+
+// BlackScholesVera computes the vera (rhova) of an option, the sensitivity of rho to volatility (dRho/dσ).
+// Vera is the same for calls and puts
+// option: the option
+// vol: the volatility
+func BlackScholesVera(option Option, vol float64) float64 {
+	if value, ok := atExpiration(option); ok {
+		return value
+	}
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	d1, d2 := blackScholesD1D2(option, vol)
+	return -option.Strike * timeToExpiration * math.Exp(-option.RiskFreeRate*timeToExpiration) * NormalDistributionDerivative(d2) * d1 / vol
 }
@@ -141,3 +141,676 @@ func TestBlackScholesDelta(t *testing.T) {
 		t.Errorf("Unexpected delta for put option: got %v, want %v", deltaPut, expectedDeltaPut)
 	}
 }
+
+func TestBlackScholesTheta(t *testing.T) {
+	option := Option{
+		Price:            10.0,
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	const tolerance = 0.00001
+
+	thetaCall := BlackScholesTheta(option, 0.2)
+	const expectedThetaCall = -16.420677
+	if diff := math.Abs(thetaCall - expectedThetaCall); diff > tolerance {
+		t.Errorf("Unexpected theta for call option: got %v, want %v", thetaCall, expectedThetaCall)
+	}
+
+	thetaCallPerDay := BlackScholesThetaPerDay(option, 0.2)
+	const expectedThetaCallPerDay = -0.04498816
+	if diff := math.Abs(thetaCallPerDay - expectedThetaCallPerDay); diff > tolerance {
+		t.Errorf("Unexpected per-day theta for call option: got %v, want %v", thetaCallPerDay, expectedThetaCallPerDay)
+	}
+
+	option.OptionType = Put
+	thetaPut := BlackScholesTheta(option, 0.2)
+	const expectedThetaPut = -11.441183
+	if diff := math.Abs(thetaPut - expectedThetaPut); diff > tolerance {
+		t.Errorf("Unexpected theta for put option: got %v, want %v", thetaPut, expectedThetaPut)
+	}
+
+	thetaPutPerDay := BlackScholesThetaPerDay(option, 0.2)
+	const expectedThetaPutPerDay = -0.03134571
+	if diff := math.Abs(thetaPutPerDay - expectedThetaPutPerDay); diff > tolerance {
+		t.Errorf("Unexpected per-day theta for put option: got %v, want %v", thetaPutPerDay, expectedThetaPutPerDay)
+	}
+}
+
+func TestBlackScholesRho(t *testing.T) {
+	option := Option{
+		Price:            10.0,
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	const tolerance = 0.00001
+
+	rhoCall := BlackScholesRho(option, 0.2)
+	const expectedRhoCall = 4.2331215
+	if diff := math.Abs(rhoCall - expectedRhoCall); diff > tolerance {
+		t.Errorf("Unexpected rho for call option: got %v, want %v", rhoCall, expectedRhoCall)
+	}
+
+	rhoCallPerPercent := BlackScholesRhoPerPercent(option, 0.2)
+	const expectedRhoCallPerPercent = 0.042331215
+	if diff := math.Abs(rhoCallPerPercent - expectedRhoCallPerPercent); diff > tolerance {
+		t.Errorf("Unexpected per-percent rho for call option: got %v, want %v", rhoCallPerPercent, expectedRhoCallPerPercent)
+	}
+
+	option.OptionType = Put
+	rhoPut := BlackScholesRho(option, 0.2)
+	const expectedRhoPut = -3.9523485
+	if diff := math.Abs(rhoPut - expectedRhoPut); diff > tolerance {
+		t.Errorf("Unexpected rho for put option: got %v, want %v", rhoPut, expectedRhoPut)
+	}
+
+	rhoPutPerPercent := BlackScholesRhoPerPercent(option, 0.2)
+	const expectedRhoPutPerPercent = -0.039523485
+	if diff := math.Abs(rhoPutPerPercent - expectedRhoPutPerPercent); diff > tolerance {
+		t.Errorf("Unexpected per-percent rho for put option: got %v, want %v", rhoPutPerPercent, expectedRhoPutPerPercent)
+	}
+}
+
+func TestBlackScholesVannaVomma(t *testing.T) {
+	tests := []struct {
+		underlying, strike, days, vol float64
+		expectedVanna, expectedVomma  float64
+	}{
+		{100.0, 100.0, 30.0, 0.2, -0.08534915, 0.24552494},
+		{100.0, 110.0, 90.0, 0.3, 0.74750340, 17.91155748},
+		{100.0, 90.0, 7.0, 0.25, -0.04138101, 1.76976190},
+	}
+
+	const tolerance = 0.00001
+
+	for _, tt := range tests {
+		option := Option{
+			Strike:           tt.strike,
+			DaysToExpiration: tt.days,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  tt.underlying,
+			OptionType:       Call,
+		}
+
+		vanna := BlackScholesVanna(option, tt.vol)
+		if diff := math.Abs(vanna - tt.expectedVanna); diff > tolerance {
+			t.Errorf("Unexpected vanna for strike %v, days %v, vol %v: got %v, want %v", tt.strike, tt.days, tt.vol, vanna, tt.expectedVanna)
+		}
+
+		vomma := BlackScholesVomma(option, tt.vol)
+		if diff := math.Abs(vomma - tt.expectedVomma); diff > tolerance {
+			t.Errorf("Unexpected vomma for strike %v, days %v, vol %v: got %v, want %v", tt.strike, tt.days, tt.vol, vomma, tt.expectedVomma)
+		}
+
+		option.OptionType = Put
+		if putVanna := BlackScholesVanna(option, tt.vol); putVanna != vanna {
+			t.Errorf("Vanna should not depend on option type: got %v for put, %v for call", putVanna, vanna)
+		}
+	}
+}
+
+func TestBlackScholesVannaSignFlip(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	// vanna changes sign where d2 = 0, just above the ATM strike
+	option.Strike = 98.0
+	below := BlackScholesVanna(option, 0.2)
+	option.Strike = 102.0
+	above := BlackScholesVanna(option, 0.2)
+
+	if below >= 0 || above <= 0 {
+		t.Errorf("Expected vanna to flip sign across the money: got %v below, %v above", below, above)
+	}
+}
+
+func TestBlackScholesVannaShortExpiration(t *testing.T) {
+	option := Option{
+		Strike:           101.0,
+		DaysToExpiration: 0.1,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	vanna := BlackScholesVanna(option, 0.2)
+	vomma := BlackScholesVomma(option, 0.2)
+	if math.IsNaN(vanna) || math.IsInf(vanna, 0) || math.IsNaN(vomma) || math.IsInf(vomma, 0) {
+		t.Errorf("Expected finite values for a short expiration: got vanna %v, vomma %v", vanna, vomma)
+	}
+
+	option.DaysToExpiration = 30.0
+	// away from the strike, vanna collapses as expiration approaches
+	if longer := BlackScholesVanna(option, 0.2); math.Abs(vanna) >= math.Abs(longer) {
+		t.Errorf("Expected vanna to concentrate near the strike as expiration approaches: got %v at 0.1 days, %v at 30 days", vanna, longer)
+	}
+}
+
+func TestBlackScholesCharm(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	const tolerance = 0.00001
+
+	charmCall := BlackScholesCharm(option, 0.2)
+	const expectedCharmCall = -0.24229674
+	if diff := math.Abs(charmCall - expectedCharmCall); diff > tolerance {
+		t.Errorf("Unexpected charm for call option: got %v, want %v", charmCall, expectedCharmCall)
+	}
+
+	charmCallPerDay := BlackScholesCharmPerDay(option, 0.2)
+	if diff := math.Abs(charmCallPerDay - expectedCharmCall/365.0); diff > tolerance {
+		t.Errorf("Unexpected per-day charm for call option: got %v, want %v", charmCallPerDay, expectedCharmCall/365.0)
+	}
+
+	option.Strike = 110.0
+	option.OptionType = Put
+	charmPut := BlackScholesCharm(option, 0.2)
+	const expectedCharmPut = -1.26317184
+	if diff := math.Abs(charmPut - expectedCharmPut); diff > tolerance {
+		t.Errorf("Unexpected charm for put option: got %v, want %v", charmPut, expectedCharmPut)
+	}
+}
+
+func TestBlackScholesCharmPerDayBelowOneDay(t *testing.T) {
+	option := Option{
+		Strike:           100.5,
+		DaysToExpiration: 0.5,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	const tolerance = 0.00001
+
+	// the OTM call's delta decays all the way to zero by expiration
+	charm := BlackScholesCharmPerDay(option, 0.2)
+	const expectedCharm = -0.25436206
+	if diff := math.Abs(charm - expectedCharm); diff > tolerance {
+		t.Errorf("Unexpected per-day charm below one day: got %v, want %v", charm, expectedCharm)
+	}
+
+	option.Strike = 100.0
+	option.DaysToExpiration = 0.01
+	charm = BlackScholesCharmPerDay(option, 0.2)
+	if math.IsNaN(charm) || math.Abs(charm) > 1 {
+		t.Errorf("Expected per-day charm to stay bounded near expiration: got %v", charm)
+	}
+}
+
+func TestBlackScholesThirdOrderGreeks(t *testing.T) {
+	const tolerance = 1e-6
+
+	for _, strike := range []float64{90.0, 100.0, 110.0} {
+		option := Option{
+			Strike:           strike,
+			DaysToExpiration: 30.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       Call,
+		}
+		const vol = 0.2
+
+		const spotBump = 0.01
+		up, down := option, option
+		up.UnderlyingPrice += spotBump
+		down.UnderlyingPrice -= spotBump
+		expectedSpeed := (BlackScholesGamma(up, vol) - BlackScholesGamma(down, vol)) / (2 * spotBump)
+		if speed := BlackScholesSpeed(option, vol); math.Abs(speed-expectedSpeed) > tolerance {
+			t.Errorf("Unexpected speed for strike %v: got %v, want %v", strike, speed, expectedSpeed)
+		}
+
+		const volBump = 0.0001
+		expectedZomma := (BlackScholesGamma(option, vol+volBump) - BlackScholesGamma(option, vol-volBump)) / (2 * volBump)
+		if zomma := BlackScholesZomma(option, vol); math.Abs(zomma-expectedZomma) > tolerance {
+			t.Errorf("Unexpected zomma for strike %v: got %v, want %v", strike, zomma, expectedZomma)
+		}
+
+		// color is measured as time passes, i.e. as days to expiration shrink
+		const dayBump = 0.01
+		later, earlier := option, option
+		later.DaysToExpiration -= dayBump
+		earlier.DaysToExpiration += dayBump
+		expectedColor := (BlackScholesGamma(later, vol) - BlackScholesGamma(earlier, vol)) / (2 * dayBump / 365.0)
+		if color := BlackScholesColor(option, vol); math.Abs(color-expectedColor) > tolerance {
+			t.Errorf("Unexpected color for strike %v: got %v, want %v", strike, color, expectedColor)
+		}
+	}
+}
+
+func TestBlackScholesLambda(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	const tolerance = 0.00001
+
+	lambdaATM := BlackScholesLambda(option, 0.2)
+	const expectedLambdaATM = 21.655914
+	if diff := math.Abs(lambdaATM - expectedLambdaATM); diff > tolerance {
+		t.Errorf("Unexpected lambda for ATM call: got %v, want %v", lambdaATM, expectedLambdaATM)
+	}
+
+	// 10-delta call
+	option.Strike = 108.2459003
+	lambdaOTM := BlackScholesLambda(option, 0.2)
+	const expectedLambdaOTM = 37.717488
+	if diff := math.Abs(lambdaOTM - expectedLambdaOTM); diff > tolerance {
+		t.Errorf("Unexpected lambda for 10-delta call: got %v, want %v", lambdaOTM, expectedLambdaOTM)
+	}
+
+	if lambdaOTM <= lambdaATM {
+		t.Errorf("Expected the 10-delta call to carry more leverage than the ATM call: got %v vs %v", lambdaOTM, lambdaATM)
+	}
+
+	option.OptionType = Put
+	if lambdaPut := BlackScholesLambda(option, 0.2); lambdaPut >= 0 {
+		t.Errorf("Expected negative lambda for put option: got %v", lambdaPut)
+	}
+}
+
+func TestBlackScholesLambdaWorthlessOption(t *testing.T) {
+	option := Option{
+		Strike:           200.0,
+		DaysToExpiration: 1.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	if lambda := BlackScholesLambda(option, 0.2); !math.IsNaN(lambda) {
+		t.Errorf("Expected NaN lambda for a worthless option: got %v", lambda)
+	}
+}
+
+func TestBlackScholesDualDeltaGamma(t *testing.T) {
+	const tolerance = 1e-6
+	const strikeBump = 0.01
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 30.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+			}
+			const vol = 0.2
+
+			up, down := option, option
+			up.Strike += strikeBump
+			down.Strike -= strikeBump
+
+			expectedDualDelta := (BlackScholesOptionPrice(up, vol) - BlackScholesOptionPrice(down, vol)) / (2 * strikeBump)
+			if dualDelta := BlackScholesDualDelta(option, vol); math.Abs(dualDelta-expectedDualDelta) > tolerance {
+				t.Errorf("Unexpected dual delta for type %v strike %v: got %v, want %v", optionType, strike, dualDelta, expectedDualDelta)
+			}
+
+			expectedDualGamma := (BlackScholesOptionPrice(up, vol) - 2*BlackScholesOptionPrice(option, vol) + BlackScholesOptionPrice(down, vol)) / (strikeBump * strikeBump)
+			if dualGamma := BlackScholesDualGamma(option, vol); math.Abs(dualGamma-expectedDualGamma) > 1e-4 {
+				t.Errorf("Unexpected dual gamma for type %v strike %v: got %v, want %v", optionType, strike, dualGamma, expectedDualGamma)
+			}
+		}
+	}
+}
+
+func TestBlackScholesEpsilon(t *testing.T) {
+	const tolerance = 1e-5
+	const yieldBump = 0.0001
+
+	for _, optionType := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 90.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       optionType,
+		}
+		const vol = 0.2
+		timeToExpiration := option.DaysToExpiration / 365.0
+
+		// a continuous yield q prices like a dividend-free underlying at S*e^{-qT}
+		up, down := option, option
+		up.UnderlyingPrice *= math.Exp(-yieldBump * timeToExpiration)
+		down.UnderlyingPrice *= math.Exp(yieldBump * timeToExpiration)
+
+		expectedEpsilon := (BlackScholesOptionPrice(up, vol) - BlackScholesOptionPrice(down, vol)) / (2 * yieldBump)
+		if epsilon := BlackScholesEpsilon(option, vol); math.Abs(epsilon-expectedEpsilon) > tolerance {
+			t.Errorf("Unexpected epsilon for type %v: got %v, want %v", optionType, epsilon, expectedEpsilon)
+		}
+	}
+}
+
+func TestBlackScholesUltimaVera(t *testing.T) {
+	const tolerance = 1e-5
+	const volBump = 0.0001
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 60.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+			}
+			const vol = 0.25
+
+			expectedUltima := (BlackScholesVomma(option, vol+volBump) - BlackScholesVomma(option, vol-volBump)) / (2 * volBump)
+			if ultima := BlackScholesUltima(option, vol); math.Abs(ultima-expectedUltima) > tolerance*math.Max(1, math.Abs(expectedUltima)) {
+				t.Errorf("Unexpected ultima for type %v strike %v: got %v, want %v", optionType, strike, ultima, expectedUltima)
+			}
+
+			expectedVera := (BlackScholesRho(option, vol+volBump) - BlackScholesRho(option, vol-volBump)) / (2 * volBump)
+			if vera := BlackScholesVera(option, vol); math.Abs(vera-expectedVera) > tolerance {
+				t.Errorf("Unexpected vera for type %v strike %v: got %v, want %v", optionType, strike, vera, expectedVera)
+			}
+		}
+	}
+}
+
+func TestBlackScholesDividendYield(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.02,
+	}
+
+	const tolerance = 0.00001
+
+	tests := []struct {
+		optionType                            OptionType
+		price, delta, gamma, vega, theta, rho float64
+	}{
+		{Call, 2.4056237, 0.5277007, 0.0692846, 11.389243, -15.319733, 4.1395433},
+		{Put, 2.1597566, -0.4706568, 0.0692846, 11.389243, -12.336954, -4.0459267},
+	}
+
+	for _, tt := range tests {
+		option.OptionType = tt.optionType
+		checks := []struct {
+			name      string
+			got, want float64
+		}{
+			{"price", BlackScholesOptionPrice(option, 0.2), tt.price},
+			{"delta", BlackScholesDelta(option, 0.2), tt.delta},
+			{"gamma", BlackScholesGamma(option, 0.2), tt.gamma},
+			{"vega", BlackScholesVega(option, 0.2), tt.vega},
+			{"theta", BlackScholesTheta(option, 0.2), tt.theta},
+			{"rho", BlackScholesRho(option, 0.2), tt.rho},
+		}
+		for _, c := range checks {
+			if diff := math.Abs(c.got - c.want); diff > tolerance {
+				t.Errorf("Unexpected %s with dividend yield for type %v: got %v, want %v", c.name, tt.optionType, c.got, c.want)
+			}
+		}
+	}
+}
+
+func TestBlackScholesDividendYieldSensitivities(t *testing.T) {
+	const tolerance = 1e-5
+	const vol = 0.25
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{90.0, 100.0, 110.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 90.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.03,
+			}
+
+			const yieldBump = 0.0001
+			up, down := option, option
+			up.DividendYield += yieldBump
+			down.DividendYield -= yieldBump
+			expectedEpsilon := (BlackScholesOptionPrice(up, vol) - BlackScholesOptionPrice(down, vol)) / (2 * yieldBump)
+			if epsilon := BlackScholesEpsilon(option, vol); math.Abs(epsilon-expectedEpsilon) > tolerance {
+				t.Errorf("Unexpected epsilon for type %v strike %v: got %v, want %v", optionType, strike, epsilon, expectedEpsilon)
+			}
+
+			// charm and color are measured as time passes, i.e. as days to expiration shrink
+			const dayBump = 0.01
+			later, earlier := option, option
+			later.DaysToExpiration -= dayBump
+			earlier.DaysToExpiration += dayBump
+			expectedCharm := (BlackScholesDelta(later, vol) - BlackScholesDelta(earlier, vol)) / (2 * dayBump / 365.0)
+			if charm := BlackScholesCharm(option, vol); math.Abs(charm-expectedCharm) > tolerance {
+				t.Errorf("Unexpected charm for type %v strike %v: got %v, want %v", optionType, strike, charm, expectedCharm)
+			}
+			expectedColor := (BlackScholesGamma(later, vol) - BlackScholesGamma(earlier, vol)) / (2 * dayBump / 365.0)
+			if color := BlackScholesColor(option, vol); math.Abs(color-expectedColor) > tolerance {
+				t.Errorf("Unexpected color for type %v strike %v: got %v, want %v", optionType, strike, color, expectedColor)
+			}
+
+			const spotBump = 0.01
+			spotUp, spotDown := option, option
+			spotUp.UnderlyingPrice += spotBump
+			spotDown.UnderlyingPrice -= spotBump
+			expectedVanna := (BlackScholesVega(spotUp, vol) - BlackScholesVega(spotDown, vol)) / (2 * spotBump)
+			if vanna := BlackScholesVanna(option, vol); math.Abs(vanna-expectedVanna) > tolerance {
+				t.Errorf("Unexpected vanna for type %v strike %v: got %v, want %v", optionType, strike, vanna, expectedVanna)
+			}
+			expectedSpeed := (BlackScholesGamma(spotUp, vol) - BlackScholesGamma(spotDown, vol)) / (2 * spotBump)
+			if speed := BlackScholesSpeed(option, vol); math.Abs(speed-expectedSpeed) > tolerance {
+				t.Errorf("Unexpected speed for type %v strike %v: got %v, want %v", optionType, strike, speed, expectedSpeed)
+			}
+		}
+	}
+}
+
+func TestForwardPrice(t *testing.T) {
+	const tolerance = 1e-12
+
+	const expected = 102.27550341644461
+	if forward := ForwardPrice(100.0, 0.05, 0.02, 0.75); math.Abs(forward-expected) > tolerance {
+		t.Errorf("Unexpected forward price: got %v, want %v", forward, expected)
+	}
+}
+
+func TestBlackScholesForwardPrice(t *testing.T) {
+	const tolerance = 1e-12
+
+	// Hull, Options, Futures, and Other Derivatives: European put on a futures at 20, strike 20, 4 months, r = 9%, vol = 25%
+	const expectedPut = 1.1166414565589438
+	if price := BlackScholesForwardPrice(20.0, 20.0, 4.0/12.0, 0.09, 0.25, Put); math.Abs(price-expectedPut) > tolerance {
+		t.Errorf("Unexpected price for put option: got %v, want %v", price, expectedPut)
+	}
+
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{80.0, 100.0, 120.0} {
+			option := Option{
+				Strike:           strike,
+				DaysToExpiration: 200.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.02,
+			}
+			timeYears := option.DaysToExpiration / 365.0
+			forward := ForwardPrice(option.UnderlyingPrice, option.RiskFreeRate, option.DividendYield, timeYears)
+
+			got := BlackScholesForwardPrice(forward, strike, timeYears, option.RiskFreeRate, 0.3, optionType)
+			if want := BlackScholesOptionPrice(option, 0.3); math.Abs(got-want) > tolerance {
+				t.Errorf("Unexpected price for type %v strike %v: got %v, want %v", optionType, strike, got, want)
+			}
+		}
+	}
+}
+
+func TestPhiTails(t *testing.T) {
+	// reference values from the continued fraction of the Mills ratio at 40 digits
+	for _, tc := range []struct{ x, want float64 }{
+		{-10, 7.619853024160526065973343251599308e-24},
+		{-20, 2.753624118606233695075622780857465e-89},
+		{-37, 5.725571222524576822683192548273202e-300},
+	} {
+		// the rounding of x/√2 moves the error function's argument, and its value by about x²·1e-16 relative
+		tolerance := 4e-16 * tc.x * tc.x
+		if got := Phi(tc.x); math.Abs(got/tc.want-1) > tolerance {
+			t.Errorf("Unexpected Phi(%v): got %v, want %v", tc.x, got, tc.want)
+		}
+		if got := PhiC(-tc.x); math.Abs(got/tc.want-1) > tolerance {
+			t.Errorf("Unexpected PhiC(%v): got %v, want %v", -tc.x, got, tc.want)
+		}
+	}
+	for _, x := range []float64{-3, -0.5, 0, 0.5, 3} {
+		if got := Phi(x) + PhiC(x); math.Abs(got-1) > 1e-15 {
+			t.Errorf("Unexpected Phi(%v) + PhiC(%v): got %v, want 1", x, x, got)
+		}
+	}
+}
+
+func TestPhiInv(t *testing.T) {
+	// published quantiles of the standard normal distribution
+	for _, tc := range []struct{ p, want float64 }{
+		{0.5, 0},
+		{0.75, 0.6744897501960817},
+		{0.9, 1.2815515655446004},
+		{0.975, 1.959963984540054},
+		{0.995, 2.5758293035489004},
+		{0.001, -3.090232306167813},
+		{1e-10, -6.361340902404056},
+	} {
+		if got := PhiInv(tc.p); math.Abs(got-tc.want) > 1e-15*math.Max(1, math.Abs(tc.want)) {
+			t.Errorf("Unexpected quantile of %v: got %v, want %v", tc.p, got, tc.want)
+		}
+	}
+
+	// the rounding of Phi(x), about 1e-16, moves the quantile by 1e-16/n(x), a lot in the upper tail
+	for x := -8.0; x <= 8.0; x += 0.05 {
+		tolerance := 1e-14*math.Max(1, math.Abs(x)) + 2e-16/NormalDistributionDerivative(x)
+		if got := PhiInv(Phi(x)); math.Abs(got-x) > tolerance {
+			t.Errorf("Unexpected round trip of %v: got %v", x, got)
+		}
+	}
+	// deep in the lower tail, where Phi keeps its relative precision
+	for _, x := range []float64{-10, -20, -37} {
+		if got := PhiInv(Phi(x)); math.Abs(got/x-1) > 1e-14 {
+			t.Errorf("Unexpected round trip of %v: got %v", x, got)
+		}
+	}
+
+	for _, tc := range []struct{ p, want float64 }{
+		{0, math.Inf(-1)},
+		{1, math.Inf(1)},
+	} {
+		if got := PhiInv(tc.p); got != tc.want {
+			t.Errorf("Unexpected quantile of %v: got %v, want %v", tc.p, got, tc.want)
+		}
+	}
+	for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+		if got := PhiInv(p); !math.IsNaN(got) {
+			t.Errorf("Unexpected quantile of %v: got %v, want NaN", p, got)
+		}
+	}
+}
+
+func TestBlackScholesAtExpiration(t *testing.T) {
+	tests := []struct {
+		optionType   OptionType
+		spot         float64
+		price, delta float64
+		description  string
+	}{
+		{Call, 110.0, 10.0, 1.0, "in the money"},
+		{Call, 100.0, 0.0, 0.0, "at the money"},
+		{Call, 90.0, 0.0, 0.0, "out of the money"},
+		{Put, 90.0, 10.0, -1.0, "in the money"},
+		{Put, 100.0, 0.0, 0.0, "at the money"},
+		{Put, 110.0, 0.0, 0.0, "out of the money"},
+	}
+
+	for _, test := range tests {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 0.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  test.spot,
+			OptionType:       test.optionType,
+			DividendYield:    0.02,
+		}
+
+		checks := []struct {
+			name      string
+			got, want float64
+		}{
+			{"price", BlackScholesOptionPrice(option, 0.2), test.price},
+			{"delta", BlackScholesDelta(option, 0.2), test.delta},
+			{"gamma", BlackScholesGamma(option, 0.2), 0},
+			{"vega", BlackScholesVega(option, 0.2), 0},
+			{"theta", BlackScholesTheta(option, 0.2), 0},
+			{"rho", BlackScholesRho(option, 0.2), 0},
+			{"vanna", BlackScholesVanna(option, 0.2), 0},
+			{"vomma", BlackScholesVomma(option, 0.2), 0},
+			{"charm", BlackScholesCharm(option, 0.2), 0},
+			{"speed", BlackScholesSpeed(option, 0.2), 0},
+			{"zomma", BlackScholesZomma(option, 0.2), 0},
+			{"color", BlackScholesColor(option, 0.2), 0},
+			{"ultima", BlackScholesUltima(option, 0.2), 0},
+			{"vera", BlackScholesVera(option, 0.2), 0},
+			{"dual gamma", BlackScholesDualGamma(option, 0.2), 0},
+		}
+		for _, c := range checks {
+			if c.got != c.want {
+				t.Errorf("Unexpected %s for %v type %v: got %v, want %v", c.name, test.description, test.optionType, c.got, c.want)
+			}
+		}
+
+		greeks := BlackScholesGreeks(option, 0.2)
+		if want := (Greeks{Price: test.price, Delta: test.delta}); greeks != want {
+			t.Errorf("Unexpected Greeks for %v type %v: got %+v, want %+v", test.description, test.optionType, greeks, want)
+		}
+	}
+}
+
+func TestBlackScholesNegativeExpiration(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: -1.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	values := map[string]float64{
+		"price": BlackScholesOptionPrice(option, 0.2),
+		"delta": BlackScholesDelta(option, 0.2),
+		"gamma": BlackScholesGamma(option, 0.2),
+		"vega":  BlackScholesVega(option, 0.2),
+		"theta": BlackScholesTheta(option, 0.2),
+		"rho":   BlackScholesRho(option, 0.2),
+	}
+	for name, value := range values {
+		if !math.IsNaN(value) {
+			t.Errorf("Unexpected %s: got %v, want NaN", name, value)
+		}
+	}
+}
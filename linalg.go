@@ -0,0 +1,134 @@
+package finance
+
+import (
+	"math"
+)
+
+// jacobiMaxSweeps bounds the sweeps of the Jacobi eigenvalue iteration, which converges quadratically and needs
+// fewer than ten for the small matrices it is used on
+const jacobiMaxSweeps = 100
+
+// symmetricEigenvalues computes the eigenvalues of a symmetric matrix by the cyclic Jacobi method, rotating away
+// each off-diagonal entry in turn until they are negligible next to the matrix. The matrix is not modified
+// matrix: the symmetric matrix
+func symmetricEigenvalues(matrix [][]float64) []float64 {
+	n := len(matrix)
+	a := make([][]float64, n)
+	var norm float64
+	for i, row := range matrix {
+		a[i] = append([]float64(nil), row...)
+		for _, x := range row {
+			norm += x * x
+		}
+	}
+
+	for sweep := 0; sweep < jacobiMaxSweeps; sweep++ {
+		var off float64
+		for i := range n {
+			for j := i + 1; j < n; j++ {
+				off += a[i][j] * a[i][j]
+			}
+		}
+		if off <= 1e-30*norm {
+			break
+		}
+		for p := range n {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				// the rotation by θ with cot 2θ = (a_qq − a_pp)/2a_pq zeroes a_pq
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := range n {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := range n {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+			}
+		}
+	}
+
+	eigenvalues := make([]float64, n)
+	for i := range n {
+		eigenvalues[i] = a[i][i]
+	}
+	return eigenvalues
+}
+
+// cholesky computes the lower triangular factor L of a positive semi-definite matrix A = L·Lᵀ. A pivot that is zero
+// to rounding, from a singular matrix, leaves its column of L zero, so that the factor still reproduces the matrix
+// matrix: the symmetric positive semi-definite matrix
+func cholesky(matrix [][]float64) [][]float64 {
+	n := len(matrix)
+	lower := make([][]float64, n)
+	for i := range lower {
+		lower[i] = make([]float64, n)
+	}
+	for j := range n {
+		pivot := matrix[j][j]
+		for k := range j {
+			pivot -= lower[j][k] * lower[j][k]
+		}
+		if pivot <= 1e-12*math.Abs(matrix[j][j]) {
+			continue
+		}
+		lower[j][j] = math.Sqrt(pivot)
+		for i := j + 1; i < n; i++ {
+			sum := matrix[i][j]
+			for k := range j {
+				sum -= lower[i][k] * lower[j][k]
+			}
+			lower[i][j] = sum / lower[j][j]
+		}
+	}
+	return lower
+}
+
+// solveLinear solves the linear system A·x = b by Gaussian elimination with partial pivoting. Reports false if the
+// matrix is singular to rounding. Neither argument is modified
+// matrix: the square matrix A
+// rhs: the right-hand side b
+func solveLinear(matrix [][]float64, rhs []float64) ([]float64, bool) {
+	n := len(matrix)
+	a := make([][]float64, n)
+	var norm float64
+	for i, row := range matrix {
+		a[i] = append(append(make([]float64, 0, n+1), row...), rhs[i])
+		for _, x := range row {
+			norm = math.Max(norm, math.Abs(x))
+		}
+	}
+	for j := range n {
+		pivot := j
+		for i := j + 1; i < n; i++ {
+			if math.Abs(a[i][j]) > math.Abs(a[pivot][j]) {
+				pivot = i
+			}
+		}
+		if !(math.Abs(a[pivot][j]) > 1e-14*norm) {
+			return nil, false
+		}
+		a[j], a[pivot] = a[pivot], a[j]
+		for i := j + 1; i < n; i++ {
+			factor := a[i][j] / a[j][j]
+			for k := j; k <= n; k++ {
+				a[i][k] -= factor * a[j][k]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := a[i][n]
+		for k := i + 1; k < n; k++ {
+			sum -= a[i][k] * x[k]
+		}
+		x[i] = sum / a[i][i]
+	}
+	return x, true
+}
@@ -0,0 +1,70 @@
+package finance
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSymmetricEigenvalues(t *testing.T) {
+	// the eigenvalues of the matrix with unit diagonal and ρ elsewhere are 1 + (n − 1)ρ and n − 1 times 1 − ρ
+	matrix := [][]float64{
+		{1, 0.3, 0.3, 0.3},
+		{0.3, 1, 0.3, 0.3},
+		{0.3, 0.3, 1, 0.3},
+		{0.3, 0.3, 0.3, 1},
+	}
+	got := symmetricEigenvalues(matrix)
+	slices.Sort(got)
+	for i, want := range []float64{0.7, 0.7, 0.7, 1.9} {
+		if math.Abs(got[i]-want) > 1e-14 {
+			t.Errorf("Unexpected eigenvalue %d: got %v, want %v", i, got[i], want)
+		}
+	}
+	if matrix[0][1] != 0.3 {
+		t.Errorf("Unexpected modification of the matrix: got %v, want 0.3", matrix[0][1])
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	for name, matrix := range map[string][][]float64{
+		"definite": {{4, 2, 0.4}, {2, 2, 0.5}, {0.4, 0.5, 1}},
+		"singular": {{1, 1, 0.5}, {1, 1, 0.5}, {0.5, 0.5, 1}},
+	} {
+		lower := cholesky(matrix)
+		for i := range matrix {
+			for j := range matrix {
+				var product float64
+				for k := range matrix {
+					product += lower[i][k] * lower[j][k]
+				}
+				if math.Abs(product-matrix[i][j]) > 1e-14 {
+					t.Errorf("Unexpected %s product at (%d, %d): got %v, want %v", name, i, j, product, matrix[i][j])
+				}
+				if j > i && lower[i][j] != 0 {
+					t.Errorf("Unexpected %s entry above the diagonal at (%d, %d): got %v", name, i, j, lower[i][j])
+				}
+			}
+		}
+	}
+}
+
+func TestSolveLinear(t *testing.T) {
+	// the first pivot is zero, so the rows must be exchanged
+	matrix := [][]float64{{0, 2, 1}, {1, 1, 1}, {2, 1, 3}}
+	got, ok := solveLinear(matrix, []float64{7, 6, 13})
+	if !ok {
+		t.Fatalf("Unexpected singular matrix")
+	}
+	for i, want := range []float64{1, 2, 3} {
+		if math.Abs(got[i]-want) > 1e-14 {
+			t.Errorf("Unexpected solution %d: got %v, want %v", i, got[i], want)
+		}
+	}
+	if matrix[0][0] != 0 || matrix[1][0] != 1 {
+		t.Errorf("Unexpected modification of the matrix: got %v", matrix)
+	}
+	if _, ok := solveLinear([][]float64{{1, 2}, {2, 4}}, []float64{1, 2}); ok {
+		t.Errorf("Unexpected solution of a singular system")
+	}
+}
@@ -0,0 +1,116 @@
+package finance
+
+import (
+	"context"
+	"math"
+)
+
+// Dupire finite differences and safeguards
+const (
+	dupireLogStrikeStep = 1e-3 // Step in the log moneyness of the differences in strike
+	dupireTimeStep      = 1e-3 // Step in years of the differences in maturity, or a tenth of the maturity if shorter
+	dupireMinTime       = 1e-4 // Earliest maturity at which the surface is differentiated, in years
+	dupireMinVariance   = 1e-8 // Local variance to which a negative or undefined one is clamped
+)
+
+// VolSurface is a surface of Black-Scholes implied volatilities by strike and time to expiration
+type VolSurface interface {
+	Vol(strike, timeYears float64) float64
+}
+
+// FlatVolSurface is a VolSurface with the same implied volatility at every strike and expiration
+type FlatVolSurface float64
+
+// Vol returns the flat volatility
+// strike: the strike price
+// timeYears: the time to expiration in years
+func (s FlatVolSurface) Vol(strike, timeYears float64) float64 {
+	return float64(s)
+}
+
+// LocalVolFunc is a local volatility, of the underlying's returns at an underlying price and a time in years from
+// today, also reporting whether it was clamped because the variance it was derived from was negative or undefined
+type LocalVolFunc func(spot, timeYears float64) (vol float64, clamped bool)
+
+// LocalVolResult is the outcome of pricing under a local volatility
+type LocalVolResult struct {
+	Price   float64 `json:"price"`   // Price of the option
+	Clamped int     `json:"clamped"` // Number of evaluations of the local volatility that were clamped
+}
+
+// DupireLocalVol returns the local volatility implied by a surface of implied volatilities through Dupire's (1994)
+// formula, written in the total implied variance w(y, T) = σ²T at log moneyness y = ln(K/F_T) as in Gatheral (2006),
+// σ_loc²(K, T) = ∂w/∂T/(1 − y/w·∂w/∂y + (−1/4 − 1/w + y²/w²)·(∂w/∂y)²/4 + ∂²w/∂y²/2). The derivatives are central
+// differences 1e-3 apart in y and in T at fixed y, or a tenth of T apart before 0.01 years, with T no earlier than
+// 1e-4 years. A surface with calendar or butterfly arbitrage gives a negative numerator or denominator, and a
+// surface that is not finite an undefined ratio; the local variance is then clamped to 1e-8 and reported as clamped
+// surface: the implied volatility surface
+// spot: the underlying price
+// rate: the risk-free interest rate
+// q: the continuous dividend yield
+func DupireLocalVol(surface VolSurface, spot, rate, q float64) LocalVolFunc {
+	total := func(y, timeYears float64) float64 {
+		strike := ForwardPrice(spot, rate, q, timeYears) * math.Exp(y)
+		vol := surface.Vol(strike, timeYears)
+		return vol * vol * timeYears
+	}
+	return func(underlying, timeYears float64) (float64, bool) {
+		timeYears = math.Max(timeYears, dupireMinTime)
+		y := math.Log(underlying / ForwardPrice(spot, rate, q, timeYears))
+		dt := math.Min(dupireTimeStep, 0.1*timeYears)
+		dy := dupireLogStrikeStep
+
+		w := total(y, timeYears)
+		wT := (total(y, timeYears+dt) - total(y, timeYears-dt)) / (2 * dt)
+		up, down := total(y+dy, timeYears), total(y-dy, timeYears)
+		wy := (up - down) / (2 * dy)
+		wyy := (up - 2*w + down) / (dy * dy)
+		denominator := 1 - y/w*wy + 0.25*(-0.25-1/w+y*y/(w*w))*wy*wy + 0.5*wyy
+
+		variance := wT / denominator
+		if !(wT > 0) || !(denominator > 0) || !(variance > dupireMinVariance) || math.IsInf(variance, 1) {
+			return math.Sqrt(dupireMinVariance), true
+		}
+		return math.Sqrt(variance), false
+	}
+}
+
+// FDMLocalVolPrice prices an option like FDMPriceE under a local volatility, so that exotics and American options
+// can be priced consistently with the vanilla surface it was derived from. The operator of the PDE is rebuilt at
+// the middle of every time step, and the grid's width is set by the local volatility at the underlying price half
+// way to expiration. The result reports how often the local volatility was clamped; a surface with arbitrage clamps
+// it in the regions concerned. At expiration the price is the intrinsic value
+// Returns the errors of Validate for an invalid option, ErrInvalidRate for invalid rates, ErrInvalidGrid and
+// ErrUnstableScheme, and ErrNonPositiveVolatility if the local volatility that sets the grid is not positive
+// option: the option
+// localVol: the local volatility
+// grid: the grid
+// scheme: the time-stepping scheme
+func FDMLocalVolPrice(option Option, localVol LocalVolFunc, grid FDGrid, scheme Scheme) (LocalVolResult, error) {
+	nan := LocalVolResult{Price: math.NaN()}
+	if err := option.Validate(); err != nil {
+		return nan, err
+	}
+	if err := validateMarket(option); err != nil {
+		return nan, err
+	}
+	if err := checkFDGrid(grid, scheme); err != nil {
+		return nan, err
+	}
+	option = escrowed(option)
+	if option.DaysToExpiration == 0 {
+		return LocalVolResult{Price: option.IntrinsicValue()}, nil
+	}
+
+	vol, _ := localVol(option.UnderlyingPrice, 0.5*option.DaysToExpiration/365.0)
+	if !(vol > 0) || math.IsInf(vol, 1) {
+		return nan, ErrNonPositiveVolatility
+	}
+	fd := newFDMGrid(option, vol, grid)
+	fd.localVol = localVol
+	nodes, err := fd.solve(context.Background(), option, vol, scheme, false, grid.Progress)
+	if err != nil {
+		return nan, err
+	}
+	return LocalVolResult{Price: nodes.value, Clamped: fd.clamped}, nil
+}
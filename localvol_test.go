@@ -0,0 +1,118 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// termStructureSurface is a VolSurface whose implied volatility depends on the time to expiration only
+type termStructureSurface func(timeYears float64) float64
+
+func (s termStructureSurface) Vol(strike, timeYears float64) float64 {
+	return s(timeYears)
+}
+
+// skewSurface is a VolSurface whose implied volatility falls linearly in the log strike
+type skewSurface struct{}
+
+func (skewSurface) Vol(strike, timeYears float64) float64 {
+	return 0.2 - 0.1*math.Log(strike/100.0)
+}
+
+func TestDupireLocalVol(t *testing.T) {
+	flat := DupireLocalVol(FlatVolSurface(0.2), 100.0, 0.05, 0.02)
+	for _, spot := range []float64{50.0, 90.0, 100.0, 120.0, 250.0} {
+		for _, timeYears := range []float64{0, 0.01, 0.25, 1.0, 5.0} {
+			if got, clamped := flat(spot, timeYears); clamped || math.Abs(got-0.2) > 1e-6 {
+				t.Errorf("Unexpected local volatility of a flat surface at %v and %v: got %v (clamped %v), want 0.2", spot, timeYears, got, clamped)
+			}
+		}
+	}
+
+	// with a term structure alone, σ_loc² = ∂(σ²T)/∂T = σ² + 2σσ'T
+	term := termStructureSurface(func(timeYears float64) float64 { return 0.15 + 0.1*timeYears })
+	local := DupireLocalVol(term, 100.0, 0.05, 0.02)
+	for _, timeYears := range []float64{0.1, 0.5, 2.0} {
+		vol := 0.15 + 0.1*timeYears
+		want := math.Sqrt(vol*vol + 2*vol*0.1*timeYears)
+		if got, clamped := local(110.0, timeYears); clamped || math.Abs(got-want) > 1e-6 {
+			t.Errorf("Unexpected local volatility at %v: got %v (clamped %v), want %v", timeYears, got, clamped, want)
+		}
+	}
+
+	// total variance that falls with maturity is a calendar arbitrage, and is clamped
+	falling := DupireLocalVol(termStructureSurface(func(timeYears float64) float64 { return 0.3 / (1 + 10*timeYears) }), 100.0, 0.05, 0.02)
+	if got, clamped := falling(100.0, 1.0); !clamped || got != 1e-4 {
+		t.Errorf("Unexpected local volatility with calendar arbitrage: got %v (clamped %v), want 1e-4", got, clamped)
+	}
+}
+
+func TestFDMLocalVolPrice(t *testing.T) {
+	grid := FDGrid{SpotSteps: 200, TimeSteps: 200}
+	for _, typ := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           105.0,
+			DaysToExpiration: 365.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       typ,
+			DividendYield:    0.02,
+		}
+		// a flat surface reprices as the constant volatility on the same grid
+		local := DupireLocalVol(FlatVolSurface(0.2), 100.0, 0.05, 0.02)
+		result, err := FDMLocalVolPrice(option, local, grid, CrankNicolson)
+		if want := FDMPrice(option, 0.2, grid, CrankNicolson); err != nil || result.Clamped != 0 || math.Abs(result.Price-want) > 1e-8 {
+			t.Errorf("Unexpected price with a flat surface for %v: got %+v (%v), want %v", typ, result, err, want)
+		}
+		if want := BlackScholesOptionPrice(option, 0.2); math.Abs(result.Price-want) > 1e-3 {
+			t.Errorf("Unexpected price with a flat surface for %v: got %v, want %v", typ, result.Price, want)
+		}
+
+		// a term structure reprices the vanilla at its implied volatility
+		term := termStructureSurface(func(timeYears float64) float64 { return 0.15 + 0.1*timeYears })
+		result, err = FDMLocalVolPrice(option, DupireLocalVol(term, 100.0, 0.05, 0.02), grid, CrankNicolson)
+		if want := BlackScholesOptionPrice(option, 0.25); err != nil || result.Clamped != 0 || math.Abs(result.Price-want) > 1e-3 {
+			t.Errorf("Unexpected price with a term structure for %v: got %+v (%v), want %v", typ, result, err, want)
+		}
+	}
+
+	// a skewed surface reprices its vanillas across strikes
+	skew := DupireLocalVol(skewSurface{}, 100.0, 0.03, 0)
+	for _, strike := range []float64{80.0, 90.0, 100.0, 110.0, 120.0} {
+		put := Option{Strike: strike, DaysToExpiration: 365.0, RiskFreeRate: 0.03, UnderlyingPrice: 100.0, OptionType: Put}
+		result, err := FDMLocalVolPrice(put, skew, FDGrid{SpotSteps: 400, TimeSteps: 200}, CrankNicolson)
+		if want := BlackScholesOptionPrice(put, skewSurface{}.Vol(strike, 1.0)); err != nil || math.Abs(result.Price-want) > 1e-3 {
+			t.Errorf("Unexpected price with a skew struck at %v: got %+v (%v), want %v", strike, result, err, want)
+		}
+	}
+
+	option := Option{Strike: 100.0, DaysToExpiration: 365.0, UnderlyingPrice: 100.0, OptionType: Call}
+	falling := DupireLocalVol(termStructureSurface(func(timeYears float64) float64 { return 0.3 / (1 + 10*timeYears) }), 100.0, 0, 0)
+	if result, err := FDMLocalVolPrice(option, falling, grid, CrankNicolson); err != nil || result.Clamped == 0 {
+		t.Errorf("Unexpected result with calendar arbitrage: got %+v (%v), want clamped evaluations", result, err)
+	}
+
+	expired := Option{Strike: 100.0, UnderlyingPrice: 110.0, OptionType: Call}
+	if result, err := FDMLocalVolPrice(expired, falling, grid, CrankNicolson); err != nil || result.Price != 10.0 {
+		t.Errorf("Unexpected price at expiration: got %+v (%v), want 10", result, err)
+	}
+	zero := func(spot, timeYears float64) (float64, bool) { return 0, false }
+	tests := []struct {
+		name     string
+		option   Option
+		localVol LocalVolFunc
+		grid     FDGrid
+		err      error
+	}{
+		{"negative days", Option{Strike: 100.0, DaysToExpiration: -1, UnderlyingPrice: 100.0}, falling, grid, ErrNegativeExpiry},
+		{"infinite rate", Option{Strike: 100.0, DaysToExpiration: 90.0, RiskFreeRate: math.Inf(1), UnderlyingPrice: 100.0}, falling, grid, ErrInvalidRate},
+		{"one spot step", option, falling, FDGrid{SpotSteps: 1, TimeSteps: 10}, ErrInvalidGrid},
+		{"zero local volatility", option, zero, grid, ErrNonPositiveVolatility},
+	}
+	for _, test := range tests {
+		if result, err := FDMLocalVolPrice(test.option, test.localVol, test.grid, CrankNicolson); !errors.Is(err, test.err) || !math.IsNaN(result.Price) {
+			t.Errorf("Unexpected result for %s: got %+v (%v), want NaN (%v)", test.name, result, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package finance
+
+import (
+	"math"
+)
+
+// Lookback options are European options on the extreme of the underlying price over their life, monitored
+// continuously. A seasoned contract passes in the extreme observed so far, the minimum for floating-strike calls and
+// fixed-strike puts and the maximum for floating-strike puts and fixed-strike calls; for a contract issued today it
+// is the underlying price. The closed forms divide by the cost of carry r − q, and are evaluated so that they stay
+// accurate as it approaches zero, where they take their limit. Discrete dividends are escrowed, an observed extreme
+// beyond the escrowed underlying price being taken at that price, which the escrowed path starts from. NaN when the
+// observed extreme lies on the wrong side of the underlying price or with negative days to expiration
+
+// FloatingLookbackPrice computes the Goldman-Sosin-Gatto (1979) price of a floating-strike lookback option, with
+// payoff S_T − min S for a call and max S − S_T for a put over the life of the option. It is worth at least the
+// vanilla option struck at the observed extreme. The option's strike is ignored
+// option: the option
+// vol: the volatility
+// observedExtreme: the minimum observed so far for a call, the maximum for a put
+func FloatingLookbackPrice(option Option, vol, observedExtreme float64) float64 {
+	if option.DaysToExpiration < 0 || !(observedExtreme > 0) || (option.OptionType == Call && observedExtreme > option.UnderlyingPrice) ||
+		(option.OptionType == Put && observedExtreme < option.UnderlyingPrice) {
+		return math.NaN()
+	}
+	option = escrowed(option)
+	spot := option.UnderlyingPrice
+	if option.OptionType == Call {
+		observedExtreme = math.Min(observedExtreme, spot)
+	}
+	if option.DaysToExpiration == 0 {
+		return math.Abs(spot - observedExtreme)
+	}
+
+	timeToExpiration := option.DaysToExpiration / 365.0
+	stdDev := vol * math.Sqrt(timeToExpiration)
+	d1 := (math.Log(spot/observedExtreme) + (option.RiskFreeRate-option.DividendYield+0.5*vol*vol)*timeToExpiration) / stdDev
+	d2 := d1 - stdDev
+	discountedSpot := spot * math.Exp(-option.DividendYield*timeToExpiration)
+	discountedExtreme := observedExtreme * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	spotDiscountedAtRate := spot * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	if option.OptionType == Call {
+		return discountedSpot*Phi(d1) - discountedExtreme*Phi(d2) + spotDiscountedAtRate*lookbackReflection(option, vol, observedExtreme, -d1, 1)
+	}
+	return discountedExtreme*PhiC(d2) - discountedSpot*PhiC(d1) - spotDiscountedAtRate*lookbackReflection(option, vol, observedExtreme, d1, -1)
+}
+
+// FixedLookbackPrice computes the Conze-Viswanathan (1991) price of a fixed-strike lookback option, with payoff
+// (max S − K)⁺ for a call and (K − min S)⁺ for a put over the life of the option. Once the observed extreme is in
+// the money, the option is worth its discounted value plus a fixed-strike lookback struck at the extreme
+// option: the option
+// vol: the volatility
+// observedExtreme: the maximum observed so far for a call, the minimum for a put
+func FixedLookbackPrice(option Option, vol, observedExtreme float64) float64 {
+	if option.DaysToExpiration < 0 || !(observedExtreme > 0) || (option.OptionType == Call && observedExtreme < option.UnderlyingPrice) ||
+		(option.OptionType == Put && observedExtreme > option.UnderlyingPrice) {
+		return math.NaN()
+	}
+	option = escrowed(option)
+	spot, strike := option.UnderlyingPrice, option.Strike
+	if option.OptionType == Put {
+		observedExtreme = math.Min(observedExtreme, spot)
+	}
+	if option.DaysToExpiration == 0 {
+		if option.OptionType == Call {
+			return math.Max(observedExtreme-strike, 0)
+		}
+		return math.Max(strike-observedExtreme, 0)
+	}
+
+	// the payoff is the part already locked in plus a lookback struck at the further of the strike and the extreme
+	timeToExpiration := option.DaysToExpiration / 365.0
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	level, lockedIn := math.Max(strike, observedExtreme), math.Max(observedExtreme-strike, 0)
+	if option.OptionType == Put {
+		level, lockedIn = math.Min(strike, observedExtreme), math.Max(strike-observedExtreme, 0)
+	}
+	stdDev := vol * math.Sqrt(timeToExpiration)
+	d1 := (math.Log(spot/level) + (option.RiskFreeRate-option.DividendYield+0.5*vol*vol)*timeToExpiration) / stdDev
+	d2 := d1 - stdDev
+	discountedSpot := spot * math.Exp(-option.DividendYield*timeToExpiration)
+	if option.OptionType == Call {
+		return discount*lockedIn + discountedSpot*Phi(d1) - level*discount*Phi(d2) - spot*discount*lookbackReflection(option, vol, level, d1, -1)
+	}
+	return discount*lockedIn + level*discount*PhiC(d2) - discountedSpot*PhiC(d1) + spot*discount*lookbackReflection(option, vol, level, -d1, 1)
+}
+
+// lookbackReflection computes the term σ²/2b·[(S/X)^{−2b/σ²}·N(d + direction·2b√T/σ) − e^{bT}·N(d)] shared by the
+// lookback closed forms, b being the cost of carry, without the cancellation that dividing by b causes as it
+// approaches zero, where the term tends to σ√T·direction·n(d) − N(d)·(ln(S/X) + σ²T/2)
+// option: the option, with any discrete dividends already escrowed and positive days to expiration
+// vol: the volatility
+// level: the extreme or strike X the term reflects in
+// d: the argument of the normal distribution
+// direction: the sign of the shift of d
+func lookbackReflection(option Option, vol, level, d, direction float64) float64 {
+	timeToExpiration := option.DaysToExpiration / 365.0
+	carry := option.RiskFreeRate - option.DividendYield
+	stdDev := vol * math.Sqrt(timeToExpiration)
+	logRatio := math.Log(option.UnderlyingPrice / level)
+
+	// σ²/2b·((S/X)^{−2b/σ²} − e^{bT})·N(d) + σ²/2b·(S/X)^{−2b/σ²}·(N(d + shift) − N(d))
+	exponent := -(2*logRatio/(vol*vol) + timeToExpiration) * carry
+	rebased := math.Exp(carry*timeToExpiration) * Phi(d) * -(logRatio + 0.5*vol*vol*timeToExpiration) * expm1Ratio(exponent)
+	shift := direction * 2 * carry * math.Sqrt(timeToExpiration) / vol
+	return rebased + math.Exp(-2*carry*logRatio/(vol*vol))*direction*stdDev*normalCDFSlope(d, shift)
+}
+
+// expm1Ratio computes (e^x − 1)/x, 1 at x = 0
+// x: the input value
+func expm1Ratio(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Expm1(x) / x
+}
+
+// normalCDFSlope computes (N(x + h) − N(x))/h, the slope of the standard normal cumulative distribution function
+// over a step, from its Taylor series for short steps where the difference would cancel
+// x: the start of the step
+// h: the length of the step, zero for the derivative
+func normalCDFSlope(x, h float64) float64 {
+	if math.Abs(h) < 1e-4 {
+		return NormalDistributionDerivative(x) * (1 - x*h/2 + (x*x-1)*h*h/6 - x*(x*x-3)*h*h*h/24)
+	}
+	return (Phi(x+h) - Phi(x)) / h
+}
@@ -0,0 +1,192 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloatingLookbackPriceHaug(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas, floating-strike lookback call
+	option := Option{
+		DaysToExpiration: 0.5 * 365,
+		RiskFreeRate:     0.1,
+		UnderlyingPrice:  120.0,
+		OptionType:       Call,
+		DividendYield:    0.06,
+	}
+	if got := FloatingLookbackPrice(option, 0.3, 100.0); math.Abs(got-25.3533) > 1e-4 {
+		t.Errorf("Unexpected floating lookback call price: got %v, want 25.3533", got)
+	}
+}
+
+func TestFixedLookbackPriceHaug(t *testing.T) {
+	// Haug, The Complete Guide to Option Pricing Formulas, fixed-strike lookbacks on new contracts
+	tests := []struct {
+		optionType OptionType
+		strike     float64
+		want       [3]float64 // at volatilities 0.1, 0.2 and 0.3
+	}{
+		{Call, 95.0, [3]float64{13.2687, 18.9263, 24.9857}},
+		{Call, 100.0, [3]float64{8.5126, 14.1702, 20.2296}},
+		{Call, 105.0, [3]float64{4.3908, 9.8905, 15.8512}},
+		{Put, 95.0, [3]float64{0.6899, 4.4448, 8.9213}},
+		{Put, 100.0, [3]float64{3.3917, 8.3177, 13.1579}},
+		{Put, 105.0, [3]float64{8.1478, 13.0739, 17.9140}},
+	}
+
+	for _, test := range tests {
+		for i, vol := range []float64{0.1, 0.2, 0.3} {
+			option := Option{
+				Strike:           test.strike,
+				DaysToExpiration: 0.5 * 365,
+				RiskFreeRate:     0.1,
+				UnderlyingPrice:  100.0,
+				OptionType:       test.optionType,
+			}
+			if got := FixedLookbackPrice(option, vol, 100.0); math.Abs(got-test.want[i]) > 1e-4 {
+				t.Errorf("Unexpected price for type %v strike %v vol %v: got %v, want %v", test.optionType, test.strike, vol, got, test.want[i])
+			}
+		}
+	}
+}
+
+func TestLookbackPriceRelations(t *testing.T) {
+	const tolerance = 1e-10
+
+	for _, dividendYield := range []float64{0.0, 0.02, 0.05} {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 270.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			DividendYield:    dividendYield,
+		}
+		timeToExpiration := option.DaysToExpiration / 365.0
+		discountedSpot := option.UnderlyingPrice * math.Exp(-dividendYield*timeToExpiration)
+		discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+
+		for _, minimum := range []float64{80.0, 95.0, 100.0} {
+			option.OptionType = Call
+			floating := FloatingLookbackPrice(option, 0.25, minimum)
+			vanilla := option
+			vanilla.Strike = minimum
+			if want := BlackScholesOptionPrice(vanilla, 0.25); !(floating >= want) {
+				t.Errorf("Unexpected floating call below the vanilla for yield %v minimum %v: got %v, want at least %v", dividendYield, minimum, floating, want)
+			}
+			// S_T − min S = S_T − m + (m − min S)⁺, a fixed-strike put struck at the observed minimum m
+			option.OptionType = Put
+			option.Strike = minimum
+			if want := discountedSpot - minimum*discount + FixedLookbackPrice(option, 0.25, minimum); math.Abs(floating-want) > tolerance {
+				t.Errorf("Unexpected floating call for yield %v minimum %v: got %v, want %v", dividendYield, minimum, floating, want)
+			}
+		}
+		for _, maximum := range []float64{100.0, 105.0, 120.0} {
+			option.OptionType = Put
+			floating := FloatingLookbackPrice(option, 0.25, maximum)
+			vanilla := option
+			vanilla.Strike = maximum
+			if want := BlackScholesOptionPrice(vanilla, 0.25); !(floating >= want) {
+				t.Errorf("Unexpected floating put below the vanilla for yield %v maximum %v: got %v, want at least %v", dividendYield, maximum, floating, want)
+			}
+			// max S − S_T = (max S − M)⁺ + M − S_T, a fixed-strike call struck at the observed maximum M
+			option.OptionType = Call
+			option.Strike = maximum
+			if want := FixedLookbackPrice(option, 0.25, maximum) + maximum*discount - discountedSpot; math.Abs(floating-want) > tolerance {
+				t.Errorf("Unexpected floating put for yield %v maximum %v: got %v, want %v", dividendYield, maximum, floating, want)
+			}
+		}
+	}
+}
+
+func TestLookbackPriceZeroCarry(t *testing.T) {
+	// the closed forms are continuous through a zero cost of carry, where they divide by it
+	for _, optionType := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           105.0,
+			DaysToExpiration: 180.0,
+			RiskFreeRate:     0.04,
+			UnderlyingPrice:  100.0,
+			OptionType:       optionType,
+			DividendYield:    0.04,
+		}
+		floating, fixed := FloatingLookbackPrice(option, 0.2, 100.0), FixedLookbackPrice(option, 0.2, 100.0)
+		up, down := option, option
+		up.DividendYield += 1e-7
+		down.DividendYield -= 1e-7
+		if got := (FloatingLookbackPrice(up, 0.2, 100.0) + FloatingLookbackPrice(down, 0.2, 100.0)) / 2; math.Abs(got-floating) > 1e-9 {
+			t.Errorf("Unexpected floating price for type %v at zero carry: got %v, want %v", optionType, floating, got)
+		}
+		if got := (FixedLookbackPrice(up, 0.2, 100.0) + FixedLookbackPrice(down, 0.2, 100.0)) / 2; math.Abs(got-fixed) > 1e-9 {
+			t.Errorf("Unexpected fixed price for type %v at zero carry: got %v, want %v", optionType, fixed, got)
+		}
+		if math.IsNaN(floating) || math.IsNaN(fixed) {
+			t.Errorf("Unexpected NaN for type %v at zero carry: got %v and %v", optionType, floating, fixed)
+		}
+	}
+}
+
+func TestLookbackPriceDividends(t *testing.T) {
+	// a seasoned contract whose observed extreme lies between the escrowed and the real underlying price is valid,
+	// and priced as a contract on the escrowed underlying whose extreme is that underlying price
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		Dividends:        []Dividend{{Amount: 10.0, DaysToExDate: 90.0}},
+	}
+	escrowedOption := escrowed(option)
+	spot := escrowedOption.UnderlyingPrice
+
+	option.OptionType, escrowedOption.OptionType = Call, Call
+	if got, want := FloatingLookbackPrice(option, 0.25, 95.0), FloatingLookbackPrice(escrowedOption, 0.25, spot); math.IsNaN(got) || math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected floating call with a dividend: got %v, want %v", got, want)
+	}
+	if got, want := FixedLookbackPrice(option, 0.25, 105.0), FixedLookbackPrice(escrowedOption, 0.25, 105.0); math.IsNaN(got) || math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected fixed call with a dividend: got %v, want %v", got, want)
+	}
+	option.OptionType, escrowedOption.OptionType = Put, Put
+	if got, want := FixedLookbackPrice(option, 0.25, 95.0), FixedLookbackPrice(escrowedOption, 0.25, spot); math.IsNaN(got) || math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected fixed put with a dividend: got %v, want %v", got, want)
+	}
+	if got, want := FloatingLookbackPrice(option, 0.25, 105.0), FloatingLookbackPrice(escrowedOption, 0.25, 105.0); math.IsNaN(got) || math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected floating put with a dividend: got %v, want %v", got, want)
+	}
+
+	// the extreme is still checked against the real underlying price
+	if got := FloatingLookbackPrice(option, 0.25, 98.0); !math.IsNaN(got) {
+		t.Errorf("Unexpected floating put with a maximum below the underlying price: got %v, want NaN", got)
+	}
+}
+
+func TestLookbackPriceEdgeCases(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	if got := FloatingLookbackPrice(option, 0.2, 105.0); !math.IsNaN(got) {
+		t.Errorf("Unexpected floating call price for a minimum above spot: got %v, want NaN", got)
+	}
+	if got := FixedLookbackPrice(option, 0.2, 95.0); !math.IsNaN(got) {
+		t.Errorf("Unexpected fixed call price for a maximum below spot: got %v, want NaN", got)
+	}
+	option.DaysToExpiration = 0
+	if got := FloatingLookbackPrice(option, 0.2, 90.0); got != 10.0 {
+		t.Errorf("Unexpected floating call price at expiration: got %v, want 10", got)
+	}
+	if got := FixedLookbackPrice(option, 0.2, 112.0); got != 12.0 {
+		t.Errorf("Unexpected fixed call price at expiration: got %v, want 12", got)
+	}
+	option.OptionType = Put
+	if got := FixedLookbackPrice(option, 0.2, 97.0); got != 3.0 {
+		t.Errorf("Unexpected fixed put price at expiration: got %v, want 3", got)
+	}
+	option.DaysToExpiration = -1
+	if got := FloatingLookbackPrice(option, 0.2, 110.0); !math.IsNaN(got) {
+		t.Errorf("Unexpected floating put price for negative days to expiration: got %v, want NaN", got)
+	}
+}
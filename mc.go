@@ -0,0 +1,461 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"runtime"
+)
+
+var (
+	// ErrInvalidPaths is returned when a Monte Carlo simulation is asked for fewer than one path
+	ErrInvalidPaths = errors.New("finance: Monte Carlo path count must be positive")
+	// ErrInvalidObservations is returned when a Monte Carlo path is asked for fewer than one observation
+	ErrInvalidObservations = errors.New("finance: Monte Carlo observation count must be positive")
+	// ErrInvalidSampler is returned for an unknown Monte Carlo sampler
+	ErrInvalidSampler = errors.New("finance: unknown Monte Carlo sampler")
+)
+
+// Sampler selects how a Monte Carlo simulation draws the normal variates that drive its paths
+type Sampler int
+
+const (
+	PseudoRandom Sampler = iota // Independent draws from a PCG generator seeded with Seed; the default
+	QuasiRandom                 // Sobol points through the inverse normal distribution, on a Brownian bridge; Seed is unused
+)
+
+// PathPayoff is the payoff at expiration of an option on a simulated path, given the underlying prices at the
+// observation dates, the last of which is expiration
+type PathPayoff func(option Option, path []float64) float64
+
+// ProgressFunc receives the progress of a long-running pricer: the units of work done so far, paths for the Monte
+// Carlo pricers and time steps for the finite-difference ones, out of the total
+type ProgressFunc func(done, total int)
+
+// mcBatchSize is the default number of paths simulated between cancellation checks and progress reports
+const mcBatchSize = 1024
+
+// ControlVariate is a payoff with a known price, simulated on the same paths as the option being priced so that
+// the error in its estimate can be subtracted from the option's
+type ControlVariate struct {
+	Payoff PathPayoff // Payoff of the control at expiration
+	Price  float64    // Exact price of the control today
+}
+
+// MCConfig configures a Monte Carlo simulation
+type MCConfig struct {
+	Paths          int             // Number of simulated paths, at least 1; rounded up to even with Antithetic
+	Seed           uint64          // Seed of the random number generator; equal seeds give identical results
+	Sampler        Sampler         // Source of the normal draws
+	Antithetic     bool            // Whether to pair each path with its mirror image, driven by the negated normal draws
+	ControlVariate *ControlVariate // Control variate, if any; composes with Antithetic
+	BatchSize      int             // Paths per batch, each with its own random stream; also the cancellation and progress granularity, 1024 if not positive
+	Workers        int             // Number of goroutines simulating batches in parallel, GOMAXPROCS if not positive; the result does not depend on it
+	Progress       ProgressFunc    // Called with the paths simulated so far after each batch, if set
+}
+
+// MCResult is the outcome of a Monte Carlo simulation
+type MCResult struct {
+	Price             float64 `json:"price"`              // Estimated price
+	StdError          float64 `json:"std_error"`          // Standard error of the estimate
+	VarianceReduction float64 `json:"variance_reduction"` // Variance of plain sampling with as many paths over that achieved
+}
+
+// MonteCarloPrice prices a European option by simulating the terminal underlying price under geometric Brownian
+// motion, S·e^{(r − q − σ²/2)·T + σ·√T·Z}, and averaging the discounted payoff, like MonteCarloPathPrice with
+// VanillaPayoff and a single observation
+// option: the option
+// vol: the volatility
+// cfg: the simulation configuration
+func MonteCarloPrice(option Option, vol float64, cfg MCConfig) (MCResult, error) {
+	return MonteCarloPathPriceCtx(context.Background(), option, vol, VanillaPayoff, 1, cfg)
+}
+
+// MonteCarloPriceCtx prices a European option like MonteCarloPrice, stopping early if ctx is done, as
+// MonteCarloPathPriceCtx does
+// ctx: the context whose cancellation stops the simulation
+// option: the option
+// vol: the volatility
+// cfg: the simulation configuration
+func MonteCarloPriceCtx(ctx context.Context, option Option, vol float64, cfg MCConfig) (MCResult, error) {
+	return MonteCarloPathPriceCtx(ctx, option, vol, VanillaPayoff, 1, cfg)
+}
+
+// MonteCarloPathPrice prices an option with a path-dependent payoff by simulating geometric Brownian motion at
+// equally spaced observation dates up to expiration and averaging the discounted payoff. With Antithetic each path
+// is paired with the one driven by the negated draws, and statistics are computed over the pair averages, which are
+// independent. With a control variate, the estimate is corrected by the control's error scaled by the regression
+// coefficient of the payoff on the control's payoff, estimated from the same paths. With QuasiRandom the error
+// shrinks nearly as fast as 1/paths rather than 1/√paths, one Sobol dimension driving each observation; the path
+// is built on a Brownian bridge so that the leading dimensions set its overall shape. The standard error is then
+// computed as if the points were independent, which overstates the true error. The paths are simulated in batches
+// of BatchSize across Workers goroutines; each batch draws from its own stream, a PCG generator seeded from Seed and
+// the batch index or the batch's run of the Sobol sequence, and the batches are combined in order, so the result is
+// the same for any number of workers. At expiration the price is the payoff of the path that has only the current
+// underlying price, with no error
+// Returns the errors of BlackScholesOptionPriceE for invalid inputs, ErrInvalidPaths for fewer than one path,
+// ErrInvalidObservations for fewer than one observation, ErrInvalidSampler for an unknown sampler and
+// ErrInvalidDimensions for more than SobolMaxDimensions observations with QuasiRandom
+// option: the option
+// vol: the volatility
+// payoff: the payoff at expiration
+// observations: the number of observation dates
+// cfg: the simulation configuration
+func MonteCarloPathPrice(option Option, vol float64, payoff PathPayoff, observations int, cfg MCConfig) (MCResult, error) {
+	return MonteCarloPathPriceCtx(context.Background(), option, vol, payoff, observations, cfg)
+}
+
+// MonteCarloPathPriceCtx prices an option with a path-dependent payoff like MonteCarloPathPrice, checking ctx before
+// each batch of BatchSize paths. If ctx is done, it returns ctx.Err() with every field of the result NaN, never an
+// estimate from the paths simulated so far
+// ctx: the context whose cancellation stops the simulation
+// option: the option
+// vol: the volatility
+// payoff: the payoff at expiration
+// observations: the number of observation dates
+// cfg: the simulation configuration
+func MonteCarloPathPriceCtx(ctx context.Context, option Option, vol float64, payoff PathPayoff, observations int, cfg MCConfig) (MCResult, error) {
+	invalid := MCResult{Price: math.NaN(), StdError: math.NaN(), VarianceReduction: math.NaN()}
+	if err := validatePricing(option, vol); err != nil {
+		return invalid, err
+	}
+	if cfg.Paths < 1 {
+		return invalid, ErrInvalidPaths
+	}
+	if observations < 1 {
+		return invalid, ErrInvalidObservations
+	}
+	pathsPerSample := 1
+	if cfg.Antithetic {
+		pathsPerSample = 2
+	}
+	samples := (cfg.Paths + pathsPerSample - 1) / pathsPerSample
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = mcBatchSize
+	}
+	batchSamples := max(1, batchSize/pathsPerSample)
+	batches := (samples + batchSamples - 1) / batchSamples
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	samplers := make([]*mcSampler, min(workers, batches))
+	for i := range samplers {
+		sampler, err := newSampler(cfg, observations, batchSamples)
+		if err != nil {
+			return invalid, err
+		}
+		samplers[i] = sampler
+	}
+	option = escrowed(option)
+	if option.DaysToExpiration == 0 {
+		return MCResult{Price: payoff(option, []float64{option.UnderlyingPrice}), VarianceReduction: 1}, nil
+	}
+
+	timeToExpiration := option.DaysToExpiration / 365.0
+	dt := timeToExpiration / float64(observations)
+	drift := (option.RiskFreeRate - option.DividendYield - 0.5*vol*vol) * dt
+	stdDev := vol * math.Sqrt(dt)
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	control := cfg.ControlVariate
+
+	// each worker simulates every len(samplers)-th batch, checking ctx before each, into its own slot of results,
+	// and sends the batch's index on finished when done
+	results := make([]mcBatch, batches)
+	finished := make(chan int, batches)
+	for worker, sampler := range samplers {
+		go func() {
+			draws, path := make([]float64, observations), make([]float64, observations)
+
+			// simulate returns the discounted payoffs of the option and the control on the path driven by sign·draws
+			simulate := func(sign float64) (target, controlled float64) {
+				spot := option.UnderlyingPrice
+				for i, z := range draws {
+					spot *= math.Exp(drift + stdDev*sign*z)
+					path[i] = spot
+				}
+				target = discount * payoff(option, path)
+				if control != nil {
+					controlled = discount * control.Payoff(option, path)
+				}
+				return target, controlled
+			}
+
+			for batch := worker; batch < batches; batch += len(samplers) {
+				if ctx.Err() != nil {
+					return
+				}
+				sampler.start(batch)
+				result := &results[batch]
+				for range min(batchSamples, samples-batch*batchSamples) {
+					sampler.draw(draws)
+					target, controlled := simulate(1)
+					result.single.add(target)
+					if cfg.Antithetic {
+						mirrorTarget, mirrorControlled := simulate(-1)
+						result.single.add(mirrorTarget)
+						target, controlled = (target+mirrorTarget)/2, (controlled+mirrorControlled)/2
+					}
+					result.stats.add(target, controlled)
+				}
+				finished <- batch
+			}
+		}()
+	}
+
+	done := 0
+	for range batches {
+		select {
+		case <-ctx.Done():
+			return invalid, ctx.Err()
+		case batch := <-finished:
+			if err := ctx.Err(); err != nil {
+				return invalid, err
+			}
+			done += results[batch].single.count
+			if cfg.Progress != nil {
+				cfg.Progress(done, samples*pathsPerSample)
+			}
+		}
+	}
+
+	// combining the batches in order makes the result independent of the number of workers
+	var single runningStats // Individual payoffs, for the variance of plain sampling
+	var stats pairedStats   // Independent samples of the option and control
+	for i := range results {
+		single.merge(&results[i].single)
+		stats.merge(&results[i].stats)
+	}
+
+	price, variance := stats.mean, stats.variance()
+	if control != nil && stats.controlM2 > 0 {
+		beta := stats.comoment / stats.controlM2
+		price -= beta * (stats.controlMean - control.Price)
+		variance -= beta * stats.comoment / float64(stats.count-1)
+	}
+	result := MCResult{Price: price, VarianceReduction: 1}
+	if stats.count > 1 {
+		result.StdError = math.Sqrt(math.Max(variance, 0) / float64(stats.count))
+		if variance > 0 {
+			result.VarianceReduction = single.variance() / float64(single.count/stats.count) / variance
+		}
+	}
+	return result, nil
+}
+
+// mcBatch holds the statistics of a batch of Monte Carlo paths
+type mcBatch struct {
+	single runningStats // Individual payoffs
+	stats  pairedStats  // Samples of the option and control
+}
+
+// mcSampler draws the normal variates that drive the paths of a Monte Carlo simulation. The draws for each batch of
+// paths depend on the index of the batch alone, so that batches can be simulated in any order, by any worker
+type mcSampler struct {
+	sampler      Sampler
+	key          uint64 // SplitMix64 hash of the seed, offset by the batch index to seed each batch's PCG generator
+	batchSamples int    // Number of draws of each batch
+	pcg          *rand.PCG
+	rng          *rand.Rand
+	sobol        *Sobol
+	bridge       *brownianBridge
+}
+
+// newSampler creates a sampler for one worker
+// Returns ErrInvalidSampler for an unknown sampler and ErrInvalidDimensions for more than SobolMaxDimensions
+// observations with QuasiRandom
+// cfg: the simulation configuration
+// observations: the number of draws per path
+// batchSamples: the number of draws of each batch
+func newSampler(cfg MCConfig, observations, batchSamples int) (*mcSampler, error) {
+	sampler := &mcSampler{sampler: cfg.Sampler, key: splitMix64(cfg.Seed), batchSamples: batchSamples}
+	switch cfg.Sampler {
+	case PseudoRandom:
+		sampler.pcg = rand.NewPCG(0, 0)
+		sampler.rng = rand.New(sampler.pcg)
+		return sampler, nil
+	case QuasiRandom:
+		sobol, err := NewSobol(observations)
+		if err != nil {
+			return nil, err
+		}
+		sampler.sobol, sampler.bridge = sobol, newBrownianBridge(observations)
+		return sampler, nil
+	}
+	return nil, ErrInvalidSampler
+}
+
+// start positions the sampler at the first draw of a batch: a PCG generator seeded from the first two outputs of a
+// SplitMix64 generator keyed by the seed and the batch, or the batch's run of the Sobol sequence
+// batch: the index of the batch
+func (sampler *mcSampler) start(batch int) {
+	switch sampler.sampler {
+	case PseudoRandom:
+		key := sampler.key + uint64(batch)
+		sampler.pcg.Seed(splitMix64(key), splitMix64(key+splitMixGamma))
+	case QuasiRandom:
+		sampler.sobol.seek(uint32(uint64(batch) * uint64(sampler.batchSamples) % sobolPeriod))
+	}
+}
+
+// draw fills its argument with the normal draws for the next path
+// draws: the destination, one per observation
+func (sampler *mcSampler) draw(draws []float64) {
+	switch sampler.sampler {
+	case PseudoRandom:
+		for i := range draws {
+			draws[i] = sampler.rng.NormFloat64()
+		}
+	case QuasiRandom:
+		sampler.sobol.Next(draws)
+		for i, u := range draws {
+			draws[i] = PhiInv(u)
+		}
+		sampler.bridge.increments(draws, draws)
+	}
+}
+
+// splitMixGamma is the increment of the SplitMix64 generator, the odd integer nearest 2^64 over the golden ratio
+const splitMixGamma = 0x9e3779b97f4a7c15
+
+// splitMix64 returns the output of the SplitMix64 generator from a state, which decorrelates nearby seeds
+// x: the state before the step
+func splitMix64(x uint64) uint64 {
+	x += splitMixGamma
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// VanillaPayoff is the payoff of a European call or put on the last underlying price of the path
+// option: the option
+// path: the underlying prices at the observation dates
+func VanillaPayoff(option Option, path []float64) float64 {
+	option.UnderlyingPrice = path[len(path)-1]
+	return option.IntrinsicValue()
+}
+
+// ArithmeticAveragePayoff is the payoff of an average-price call or put on the arithmetic average of the path
+// option: the option
+// path: the underlying prices at the observation dates
+func ArithmeticAveragePayoff(option Option, path []float64) float64 {
+	var sum float64
+	for _, spot := range path {
+		sum += spot
+	}
+	option.UnderlyingPrice = sum / float64(len(path))
+	return option.IntrinsicValue()
+}
+
+// GeometricAveragePayoff is the payoff of an average-price call or put on the geometric average of the path
+// option: the option
+// path: the underlying prices at the observation dates
+func GeometricAveragePayoff(option Option, path []float64) float64 {
+	var sum float64
+	for _, spot := range path {
+		sum += math.Log(spot)
+	}
+	option.UnderlyingPrice = math.Exp(sum / float64(len(path)))
+	return option.IntrinsicValue()
+}
+
+// DiscreteGeometricAsianPrice computes the exact price of an average-price option on the geometric average of the
+// underlying price at equally spaced observation dates up to expiration, whose logarithm is normally distributed.
+// Paired with GeometricAveragePayoff, it makes a control variate for ArithmeticAveragePayoff that removes nearly
+// all of its sampling error. With a single observation it is the Black-Scholes price
+// option: the option
+// vol: the volatility
+// observations: the number of observation dates
+func DiscreteGeometricAsianPrice(option Option, vol float64, observations int) float64 {
+	option = escrowed(option)
+	if observations < 1 || option.DaysToExpiration < 0 {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		return option.IntrinsicValue()
+	}
+
+	// the log of the average of the prices at iT/n has mean ln S + (r − q − σ²/2)·T(n + 1)/2n and variance
+	// σ²·T(n + 1)(2n + 1)/6n²
+	timeToExpiration := option.DaysToExpiration / 365.0
+	n := float64(observations)
+	mean := math.Log(option.UnderlyingPrice) + (option.RiskFreeRate-option.DividendYield-0.5*vol*vol)*timeToExpiration*(n+1)/(2*n)
+	variance := vol * vol * timeToExpiration * (n + 1) * (2*n + 1) / (6 * n * n)
+	forward := math.Exp(mean + variance/2)
+	return BlackScholesForwardPrice(forward, option.Strike, timeToExpiration, option.RiskFreeRate, math.Sqrt(variance/timeToExpiration), option.OptionType)
+}
+
+// runningStats accumulates the mean and variance of a stream of samples with Welford's algorithm, which stays
+// accurate where the variance is small next to the mean
+type runningStats struct {
+	count int
+	mean  float64
+	m2    float64 // Sum of squared deviations from the mean
+}
+
+// add adds a sample
+// x: the sample
+func (stats *runningStats) add(x float64) {
+	stats.count++
+	delta := x - stats.mean
+	stats.mean += delta / float64(stats.count)
+	stats.m2 += delta * (x - stats.mean)
+}
+
+// variance returns the sample variance, zero for fewer than two samples
+func (stats *runningStats) variance() float64 {
+	if stats.count < 2 {
+		return 0
+	}
+	return stats.m2 / float64(stats.count-1)
+}
+
+// merge adds the samples accumulated by other, by the pairwise update of Chan, Golub and LeVeque
+// other: the statistics to add
+func (stats *runningStats) merge(other *runningStats) {
+	if other.count == 0 {
+		return
+	}
+	count := stats.count + other.count
+	delta := other.mean - stats.mean
+	weight := float64(other.count) / float64(count)
+	stats.mean += delta * weight
+	stats.m2 += other.m2 + delta*delta*float64(stats.count)*weight
+	stats.count = count
+}
+
+// pairedStats accumulates the means, variances and covariance of a stream of paired samples with Welford's
+// algorithm
+type pairedStats struct {
+	runningStats
+	controlMean float64
+	controlM2   float64 // Sum of squared deviations of the second samples from their mean
+	comoment    float64 // Sum of products of the deviations of the pairs from their means
+}
+
+// add adds a pair of samples
+// x: the first sample
+// y: the second sample
+func (stats *pairedStats) add(x, y float64) {
+	deltaY := y - stats.controlMean
+	stats.runningStats.add(x)
+	stats.controlMean += deltaY / float64(stats.count)
+	stats.controlM2 += deltaY * (y - stats.controlMean)
+	stats.comoment += deltaY * (x - stats.mean)
+}
+
+// merge adds the pairs of samples accumulated by other
+// other: the statistics to add
+func (stats *pairedStats) merge(other *pairedStats) {
+	if other.count == 0 {
+		return
+	}
+	weight := float64(stats.count) * float64(other.count) / float64(stats.count+other.count)
+	deltaX, deltaY := other.mean-stats.mean, other.controlMean-stats.controlMean
+	stats.controlMean += deltaY * float64(other.count) / float64(stats.count+other.count)
+	stats.controlM2 += other.controlM2 + deltaY*deltaY*weight
+	stats.comoment += other.comoment + deltaX*deltaY*weight
+	stats.runningStats.merge(&other.runningStats)
+}
@@ -0,0 +1,300 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestMonteCarloPriceAgainstAnalytic(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, antithetic := range []bool{false, true} {
+			option := Option{
+				Strike:           105.0,
+				DaysToExpiration: 180.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       optionType,
+				DividendYield:    0.02,
+			}
+
+			result, err := MonteCarloPrice(option, 0.25, MCConfig{Paths: 100000, Seed: 42, Antithetic: antithetic})
+			if err != nil {
+				t.Fatalf("Unexpected error for type %v antithetic %v: %v", optionType, antithetic, err)
+			}
+			want := BlackScholesOptionPrice(option, 0.25)
+			if math.Abs(result.Price-want) > 3*result.StdError {
+				t.Errorf("Unexpected price for type %v antithetic %v: got %v ± %v, want %v", optionType, antithetic, result.Price, result.StdError, want)
+			}
+			if !(result.StdError > 0) || result.StdError > 0.05 {
+				t.Errorf("Unexpected standard error for type %v antithetic %v: got %v", optionType, antithetic, result.StdError)
+			}
+		}
+	}
+}
+
+func TestMonteCarloPriceDeterministic(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	cfg := MCConfig{Paths: 10000, Seed: 7, Antithetic: true}
+
+	first, _ := MonteCarloPrice(option, 0.2, cfg)
+	second, _ := MonteCarloPrice(option, 0.2, cfg)
+	if first != second {
+		t.Errorf("Unexpected result for a repeated seed: got %v, want %v", second, first)
+	}
+	cfg.Seed++
+	if other, _ := MonteCarloPrice(option, 0.2, cfg); other == first {
+		t.Errorf("Unexpected identical result for a different seed: got %v", other)
+	}
+}
+
+func TestMonteCarloPriceEdgeCases(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	if _, err := MonteCarloPrice(option, 0.2, MCConfig{}); !errors.Is(err, ErrInvalidPaths) {
+		t.Errorf("Expected ErrInvalidPaths: got %v", err)
+	}
+	if _, err := MonteCarloPrice(option, -0.2, MCConfig{Paths: 100}); !errors.Is(err, ErrNonPositiveVolatility) {
+		t.Errorf("Expected ErrNonPositiveVolatility: got %v", err)
+	}
+	option.DaysToExpiration = 0
+	option.UnderlyingPrice = 110.0
+	if got, err := MonteCarloPrice(option, 0.2, MCConfig{Paths: 100}); err != nil || got != (MCResult{Price: 10.0, VarianceReduction: 1}) {
+		t.Errorf("Unexpected result at expiration: got %v, %v, want %v", got, err, MCResult{Price: 10.0, VarianceReduction: 1})
+	}
+}
+
+func TestMonteCarloPathPriceControlVariate(t *testing.T) {
+	const observations = 12
+
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.02,
+	}
+	control := &ControlVariate{Payoff: GeometricAveragePayoff, Price: DiscreteGeometricAsianPrice(option, 0.3, observations)}
+
+	plain, err := MonteCarloPathPrice(option, 0.3, ArithmeticAveragePayoff, observations, MCConfig{Paths: 20000, Seed: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, antithetic := range []bool{false, true} {
+		cfg := MCConfig{Paths: 20000, Seed: 1, Antithetic: antithetic, ControlVariate: control}
+		controlled, err := MonteCarloPathPrice(option, 0.3, ArithmeticAveragePayoff, observations, cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error for antithetic %v: %v", antithetic, err)
+		}
+		if ratio := plain.StdError / controlled.StdError; ratio < 5 {
+			t.Errorf("Unexpected standard error reduction for antithetic %v: got %v, want at least 5", antithetic, ratio)
+		}
+		if controlled.VarianceReduction < 25 {
+			t.Errorf("Unexpected variance reduction for antithetic %v: got %v, want at least 25", antithetic, controlled.VarianceReduction)
+		}
+		if math.Abs(controlled.Price-plain.Price) > 3*plain.StdError {
+			t.Errorf("Unexpected price for antithetic %v: got %v, want %v ± %v", antithetic, controlled.Price, plain.Price, plain.StdError)
+		}
+	}
+	if plain.VarianceReduction != 1 {
+		t.Errorf("Unexpected variance reduction without a control: got %v, want 1", plain.VarianceReduction)
+	}
+}
+
+func TestDiscreteGeometricAsianPrice(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 180.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       optionType,
+			DividendYield:    0.02,
+		}
+
+		if got, want := DiscreteGeometricAsianPrice(option, 0.25, 1), BlackScholesOptionPrice(option, 0.25); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Unexpected single-observation price for type %v: got %v, want %v", optionType, got, want)
+		}
+		result, err := MonteCarloPathPrice(option, 0.25, GeometricAveragePayoff, 6, MCConfig{Paths: 100000, Seed: 3, Antithetic: true})
+		if err != nil {
+			t.Fatalf("Unexpected error for type %v: %v", optionType, err)
+		}
+		if got := DiscreteGeometricAsianPrice(option, 0.25, 6); math.Abs(got-result.Price) > 3*result.StdError {
+			t.Errorf("Unexpected price for type %v: got %v, want %v ± %v", optionType, got, result.Price, result.StdError)
+		}
+	}
+}
+
+func TestMonteCarloPriceQuasiRandomConvergence(t *testing.T) {
+	option := Option{
+		Strike:           105.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.02,
+	}
+	want := BlackScholesOptionPrice(option, 0.25)
+
+	// least-squares slope of log error against log paths, -1 for quasi-random and -1/2 for pseudo-random sampling
+	var sumX, sumY, sumXX, sumXY float64
+	for k := 10; k <= 16; k++ {
+		paths := 1 << k
+		quasi, err := MonteCarloPrice(option, 0.25, MCConfig{Paths: paths, Sampler: QuasiRandom})
+		if err != nil {
+			t.Fatalf("Unexpected error for %d paths: %v", paths, err)
+		}
+		quasiError := math.Abs(quasi.Price - want)
+
+		var pseudoError float64
+		for seed := range uint64(10) {
+			pseudo, _ := MonteCarloPrice(option, 0.25, MCConfig{Paths: paths, Seed: seed})
+			pseudoError += (pseudo.Price - want) * (pseudo.Price - want) / 10
+		}
+		pseudoError = math.Sqrt(pseudoError)
+		if quasiError >= pseudoError {
+			t.Errorf("Unexpected quasi-random error for %d paths: got %v, want below pseudo-random %v", paths, quasiError, pseudoError)
+		}
+
+		x, y := math.Log(float64(paths)), math.Log(quasiError)
+		sumX, sumY, sumXX, sumXY = sumX+x, sumY+y, sumXX+x*x, sumXY+x*y
+	}
+	if slope := (7*sumXY - sumX*sumY) / (7*sumXX - sumX*sumX); slope > -0.8 {
+		t.Errorf("Unexpected quasi-random convergence rate: got %v, want below -0.8", slope)
+	}
+}
+
+func TestMonteCarloPathPriceQuasiRandom(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Put,
+	}
+
+	result, err := MonteCarloPathPrice(option, 0.3, GeometricAveragePayoff, 16, MCConfig{Paths: 1 << 14, Sampler: QuasiRandom})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := DiscreteGeometricAsianPrice(option, 0.3, 16); math.Abs(result.Price-want) > 1e-2 {
+		t.Errorf("Unexpected price: got %v, want %v", result.Price, want)
+	}
+
+	if _, err := MonteCarloPathPrice(option, 0.3, GeometricAveragePayoff, SobolMaxDimensions+1, MCConfig{Paths: 100, Sampler: QuasiRandom}); !errors.Is(err, ErrInvalidDimensions) {
+		t.Errorf("Expected ErrInvalidDimensions: got %v", err)
+	}
+	if _, err := MonteCarloPrice(option, 0.3, MCConfig{Paths: 100, Sampler: Sampler(2)}); !errors.Is(err, ErrInvalidSampler) {
+		t.Errorf("Expected ErrInvalidSampler: got %v", err)
+	}
+}
+
+func TestMonteCarloPriceCtx(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	cfg := MCConfig{Paths: 10000, Seed: 7, Antithetic: true, BatchSize: 1000}
+
+	var reports [][2]int
+	cfg.Progress = func(done, total int) { reports = append(reports, [2]int{done, total}) }
+	complete, err := MonteCarloPriceCtx(context.Background(), option, 0.2, cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(reports) != 10 || reports[0] != [2]int{1000, 10000} || reports[9] != [2]int{10000, 10000} {
+		t.Errorf("Unexpected progress reports: got %v", reports)
+	}
+	cfg.Progress = nil
+	if plain, _ := MonteCarloPrice(option, 0.2, cfg); plain != complete {
+		t.Errorf("Unexpected result without a context: got %v, want %v", plain, complete)
+	}
+
+	// cancelling during the first batch stops the simulation before the second
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	cfg.Progress = func(done, total int) {
+		calls++
+		cancel()
+	}
+	result, err := MonteCarloPriceCtx(ctx, option, 0.2, cfg)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled: got %v", err)
+	}
+	if !math.IsNaN(result.Price) || !math.IsNaN(result.StdError) || !math.IsNaN(result.VarianceReduction) {
+		t.Errorf("Unexpected partial result after cancellation: got %v", result)
+	}
+	if calls != 1 {
+		t.Errorf("Unexpected batches after cancellation: got %d progress reports, want 1", calls)
+	}
+
+	calls = 0
+	if _, err := MonteCarloPriceCtx(ctx, option, 0.2, cfg); !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("Unexpected result for a cancelled context: got %v after %d progress reports", err, calls)
+	}
+}
+
+func TestMonteCarloPriceWorkers(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.02,
+	}
+	control := &ControlVariate{Payoff: GeometricAveragePayoff, Price: DiscreteGeometricAsianPrice(option, 0.3, 12)}
+
+	for _, sampler := range []Sampler{PseudoRandom, QuasiRandom} {
+		for _, antithetic := range []bool{false, true} {
+			cfg := MCConfig{Paths: 20001, Seed: 11, Sampler: sampler, Antithetic: antithetic, ControlVariate: control, BatchSize: 500, Workers: 1}
+			want, err := MonteCarloPathPrice(option, 0.3, ArithmeticAveragePayoff, 12, cfg)
+			if err != nil {
+				t.Fatalf("Unexpected error for sampler %v antithetic %v: %v", sampler, antithetic, err)
+			}
+			for _, workers := range []int{2, 8, 64} {
+				cfg.Workers = workers
+				if got, _ := MonteCarloPathPrice(option, 0.3, ArithmeticAveragePayoff, 12, cfg); got != want {
+					t.Errorf("Unexpected result for sampler %v antithetic %v with %d workers: got %v, want %v", sampler, antithetic, workers, got, want)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkMonteCarloPrice(b *testing.B) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			cfg := MCConfig{Paths: 1 << 18, Seed: 1, Workers: workers}
+			for i := 0; i < b.N; i++ {
+				MonteCarloPathPrice(option, 0.2, ArithmeticAveragePayoff, 12, cfg)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package finance
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+const (
+	mertonTailWeight = 1e-12  // Poisson probability left out of the series by the default number of terms
+	mertonMaxTerms   = 100000 // Largest number of terms the default may reach, for extreme jump intensities
+)
+
+// MertonJumpDiffusionPrice computes the Merton (1976) price of a European option on an underlying that diffuses with
+// the volatility and jumps at the Poisson intensity, each jump multiplying the price by a lognormal factor. Given n
+// jumps by expiration the underlying is lognormal, with variance σ²T + nδ² and forward S·e^{(r − q − λk)T}·(1 + k)ⁿ,
+// k = e^{μ + δ²/2} − 1 being the mean jump, so the price is the sum of the Black prices weighted by the Poisson
+// probabilities of n jumps. Without jumps it is the Black-Scholes price. The series is truncated after terms terms,
+// or by default once the Poisson probability left out is below 1e-12, the weights being computed in log space so
+// that high intensities do not underflow. At expiration it is worth its intrinsic value. Discrete dividends are
+// escrowed. NaN with negative days to expiration, a negative intensity or jump volatility
+// option: the option
+// vol: the volatility of the diffusion
+// jumpIntensity: the expected number of jumps per year λ
+// meanJump: the mean μ of the logarithm of the jump factor
+// jumpVol: the standard deviation δ of the logarithm of the jump factor
+// terms: the number of terms of the series, from that for no jumps, chosen to leave out less than 1e-12 of the
+// Poisson probability if not positive
+func MertonJumpDiffusionPrice(option Option, vol, jumpIntensity, meanJump, jumpVol float64, terms int) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration < 0 || !(jumpIntensity >= 0) || math.IsInf(jumpIntensity, 1) || !(jumpVol >= 0) || math.IsNaN(meanJump) {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		return option.IntrinsicValue()
+	}
+
+	timeToExpiration := option.DaysToExpiration / 365.0
+	expectedJumps := jumpIntensity * timeToExpiration
+	logGrowth := meanJump + 0.5*jumpVol*jumpVol
+	compensated := ForwardPrice(option.UnderlyingPrice, option.RiskFreeRate, option.DividendYield+jumpIntensity*math.Expm1(logGrowth), timeToExpiration)
+	limit := terms
+	if terms <= 0 {
+		limit = mertonMaxTerms
+	}
+
+	var price, cumulative float64
+	for n := 0; n < limit; n++ {
+		jumps := float64(n)
+		weight := 1.0
+		if expectedJumps > 0 {
+			logFactorial, _ := math.Lgamma(jumps + 1)
+			weight = math.Exp(-expectedJumps + jumps*math.Log(expectedJumps) - logFactorial)
+		} else if n > 0 {
+			weight = 0
+		}
+		variance := vol*vol*timeToExpiration + jumps*jumpVol*jumpVol
+		forward := compensated * math.Exp(jumps*logGrowth)
+		price += weight * BlackScholesForwardPrice(forward, option.Strike, timeToExpiration, option.RiskFreeRate, math.Sqrt(variance/timeToExpiration), option.OptionType)
+		cumulative += weight
+		// past the mode the weights only fall, so the probability left out is below 1 less the sum so far
+		if terms <= 0 && jumps >= expectedJumps && 1-cumulative < mertonTailWeight {
+			break
+		}
+	}
+	return price
+}
+
+// MertonCharacteristic returns the characteristic function of the logarithm of the underlying price at expiration
+// relative to its forward under the Merton jump-diffusion,
+// φ(u) = exp(T·(−σ²(iu + u²)/2 + λ·(e^{iuμ − δ²u²/2} − 1) − iuλk)) with k = e^{μ + δ²/2} − 1, for FFTPrice. The
+// function is NaN everywhere for a negative intensity or jump volatility
+// vol: the volatility of the diffusion
+// jumpIntensity: the expected number of jumps per year λ
+// meanJump: the mean μ of the logarithm of the jump factor
+// jumpVol: the standard deviation δ of the logarithm of the jump factor
+func MertonCharacteristic(vol, jumpIntensity, meanJump, jumpVol float64) CharacteristicFunc {
+	if !(jumpIntensity >= 0) || math.IsInf(jumpIntensity, 1) || !(jumpVol >= 0) || math.IsNaN(meanJump) {
+		return func(complex128, float64) complex128 { return cmplx.NaN() }
+	}
+	meanFactor := math.Expm1(meanJump + 0.5*jumpVol*jumpVol)
+	return func(u complex128, timeYears float64) complex128 {
+		iu := complex(0, 1) * u
+		jump := cmplx.Exp(iu*complex(meanJump, 0)-complex(0.5*jumpVol*jumpVol, 0)*u*u) - 1
+		exponent := complex(-0.5*vol*vol, 0)*(iu+u*u) + complex(jumpIntensity, 0)*(jump-iu*complex(meanFactor, 0))
+		return cmplx.Exp(exponent * complex(timeYears, 0))
+	}
+}
@@ -0,0 +1,85 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMertonJumpDiffusionPriceWithoutJumps(t *testing.T) {
+	for _, typ := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           105.0,
+			DaysToExpiration: 180.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       typ,
+			DividendYield:    0.02,
+		}
+		want := BlackScholesOptionPrice(option, 0.25)
+		if got := MertonJumpDiffusionPrice(option, 0.25, 0, -0.1, 0.3, 0); got != want {
+			t.Errorf("Unexpected price with zero intensity for %v: got %v, want %v", typ, got, want)
+		}
+		// jumps that leave the price unchanged do not matter however often they come, up to the truncated tail
+		if got := MertonJumpDiffusionPrice(option, 0.25, 5.0, 0, 0, 0); math.Abs(got-want) > 1e-12*want {
+			t.Errorf("Unexpected price with trivial jumps for %v: got %v, want %v", typ, got, want)
+		}
+	}
+}
+
+func TestMertonJumpDiffusionPriceConvergence(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	// fifty jumps a year on average, so the series needs well over a hundred terms
+	want := MertonJumpDiffusionPrice(option, 0.2, 50.0, -0.02, 0.05, 1000)
+	if got := MertonJumpDiffusionPrice(option, 0.2, 50.0, -0.02, 0.05, 0); math.Abs(got-want) > 1e-11 {
+		t.Errorf("Unexpected price with the default terms: got %v, want %v", got, want)
+	}
+	previous := 0.0
+	for _, terms := range []int{10, 30, 50, 70, 100, 150} {
+		got := MertonJumpDiffusionPrice(option, 0.2, 50.0, -0.02, 0.05, terms)
+		if got < previous {
+			t.Errorf("Unexpected partial sum with %d terms: got %v, below %v", terms, got, previous)
+		}
+		previous = got
+	}
+	if math.Abs(previous-want) > 1e-10 {
+		t.Errorf("Unexpected partial sum with 150 terms: got %v, want %v", previous, want)
+	}
+
+	// the call and put satisfy put-call parity, the compensated drift keeping the forward unchanged
+	put := option
+	put.OptionType = Put
+	got := MertonJumpDiffusionPrice(option, 0.2, 50.0, -0.02, 0.05, 0) - MertonJumpDiffusionPrice(put, 0.2, 50.0, -0.02, 0.05, 0)
+	if parity := 100.0 - 100.0*math.Exp(-0.05); math.Abs(got-parity) > 1e-10 {
+		t.Errorf("Unexpected put-call parity: got %v, want %v", got, parity)
+	}
+}
+
+func TestMertonJumpDiffusionPriceEdgeCases(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  110.0,
+		OptionType:       Call,
+	}
+	if got := MertonJumpDiffusionPrice(option, 0.2, 1.0, -0.1, 0.2, 0); got != 10.0 {
+		t.Errorf("Unexpected price at expiration: got %v, want 10", got)
+	}
+	option.DaysToExpiration = 90.0
+	for name, got := range map[string]float64{
+		"negative intensity":  MertonJumpDiffusionPrice(option, 0.2, -1.0, -0.1, 0.2, 0),
+		"negative jump vol":   MertonJumpDiffusionPrice(option, 0.2, 1.0, -0.1, -0.2, 0),
+		"infinite intensity":  MertonJumpDiffusionPrice(option, 0.2, math.Inf(1), -0.1, 0.2, 0),
+		"negative expiration": MertonJumpDiffusionPrice(Option{Strike: 100.0, DaysToExpiration: -1, UnderlyingPrice: 110.0}, 0.2, 1.0, -0.1, 0.2, 0),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package finance
+
+import "math"
+
+// IntrinsicValue returns the value of exercising the option now: max(S − K, 0) for calls and max(K − S, 0) for puts
+func (option Option) IntrinsicValue() float64 {
+	if option.OptionType == Call {
+		return math.Max(option.UnderlyingPrice-option.Strike, 0)
+	}
+	return math.Max(option.Strike-option.UnderlyingPrice, 0)
+}
+
+// TimeValue returns the Black-Scholes price of the option minus its intrinsic value, clamped at zero.
+// Deep in-the-money European puts, and calls on dividend-paying underlyings, can be worth less than their
+// intrinsic value because they cannot be exercised early; their time value is reported as zero rather than negative
+// vol: the volatility
+func (option Option) TimeValue(vol float64) float64 {
+	return math.Max(BlackScholesOptionPrice(option, vol)-option.IntrinsicValue(), 0)
+}
+
+// Moneyness returns the ratio of the underlying price to the strike, S/K
+func (option Option) Moneyness() float64 {
+	return option.UnderlyingPrice / option.Strike
+}
+
+// LogMoneyness returns the natural logarithm of the ratio of the underlying price to the strike, ln(S/K)
+func (option Option) LogMoneyness() float64 {
+	return math.Log(option.UnderlyingPrice / option.Strike)
+}
+
+// IsITM reports whether the option is in the money, i.e. has positive intrinsic value
+func (option Option) IsITM() bool {
+	if option.OptionType == Call {
+		return option.UnderlyingPrice > option.Strike
+	}
+	return option.UnderlyingPrice < option.Strike
+}
+
+// IsATM reports whether the underlying price is within a relative tolerance of the strike, |S/K − 1| ≤ tolerance.
+// A zero tolerance requires the underlying price to equal the strike exactly
+// tolerance: the relative tolerance
+func (option Option) IsATM(tolerance float64) bool {
+	return math.Abs(option.Moneyness()-1) <= tolerance
+}
+
+// IsOTM reports whether the option is out of the money. An option struck exactly at the underlying price
+// is neither in nor out of the money
+func (option Option) IsOTM() bool {
+	if option.OptionType == Call {
+		return option.UnderlyingPrice < option.Strike
+	}
+	return option.UnderlyingPrice > option.Strike
+}
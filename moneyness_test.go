@@ -0,0 +1,86 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMoneynessHelpers(t *testing.T) {
+	const tolerance = 1e-12
+
+	tests := []struct {
+		optionType    OptionType
+		spot          float64
+		intrinsic     float64
+		itm, atm, otm bool
+		description   string
+	}{
+		{Call, 110.0, 10.0, true, false, false, "in the money"},
+		{Call, 100.0, 0.0, false, true, false, "at the money"},
+		{Call, 90.0, 0.0, false, false, true, "out of the money"},
+		{Put, 90.0, 10.0, true, false, false, "in the money"},
+		{Put, 100.0, 0.0, false, true, false, "at the money"},
+		{Put, 110.0, 0.0, false, false, true, "out of the money"},
+	}
+
+	for _, test := range tests {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 60.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  test.spot,
+			OptionType:       test.optionType,
+		}
+
+		if got := option.IntrinsicValue(); got != test.intrinsic {
+			t.Errorf("Unexpected intrinsic value for %v type %v: got %v, want %v", test.description, test.optionType, got, test.intrinsic)
+		}
+		if got, want := option.TimeValue(0.2), math.Max(BlackScholesOptionPrice(option, 0.2)-test.intrinsic, 0); math.Abs(got-want) > tolerance {
+			t.Errorf("Unexpected time value for %v type %v: got %v, want %v", test.description, test.optionType, got, want)
+		}
+		if got, want := option.Moneyness(), test.spot/100.0; got != want {
+			t.Errorf("Unexpected moneyness for %v type %v: got %v, want %v", test.description, test.optionType, got, want)
+		}
+		if got, want := option.LogMoneyness(), math.Log(test.spot/100.0); math.Abs(got-want) > tolerance {
+			t.Errorf("Unexpected log moneyness for %v type %v: got %v, want %v", test.description, test.optionType, got, want)
+		}
+		if got := option.IsITM(); got != test.itm {
+			t.Errorf("Unexpected IsITM for %v type %v: got %v, want %v", test.description, test.optionType, got, test.itm)
+		}
+		if got := option.IsATM(0.01); got != test.atm {
+			t.Errorf("Unexpected IsATM for %v type %v: got %v, want %v", test.description, test.optionType, got, test.atm)
+		}
+		if got := option.IsOTM(); got != test.otm {
+			t.Errorf("Unexpected IsOTM for %v type %v: got %v, want %v", test.description, test.optionType, got, test.otm)
+		}
+	}
+}
+
+func TestIsATMTolerance(t *testing.T) {
+	option := Option{Strike: 100.0, UnderlyingPrice: 100.5, OptionType: Call}
+
+	if option.IsATM(0.001) {
+		t.Errorf("Unexpected IsATM within 0.1%%: got true, want false")
+	}
+	if !option.IsATM(0.01) {
+		t.Errorf("Unexpected IsATM within 1%%: got false, want true")
+	}
+}
+
+func TestTimeValueNeverNegative(t *testing.T) {
+	// a deep in-the-money European put is worth less than its intrinsic value
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.08,
+		UnderlyingPrice:  50.0,
+		OptionType:       Put,
+	}
+
+	if price := BlackScholesOptionPrice(option, 0.2); !(price < option.IntrinsicValue()) {
+		t.Fatalf("Unexpected price: got %v, want less than %v", price, option.IntrinsicValue())
+	}
+	if got := option.TimeValue(0.2); got != 0 {
+		t.Errorf("Unexpected time value: got %v, want 0", got)
+	}
+}
@@ -0,0 +1,83 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidConfidence is returned for a confidence level that is not strictly between 0 and 1
+var ErrInvalidConfidence = errors.New("finance: confidence level must be between 0 and 1")
+
+// ImpliedMove is the move of the underlying implied by the prices of an at-the-money straddle
+type ImpliedMove struct {
+	Straddle float64 `json:"straddle"` // Price of the call and the put together
+	Move     float64 `json:"move"`     // Expected absolute move to expiration, the straddle price compounded to expiration
+	Vol      float64 `json:"vol"`      // Implied volatility of the straddle, the average of the call's and the put's
+	Lower    float64 `json:"lower"`    // Lowest underlying price at expiration within the range of the confidence level
+	Upper    float64 `json:"upper"`    // Highest underlying price at expiration within the range of the confidence level
+}
+
+// ExpectedMove computes the one standard deviation move of the underlying price to an expiration, S·σ·√T, within
+// which it finishes about 68% of the time for short expirations. NaN for negative or undefined days
+// spot: the underlying price
+// vol: the volatility
+// days: the days to expiration
+func ExpectedMove(spot, vol, days float64) float64 {
+	if !(days >= 0) {
+		return math.NaN()
+	}
+	return spot * vol * math.Sqrt(days/365.0)
+}
+
+// StraddleImpliedMove computes the move implied by an at-the-money straddle as a fraction of the underlying price,
+// the straddle price over the spot. The straddle pays the absolute move, so that this is the expected absolute move,
+// √(2/π) ≈ 0.8 of the one standard deviation move of ExpectedMove for short expirations; about 85% of it is the
+// median absolute move, within which the underlying finishes half the time
+// callPrice: the price of the at-the-money call
+// putPrice: the price of the at-the-money put
+// spot: the underlying price
+func StraddleImpliedMove(callPrice, putPrice, spot float64) float64 {
+	return (callPrice + putPrice) / spot
+}
+
+// StraddleMove computes the move implied by the prices of a call and a put at the same strike, near the money, and
+// the range in which the underlying finishes with a confidence level, F·e^{−σ²T/2 ± z·σ√T} at the lognormal
+// quantiles z = ±N⁻¹((1 + c)/2) of the straddle's implied volatility
+// Returns ErrMismatchedPair when the options are not a matched pair, ErrInvalidConfidence for an invalid confidence
+// level, and the errors of ImpliedVolatility for either option
+// call: the call option, with its price
+// put: the put option, with its price
+// confidence: the probability that the underlying finishes within the range, between 0 and 1
+func StraddleMove(call, put Option, confidence float64) (ImpliedMove, error) {
+	nan := ImpliedMove{Straddle: math.NaN(), Move: math.NaN(), Vol: math.NaN(), Lower: math.NaN(), Upper: math.NaN()}
+	if err := checkParityPair(call, put); err != nil {
+		return nan, err
+	}
+	if !(confidence > 0 && confidence < 1) {
+		return nan, fmt.Errorf("%w: %v", ErrInvalidConfidence, confidence)
+	}
+	callVol, err := ImpliedVolatility(call)
+	if err != nil {
+		return nan, fmt.Errorf("call: %w", err)
+	}
+	putVol, err := ImpliedVolatility(put)
+	if err != nil {
+		return nan, fmt.Errorf("put: %w", err)
+	}
+
+	e := escrowed(call)
+	timeYears := e.DaysToExpiration / 365.0
+	vol := 0.5 * (callVol.Volatility + putVol.Volatility)
+	stdDev := vol * math.Sqrt(timeYears)
+	forward := ForwardPrice(e.UnderlyingPrice, e.RiskFreeRate, e.DividendYield, timeYears)
+	z := PhiInv(0.5 * (1 + confidence))
+	straddle := call.Price + put.Price
+	return ImpliedMove{
+		Straddle: straddle,
+		Move:     straddle * math.Exp(call.RiskFreeRate*timeYears),
+		Vol:      vol,
+		Lower:    forward * math.Exp(-0.5*stdDev*stdDev-z*stdDev),
+		Upper:    forward * math.Exp(-0.5*stdDev*stdDev+z*stdDev),
+	}, nil
+}
@@ -0,0 +1,91 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestExpectedMove(t *testing.T) {
+	// a 20% volatility over a quarter of a year is a 10% move
+	if got := ExpectedMove(100.0, 0.2, 365.0/4); math.Abs(got-10) > 1e-12 {
+		t.Errorf("Unexpected expected move: got %v, want 10", got)
+	}
+	if got := ExpectedMove(100.0, 0.2, 0); got != 0 {
+		t.Errorf("Unexpected expected move at expiration: got %v, want 0", got)
+	}
+	if got := ExpectedMove(100.0, 0.2, -1); !math.IsNaN(got) {
+		t.Errorf("Unexpected expected move for negative days: got %v, want NaN", got)
+	}
+	if got, want := StraddleImpliedMove(2.5, 1.5, 80.0), 0.05; math.Abs(got-want) > 1e-15 {
+		t.Errorf("Unexpected straddle implied move: got %v, want %v", got, want)
+	}
+}
+
+func TestStraddleMove(t *testing.T) {
+	call := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.04,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	put := call
+	put.OptionType = Put
+	call.Price = BlackScholesOptionPrice(call, 0.3)
+	put.Price = BlackScholesOptionPrice(put, 0.3)
+
+	move, err := StraddleMove(call, put, 0.5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(move.Vol-0.3) > 1e-6 {
+		t.Errorf("Unexpected straddle vol: got %v, want 0.3", move.Vol)
+	}
+	if got, want := move.Straddle, call.Price+put.Price; got != want {
+		t.Errorf("Unexpected straddle price: got %v, want %v", got, want)
+	}
+	// the straddle is the expected absolute move, √(2/π) of the one standard deviation move
+	if got, want := move.Move, ExpectedMove(100.0, 0.3, 30.0)*math.Sqrt(2/math.Pi); math.Abs(got/want-1) > 1e-2 {
+		t.Errorf("Unexpected move: got %v, want %v", got, want)
+	}
+	// the rule of thumb that the underlying finishes within 85% of the straddle half the time, 0.6745/0.7979
+	if got, want := 0.5*(move.Upper-move.Lower), 0.85*move.Straddle; math.Abs(got/want-1) > 2e-2 {
+		t.Errorf("Unexpected half-width of the 50%% range: got %v, want %v", got, want)
+	}
+	// the range has the confidence under the lognormal distribution
+	timeYears := 30.0 / 365
+	forward := 100 * math.Exp(0.04*timeYears)
+	stdDev := 0.3 * math.Sqrt(timeYears)
+	probability := func(level float64) float64 {
+		return Phi((math.Log(level/forward) + 0.5*stdDev*stdDev) / stdDev)
+	}
+	if got := probability(move.Upper) - probability(move.Lower); math.Abs(got-0.5) > 1e-6 {
+		t.Errorf("Unexpected probability of the range: got %v, want 0.5", got)
+	}
+	wide, err := StraddleMove(call, put, 0.95)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !(wide.Lower < move.Lower && wide.Upper > move.Upper) {
+		t.Errorf("Unexpected 95%% range [%v, %v] within the 50%% range [%v, %v]", wide.Lower, wide.Upper, move.Lower, move.Upper)
+	}
+
+	mismatched := put
+	mismatched.Strike = 105.0
+	for name, tc := range map[string]struct {
+		call, put  Option
+		confidence float64
+		want       error
+	}{
+		"mismatched strike": {call, mismatched, 0.5, ErrMismatchedPair},
+		"two calls":         {call, call, 0.5, ErrMismatchedPair},
+		"zero confidence":   {call, put, 0, ErrInvalidConfidence},
+		"full confidence":   {call, put, 1, ErrInvalidConfidence},
+		"nan confidence":    {call, put, math.NaN(), ErrInvalidConfidence},
+	} {
+		if _, err := StraddleMove(tc.call, tc.put, tc.confidence); !errors.Is(err, tc.want) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package finance
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns "call" or "put", or "OptionType(n)" for an invalid value
+func (t OptionType) String() string {
+	switch t {
+	case Call:
+		return "call"
+	case Put:
+		return "put"
+	}
+	return "OptionType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// ParseOptionType parses "call", "put", "C" or "P", ignoring case. Returns an error wrapping ErrInvalidOptionType
+// for any other string
+// s: the string
+func ParseOptionType(s string) (OptionType, error) {
+	switch strings.ToLower(s) {
+	case "call", "c":
+		return Call, nil
+	case "put", "p":
+		return Put, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidOptionType, s)
+}
+
+// MarshalJSON encodes the option type as the JSON string "call" or "put". Returns an error wrapping
+// ErrInvalidOptionType for an invalid value
+func (t OptionType) MarshalJSON() ([]byte, error) {
+	if t != Call && t != Put {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOptionType, t)
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes a JSON string accepted by ParseOptionType
+func (t *OptionType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidOptionType, data)
+	}
+	parsed, err := ParseOptionType(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
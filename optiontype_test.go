@@ -0,0 +1,88 @@
+package finance
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestOptionTypeString(t *testing.T) {
+	tests := []struct {
+		optionType OptionType
+		expected   string
+	}{
+		{Call, "call"},
+		{Put, "put"},
+		{OptionType(7), "OptionType(7)"},
+	}
+
+	for _, test := range tests {
+		if got := test.optionType.String(); got != test.expected {
+			t.Errorf("Unexpected string: got %v, want %v", got, test.expected)
+		}
+	}
+}
+
+func TestParseOptionType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected OptionType
+	}{
+		{"call", Call},
+		{"CALL", Call},
+		{"Call", Call},
+		{"c", Call},
+		{"C", Call},
+		{"put", Put},
+		{"PUT", Put},
+		{"p", Put},
+		{"P", Put},
+	}
+
+	for _, test := range tests {
+		got, err := ParseOptionType(test.input)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", test.input, err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("Unexpected option type for %q: got %v, want %v", test.input, got, test.expected)
+		}
+	}
+
+	for _, input := range []string{"", "calls", "x", " call", "1"} {
+		if _, err := ParseOptionType(input); !errors.Is(err, ErrInvalidOptionType) {
+			t.Errorf("Unexpected error for %q: got %v, want %v", input, err, ErrInvalidOptionType)
+		}
+	}
+}
+
+func TestOptionTypeJSON(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		data, err := json.Marshal(optionType)
+		if err != nil {
+			t.Errorf("Unexpected error marshalling %v: %v", optionType, err)
+			continue
+		}
+		if want := `"` + optionType.String() + `"`; string(data) != want {
+			t.Errorf("Unexpected JSON: got %s, want %s", data, want)
+		}
+
+		var decoded OptionType
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("Unexpected error unmarshalling %s: %v", data, err)
+		} else if decoded != optionType {
+			t.Errorf("Unexpected option type: got %v, want %v", decoded, optionType)
+		}
+	}
+
+	if _, err := json.Marshal(OptionType(7)); !errors.Is(err, ErrInvalidOptionType) {
+		t.Errorf("Unexpected marshal error: got %v, want %v", err, ErrInvalidOptionType)
+	}
+	for _, input := range []string{`"straddle"`, `0`, `null`, `{}`} {
+		var decoded OptionType
+		if err := json.Unmarshal([]byte(input), &decoded); !errors.Is(err, ErrInvalidOptionType) {
+			t.Errorf("Unexpected unmarshal error for %s: got %v, want %v", input, err, ErrInvalidOptionType)
+		}
+	}
+}
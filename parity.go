@@ -0,0 +1,55 @@
+package finance
+
+import (
+	"math"
+	"slices"
+)
+
+// PutFromCall computes the price of the European put matching a call through put-call parity,
+// P = C − S·e^{−qT} + K·e^{−rT}, with discrete dividends taken out of the underlying price.
+// Returns NaN when call is not a call
+// call: the call option, whose Price is ignored
+// callPrice: the call price
+func PutFromCall(call Option, callPrice float64) float64 {
+	if call.OptionType != Call {
+		return math.NaN()
+	}
+	return callPrice - parityForwardValue(call)
+}
+
+// CallFromPut computes the price of the European call matching a put through put-call parity,
+// C = P + S·e^{−qT} − K·e^{−rT}, with discrete dividends taken out of the underlying price.
+// Returns NaN when put is not a put
+// put: the put option, whose Price is ignored
+// putPrice: the put price
+func CallFromPut(put Option, putPrice float64) float64 {
+	if put.OptionType != Put {
+		return math.NaN()
+	}
+	return putPrice + parityForwardValue(put)
+}
+
+// ParityGap measures how far the quoted prices of a call and a put deviate from put-call parity,
+// (C − P) − (S·e^{−qT} − K·e^{−rT}); a positive gap means the call is rich relative to the put.
+// Returns NaN when the options are not a matched pair (see ImpliedRateFromParity) or differ in
+// rate, dividend yield or dividends
+// call: the call option
+// put: the put option
+func ParityGap(call, put Option) float64 {
+	if err := checkParityPair(call, put); err != nil {
+		return math.NaN()
+	}
+	if call.RiskFreeRate != put.RiskFreeRate || call.DividendYield != put.DividendYield || !slices.Equal(call.Dividends, put.Dividends) {
+		return math.NaN()
+	}
+	return call.Price - put.Price - parityForwardValue(call)
+}
+
+// parityForwardValue computes the present value of a forward struck at the option's strike, S·e^{−qT} − K·e^{−rT},
+// which is the value of a long call and short put
+// option: the option
+func parityForwardValue(option Option) float64 {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	return option.UnderlyingPrice*math.Exp(-option.DividendYield*timeToExpiration) - option.Strike*math.Exp(-option.RiskFreeRate*timeToExpiration)
+}
@@ -0,0 +1,84 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPutCallParityConversions(t *testing.T) {
+	const tolerance = 1e-10
+
+	for _, dividendYield := range []float64{0.0, 0.025} {
+		for _, strike := range []float64{80.0, 100.0, 125.0} {
+			call := Option{
+				Strike:           strike,
+				DaysToExpiration: 120.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice:  100.0,
+				OptionType:       Call,
+				DividendYield:    dividendYield,
+				Dividends:        []Dividend{{Amount: 1.0, DaysToExDate: 45.0}},
+			}
+			put := call
+			put.OptionType = Put
+			callPrice, putPrice := BlackScholesOptionPrice(call, 0.3), BlackScholesOptionPrice(put, 0.3)
+
+			if got := PutFromCall(call, callPrice); math.Abs(got-putPrice) > tolerance {
+				t.Errorf("Unexpected put for yield %v strike %v: got %v, want %v", dividendYield, strike, got, putPrice)
+			}
+			if got := CallFromPut(put, putPrice); math.Abs(got-callPrice) > tolerance {
+				t.Errorf("Unexpected call for yield %v strike %v: got %v, want %v", dividendYield, strike, got, callPrice)
+			}
+
+			call.Price, put.Price = callPrice, putPrice
+			if gap := ParityGap(call, put); math.Abs(gap) > tolerance {
+				t.Errorf("Unexpected parity gap for yield %v strike %v: got %v, want 0", dividendYield, strike, gap)
+			}
+			call.Price += 0.15
+			if gap := ParityGap(call, put); math.Abs(gap-0.15) > tolerance {
+				t.Errorf("Unexpected parity gap for rich call, yield %v strike %v: got %v, want %v", dividendYield, strike, gap, 0.15)
+			}
+		}
+	}
+}
+
+func TestPutCallParityMismatches(t *testing.T) {
+	call := Option{
+		Price:            5.0,
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	put := call
+	put.OptionType = Put
+	put.Price = 4.5
+
+	if got := PutFromCall(put, 5.0); !math.IsNaN(got) {
+		t.Errorf("Unexpected put from a put: got %v, want NaN", got)
+	}
+	if got := CallFromPut(call, 5.0); !math.IsNaN(got) {
+		t.Errorf("Unexpected call from a call: got %v, want NaN", got)
+	}
+
+	tests := []struct {
+		description string
+		modify      func(*Option)
+	}{
+		{"strike", func(o *Option) { o.Strike = 105.0 }},
+		{"expiration", func(o *Option) { o.DaysToExpiration = 31.0 }},
+		{"underlying", func(o *Option) { o.UnderlyingPrice = 101.0 }},
+		{"rate", func(o *Option) { o.RiskFreeRate = 0.04 }},
+		{"dividend yield", func(o *Option) { o.DividendYield = 0.01 }},
+		{"dividends", func(o *Option) { o.Dividends = []Dividend{{Amount: 1.0, DaysToExDate: 10.0}} }},
+		{"type", func(o *Option) { o.OptionType = Call }},
+	}
+	for _, test := range tests {
+		mismatched := put
+		test.modify(&mismatched)
+		if gap := ParityGap(call, mismatched); !math.IsNaN(gap) {
+			t.Errorf("Unexpected parity gap for mismatched %v: got %v, want NaN", test.description, gap)
+		}
+	}
+}
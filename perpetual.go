@@ -0,0 +1,52 @@
+package finance
+
+import (
+	"math"
+)
+
+// PerpetualAmericanPrice computes the price of an American option that never expires, and the underlying price at
+// which it is optimal to exercise it, from the roots β± = 1/2 − b/σ² ± √((b/σ² − 1/2)² + 2r/σ²) of the fundamental
+// quadratic, b being the cost of carry r − q. A call is exercised at S* = K·β₊/(β₊ − 1) and worth (S* − K)·(S/S*)^β₊
+// below it; a put is exercised at S* = K·β₋/(β₋ − 1) and worth (K − S*)·(S/S*)^β₋ above it. Without a positive
+// dividend yield a call is never exercised and is worth the underlying price, with an infinite threshold; it bounds
+// from above the value of any American option with the same strike. NaN for non-positive prices or volatility, and
+// for a put without a positive rate, which is then never exercised and never attains its supremum, or rates for which
+// the quadratic has no real roots
+// spot: the underlying price
+// strike: the strike price
+// r: the risk-free interest rate
+// q: the continuous dividend yield
+// vol: the volatility
+// typ: the type of the option
+func PerpetualAmericanPrice(spot, strike, r, q, vol float64, typ OptionType) (float64, float64) {
+	if !(spot > 0) || !(strike > 0) || !(vol > 0) || math.IsInf(spot, 1) || math.IsInf(vol, 1) || math.IsNaN(r) || math.IsNaN(q) {
+		return math.NaN(), math.NaN()
+	}
+	if typ == Call && q <= 0 {
+		return spot, math.Inf(1)
+	}
+	if typ == Put && r <= 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	variance := vol * vol
+	half := (r-q)/variance - 0.5
+	discriminant := half*half + 2*r/variance
+	if discriminant < 0 {
+		return math.NaN(), math.NaN()
+	}
+	if typ == Call {
+		beta := -half + math.Sqrt(discriminant)
+		threshold := strike * beta / (beta - 1)
+		if spot >= threshold {
+			return spot - strike, threshold
+		}
+		return (threshold - strike) * math.Pow(spot/threshold, beta), threshold
+	}
+	beta := -half - math.Sqrt(discriminant)
+	threshold := strike * beta / (beta - 1)
+	if spot <= threshold {
+		return strike - spot, threshold
+	}
+	return (strike - threshold) * math.Pow(spot/threshold, beta), threshold
+}
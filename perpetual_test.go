@@ -0,0 +1,67 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPerpetualAmericanPrice(t *testing.T) {
+	const (
+		strike = 100.0
+		vol    = 0.3
+		bump   = 1e-3
+	)
+	for _, typ := range []OptionType{Call, Put} {
+		for _, spot := range []float64{40.0, 70.0, 100.0, 150.0, 300.0} {
+			price, threshold := PerpetualAmericanPrice(spot, strike, 0.05, 0.03, vol, typ)
+			continuing := (typ == Call && spot < threshold) || (typ == Put && spot > threshold)
+			if !continuing {
+				if want := math.Abs(spot - strike); math.Abs(price-want) > 1e-12 {
+					t.Errorf("Unexpected exercised price for type %v spot %v: got %v, want %v", typ, spot, price, want)
+				}
+				continue
+			}
+			// the price solves ½σ²S²V'' + (r − q)SV' − rV = 0 where the option is held
+			up, _ := PerpetualAmericanPrice(spot+bump, strike, 0.05, 0.03, vol, typ)
+			down, _ := PerpetualAmericanPrice(spot-bump, strike, 0.05, 0.03, vol, typ)
+			delta, gamma := (up-down)/(2*bump), (up-2*price+down)/(bump*bump)
+			if residual := 0.5*vol*vol*spot*spot*gamma + 0.02*spot*delta - 0.05*price; math.Abs(residual) > 1e-4 {
+				t.Errorf("Unexpected residual for type %v spot %v: got %v, want 0", typ, spot, residual)
+			}
+		}
+
+		// value and delta match the exercise value at the threshold
+		_, threshold := PerpetualAmericanPrice(strike, strike, 0.05, 0.03, vol, typ)
+		held := threshold * (1 + 1e-6)
+		if typ == Call {
+			held = threshold * (1 - 1e-6)
+		}
+		price, _ := PerpetualAmericanPrice(held, strike, 0.05, 0.03, vol, typ)
+		next, _ := PerpetualAmericanPrice(held*(1+1e-7), strike, 0.05, 0.03, vol, typ)
+		exercise, slope := held-strike, 1.0
+		if typ == Put {
+			exercise, slope = strike-held, -1.0
+		}
+		if math.Abs(price-exercise) > 1e-8 || math.Abs((next-price)/(held*1e-7)-slope) > 1e-4 {
+			t.Errorf("Unexpected smooth pasting for type %v at %v: got value %v and delta %v, want %v and %v", typ, held, price, (next-price)/(held*1e-7), exercise, slope)
+		}
+	}
+
+	// a long-dated American put approaches the perpetual one from below
+	perpetual, _ := PerpetualAmericanPrice(100.0, strike, 0.05, 0.03, vol, Put)
+	option := Option{Strike: strike, DaysToExpiration: 100 * 365, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Put, DividendYield: 0.03}
+	if got := BinomialOptionPrice(option, vol, 4000, American); !(got <= perpetual && got > perpetual-0.05) {
+		t.Errorf("Unexpected 100-year American put: got %v, want just below %v", got, perpetual)
+	}
+
+	// without dividends a call is never exercised
+	if price, threshold := PerpetualAmericanPrice(80.0, strike, 0.05, 0, vol, Call); price != 80.0 || !math.IsInf(threshold, 1) {
+		t.Errorf("Unexpected dividend-free perpetual call: got %v and threshold %v, want 80 and +Inf", price, threshold)
+	}
+	if price, threshold := PerpetualAmericanPrice(80.0, strike, 0, 0.02, vol, Put); !math.IsNaN(price) || !math.IsNaN(threshold) {
+		t.Errorf("Unexpected zero-rate perpetual put: got %v and threshold %v, want NaN", price, threshold)
+	}
+	if price, _ := PerpetualAmericanPrice(80.0, strike, 0.05, 0.02, 0, Put); !math.IsNaN(price) {
+		t.Errorf("Unexpected price with zero volatility: got %v, want NaN", price)
+	}
+}
@@ -0,0 +1,124 @@
+package finance
+
+import "math"
+
+// Portfolio is a position in options on one underlying, and in the underlying itself
+type Portfolio struct {
+	Legs       []Leg   `json:"legs"`                  // Option legs
+	Shares     float64 `json:"shares,omitempty"`      // Units of the underlying held, negative when short
+	SharePrice float64 `json:"share_price,omitempty"` // Price of a unit of the underlying
+}
+
+// Greeks computes the net Black-Scholes price and Greeks of the portfolio, those of LegGreeks with the shares
+// adding their value to the price and one each to the delta
+func (p Portfolio) Greeks() Greeks {
+	net := LegGreeks(p.Legs)
+	net.Price += p.Shares * p.SharePrice
+	net.Delta += p.Shares
+	return net
+}
+
+// Cost returns the premium paid to open the portfolio at the prices of its options and shares, negative for a
+// credit received
+func (p Portfolio) Cost() float64 {
+	cost := p.Shares * p.SharePrice
+	for _, leg := range p.Legs {
+		cost += legSign(leg) * leg.Quantity * leg.Option.Price
+	}
+	return cost
+}
+
+// ValueAt computes the value of the portfolio at the first expiration of its legs with the underlying at a price.
+// The legs expiring then are worth their intrinsic value, and those expiring later their Black-Scholes value at
+// their volatility over the time they have left, with the discrete dividends still to be paid
+// price: the underlying price at the first expiration
+func (p Portfolio) ValueAt(price float64) float64 {
+	first := p.firstExpiration()
+	value := p.Shares * price
+	for _, leg := range p.Legs {
+		option := leg.Option
+		option.UnderlyingPrice = price
+		option.DaysToExpiration -= first
+		option.Dividends = nil
+		for _, dividend := range leg.Option.Dividends {
+			if dividend.DaysToExDate > first {
+				option.Dividends = append(option.Dividends, Dividend{Amount: dividend.Amount, DaysToExDate: dividend.DaysToExDate - first})
+			}
+		}
+		legValue := option.IntrinsicValue()
+		if option.DaysToExpiration > 0 {
+			legValue = BlackScholesOptionPrice(option, leg.Volatility)
+		}
+		value += legSign(leg) * leg.Quantity * legValue
+	}
+	return value
+}
+
+// ProfitAt computes the profit of the portfolio at the first expiration of its legs with the underlying at a price,
+// its ValueAt less its Cost, the interest on the premium aside
+// price: the underlying price at the first expiration
+func (p Portfolio) ProfitAt(price float64) float64 {
+	return p.ValueAt(price) - p.Cost()
+}
+
+// MaxLoss returns the largest loss of the portfolio at expiration, negative for a portfolio that profits at any price,
+// and infinite when the loss grows without bound with the underlying price. The profit of legs that expire together
+// is linear between their strikes, so that its minimum is at a strike, at a zero underlying price, or at an infinite
+// one if its slope beyond the highest strike is negative. NaN for legs that expire at different times, whose profit
+// is not piecewise linear
+func (p Portfolio) MaxLoss() float64 {
+	return -p.extremeProfit(-1)
+}
+
+// MaxProfit returns the largest profit of the portfolio at expiration, negative for a portfolio that cannot profit,
+// and infinite when the profit grows without bound with the underlying price, as for MaxLoss. NaN for legs that
+// expire at different times
+func (p Portfolio) MaxProfit() float64 {
+	return p.extremeProfit(1)
+}
+
+// extremeProfit finds the largest or smallest profit at expiration of a portfolio whose legs expire together, at a
+// zero underlying price, at a strike or beyond the highest strike
+// sign: 1 for the largest profit, −1 for the smallest
+func (p Portfolio) extremeProfit(sign float64) float64 {
+	first := p.firstExpiration()
+	// beyond the highest strike the profit moves with the calls and the shares
+	slope := p.Shares
+	for _, leg := range p.Legs {
+		if leg.Option.DaysToExpiration != first {
+			return math.NaN()
+		}
+		if leg.Option.OptionType == Call {
+			slope += legSign(leg) * leg.Quantity
+		}
+	}
+	if sign*slope > 0 {
+		return sign * math.Inf(1)
+	}
+	extreme := sign * p.ProfitAt(0)
+	for _, leg := range p.Legs {
+		extreme = math.Max(extreme, sign*p.ProfitAt(leg.Option.Strike))
+	}
+	return sign * extreme
+}
+
+// firstExpiration returns the days to the earliest expiration of the legs, zero without legs
+func (p Portfolio) firstExpiration() float64 {
+	if len(p.Legs) == 0 {
+		return 0
+	}
+	first := math.Inf(1)
+	for _, leg := range p.Legs {
+		first = math.Min(first, leg.Option.DaysToExpiration)
+	}
+	return first
+}
+
+// legSign returns 1 for a long leg and −1 for a short one
+// leg: the leg
+func legSign(leg Leg) float64 {
+	if leg.Side == Short {
+		return -1
+	}
+	return 1
+}
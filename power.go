@@ -0,0 +1,43 @@
+package finance
+
+import (
+	"math"
+)
+
+// PowerOptionPrice computes the price of a power option, with payoff (Sᵢ − K)⁺ for a call and (K − Sᵢ)⁺ for a put, Sᵢ
+// being the underlying price at expiration raised to the power i. Sᵢ is lognormal with volatility |i|·σ and
+// forward Sⁱ·e^{i(r − q)T + i(i − 1)σ²T/2}, so the price is the Black formula on it. With a cap the payoff is limited
+// to the cap, a call spread struck at K and K + cap, or a put spread struck at K and K − cap. Every moment of the
+// lognormal distribution exists, so the price is finite for any power, but with a power above 1 the forward grows like
+// e^{i(i − 1)σ²T/2}, and an uncapped long-dated option is priced almost entirely by scenarios far in the tail; the
+// price is NaN once the forward overflows. At expiration it is worth its payoff. Discrete dividends are escrowed.
+// NaN with negative days to expiration, a zero or non-finite power or a non-positive cap
+// option: the option, whose strike is compared with the powered underlying price
+// vol: the volatility
+// power: the power i the underlying price is raised to
+// capped: whether the payoff is capped
+// payoffCap: the cap on the payoff, unused if not capped
+func PowerOptionPrice(option Option, vol float64, power float64, capped bool, payoffCap float64) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration < 0 || power == 0 || math.IsNaN(power) || math.IsInf(power, 0) || (capped && !(payoffCap > 0)) {
+		return math.NaN()
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	carry := option.RiskFreeRate - option.DividendYield
+	forward := math.Exp(power*math.Log(option.UnderlyingPrice) + power*carry*timeToExpiration + 0.5*power*(power-1)*vol*vol*timeToExpiration)
+	if math.IsInf(forward, 1) {
+		return math.NaN()
+	}
+	poweredVol := math.Abs(power) * vol
+	price := BlackScholesForwardPrice(forward, option.Strike, timeToExpiration, option.RiskFreeRate, poweredVol, option.OptionType)
+	if !capped {
+		return price
+	}
+	if option.OptionType == Call {
+		return price - BlackScholesForwardPrice(forward, option.Strike+payoffCap, timeToExpiration, option.RiskFreeRate, poweredVol, Call)
+	}
+	if option.Strike <= payoffCap {
+		return price
+	}
+	return price - BlackScholesForwardPrice(forward, option.Strike-payoffCap, timeToExpiration, option.RiskFreeRate, poweredVol, Put)
+}
@@ -0,0 +1,85 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPowerOptionPrice(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.02,
+	}
+	for _, optionType := range []OptionType{Call, Put} {
+		option.OptionType = optionType
+		// a power of 1 is the vanilla option
+		if got, want := PowerOptionPrice(option, 0.25, 1, false, 0), BlackScholesOptionPrice(option, 0.25); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Unexpected unit power price for type %v: got %v, want %v", optionType, got, want)
+		}
+
+		// squared and capped payoffs against the discounted expected payoff, integrated by the midpoint rule
+		for _, c := range []struct {
+			strike, power float64
+			capped        bool
+			cap           float64
+		}{
+			{10000.0, 2, false, 0},
+			{9000.0, 2, true, 2000.0},
+			{11000.0, 2, true, 2000.0},
+			{0.01, -1, false, 0},
+			{300.0, 1.5, true, 100.0},
+		} {
+			option.Strike = c.strike
+			timeToExpiration := option.DaysToExpiration / 365.0
+			const steps = 20000
+			var sum float64
+			for i := range steps {
+				z := -10 + 20*(float64(i)+0.5)/steps
+				spot := 100.0 * math.Exp((0.03-0.5*0.25*0.25)*timeToExpiration+0.25*math.Sqrt(timeToExpiration)*z)
+				payoff := math.Max(math.Pow(spot, c.power)-c.strike, 0)
+				if optionType == Put {
+					payoff = math.Max(c.strike-math.Pow(spot, c.power), 0)
+				}
+				if c.capped {
+					payoff = math.Min(payoff, c.cap)
+				}
+				sum += 20.0 / steps * NormalDistributionDerivative(z) * payoff
+			}
+			want := math.Exp(-0.05*timeToExpiration) * sum
+			if got := PowerOptionPrice(option, 0.25, c.power, c.capped, c.cap); math.Abs(got-want) > 1e-6*math.Max(1, want) {
+				t.Errorf("Unexpected price for type %v power %v strike %v cap %v: got %v, want %v", optionType, c.power, c.strike, c.cap, got, want)
+			}
+		}
+	}
+
+	option.Strike, option.OptionType, option.DaysToExpiration = 10000.0, Call, 0
+	option.UnderlyingPrice = 110.0
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"payoff at expiration", PowerOptionPrice(option, 0.25, 2, false, 0), 2100.0},
+		{"capped payoff at expiration", PowerOptionPrice(option, 0.25, 2, true, 1000.0), 1000.0},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("Unexpected %s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	option.DaysToExpiration = 3650.0
+	for name, got := range map[string]float64{
+		"overflowing forward": PowerOptionPrice(option, 2, 20, false, 0),
+		"zero power":          PowerOptionPrice(option, 0.25, 0, false, 0),
+		"zero cap":            PowerOptionPrice(option, 0.25, 2, true, 0),
+		"negative days":       PowerOptionPrice(Option{Strike: 100.0, DaysToExpiration: -1, UnderlyingPrice: 100.0}, 0.25, 2, false, 0),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package finance
+
+import "math"
+
+// ProbabilityITM computes the risk-neutral probability that an option expires in the money, N(d2) for a call and
+// N(−d2) for a put. At expiration it is 1 for an option in the money and 0 otherwise. NaN for an option that cannot
+// be priced at the volatility
+// option: the option
+// vol: the volatility
+func ProbabilityITM(option Option, vol float64) float64 {
+	if err := validatePricing(option, vol); err != nil {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		if escrowed(option).IsITM() {
+			return 1
+		}
+		return 0
+	}
+	_, d2 := blackScholesD1D2(option, vol)
+	if option.OptionType == Put {
+		return PhiC(d2)
+	}
+	return Phi(d2)
+}
+
+// RealWorldProbabilityITM computes the probability that an option expires in the money with the underlying growing
+// at an expected return instead of the risk-free rate, as ProbabilityITM with the drift in place of the rate. The
+// dividend yield still lowers the growth of the price. NaN for an option that cannot be priced at the volatility or
+// a drift that is not finite
+// option: the option
+// vol: the volatility
+// drift: the expected return of the underlying, continuously compounded
+func RealWorldProbabilityITM(option Option, vol, drift float64) float64 {
+	if math.IsNaN(drift) || math.IsInf(drift, 0) {
+		return math.NaN()
+	}
+	option.RiskFreeRate = drift
+	return ProbabilityITM(option, vol)
+}
+
+// ProbabilityOfTouch computes the risk-neutral probability that the underlying touches a level before expiration,
+// from the reflected first-passage distribution of geometric Brownian motion, as for OneTouchPrice. For a level
+// near the forward it is close to twice the probability of finishing beyond the level, as a path that finishes
+// beyond it has touched it, and one that has touched it is about as likely to finish on either side. It is 1 with
+// the underlying at the level and 0 at expiration otherwise. Discrete dividends are escrowed, the level then
+// applying to the escrowed underlying price. NaN for negative days to expiration, or a volatility or level that is
+// not positive and finite
+// option: the option, whose strike and type are ignored
+// vol: the volatility
+// level: the price level
+func ProbabilityOfTouch(option Option, vol, level float64) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration < 0 || !(vol > 0) || math.IsInf(vol, 1) || !(level > 0) || math.IsInf(level, 1) {
+		return math.NaN()
+	}
+	switch {
+	case option.UnderlyingPrice == level:
+		return 1
+	case option.DaysToExpiration == 0:
+		return 0
+	}
+	return 1 - noTouchProbability(option, vol, level)
+}
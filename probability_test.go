@@ -0,0 +1,91 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProbabilityITM(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.01,
+	}
+	// at the money the call is a little more likely than not to finish in the money, by the drift less half the
+	// variance, and the call and put probabilities add up to one
+	want := Phi((0.05 - 0.01 - 0.5*0.2*0.2) / 0.2)
+	if got := ProbabilityITM(option, 0.2); math.Abs(got-want) > 1e-15 || !(got > 0.5) {
+		t.Errorf("Unexpected probability of an at-the-money call: got %v, want %v", got, want)
+	}
+	put := option
+	put.OptionType = Put
+	if got := ProbabilityITM(put, 0.2) + ProbabilityITM(option, 0.2); math.Abs(got-1) > 1e-15 {
+		t.Errorf("Unexpected total probability of a call and a put: got %v, want 1", got)
+	}
+
+	// a higher expected return makes the call likelier to finish in the money
+	if got, want := RealWorldProbabilityITM(option, 0.2, 0.12), Phi((0.12-0.01-0.5*0.2*0.2)/0.2); math.Abs(got-want) > 1e-15 {
+		t.Errorf("Unexpected real-world probability: got %v, want %v", got, want)
+	}
+	if got := RealWorldProbabilityITM(option, 0.2, 0.05); got != ProbabilityITM(option, 0.2) {
+		t.Errorf("Unexpected real-world probability at the risk-free rate: got %v, want %v", got, ProbabilityITM(option, 0.2))
+	}
+
+	expired := option
+	expired.DaysToExpiration = 0
+	expired.Strike = 90.0
+	if got := ProbabilityITM(expired, 0.2); got != 1 {
+		t.Errorf("Unexpected probability of an expired call in the money: got %v, want 1", got)
+	}
+	expired.Strike = 100.0
+	if got := ProbabilityITM(expired, 0.2); got != 0 {
+		t.Errorf("Unexpected probability of an expired call at the money: got %v, want 0", got)
+	}
+
+	for name, got := range map[string]float64{
+		"zero vol":  ProbabilityITM(option, 0),
+		"nan drift": RealWorldProbabilityITM(option, 0.2, math.NaN()),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected probability for %s: got %v, want NaN", name, got)
+		}
+	}
+}
+
+func TestProbabilityOfTouch(t *testing.T) {
+	option := Option{DaysToExpiration: 30.0, RiskFreeRate: 0.03, UnderlyingPrice: 100.0}
+	// near the money, about twice the probability of finishing beyond the level
+	for _, level := range []float64{97.0, 103.0} {
+		beyond := Option{Strike: level, DaysToExpiration: 30.0, RiskFreeRate: 0.03, UnderlyingPrice: 100.0, OptionType: Call}
+		if level < 100.0 {
+			beyond.OptionType = Put
+		}
+		itm := ProbabilityITM(beyond, 0.25)
+		if got := ProbabilityOfTouch(option, 0.25, level); math.Abs(got-2*itm) > 0.02 || !(got > itm) {
+			t.Errorf("Unexpected probability of touching %v: got %v, want about %v", level, got, 2*itm)
+		}
+	}
+	// the complement of the no-touch probability, undiscounted
+	if got, want := ProbabilityOfTouch(option, 0.25, 110.0), 1-NoTouchPrice(option, 0.25, 110.0)*math.Exp(0.03*30.0/365.0); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected probability of touch: got %v, want %v", got, want)
+	}
+	if got := ProbabilityOfTouch(option, 0.25, 100.0); got != 1 {
+		t.Errorf("Unexpected probability of touching the underlying price: got %v, want 1", got)
+	}
+	expired := option
+	expired.DaysToExpiration = 0
+	if got := ProbabilityOfTouch(expired, 0.25, 105.0); got != 0 {
+		t.Errorf("Unexpected probability of touch at expiration: got %v, want 0", got)
+	}
+	for name, got := range map[string]float64{
+		"zero level": ProbabilityOfTouch(option, 0.25, 0),
+		"zero vol":   ProbabilityOfTouch(option, 0, 105.0),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected probability for %s: got %v, want NaN", name, got)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntegrate(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() (float64, bool)
+		want float64
+	}{
+		{"polynomial", func() (float64, bool) { return integrate(func(x float64) float64 { return x * x * x }, 0, 2, 1e-14) }, 4},
+		{"oscillating", func() (float64, bool) { return integrate(math.Cos, 0, 50, 1e-12) }, math.Sin(50)},
+		{"kink", func() (float64, bool) { return integrate(math.Abs, -1, 3, 1e-12) }, 5},
+		{"normal density", func() (float64, bool) { return integrateToInfinity(NormalDistributionDerivative, 0, 1e-13) }, 0.5},
+		{"lorentzian", func() (float64, bool) {
+			return integrateToInfinity(func(x float64) float64 { return 1 / (1 + x*x) }, 1, 1e-13)
+		}, math.Pi / 4},
+	}
+	for _, test := range tests {
+		got, ok := test.got()
+		if !ok || math.Abs(got-test.want) > 1e-11 {
+			t.Errorf("Unexpected integral of %s: got %v, %v; want %v", test.name, got, ok, test.want)
+		}
+	}
+	if _, ok := integrate(func(x float64) float64 { return 1 / x }, 0, 1, 1e-12); ok {
+		t.Errorf("Unexpected convergence for a divergent integral")
+	}
+}
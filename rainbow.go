@@ -0,0 +1,93 @@
+package finance
+
+import (
+	"math"
+)
+
+// RainbowKind is whether a two-asset rainbow option is written on the better or the worse of its two assets
+type RainbowKind int
+
+const (
+	CallOnMax RainbowKind = iota // Call on the higher of the two prices, paying (max(S1, S2) − K)⁺
+	CallOnMin                    // Call on the lower of the two prices, paying (min(S1, S2) − K)⁺
+	PutOnMax                     // Put on the higher of the two prices, paying (K − max(S1, S2))⁺
+	PutOnMin                     // Put on the lower of the two prices, paying (K − min(S1, S2))⁺
+)
+
+// RainbowTwoAssetPrice computes the Stulz (1982) price of a European option on the maximum or minimum of two assets,
+// with bivariate normal probabilities over the two assets and their ratio. The calls are in closed form and the puts
+// follow from parity, as K·e^{−rT} less the value of receiving the maximum or minimum, which Margrabe's exchange
+// option prices, plus the call. With the two assets perfectly correlated and equally volatile their ratio is fixed
+// and the option is a vanilla option on the one with the better or worse forward. The option's type is ignored in
+// favour of the kind. At expiration it is worth its payoff. NaN for negative days to expiration, a non-positive
+// strike, a correlation outside [-1, 1] or an unknown kind
+// option: the option
+// kind: the kind of rainbow
+func RainbowTwoAssetPrice(option TwoAssetOption, kind RainbowKind) float64 {
+	if option.DaysToExpiration < 0 || !(option.Strike > 0) || !(option.Correlation >= -1 && option.Correlation <= 1) ||
+		kind < CallOnMax || kind > PutOnMin {
+		return math.NaN()
+	}
+	s1, s2, strike := option.UnderlyingPrice1, option.UnderlyingPrice2, option.Strike
+	onMax := kind == CallOnMax || kind == PutOnMax
+	if option.DaysToExpiration == 0 {
+		level := math.Min(s1, s2)
+		if onMax {
+			level = math.Max(s1, s2)
+		}
+		if kind == CallOnMax || kind == CallOnMin {
+			return math.Max(level-strike, 0)
+		}
+		return math.Max(strike-level, 0)
+	}
+
+	timeToExpiration := option.DaysToExpiration / 365.0
+	vol1, vol2, rho := option.Vol1, option.Vol2, option.Correlation
+	vol := math.Sqrt(math.Max(vol1*vol1+vol2*vol2-2*rho*vol1*vol2, 0))
+	call := kind == CallOnMax || kind == CallOnMin
+	if vol == 0 {
+		// the assets keep their ratio: the option is on whichever has the better or worse forward
+		first := s1*math.Exp(-option.DividendYield1*timeToExpiration) >= s2*math.Exp(-option.DividendYield2*timeToExpiration)
+		vanilla := Option{
+			Strike:           strike,
+			DaysToExpiration: option.DaysToExpiration,
+			RiskFreeRate:     option.RiskFreeRate,
+			UnderlyingPrice:  s1,
+			OptionType:       Put,
+			DividendYield:    option.DividendYield1,
+		}
+		if first != onMax {
+			vanilla.UnderlyingPrice, vanilla.DividendYield = s2, option.DividendYield2
+		}
+		if call {
+			vanilla.OptionType = Call
+		}
+		return BlackScholesOptionPrice(vanilla, vol1)
+	}
+
+	sqrtTime := math.Sqrt(timeToExpiration)
+	discountedSpot1 := s1 * math.Exp(-option.DividendYield1*timeToExpiration)
+	discountedSpot2 := s2 * math.Exp(-option.DividendYield2*timeToExpiration)
+	discountedStrike := strike * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	carry1, carry2 := option.RiskFreeRate-option.DividendYield1, option.RiskFreeRate-option.DividendYield2
+	d := (math.Log(s1/s2) + (carry1-carry2+0.5*vol*vol)*timeToExpiration) / (vol * sqrtTime)
+	y1 := (math.Log(s1/strike) + (carry1+0.5*vol1*vol1)*timeToExpiration) / (vol1 * sqrtTime)
+	y2 := (math.Log(s2/strike) + (carry2+0.5*vol2*vol2)*timeToExpiration) / (vol2 * sqrtTime)
+	rho1, rho2 := (vol1-rho*vol2)/vol, (vol2-rho*vol1)/vol
+
+	var price, receive float64
+	exchange := MargrabePrice(s1, s2, vol1, vol2, rho, option.DividendYield1, option.DividendYield2, timeToExpiration)
+	if onMax {
+		price = discountedSpot1*Phi2(y1, d, rho1) + discountedSpot2*Phi2(y2, -d+vol*sqrtTime, rho2) -
+			discountedStrike*(1-Phi2(-y1+vol1*sqrtTime, -y2+vol2*sqrtTime, rho))
+		receive = discountedSpot2 + exchange
+	} else {
+		price = discountedSpot1*Phi2(y1, -d, -rho1) + discountedSpot2*Phi2(y2, d-vol*sqrtTime, -rho2) -
+			discountedStrike*Phi2(y1-vol1*sqrtTime, y2-vol2*sqrtTime, rho)
+		receive = discountedSpot1 - exchange
+	}
+	if call {
+		return price
+	}
+	return discountedStrike - receive + price
+}
@@ -0,0 +1,142 @@
+package finance
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestRainbowTwoAssetPrice(t *testing.T) {
+	for _, strike := range []float64{90.0, 100.0, 115.0} {
+		for _, corr := range []float64{-0.6, 0, 0.5, 0.95} {
+			option := TwoAssetOption{
+				Strike:           strike,
+				DaysToExpiration: 270.0,
+				RiskFreeRate:     0.05,
+				UnderlyingPrice1: 100.0,
+				UnderlyingPrice2: 105.0,
+				Vol1:             0.3,
+				Vol2:             0.2,
+				Correlation:      corr,
+				DividendYield1:   0.01,
+				DividendYield2:   0.03,
+			}
+			vanilla1 := Option{Strike: strike, DaysToExpiration: 270.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Call, DividendYield: 0.01}
+			vanilla2 := Option{Strike: strike, DaysToExpiration: 270.0, RiskFreeRate: 0.05, UnderlyingPrice: 105.0, OptionType: Call, DividendYield: 0.03}
+			calls := BlackScholesOptionPrice(vanilla1, 0.3) + BlackScholesOptionPrice(vanilla2, 0.2)
+			vanilla1.OptionType, vanilla2.OptionType = Put, Put
+			puts := BlackScholesOptionPrice(vanilla1, 0.3) + BlackScholesOptionPrice(vanilla2, 0.2)
+			timeToExpiration := 270.0 / 365.0
+			discountedStrike := strike * math.Exp(-0.05*timeToExpiration)
+			maximum := 105.0*math.Exp(-0.03*timeToExpiration) + MargrabePrice(100.0, 105.0, 0.3, 0.2, corr, 0.01, 0.03, timeToExpiration)
+
+			callOnMax, callOnMin := RainbowTwoAssetPrice(option, CallOnMax), RainbowTwoAssetPrice(option, CallOnMin)
+			putOnMax, putOnMin := RainbowTwoAssetPrice(option, PutOnMax), RainbowTwoAssetPrice(option, PutOnMin)
+			checks := []struct {
+				name      string
+				got, want float64
+			}{
+				// max + min = S1 + S2, so the rainbows on both add up to the vanilla options on each
+				{"calls on max and min", callOnMax + callOnMin, calls},
+				{"puts on max and min", putOnMax + putOnMin, puts},
+				{"parity on max", callOnMax - putOnMax, maximum - discountedStrike},
+			}
+			for _, c := range checks {
+				if math.Abs(c.got-c.want) > 1e-9 {
+					t.Errorf("Unexpected %s for strike %v correlation %v: got %v, want %v", c.name, strike, corr, c.got, c.want)
+				}
+			}
+		}
+	}
+}
+
+func TestRainbowTwoAssetPriceMonteCarlo(t *testing.T) {
+	const paths = 200000
+	option := TwoAssetOption{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.04,
+		UnderlyingPrice1: 100.0,
+		UnderlyingPrice2: 95.0,
+		Vol1:             0.25,
+		Vol2:             0.35,
+		Correlation:      0.4,
+		DividendYield1:   0.02,
+	}
+	rng := rand.New(rand.NewPCG(3, 4))
+	kinds := []RainbowKind{CallOnMax, CallOnMin, PutOnMax, PutOnMin}
+	var sums, sumSquares [4]float64
+	for range paths {
+		z1, z := rng.NormFloat64(), rng.NormFloat64()
+		z2 := 0.4*z1 + math.Sqrt(1-0.4*0.4)*z
+		s1 := 100.0 * math.Exp(0.04-0.02-0.5*0.25*0.25+0.25*z1)
+		s2 := 95.0 * math.Exp(0.04-0.5*0.35*0.35+0.35*z2)
+		high, low := math.Max(s1, s2), math.Min(s1, s2)
+		for i, payoff := range []float64{math.Max(high-100, 0), math.Max(low-100, 0), math.Max(100-high, 0), math.Max(100-low, 0)} {
+			sums[i] += payoff
+			sumSquares[i] += payoff * payoff
+		}
+	}
+	for i, kind := range kinds {
+		mean := sums[i] / paths
+		stdError := math.Exp(-0.04) * math.Sqrt((sumSquares[i]/paths-mean*mean)/paths)
+		want := math.Exp(-0.04) * mean
+		if got := RainbowTwoAssetPrice(option, kind); math.Abs(got-want) > 3*stdError {
+			t.Errorf("Unexpected price for kind %v: got %v, want %v ± %v", kind, got, want, stdError)
+		}
+	}
+}
+
+func TestRainbowTwoAssetPriceEdgeCases(t *testing.T) {
+	option := TwoAssetOption{
+		Strike:           100.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice1: 100.0,
+		UnderlyingPrice2: 110.0,
+		Vol1:             0.2,
+		Vol2:             0.2,
+		Correlation:      1,
+	}
+	// identical assets bar their price keep their ratio: the max is the second and the min the first
+	vanilla := Option{Strike: 100.0, DaysToExpiration: 180.0, RiskFreeRate: 0.05, UnderlyingPrice: 110.0, OptionType: Call}
+	if got, want := RainbowTwoAssetPrice(option, CallOnMax), BlackScholesOptionPrice(vanilla, 0.2); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected call on max of comonotonic assets: got %v, want %v", got, want)
+	}
+	vanilla.UnderlyingPrice, vanilla.OptionType = 100.0, Put
+	if got, want := RainbowTwoAssetPrice(option, PutOnMin), BlackScholesOptionPrice(vanilla, 0.2); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected put on min of comonotonic assets: got %v, want %v", got, want)
+	}
+
+	option.DaysToExpiration = 0
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"call on max", RainbowTwoAssetPrice(option, CallOnMax), 10.0},
+		{"call on min", RainbowTwoAssetPrice(option, CallOnMin), 0},
+		{"put on max", RainbowTwoAssetPrice(option, PutOnMax), 0},
+		{"put on min", RainbowTwoAssetPrice(option, PutOnMin), 0},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("Unexpected %s at expiration: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	option.DaysToExpiration = 180.0
+	for name, modify := range map[string]func(*TwoAssetOption){
+		"negative days":     func(o *TwoAssetOption) { o.DaysToExpiration = -1 },
+		"zero strike":       func(o *TwoAssetOption) { o.Strike = 0 },
+		"correlation above": func(o *TwoAssetOption) { o.Correlation = 1.1 },
+	} {
+		input := option
+		modify(&input)
+		if got := RainbowTwoAssetPrice(input, CallOnMax); !math.IsNaN(got) {
+			t.Errorf("Unexpected price for %s: got %v, want NaN", name, got)
+		}
+	}
+	if got := RainbowTwoAssetPrice(option, RainbowKind(4)); !math.IsNaN(got) {
+		t.Errorf("Unexpected price for an unknown kind: got %v, want NaN", got)
+	}
+}
@@ -0,0 +1,223 @@
+package finance
+
+import (
+	"math"
+)
+
+// rationalMaxSteps is the maximum number of Householder steps taken by RationalImpliedVolatility
+const rationalMaxSteps = 6
+
+// RationalImpliedVolatility computes the Black-Scholes implied volatility of an option without a general purpose
+// root search, following the approach of Jäckel's "Let's Be Rational". The price is mapped to the normalised
+// Black function of log-moneyness x and total volatility s and reflected to an out-of-the-money call. The tangent
+// at the inflection point s = sqrt(2|x|) splits the price range into three regions: around the inflection point
+// the tangent itself is the initial guess and the price is solved directly; for low prices the guess comes
+// from the asymptote ln b ≈ A - x²/(2s²) and the logarithm of the price is solved; for high prices the guess
+// comes from the large-volatility asymptote and the logarithm of the distance to the upper bound is solved.
+// The guess is polished with third-order Householder steps, which reach machine precision in two or three
+// steps (at most six are taken). Returns the error from Validate for invalid inputs, ErrPriceOutOfBounds for a price
+// outside the no-arbitrage bounds and ErrNegativeAdjustedSpot when discrete dividends exceed the underlying price
+// option: the option
+func RationalImpliedVolatility(option Option) (float64, error) {
+	if err := option.Validate(); err != nil {
+		return math.NaN(), err
+	}
+	if err := checkDividends(option); err != nil {
+		return math.NaN(), err
+	}
+	option = escrowed(option)
+
+	timeToExpiration := option.DaysToExpiration / 365.0
+	growth := math.Exp(option.RiskFreeRate * timeToExpiration)
+	forward := option.UnderlyingPrice * math.Exp((option.RiskFreeRate-option.DividendYield)*timeToExpiration)
+	x := math.Log(forward / option.Strike)
+	beta := option.Price * growth / math.Sqrt(forward*option.Strike)
+
+	// a put at x is a call at -x
+	if option.OptionType == Put {
+		x = -x
+	}
+	// an in-the-money call is its intrinsic value plus an out-of-the-money call at -x
+	if x > 0 {
+		beta -= 2 * math.Sinh(x/2)
+		x = -x
+	}
+	if !(beta > 0 && beta < math.Exp(x/2)) {
+		return math.NaN(), ErrPriceOutOfBounds
+	}
+
+	s := normalisedImpliedTotalVolatility(beta, x)
+	return s / math.Sqrt(timeToExpiration), nil
+}
+
+// normalisedImpliedTotalVolatility finds the total volatility s at which the normalised Black call price b(x, s)
+// equals beta, for x <= 0 and 0 < beta < e^{x/2}
+// beta: the normalised price
+// x: the log-moneyness
+func normalisedImpliedTotalVolatility(beta, x float64) float64 {
+	if x == 0 {
+		// at the money b(0, s) = 1 - 2N(-s/2), which inverts in closed form
+		return -2 * PhiInv(0.5*(1-beta))
+	}
+
+	// the tangent at the inflection point sc crosses zero at sl and the upper bound e^{x/2} at su
+	bMax := math.Exp(x / 2)
+	sc := math.Sqrt(2 * math.Abs(x))
+	bc := normalisedBlackCall(x, sc)
+	vc := normalisedVega(x, sc)
+	sl := sc - bc/vc
+	su := sc + (bMax-bc)/vc
+
+	const (
+		lowerRegion = iota
+		middleRegion
+		upperRegion
+	)
+	var s float64
+	region := middleRegion
+	if beta < bc && sl > 0 {
+		if bl := normalisedBlackCall(x, sl); beta < bl {
+			// ln b(s) ≈ A - x²/(2s²) for small prices, with A matched at sl
+			region = lowerRegion
+			a := math.Log(bl) + x*x/(2*sl*sl)
+			s = math.Abs(x) / math.Sqrt(2*(a-math.Log(beta)))
+		}
+	} else if mu := normalisedBlackCallComplement(x, su); beta >= bc && beta > bMax-mu {
+		// e^{x/2} - b(s) ≈ cN(-s/2) for large volatilities, with c matched at su
+		region = upperRegion
+		c := mu / PhiC(su/2)
+		s = -2 * PhiInv((bMax-beta)/c)
+	}
+	if region == middleRegion {
+		// b is nearly linear around its inflection point
+		s = sc + (beta-bc)/vc
+	}
+	if !(s > 0) {
+		s = sc
+	}
+
+	for i := 0; i < rationalMaxSteps; i++ {
+		// derivatives of b with respect to s, as multiples of the first
+		v := normalisedVega(x, s)
+		q := x*x/(s*s*s) - s/4
+		r := q*q - 3*x*x/(s*s*s*s) - 0.25
+
+		var f, f1, f2, f3 float64
+		switch region {
+		case lowerRegion:
+			// solve ln b(s) = ln β
+			b := normalisedBlackCall(x, s)
+			g := v / b
+			f = math.Log(b) - math.Log(beta)
+			f1 = g
+			f2 = g*q - g*g
+			f3 = g*r - 3*g*g*q + 2*g*g*g
+		case upperRegion:
+			// solve ln(e^{x/2} - b(s)) = ln(e^{x/2} - β)
+			m := normalisedBlackCallComplement(x, s)
+			g := v / m
+			f = math.Log(m) - math.Log(bMax-beta)
+			f1 = -g
+			f2 = -g*q - g*g
+			f3 = -g*r - 3*g*g*q - 2*g*g*g
+		default:
+			f = normalisedBlackCall(x, s) - beta
+			f1 = v
+			f2 = v * q
+			f3 = v * r
+		}
+		if f1 == 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+			break
+		}
+
+		nu := -f / f1
+		h2 := f2 / f1
+		h3 := f3 / f1
+		step := nu * (1 + 0.5*h2*nu) / (1 + nu*(h2+h3*nu/6))
+		if math.IsNaN(step) || math.IsInf(step, 0) {
+			step = nu
+		}
+
+		next := s + step
+		if next <= 0 {
+			next = 0.5 * s
+		}
+		// convergence is cubic, so once the step is this small the next error is below machine precision
+		converged := math.Abs(next-s) <= 1e-7*s
+		s = next
+		if converged {
+			break
+		}
+	}
+	return s
+}
+
+// normalisedBlackCall computes the normalised Black call price b(x, s) = e^{x/2}N(x/s+s/2) - e^{-x/2}N(x/s-s/2),
+// where x is the log-moneyness ln(F/K) and s the total volatility σ√T. Out of the money, where both terms are
+// tiny and nearly equal, the difference is taken between scaled Mills ratios so that precision is kept
+// x: the log-moneyness
+// s: the total volatility
+func normalisedBlackCall(x, s float64) float64 {
+	h := x / s
+	t := s / 2
+	if h+t < 0 {
+		// e^{x/2}n(h+t) = e^{-x/2}n(h-t) = n(h)n(t)√(2π)
+		return math.Exp(-0.5*(h*h+t*t)) / math.Sqrt(2*math.Pi) * (normalMillsRatio(h+t) - normalMillsRatio(h-t))
+	}
+	return math.Exp(x/2)*Phi(h+t) - math.Exp(-x/2)*Phi(h-t)
+}
+
+// normalisedBlackCallComplement computes e^{x/2} - b(x, s), the distance of the normalised Black call price
+// from its upper bound, without the cancellation of subtracting b directly
+// x: the log-moneyness
+// s: the total volatility
+func normalisedBlackCallComplement(x, s float64) float64 {
+	h := x / s
+	t := s / 2
+	return math.Exp(x/2)*PhiC(h+t) + math.Exp(-x/2)*Phi(h-t)
+}
+
+// normalisedVega computes the derivative of the normalised Black call price with respect to the total volatility
+// x: the log-moneyness
+// s: the total volatility
+func normalisedVega(x, s float64) float64 {
+	h := x / s
+	t := s / 2
+	return math.Exp(-0.5*(h*h+t*t)) / math.Sqrt(2*math.Pi)
+}
+
+// normalMillsRatio computes N(z)/n(z), the ratio of the standard normal cumulative distribution function
+// to its density, without underflow for negative z
+// z: the input value
+func normalMillsRatio(z float64) float64 {
+	if z > -1 {
+		return Phi(z) / NormalDistributionDerivative(z)
+	}
+	return math.Sqrt(math.Pi/2) * erfcx(-z/math.Sqrt2)
+}
+
+// logNormalCDF computes the logarithm of the standard normal cumulative distribution function without underflow
+// in the lower tail
+// x: the input value
+func logNormalCDF(x float64) float64 {
+	if x > -1 {
+		return math.Log(Phi(x))
+	}
+	return math.Log(0.5*erfcx(-x/math.Sqrt2)) - 0.5*x*x
+}
+
+// erfcx computes the scaled complementary error function e^{x²}erfc(x) for x >= 0
+// x: the input value
+func erfcx(x float64) float64 {
+	if x < 26 {
+		return math.Exp(x*x) * math.Erfc(x)
+	}
+	// asymptotic expansion, accurate to machine precision this far out
+	inv := 1 / (2 * x * x)
+	sum, term := 1.0, 1.0
+	for k := 1; k <= 6; k++ {
+		term *= -float64(2*k-1) * inv
+		sum += term
+	}
+	return sum / (x * math.Sqrt(math.Pi))
+}
@@ -0,0 +1,108 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRationalImpliedVolatilityRoundTrip(t *testing.T) {
+	for _, optionType := range []OptionType{Call, Put} {
+		for _, strike := range []float64{25.0, 50.0, 80.0, 95.0, 100.0, 105.0, 125.0, 200.0, 400.0} {
+			for _, days := range []float64{1.0, 7.0, 30.0, 365.0, 1825.0} {
+				for _, vol := range []float64{0.01, 0.05, 0.2, 0.5, 1.0, 3.0} {
+					option := Option{
+						Strike:           strike,
+						DaysToExpiration: days,
+						RiskFreeRate:     0.05,
+						UnderlyingPrice:  100.0,
+						OptionType:       optionType,
+					}
+					option.Price = BlackScholesOptionPrice(option, vol)
+
+					// skip prices that carry no information beyond intrinsic value
+					low, _ := PriceBounds(option)
+					if option.Price-low < 1e-12*option.UnderlyingPrice {
+						continue
+					}
+
+					volatility, err := RationalImpliedVolatility(option)
+					if err != nil {
+						t.Errorf("Unexpected error for type %v strike %v days %v vol %v: %v", optionType, strike, days, vol, err)
+						continue
+					}
+
+					price := BlackScholesOptionPrice(option, volatility)
+					if diff := math.Abs(price - option.Price); diff > 1e-10*math.Max(option.Price, 1) {
+						t.Errorf("Round trip failed for type %v strike %v days %v vol %v: got price %v, want %v (vol %v)", optionType, strike, days, vol, price, option.Price, volatility)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRationalImpliedVolatilityExtremeWings(t *testing.T) {
+	// far out-of-the-money prices well below double precision of the spot
+	option := Option{
+		Strike:           300.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	forward := option.UnderlyingPrice * math.Exp(option.RiskFreeRate*timeToExpiration)
+	x := math.Log(forward / option.Strike)
+	option.Price = math.Sqrt(forward*option.Strike) * math.Exp(-option.RiskFreeRate*timeToExpiration) * normalisedBlackCall(x, 0.3*math.Sqrt(timeToExpiration))
+
+	volatility, err := RationalImpliedVolatility(option)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := math.Abs(volatility - 0.3); diff > 1e-8 {
+		t.Errorf("Unexpected volatility for a far OTM call priced at %v: got %v, want 0.3", option.Price, volatility)
+	}
+}
+
+func TestRationalImpliedVolatilityErrors(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	option.Price = -1.0
+	if _, err := RationalImpliedVolatility(option); !errors.Is(err, ErrNegativePrice) {
+		t.Errorf("Expected ErrNegativePrice: got %v", err)
+	}
+
+	for _, price := range []float64{0.0, 100.0, 120.0} {
+		option.Price = price
+		if _, err := RationalImpliedVolatility(option); !errors.Is(err, ErrPriceOutOfBounds) {
+			t.Errorf("Expected ErrPriceOutOfBounds for price %v: got %v", price, err)
+		}
+	}
+}
+
+func BenchmarkRationalImpliedVolatility(b *testing.B) {
+	options := ivGrid()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, option := range options {
+			RationalImpliedVolatility(option)
+		}
+	}
+}
+
+func BenchmarkNewtonImpliedVolatility(b *testing.B) {
+	options := ivGrid()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, option := range options {
+			ImpliedVolatility(option)
+		}
+	}
+}
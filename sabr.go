@@ -0,0 +1,136 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidSABR is returned for a SABR smile to calibrate with a forward, time or β out of range, with strikes and
+// volatilities that differ in number, are fewer than three or are not positive and finite
+var ErrInvalidSABR = errors.New("finance: invalid SABR smile")
+
+// Lower and upper bounds on the SABR parameters searched by CalibrateSABR, β being fixed
+var (
+	sabrLowerBounds = SABRParams{Alpha: 1e-8, Rho: -0.9999, Nu: 1e-6}
+	sabrUpperBounds = SABRParams{Alpha: 1e4, Rho: 0.9999, Nu: 10}
+)
+
+// sabrInitialNu is the volatility of volatility CalibrateSABR starts from
+const sabrInitialNu = 0.5
+
+// SABRParams holds the parameters of the SABR model of Hagan et al. (2002), in which the forward F and its
+// volatility α follow dF = α·F^β·dW and dα = ν·α·dZ, with dW·dZ = ρ·dt
+type SABRParams struct {
+	Alpha float64 `json:"alpha"` // Initial volatility α, in units of F^{1−β}
+	Beta  float64 `json:"beta"`  // Elasticity of the forward's volatility β, in [0, 1]
+	Rho   float64 `json:"rho"`   // Correlation of the forward and its volatility ρ, in (-1, 1)
+	Nu    float64 `json:"nu"`    // Volatility of the volatility ν
+}
+
+// SABRImpliedVol computes the Black implied volatility of the SABR model by the expansion of Hagan et al. (2002),
+// σ = α/((FK)^{(1−β)/2}·(1 + (1−β)²/24·ln²(F/K) + (1−β)⁴/1920·ln⁴(F/K)))·(z/x(z))·(1 + ((1−β)²/24·α²/(FK)^{1−β} +
+// ρβνα/(4(FK)^{(1−β)/2}) + (2 − 3ρ²)/24·ν²)·T), with z = ν/α·(FK)^{(1−β)/2}·ln(F/K) and
+// x(z) = ln((√(1 − 2ρz + z²) + z − ρ)/(1 − ρ)). The ratio z/x(z), 0/0 at the money, is evaluated through log1p in a
+// form without cancellation, so that the volatility is continuous through the money, where z/x(z) = 1. With ν = 0
+// and β = 1 it is the flat volatility α. The expansion is accurate for small ν²T, and can turn negative far from the
+// money at long maturities. NaN for a forward, strike or time to expiration that is not positive, or for parameters
+// out of range
+// forward: the forward price
+// strike: the strike price
+// timeYears: the time to expiration in years
+// p: the parameters of the model
+func SABRImpliedVol(forward, strike, timeYears float64, p SABRParams) float64 {
+	if !(forward > 0) || !(strike > 0) || !(timeYears >= 0) || math.IsInf(forward, 1) || math.IsInf(strike, 1) ||
+		math.IsInf(timeYears, 1) || p.validate() != nil {
+		return math.NaN()
+	}
+	oneLessBeta := 1 - p.Beta
+	logMoneyness := math.Log(forward / strike)
+	scale := math.Pow(forward*strike, 0.5*oneLessBeta) // (FK)^{(1−β)/2}
+	squared := oneLessBeta * oneLessBeta * logMoneyness * logMoneyness
+	denominator := scale * (1 + squared/24 + squared*squared/1920)
+
+	// x(z) = ln(1 + ((z² − 2ρz)/(√(1 − 2ρz + z²) + 1) + z)/(1 − ρ)), whose argument is z/(1 − ρ)·(1 − ρ + O(z))
+	ratio := 1.0
+	if z := p.Nu / p.Alpha * scale * logMoneyness; z != 0 {
+		root := math.Sqrt(1 - 2*p.Rho*z + z*z)
+		ratio = z / math.Log1p(((z*z-2*p.Rho*z)/(root+1)+z)/(1-p.Rho))
+	}
+	correction := oneLessBeta*oneLessBeta/24*p.Alpha*p.Alpha/(scale*scale) +
+		0.25*p.Rho*p.Beta*p.Nu*p.Alpha/scale + (2-3*p.Rho*p.Rho)/24*p.Nu*p.Nu
+	return p.Alpha / denominator * ratio * (1 + correction*timeYears)
+}
+
+// CalibrateSABR fits α, ρ and ν of the SABR model, for a fixed β, to a smile of Black implied volatilities,
+// minimizing the sum of the squared differences between SABRImpliedVol and the quoted volatilities by leastSquares.
+// The search runs over the logarithms of α and ν, within α ∈ [1e-8, 1e4], ρ ∈ [−0.9999, 0.9999] and ν ∈ [1e-6, 10],
+// starting from ρ = 0, ν = 0.5 and the α that reproduces the quoted volatility nearest the money
+// Returns ErrInvalidSABR for an invalid smile and ErrNotConverged with the best parameters found if the iterations
+// run out
+// forward: the forward price
+// timeYears: the time to expiration in years
+// strikes: the strike of each quote
+// vols: the Black implied volatility of each quote
+// beta: the fixed elasticity β, in [0, 1]
+func CalibrateSABR(forward, timeYears float64, strikes, vols []float64, beta float64) (SABRParams, error) {
+	switch {
+	case !(forward > 0) || math.IsInf(forward, 1):
+		return SABRParams{}, fmt.Errorf("%w: forward %v", ErrInvalidSABR, forward)
+	case !(timeYears > 0) || math.IsInf(timeYears, 1):
+		return SABRParams{}, fmt.Errorf("%w: time to expiration %v", ErrInvalidSABR, timeYears)
+	case !(beta >= 0 && beta <= 1):
+		return SABRParams{}, fmt.Errorf("%w: beta %v", ErrInvalidSABR, beta)
+	case len(strikes) != len(vols):
+		return SABRParams{}, fmt.Errorf("%w: %d strikes and %d volatilities", ErrInvalidSABR, len(strikes), len(vols))
+	case len(strikes) < 3:
+		return SABRParams{}, fmt.Errorf("%w: %d quotes for three parameters", ErrInvalidSABR, len(strikes))
+	}
+	nearest := 0
+	for i, strike := range strikes {
+		if !(strike > 0) || math.IsInf(strike, 1) || !(vols[i] > 0) || math.IsInf(vols[i], 1) {
+			return SABRParams{}, fmt.Errorf("%w: strike %v with volatility %v", ErrInvalidSABR, strike, vols[i])
+		}
+		if math.Abs(math.Log(strike/forward)) < math.Abs(math.Log(strikes[nearest]/forward)) {
+			nearest = i
+		}
+	}
+
+	// the parameters searched are ln α, ρ and ln ν
+	params := func(x []float64) SABRParams {
+		return SABRParams{Alpha: math.Exp(x[0]), Beta: beta, Rho: x[1], Nu: math.Exp(x[2])}
+	}
+	residuals := func(x []float64) ([]float64, error) {
+		p := params(x)
+		r := make([]float64, len(strikes))
+		for i, strike := range strikes {
+			r[i] = SABRImpliedVol(forward, strike, timeYears, p) - vols[i]
+		}
+		return r, nil
+	}
+	lower := []float64{math.Log(sabrLowerBounds.Alpha), sabrLowerBounds.Rho, math.Log(sabrLowerBounds.Nu)}
+	upper := []float64{math.Log(sabrUpperBounds.Alpha), sabrUpperBounds.Rho, math.Log(sabrUpperBounds.Nu)}
+	alpha := vols[nearest] * math.Pow(forward*strikes[nearest], 0.5*(1-beta))
+	initial := []float64{math.Min(math.Max(math.Log(alpha), lower[0]), upper[0]), 0, math.Log(sabrInitialNu)}
+
+	x, iterations, converged, _ := leastSquares(residuals, initial, lower, upper, calibrationMaxIterations)
+	if !converged {
+		return params(x), fmt.Errorf("%w: SABR calibration after %d iterations", ErrNotConverged, iterations)
+	}
+	return params(x), nil
+}
+
+// validate checks that the parameters of the SABR model are in range
+func (p SABRParams) validate() error {
+	switch {
+	case !(p.Alpha > 0) || math.IsInf(p.Alpha, 1):
+		return fmt.Errorf("%w: alpha %v", ErrInvalidSABR, p.Alpha)
+	case !(p.Beta >= 0 && p.Beta <= 1):
+		return fmt.Errorf("%w: beta %v", ErrInvalidSABR, p.Beta)
+	case !(p.Rho > -1 && p.Rho < 1):
+		return fmt.Errorf("%w: rho %v", ErrInvalidSABR, p.Rho)
+	case !(p.Nu >= 0) || math.IsInf(p.Nu, 1):
+		return fmt.Errorf("%w: nu %v", ErrInvalidSABR, p.Nu)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSABRImpliedVolAtTheMoney(t *testing.T) {
+	// Hagan et al. (2002), at the money σ = α/F^{1−β}·(1 + ((1−β)²α²/(24F^{2−2β}) + ρβνα/(4F^{1−β}) + (2 − 3ρ²)ν²/24)T)
+	p := SABRParams{Alpha: 0.035, Beta: 0.5, Rho: -0.3, Nu: 0.45}
+	forward, timeYears := 0.03, 2.0
+	scale := math.Pow(forward, 1-p.Beta)
+	want := p.Alpha / scale * (1 + (0.25*p.Alpha*p.Alpha/(24*scale*scale)+p.Rho*p.Beta*p.Nu*p.Alpha/(4*scale)+(2-3*p.Rho*p.Rho)/24*p.Nu*p.Nu)*timeYears)
+	if got := SABRImpliedVol(forward, forward, timeYears, p); math.Abs(got-want) > 1e-15 {
+		t.Errorf("Unexpected at-the-money volatility: got %v, want %v", got, want)
+	}
+	// the smile is continuous through the money
+	for _, strike := range []float64{forward * (1 - 1e-10), forward * (1 + 1e-10)} {
+		if got := SABRImpliedVol(forward, strike, timeYears, p); math.Abs(got-want) > 1e-10 {
+			t.Errorf("Unexpected volatility at strike %v: got %v, want %v", strike, got, want)
+		}
+	}
+}
+
+func TestSABRImpliedVolLimits(t *testing.T) {
+	// without volatility of volatility, β = 1 is Black's model with volatility α
+	for _, strike := range []float64{50.0, 100.0, 150.0} {
+		if got := SABRImpliedVol(100.0, strike, 1.0, SABRParams{Alpha: 0.2, Beta: 1}); math.Abs(got-0.2) > 1e-15 {
+			t.Errorf("Unexpected lognormal volatility at strike %v: got %v, want 0.2", strike, got)
+		}
+	}
+
+	// and β = 0 is Bachelier's model with normal volatility α, whose Black volatility the expansion approximates to its order of accuracy; with
+	// no discounting the forward is the spot
+	forward := 0.04
+	for _, strike := range []float64{0.02, 0.03, 0.04, 0.05, 0.07} {
+		option := Option{
+			Strike:           strike,
+			DaysToExpiration: 365.0,
+			UnderlyingPrice:  forward,
+			OptionType:       Call,
+		}
+		option.Price = BachelierOptionPrice(option, 0.008)
+		want, err := NewIVSolver(WithTolerance(1e-15)).Solve(option)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := SABRImpliedVol(forward, strike, 1.0, SABRParams{Alpha: 0.008, Beta: 0}); math.Abs(got-want.Volatility) > 5e-4*want.Volatility {
+			t.Errorf("Unexpected normal volatility at strike %v: got %v, want %v", strike, got, want.Volatility)
+		}
+	}
+
+	// the skew follows the correlation
+	p := SABRParams{Alpha: 0.2, Beta: 1, Rho: -0.5, Nu: 0.6}
+	if low, high := SABRImpliedVol(100.0, 90.0, 1.0, p), SABRImpliedVol(100.0, 110.0, 1.0, p); !(low > high) {
+		t.Errorf("Unexpected skew with negative correlation: got %v at 90 and %v at 110", low, high)
+	}
+
+	for name, got := range map[string]float64{
+		"zero strike":          SABRImpliedVol(100.0, 0, 1.0, p),
+		"negative time":        SABRImpliedVol(100.0, 100.0, -1.0, p),
+		"correlation of one":   SABRImpliedVol(100.0, 100.0, 1.0, SABRParams{Alpha: 0.2, Beta: 1, Rho: 1, Nu: 0.6}),
+		"beta above one":       SABRImpliedVol(100.0, 100.0, 1.0, SABRParams{Alpha: 0.2, Beta: 1.5}),
+		"zero alpha":           SABRImpliedVol(100.0, 100.0, 1.0, SABRParams{Beta: 1}),
+		"negative vol of vol":  SABRImpliedVol(100.0, 100.0, 1.0, SABRParams{Alpha: 0.2, Beta: 1, Nu: -0.1}),
+		"non-positive forward": SABRImpliedVol(0, 100.0, 1.0, p),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected volatility for %s: got %v, want NaN", name, got)
+		}
+	}
+}
+
+func TestCalibrateSABR(t *testing.T) {
+	for _, want := range []SABRParams{
+		{Alpha: 0.03, Beta: 0.5, Rho: -0.25, Nu: 0.4},
+		{Alpha: 0.2, Beta: 1, Rho: 0.3, Nu: 1.2},
+		{Alpha: 0.007, Beta: 0, Rho: -0.6, Nu: 0.25},
+	} {
+		forward := 0.035
+		if want.Beta == 1 {
+			forward = 1.25
+		}
+		var strikes, vols []float64
+		for i := range 11 {
+			strike := forward * (0.6 + 0.08*float64(i))
+			strikes = append(strikes, strike)
+			vols = append(vols, SABRImpliedVol(forward, strike, 1.5, want))
+		}
+		got, err := CalibrateSABR(forward, 1.5, strikes, vols, want.Beta)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if math.Abs(got.Alpha-want.Alpha) > 1e-8*want.Alpha || math.Abs(got.Rho-want.Rho) > 1e-6 || math.Abs(got.Nu-want.Nu) > 1e-6 || got.Beta != want.Beta {
+			t.Errorf("Unexpected calibrated parameters: got %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestCalibrateSABRErrors(t *testing.T) {
+	strikes, vols := []float64{90.0, 100.0, 110.0}, []float64{0.22, 0.2, 0.19}
+	tests := []struct {
+		name          string
+		forward, time float64
+		strikes, vols []float64
+		beta          float64
+	}{
+		{"zero forward", 0, 1.0, strikes, vols, 1},
+		{"zero time", 100.0, 0, strikes, vols, 1},
+		{"beta below zero", 100.0, 1.0, strikes, vols, -0.5},
+		{"mismatched quotes", 100.0, 1.0, strikes, vols[:2], 1},
+		{"too few quotes", 100.0, 1.0, strikes[:2], vols[:2], 1},
+		{"negative volatility", 100.0, 1.0, strikes, []float64{0.22, -0.2, 0.19}, 1},
+		{"zero strike", 100.0, 1.0, []float64{0, 100.0, 110.0}, vols, 1},
+	}
+	for _, test := range tests {
+		if _, err := CalibrateSABR(test.forward, test.time, test.strikes, test.vols, test.beta); !errors.Is(err, ErrInvalidSABR) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", test.name, err, ErrInvalidSABR)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package finance
+
+import "math"
+
+// ShiftedLognormalPrice computes the price of an option under the displaced diffusion of Rubinstein (1983), in which
+// the underlying price plus a fixed shift is lognormal, so that the forward F + s and the strike K + s take the place
+// of the forward and strike in the Black formula. A positive shift admits negative forwards and strikes down to −s, as
+// rates need, and the smile it implies in the plain lognormal volatility falls with the strike. Without a shift it is
+// the Black-Scholes price, and as the shift grows with σ·(F + s) held fixed it tends to the Bachelier price with
+// that normal volatility. At expiration the option is worth its intrinsic value. Discrete dividends are escrowed. NaN
+// with negative days to expiration, or a shifted forward or strike that is not positive
+// option: the option
+// vol: the volatility of the shifted underlying
+// shift: the shift s
+func ShiftedLognormalPrice(option Option, vol, shift float64) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration < 0 {
+		return math.NaN()
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	forward := ForwardPrice(option.UnderlyingPrice, option.RiskFreeRate, option.DividendYield, timeToExpiration)
+	if !(forward+shift > 0) || !(option.Strike+shift > 0) {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		return option.IntrinsicValue()
+	}
+	return BlackScholesForwardPrice(forward+shift, option.Strike+shift, timeToExpiration, option.RiskFreeRate, vol, option.OptionType)
+}
+
+// ShiftedLognormalImpliedVolatility computes the volatility of the shifted underlying that reproduces an option's
+// price under ShiftedLognormalPrice. The shifted option is a Black-Scholes option on a spot of (F + s)·e^{−rT}
+// without dividends, struck at K + s, so this delegates to an IVSolver with the settings of ImpliedVolatility
+// modified by opts, whose price tolerance of 1e-4 will usually need tightening for rates, and returns the same
+// errors, with ErrNonPositiveUnderlying or ErrNonPositiveStrike for a shifted forward or strike that is not positive
+// option: the option, with Price holding its price
+// shift: the shift s
+// opts: the settings of the solver
+func ShiftedLognormalImpliedVolatility(option Option, shift float64, opts ...IVOption) (IVResult, error) {
+	option = escrowed(option)
+	timeToExpiration := option.DaysToExpiration / 365.0
+	forward := ForwardPrice(option.UnderlyingPrice, option.RiskFreeRate, option.DividendYield, timeToExpiration)
+	option.UnderlyingPrice = (forward + shift) * math.Exp(-option.RiskFreeRate*timeToExpiration)
+	option.Strike += shift
+	option.DividendYield = 0
+	return NewIVSolver(opts...).Solve(option)
+}
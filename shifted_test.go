@@ -0,0 +1,83 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestShiftedLognormalPrice(t *testing.T) {
+	for _, typ := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           105.0,
+			DaysToExpiration: 180.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       typ,
+			DividendYield:    0.02,
+		}
+		want := BlackScholesOptionPrice(option, 0.25)
+		if got := ShiftedLognormalPrice(option, 0.25, 0); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Unexpected price without a shift for %v: got %v, want %v", typ, got, want)
+		}
+
+		// a large shift at the same normal volatility σ·(F + s) approaches Bachelier on the forward
+		forward := ForwardPrice(100.0, 0.05, 0.02, 180.0/365.0)
+		normal := option
+		normal.UnderlyingPrice = forward
+		want = BachelierOptionPrice(normal, 20.0)
+		previous := math.Inf(1)
+		for _, shift := range []float64{1e3, 1e4, 1e5} {
+			got := ShiftedLognormalPrice(option, 20.0/(forward+shift), shift)
+			if diff := math.Abs(got - want); !(diff < previous) || diff > 5*want/shift {
+				t.Errorf("Unexpected price with shift %v for %v: got %v, want %v", shift, typ, got, want)
+			}
+			previous = math.Abs(got - want)
+		}
+	}
+
+	// negative forwards and strikes, as rates need
+	rate := Option{Strike: -0.002, DaysToExpiration: 365.0, UnderlyingPrice: -0.001, OptionType: Call}
+	if got := ShiftedLognormalPrice(rate, 0.2, 0.03); !(got > 0 && got < 0.03) {
+		t.Errorf("Unexpected price with a negative forward: got %v", got)
+	}
+
+	expired := Option{Strike: 100.0, UnderlyingPrice: 110.0, OptionType: Call}
+	if got := ShiftedLognormalPrice(expired, 0.25, 10.0); got != 10.0 {
+		t.Errorf("Unexpected price at expiration: got %v, want 10", got)
+	}
+	for name, got := range map[string]float64{
+		"negative days":      ShiftedLognormalPrice(Option{Strike: 100.0, DaysToExpiration: -1, UnderlyingPrice: 100.0}, 0.25, 0),
+		"strike below shift": ShiftedLognormalPrice(Option{Strike: -0.05, DaysToExpiration: 30.0, UnderlyingPrice: 0.01}, 0.25, 0.03),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected price with %s: got %v, want NaN", name, got)
+		}
+	}
+}
+
+func TestShiftedLognormalImpliedVolatility(t *testing.T) {
+	for _, typ := range []OptionType{Call, Put} {
+		option := Option{
+			Strike:           0.01,
+			DaysToExpiration: 730.0,
+			RiskFreeRate:     0.01,
+			UnderlyingPrice:  -0.005,
+			OptionType:       typ,
+		}
+		option.Price = ShiftedLognormalPrice(option, 0.3, 0.02)
+		result, err := NewIVSolver(WithTolerance(1e-12)).Solve(option)
+		if err == nil {
+			t.Errorf("Unexpected plain implied volatility for a negative forward for %v: got %v", typ, result.Volatility)
+		}
+		result, err = ShiftedLognormalImpliedVolatility(option, 0.02, WithTolerance(1e-12))
+		if err != nil || math.Abs(result.Volatility-0.3) > 1e-8 {
+			t.Errorf("Unexpected implied volatility for %v: got %v (%v), want 0.3", typ, result.Volatility, err)
+		}
+	}
+
+	option := Option{Strike: -0.05, DaysToExpiration: 30.0, UnderlyingPrice: 0.01, OptionType: Put, Price: 0.001}
+	if _, err := ShiftedLognormalImpliedVolatility(option, 0.03); !errors.Is(err, ErrNonPositiveStrike) {
+		t.Errorf("Unexpected error for a strike below the shift: got %v, want %v", err, ErrNonPositiveStrike)
+	}
+}
@@ -0,0 +1,125 @@
+package finance
+
+import "math"
+
+// Strike from delta inversion
+const (
+	skewGridPoints    = 400 // Points of the scan in log moneyness for the crossing of a delta
+	skewMaxIterations = 100 // Iterations of the bisection of a crossing, and of the fixed point of the at-the-money strike
+	skewStdDevs       = 8   // Half-width of the scan, in at-the-money standard deviations
+)
+
+// DeltaConvention is how the delta quoting a smile is measured. Spot deltas are discounted at the dividend yield, the
+// foreign rate for a currency pair, and premium-adjusted deltas are net of the premium paid in the underlying
+type DeltaConvention int
+
+const (
+	ForwardDelta                DeltaConvention = iota // N(d1) for a call and −N(−d1) for a put; the default
+	SpotDelta                                          // e^{−qT}·N(d1) for a call and −e^{−qT}·N(−d1) for a put
+	PremiumAdjustedForwardDelta                        // (K/F)·N(d2) for a call and −(K/F)·N(−d2) for a put
+	PremiumAdjustedSpotDelta                           // e^{−qT}·(K/F)·N(d2) for a call and −e^{−qT}·(K/F)·N(−d2) for a put
+)
+
+// SkewReport summarizes a smile as FX and index desks quote it, all volatilities being the smile's. A strike whose
+// delta the smile cannot reach, and the figures from it, are NaN
+type SkewReport struct {
+	ATMStrike      float64 `json:"atm_strike"`       // Strike of the delta-neutral straddle, whose call and put deltas sum to zero
+	ATMVol         float64 `json:"atm_vol"`          // Volatility at the delta-neutral straddle strike
+	Call25Strike   float64 `json:"call_25_strike"`   // Strike of the 25-delta call
+	Put25Strike    float64 `json:"put_25_strike"`    // Strike of the 25-delta put
+	RiskReversal25 float64 `json:"risk_reversal_25"` // Volatility of the 25-delta call less that of the 25-delta put
+	Butterfly25    float64 `json:"butterfly_25"`     // Average volatility of the 25-delta call and put less the at-the-money volatility
+	Call10Strike   float64 `json:"call_10_strike"`   // Strike of the 10-delta call
+	Put10Strike    float64 `json:"put_10_strike"`    // Strike of the 10-delta put
+	RiskReversal10 float64 `json:"risk_reversal_10"` // Volatility of the 10-delta call less that of the 10-delta put
+	Butterfly10    float64 `json:"butterfly_10"`     // Average volatility of the 10-delta call and put less the at-the-money volatility
+}
+
+// SkewMetrics computes the 25- and 10-delta risk reversals and butterflies of a smile and its delta-neutral
+// straddle volatility, under a delta convention. The strike of each delta is where the delta at the smile's
+// volatility there crosses it, found by scanning 8 at-the-money standard deviations either side of the forward in
+// log moneyness, from the wing inwards, and bisecting, so that the premium-adjusted call delta, which rises and then
+// falls with the strike, takes its higher strike. The delta-neutral straddle strike is F·e^{σ²T/2}, or F·e^{−σ²T/2}
+// premium-adjusted, at its own volatility, found by fixed-point iteration. The butterfly is the smile strangle, the
+// average of the wing volatilities less the at-the-money volatility. The rate does not enter any of the conventions
+// smile: the smile
+// forward: the forward price, usually smile.Forward()
+// rate: the risk-free interest rate
+// q: the continuous dividend yield, or the foreign interest rate, which discounts spot deltas
+// timeYears: the time to expiration in years, usually smile.TimeYears()
+// convention: how the deltas are measured
+func SkewMetrics(smile VolSmile, forward, rate, q, timeYears float64, convention DeltaConvention) SkewReport {
+	premiumAdjusted := convention == PremiumAdjustedForwardDelta || convention == PremiumAdjustedSpotDelta
+	discount := 1.0
+	if convention == SpotDelta || convention == PremiumAdjustedSpotDelta {
+		discount = math.Exp(-q * timeYears)
+	}
+
+	var report SkewReport
+	report.ATMStrike, report.ATMVol = math.NaN(), math.NaN()
+	strike := forward
+	for range skewMaxIterations {
+		vol := smile.Vol(strike)
+		next := forward * math.Exp(0.5*vol*vol*timeYears)
+		if premiumAdjusted {
+			next = forward * math.Exp(-0.5*vol*vol*timeYears)
+		}
+		if math.Abs(next-strike) <= 1e-14*strike {
+			report.ATMStrike, report.ATMVol = next, smile.Vol(next)
+			break
+		}
+		strike = next
+	}
+
+	// the delta of a call or put at a log moneyness and the smile's volatility there
+	delta := func(y float64, call bool) float64 {
+		vol := smile.Vol(forward * math.Exp(y))
+		stdDev := vol * math.Sqrt(timeYears)
+		d1 := -y/stdDev + 0.5*stdDev
+		sign := 1.0
+		if !call {
+			sign = -1
+		}
+		if premiumAdjusted {
+			return sign * discount * math.Exp(y) * Phi(sign*(d1-stdDev))
+		}
+		return sign * discount * Phi(sign*d1)
+	}
+	width := skewStdDevs * smile.Vol(forward) * math.Sqrt(timeYears)
+	strikeAt := func(target float64) float64 {
+		call := target > 0
+		// from the wing inwards, to the first crossing of the target
+		outer := width
+		if !call {
+			outer = -width
+		}
+		step := -2 * outer / skewGridPoints
+		if (delta(outer, call)-target > 0) == call {
+			return math.NaN()
+		}
+		for i := 1; i <= skewGridPoints; i++ {
+			inner := outer + step
+			if (delta(inner, call)-target > 0) == call {
+				for range skewMaxIterations {
+					middle := 0.5 * (outer + inner)
+					if (delta(middle, call)-target > 0) == call {
+						inner = middle
+					} else {
+						outer = middle
+					}
+				}
+				return forward * math.Exp(0.5*(outer+inner))
+			}
+			outer = inner
+		}
+		return math.NaN()
+	}
+
+	report.Call25Strike, report.Put25Strike = strikeAt(0.25), strikeAt(-0.25)
+	report.Call10Strike, report.Put10Strike = strikeAt(0.1), strikeAt(-0.1)
+	call25, put25 := smile.Vol(report.Call25Strike), smile.Vol(report.Put25Strike)
+	call10, put10 := smile.Vol(report.Call10Strike), smile.Vol(report.Put10Strike)
+	report.RiskReversal25, report.Butterfly25 = call25-put25, 0.5*(call25+put25)-report.ATMVol
+	report.RiskReversal10, report.Butterfly10 = call10-put10, 0.5*(call10+put10)-report.ATMVol
+	return report
+}
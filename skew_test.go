@@ -0,0 +1,80 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSkewMetrics(t *testing.T) {
+	// a flat smile has no risk reversal or butterfly, and its strikes invert the deltas in closed form
+	strikes := []float64{60.0, 80.0, 100.0, 120.0, 150.0}
+	flat, err := NewVolSmile(100.0, 0.5, strikes, []float64{0.2, 0.2, 0.2, 0.2, 0.2}, SplineInterpolation, FlatExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	stdDev := 0.2 * math.Sqrt(0.5)
+	report := SkewMetrics(flat, 100.0, 0.05, 0.02, 0.5, ForwardDelta)
+	if math.Abs(report.ATMStrike-100.0*math.Exp(0.5*stdDev*stdDev)) > 1e-9 || math.Abs(report.ATMVol-0.2) > 1e-12 {
+		t.Errorf("Unexpected delta-neutral straddle: got %v at %v, want 0.2 at %v", report.ATMVol, report.ATMStrike, 100.0*math.Exp(0.5*stdDev*stdDev))
+	}
+	if want := 100.0 * math.Exp(0.5*stdDev*stdDev-stdDev*PhiInv(0.25)); math.Abs(report.Call25Strike-want) > 1e-9 {
+		t.Errorf("Unexpected 25-delta call strike: got %v, want %v", report.Call25Strike, want)
+	}
+	if want := 100.0 * math.Exp(0.5*stdDev*stdDev+stdDev*PhiInv(0.1)); math.Abs(report.Put10Strike-want) > 1e-9 {
+		t.Errorf("Unexpected 10-delta put strike: got %v, want %v", report.Put10Strike, want)
+	}
+	for name, got := range map[string]float64{"rr25": report.RiskReversal25, "bf25": report.Butterfly25, "rr10": report.RiskReversal10, "bf10": report.Butterfly10} {
+		if math.Abs(got) > 1e-12 {
+			t.Errorf("Unexpected %s of a flat smile: got %v, want 0", name, got)
+		}
+	}
+
+	// a skewed smile with a smile in its wings
+	vols := []float64{0.32, 0.24, 0.2, 0.19, 0.21}
+	smile, err := NewVolSmile(100.0, 0.5, strikes, vols, SplineInterpolation, StickyDeltaExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	conventions := []DeltaConvention{ForwardDelta, SpotDelta, PremiumAdjustedForwardDelta, PremiumAdjustedSpotDelta}
+	reports := make([]SkewReport, len(conventions))
+	for i, convention := range conventions {
+		report := SkewMetrics(smile, 100.0, 0.05, 0.02, 0.5, convention)
+		reports[i] = report
+		if !(report.RiskReversal25 < 0) || !(report.RiskReversal10 < report.RiskReversal25) || !(report.Butterfly25 > 0) || !(report.Butterfly10 > report.Butterfly25) {
+			t.Errorf("Unexpected skew with convention %d: got %+v", convention, report)
+		}
+		// each strike has its delta under the convention, at the smile's volatility there
+		discount := 1.0
+		if convention == SpotDelta || convention == PremiumAdjustedSpotDelta {
+			discount = math.Exp(-0.02 * 0.5)
+		}
+		premiumAdjusted := convention == PremiumAdjustedForwardDelta || convention == PremiumAdjustedSpotDelta
+		for target, strike := range map[float64]float64{0.25: report.Call25Strike, -0.25: report.Put25Strike, 0.1: report.Call10Strike, -0.1: report.Put10Strike} {
+			typ := Call
+			if target < 0 {
+				typ = Put
+			}
+			vol := smile.Vol(strike)
+			s := vol * math.Sqrt(0.5)
+			d1 := (math.Log(100.0/strike) + 0.5*s*s) / s
+			sign := 1.0
+			if typ == Put {
+				sign = -1
+			}
+			got := sign * discount * Phi(sign*d1)
+			if premiumAdjusted {
+				got = sign * discount * strike / 100.0 * Phi(sign*(d1-s))
+			}
+			if math.Abs(got-target) > 1e-9 {
+				t.Errorf("Unexpected delta at the %v strike %v with convention %d: got %v", target, strike, convention, got)
+			}
+		}
+	}
+	// premium adjustment lowers every strike of a given delta, and spot deltas take calls lower and puts higher
+	if !(reports[2].Call25Strike < reports[0].Call25Strike) || !(reports[2].Put25Strike < reports[0].Put25Strike) || !(reports[2].ATMStrike < reports[0].ATMStrike) {
+		t.Errorf("Unexpected premium-adjusted strikes: got %+v, want below %+v", reports[2], reports[0])
+	}
+	if !(reports[1].Call25Strike < reports[0].Call25Strike) || !(reports[1].Put25Strike > reports[0].Put25Strike) {
+		t.Errorf("Unexpected spot delta strikes: got %+v, want inside %+v", reports[1], reports[0])
+	}
+}
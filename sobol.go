@@ -0,0 +1,183 @@
+package finance
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrInvalidDimensions is returned when a Sobol sequence is asked for fewer than one or more than
+// SobolMaxDimensions dimensions
+var ErrInvalidDimensions = errors.New("finance: Sobol dimension count out of range")
+
+// SobolMaxDimensions is the number of dimensions for which Sobol direction numbers are tabulated
+const SobolMaxDimensions = 64
+
+// sobolBits is the number of bits of precision of each Sobol coordinate, which also caps the sequence at
+// 2^sobolBits − 1 points after the origin
+const sobolBits = 32
+
+// sobolPeriod is the number of points after the origin before the sequence starts again
+const sobolPeriod = 1<<sobolBits - 1
+
+// sobolPolynomials tabulates, for Sobol dimensions 2 to SobolMaxDimensions, the degree s and the inner coefficients
+// a (bit s−1−k holding the coefficient of x^{s−k}) of the primitive polynomial over GF(2), taken in order of degree
+// and then of coefficients, and the initial direction numbers m_1 to m_s, odd with m_k < 2^k. The direction numbers
+// give the sequence Sobol's property A in every leading set of dimensions: the first 2^d points in d dimensions fall
+// one in each of the 2^d subcubes that halve every axis. The first dimension is the van der Corput sequence
+var sobolPolynomials = []struct {
+	degree       int
+	coefficients uint32
+	initial      []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 5}},
+	{3, 2, []uint32{1, 3, 7}},
+	{4, 1, []uint32{1, 1, 7, 3}},
+	{4, 4, []uint32{1, 1, 5, 5}},
+	{5, 2, []uint32{1, 3, 1, 11, 31}},
+	{5, 4, []uint32{1, 3, 1, 9, 1}},
+	{5, 7, []uint32{1, 1, 5, 7, 23}},
+	{5, 11, []uint32{1, 1, 1, 7, 23}},
+	{5, 13, []uint32{1, 1, 3, 5, 1}},
+	{5, 14, []uint32{1, 3, 7, 15, 29}},
+	{6, 1, []uint32{1, 1, 7, 1, 1, 19}},
+	{6, 13, []uint32{1, 1, 3, 11, 7, 3}},
+	{6, 16, []uint32{1, 1, 3, 13, 21, 13}},
+	{6, 19, []uint32{1, 3, 1, 3, 11, 51}},
+	{6, 22, []uint32{1, 3, 1, 9, 29, 29}},
+	{6, 25, []uint32{1, 3, 3, 5, 15, 7}},
+	{7, 1, []uint32{1, 1, 3, 11, 23, 61, 103}},
+	{7, 4, []uint32{1, 1, 1, 13, 29, 57, 75}},
+	{7, 7, []uint32{1, 1, 5, 5, 13, 17, 97}},
+	{7, 8, []uint32{1, 1, 1, 7, 11, 23, 45}},
+	{7, 14, []uint32{1, 3, 1, 1, 25, 23, 99}},
+	{7, 19, []uint32{1, 1, 1, 15, 9, 41, 71}},
+	{7, 21, []uint32{1, 1, 7, 13, 9, 39, 47}},
+	{7, 28, []uint32{1, 3, 3, 1, 1, 41, 111}},
+	{7, 31, []uint32{1, 3, 5, 3, 7, 43, 33}},
+	{7, 32, []uint32{1, 3, 5, 7, 3, 9, 51}},
+	{7, 37, []uint32{1, 3, 3, 13, 25, 57, 81}},
+	{7, 41, []uint32{1, 3, 5, 1, 3, 39, 37}},
+	{7, 42, []uint32{1, 3, 3, 3, 25, 47, 45}},
+	{7, 50, []uint32{1, 1, 3, 13, 7, 51, 109}},
+	{7, 55, []uint32{1, 1, 7, 7, 11, 61, 33}},
+	{7, 56, []uint32{1, 1, 1, 3, 29, 53, 25}},
+	{7, 59, []uint32{1, 1, 3, 1, 19, 45, 27}},
+	{7, 62, []uint32{1, 3, 5, 9, 5, 33, 29}},
+	{8, 14, []uint32{1, 1, 1, 15, 7, 9, 125, 57}},
+	{8, 21, []uint32{1, 1, 1, 5, 31, 27, 29, 249}},
+	{8, 22, []uint32{1, 1, 3, 7, 21, 33, 25, 107}},
+	{8, 38, []uint32{1, 3, 7, 1, 9, 21, 85, 87}},
+	{8, 47, []uint32{1, 3, 7, 3, 25, 17, 3, 55}},
+	{8, 49, []uint32{1, 3, 1, 7, 17, 59, 61, 253}},
+	{8, 50, []uint32{1, 3, 1, 13, 15, 29, 1, 167}},
+	{8, 52, []uint32{1, 3, 3, 15, 13, 7, 11, 141}},
+	{8, 56, []uint32{1, 3, 5, 15, 13, 11, 7, 27}},
+	{8, 67, []uint32{1, 1, 7, 7, 19, 25, 75, 45}},
+	{8, 70, []uint32{1, 3, 7, 3, 11, 11, 87, 147}},
+	{8, 84, []uint32{1, 1, 1, 9, 29, 55, 93, 3}},
+	{8, 97, []uint32{1, 3, 1, 1, 7, 13, 105, 59}},
+	{8, 103, []uint32{1, 3, 7, 5, 13, 43, 31, 67}},
+	{8, 115, []uint32{1, 1, 3, 7, 7, 45, 27, 1}},
+	{8, 122, []uint32{1, 1, 3, 1, 9, 23, 69, 103}},
+	{9, 8, []uint32{1, 3, 1, 11, 31, 7, 5, 177, 257}},
+	{9, 13, []uint32{1, 1, 7, 1, 31, 31, 37, 155, 327}},
+	{9, 16, []uint32{1, 3, 3, 15, 3, 31, 69, 11, 369}},
+	{9, 22, []uint32{1, 3, 1, 15, 21, 23, 71, 73, 61}},
+	{9, 25, []uint32{1, 3, 7, 5, 7, 51, 21, 109, 449}},
+	{9, 44, []uint32{1, 3, 5, 1, 5, 23, 107, 73, 463}},
+	{9, 47, []uint32{1, 1, 7, 5, 5, 17, 109, 157, 79}},
+	{9, 52, []uint32{1, 3, 3, 3, 25, 25, 93, 185, 305}},
+	{9, 55, []uint32{1, 1, 7, 1, 9, 35, 97, 67, 489}},
+	{9, 59, []uint32{1, 3, 5, 13, 27, 51, 43, 117, 307}},
+	{9, 62, []uint32{1, 1, 7, 1, 7, 13, 81, 103, 483}},
+}
+
+// Sobol generates a Sobol low-discrepancy sequence in the unit hypercube, in Gray-code order. The origin, the first
+// point of the sequence, is skipped: it lies on the edge of the hypercube, where the inverse normal distribution is
+// infinite, so the first point returned is the second of the sequence, with every coordinate 0.5
+type Sobol struct {
+	directions [][sobolBits]uint32 // Direction numbers by dimension, scaled to sobolBits bits
+	state      []uint32            // Coordinates of the last point, scaled to sobolBits bits
+	index      uint32              // Index of the last point in the sequence
+}
+
+// NewSobol creates a Sobol sequence generator positioned at the origin, which it skips
+// Returns ErrInvalidDimensions for fewer than one or more than SobolMaxDimensions dimensions
+// dimensions: the number of dimensions
+func NewSobol(dimensions int) (*Sobol, error) {
+	if dimensions < 1 || dimensions > SobolMaxDimensions {
+		return nil, ErrInvalidDimensions
+	}
+
+	directions := make([][sobolBits]uint32, dimensions)
+	for k := range directions[0] {
+		directions[0][k] = 1 << (sobolBits - 1 - k)
+	}
+	for d := 1; d < dimensions; d++ {
+		polynomial := sobolPolynomials[d-1]
+		s, v := polynomial.degree, &directions[d]
+		for k, m := range polynomial.initial {
+			v[k] = m << (sobolBits - 1 - k)
+		}
+		// v_k = a_1·v_{k−1} ⊕ ... ⊕ a_{s−1}·v_{k−s+1} ⊕ v_{k−s} ⊕ v_{k−s}/2^s
+		for k := s; k < sobolBits; k++ {
+			v[k] = v[k-s] ^ v[k-s]>>s
+			for j := 1; j < s; j++ {
+				if polynomial.coefficients>>(s-1-j)&1 == 1 {
+					v[k] ^= v[k-j]
+				}
+			}
+		}
+	}
+	return &Sobol{directions: directions, state: make([]uint32, dimensions)}, nil
+}
+
+// Dimensions returns the number of dimensions of the sequence
+func (sobol *Sobol) Dimensions() int {
+	return len(sobol.state)
+}
+
+// Next advances to the next point of the sequence and writes its coordinates, each in (0, 1), to point. After
+// 2^32 − 1 points the sequence is exhausted and starts again from its first point
+// point: the destination, with one element per dimension
+func (sobol *Sobol) Next(point []float64) {
+	if sobol.index == sobolPeriod {
+		sobol.index = 0
+		clear(sobol.state)
+	}
+	// the next Gray code differs from the last in the lowest zero bit of the index
+	bit := bits.TrailingZeros32(^sobol.index)
+	sobol.index++
+	for d := range sobol.state {
+		sobol.state[d] ^= sobol.directions[d][bit]
+		point[d] = float64(sobol.state[d]) / (1 << sobolBits)
+	}
+}
+
+// Skip advances past the next points of the sequence without generating them, in time independent of their number,
+// so that the sequence can be split into runs generated in parallel
+// points: the number of points to skip
+func (sobol *Sobol) Skip(points uint64) {
+	if points == 0 {
+		return
+	}
+	// the points after the origin repeat with period 2^32 − 1
+	sobol.seek(uint32((uint64(sobol.index)+points-1)%sobolPeriod + 1))
+}
+
+// seek positions the sequence at the point of an index, 0 for the origin, as the sum of the direction numbers of the
+// set bits of its Gray code i ⊕ i/2
+// index: the index of the point
+func (sobol *Sobol) seek(index uint32) {
+	sobol.index = index
+	gray := index ^ index>>1
+	for d := range sobol.state {
+		var state uint32
+		for code := gray; code != 0; code &= code - 1 {
+			state ^= sobol.directions[d][bits.TrailingZeros32(code)]
+		}
+		sobol.state[d] = state
+	}
+}
@@ -0,0 +1,116 @@
+package finance
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSobolFirstPoints(t *testing.T) {
+	sobol, err := NewSobol(2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := [][]float64{{0.5, 0.5}, {0.75, 0.25}, {0.25, 0.75}, {0.375, 0.375}, {0.875, 0.875}}
+	point := make([]float64, 2)
+	for i, w := range want {
+		sobol.Next(point)
+		if point[0] != w[0] || point[1] != w[1] {
+			t.Errorf("Unexpected point %d: got %v, want %v", i+1, point, w)
+		}
+	}
+}
+
+func TestSobolPropertyA(t *testing.T) {
+	// with the origin, the first 2^d points in d dimensions fall one in each subcube from halving every axis
+	for dimensions := 1; dimensions <= 12; dimensions++ {
+		sobol, err := NewSobol(dimensions)
+		if err != nil {
+			t.Fatalf("Unexpected error for %d dimensions: %v", dimensions, err)
+		}
+		seen := make([]bool, 1<<dimensions)
+		seen[0] = true
+		point := make([]float64, dimensions)
+		for range 1<<dimensions - 1 {
+			sobol.Next(point)
+			cell := 0
+			for d, x := range point {
+				if x <= 0 || x >= 1 {
+					t.Fatalf("Unexpected coordinate in %d dimensions: got %v, want in (0, 1)", dimensions, x)
+				}
+				if x >= 0.5 {
+					cell |= 1 << d
+				}
+			}
+			if seen[cell] {
+				t.Errorf("Unexpected second point in subcube %b of %d dimensions", cell, dimensions)
+			}
+			seen[cell] = true
+		}
+	}
+}
+
+func TestSobolStratification(t *testing.T) {
+	// every one-dimensional projection of the first 2^k points, with the origin, has one point in each of 2^k cells
+	const k = 10
+
+	sobol, err := NewSobol(SobolMaxDimensions)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	counts := make([][]int, SobolMaxDimensions)
+	for d := range counts {
+		counts[d] = make([]int, 1<<k)
+		counts[d][0] = 1
+	}
+	point := make([]float64, SobolMaxDimensions)
+	for range 1<<k - 1 {
+		sobol.Next(point)
+		for d, x := range point {
+			counts[d][int(x*(1<<k))]++
+		}
+	}
+	for d, cells := range counts {
+		for cell, count := range cells {
+			if count != 1 {
+				t.Errorf("Unexpected count in cell %d of dimension %d: got %d, want 1", cell, d+1, count)
+			}
+		}
+	}
+}
+
+func TestNewSobolInvalidDimensions(t *testing.T) {
+	for _, dimensions := range []int{0, SobolMaxDimensions + 1} {
+		if _, err := NewSobol(dimensions); !errors.Is(err, ErrInvalidDimensions) {
+			t.Errorf("Expected ErrInvalidDimensions for %d dimensions: got %v", dimensions, err)
+		}
+	}
+}
+
+func TestSobolSkip(t *testing.T) {
+	sequential, _ := NewSobol(8)
+	skipped, _ := NewSobol(8)
+	want, got := make([]float64, 8), make([]float64, 8)
+	index := uint64(0)
+	for _, points := range []uint64{0, 1, 2, 5, 64, 1000} {
+		for range points {
+			sequential.Next(want)
+		}
+		skipped.Skip(points)
+		index += points + 1
+		sequential.Next(want)
+		skipped.Next(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("Unexpected point %d after skipping %d: got %v, want %v", index, points, got, want)
+		}
+	}
+
+	// a whole period brings the sequence back to the same point
+	skipped.Skip(1<<32 - 1)
+	skipped.Next(got)
+	sequential.Next(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("Unexpected point after skipping a whole period: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package finance
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNotBracketed is returned when a root finder is given a bracket whose ends do not straddle a root
+var ErrNotBracketed = errors.New("finance: root not bracketed")
+
+// machineEpsilon is the spacing of float64 values at 1
+const machineEpsilon = 2.220446049250313e-16
+
+// brent finds a root of f in [a, b] using Brent's method, which combines bisection with secant and inverse
+// quadratic interpolation steps. f(a) and f(b) must have opposite signs, otherwise ErrNotBracketed is returned.
+// Returns ErrNotConverged with the best estimate when the root is not located to within tolerance
+// after maxIterations iterations
+// f: the function
+// a, b: the ends of the bracket
+// tolerance: the absolute tolerance on the root, which is never taken below a few ulps of the estimate
+// maxIterations: the maximum number of iterations
+func brent(f func(float64) float64, a, b, tolerance float64, maxIterations int) (float64, int, error) {
+	fa, fb := f(a), f(b)
+	if fa == 0 {
+		return a, 0, nil
+	}
+	if fb == 0 {
+		return b, 0, nil
+	}
+	if (fa > 0) == (fb > 0) {
+		return math.NaN(), 0, ErrNotBracketed
+	}
+
+	c, fc := b, fb
+	var d, e float64
+	for i := 1; i <= maxIterations; i++ {
+		if (fb > 0) == (fc > 0) {
+			// move c so that the root lies between b and c
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		tol := 2*machineEpsilon*math.Abs(b) + 0.5*tolerance
+		m := 0.5 * (c - b)
+		if math.Abs(m) <= tol || fb == 0 {
+			return b, i, nil
+		}
+
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			// attempt interpolation
+			s := fb / fa
+			var p, q float64
+			if a == c {
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = d
+			}
+		} else {
+			d = m
+			e = d
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, m)
+		}
+		fb = f(b)
+	}
+	return b, maxIterations, ErrNotConverged
+}
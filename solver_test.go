@@ -0,0 +1,36 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBrent(t *testing.T) {
+	root, _, err := brent(func(x float64) float64 { return x*x*x - 2*x - 5 }, 2, 3, 1e-12, 100)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	const expectedRoot = 2.0945514815423265
+	if diff := math.Abs(root - expectedRoot); diff > 1e-11 {
+		t.Errorf("Unexpected root: got %v, want %v", root, expectedRoot)
+	}
+
+	if _, _, err := brent(func(x float64) float64 { return x*x + 1 }, -1, 1, 1e-12, 100); !errors.Is(err, ErrNotBracketed) {
+		t.Errorf("Expected ErrNotBracketed for an unbracketed root: got %v", err)
+	}
+
+	if _, _, err := brent(math.Cos, 0, 3, 1e-15, 2); !errors.Is(err, ErrNotConverged) {
+		t.Errorf("Expected ErrNotConverged after two iterations: got %v", err)
+	}
+
+	// a tolerance below the spacing of floats at the root still stops, on a relative floor
+	root, _, err = brent(func(x float64) float64 { return x*x - 2e6 }, 1000, 2000, 0, 100)
+	if err != nil {
+		t.Fatalf("Unexpected error for a zero tolerance: %v", err)
+	}
+	if want := math.Sqrt(2e6); math.Abs(root-want) > 1e-10 {
+		t.Errorf("Unexpected root for a zero tolerance: got %v, want %v", root, want)
+	}
+}
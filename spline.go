@@ -0,0 +1,53 @@
+package finance
+
+import "sort"
+
+// naturalSpline is a natural cubic spline through a set of knots, twice continuously differentiable and linear
+// beyond its end knots, where its second derivative vanishes
+type naturalSpline struct {
+	x, y   []float64 // Knots, with x strictly ascending
+	second []float64 // Second derivative at each knot
+}
+
+// newNaturalSpline fits a natural cubic spline through knots, solving the tridiagonal system for the second
+// derivatives at the interior knots by the Thomas algorithm. Two knots give a straight line. The slices are kept
+// x: the abscissae, strictly ascending, at least two
+// y: the ordinates
+func newNaturalSpline(x, y []float64) naturalSpline {
+	n := len(x)
+	second := make([]float64, n)
+	// (h[i−1]·M[i−1] + 2(h[i−1] + h[i])·M[i] + h[i]·M[i+1])/6 = slope[i] − slope[i−1], with M at the ends zero
+	ratio, reduced := make([]float64, n), make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		before, after := x[i]-x[i-1], x[i+1]-x[i]
+		rhs := 6 * ((y[i+1]-y[i])/after - (y[i]-y[i-1])/before)
+		denominator := 2*(before+after) - before*ratio[i-1]
+		ratio[i], reduced[i] = after/denominator, (rhs-before*reduced[i-1])/denominator
+	}
+	for i := n - 2; i > 0; i-- {
+		second[i] = reduced[i] - ratio[i]*second[i+1]
+	}
+	return naturalSpline{x: x, y: y, second: second}
+}
+
+// evaluate computes the spline and its first and second derivatives, extending it linearly beyond the end knots
+// at: the abscissa
+func (s naturalSpline) evaluate(at float64) (value, slope, curvature float64) {
+	n := len(s.x)
+	// the interval [x[i], x[i+1]] holding the abscissa, the first or last beyond the knots
+	i := min(max(sort.SearchFloat64s(s.x, at)-1, 0), n-2)
+	h := s.x[i+1] - s.x[i]
+	switch {
+	case at < s.x[0]:
+		slope = (s.y[1]-s.y[0])/h - h*(2*s.second[0]+s.second[1])/6
+		return s.y[0] + slope*(at-s.x[0]), slope, 0
+	case at > s.x[n-1]:
+		slope = (s.y[n-1]-s.y[n-2])/h + h*(s.second[n-2]+2*s.second[n-1])/6
+		return s.y[n-1] + slope*(at-s.x[n-1]), slope, 0
+	}
+	a, b := (s.x[i+1]-at)/h, (at-s.x[i])/h
+	value = a*s.y[i] + b*s.y[i+1] + ((a*a*a-a)*s.second[i]+(b*b*b-b)*s.second[i+1])*h*h/6
+	slope = (s.y[i+1]-s.y[i])/h + ((1-3*a*a)*s.second[i]+(3*b*b-1)*s.second[i+1])*h/6
+	curvature = a*s.second[i] + b*s.second[i+1]
+	return value, slope, curvature
+}
@@ -0,0 +1,54 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNaturalSpline(t *testing.T) {
+	x := []float64{0, 0.5, 1.0, 2.0, 3.0, 4.5}
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = math.Sin(x[i])
+	}
+	s := newNaturalSpline(x, y)
+	for i := range x {
+		if got, _, _ := s.evaluate(x[i]); math.Abs(got-y[i]) > 1e-12 {
+			t.Errorf("Unexpected spline at knot %v: got %v, want %v", x[i], got, y[i])
+		}
+	}
+	for _, at := range []float64{0.8, 1.5, 2.5} {
+		// the natural ends leave an error that is largest toward the ends, where sin has curvature
+		if got, slope, _ := s.evaluate(at); math.Abs(got-math.Sin(at)) > 3e-2 || math.Abs(slope-math.Cos(at)) > 5e-2 {
+			t.Errorf("Unexpected spline at %v: got %v and slope %v, want %v and %v", at, got, slope, math.Sin(at), math.Cos(at))
+		}
+	}
+
+	// the slope and curvature are continuous at the interior knots
+	for _, knot := range x[1 : len(x)-1] {
+		_, before, curveBefore := s.evaluate(knot - 1e-9)
+		_, after, curveAfter := s.evaluate(knot + 1e-9)
+		if math.Abs(before-after) > 1e-7 || math.Abs(curveBefore-curveAfter) > 1e-6 {
+			t.Errorf("Unexpected discontinuity at knot %v: got slopes %v and %v, curvatures %v and %v", knot, before, after, curveBefore, curveAfter)
+		}
+	}
+
+	// natural ends: no curvature at the end knots, and straight lines with the end slopes beyond them
+	for _, end := range []float64{0, 4.5} {
+		if _, _, curvature := s.evaluate(end); math.Abs(curvature) > 1e-12 {
+			t.Errorf("Unexpected curvature at end knot %v: got %v, want 0", end, curvature)
+		}
+	}
+	for _, pair := range [][2]float64{{0, -2}, {4.5, 10}} {
+		value, slope, _ := s.evaluate(pair[0])
+		if got, _, curvature := s.evaluate(pair[1]); math.Abs(got-(value+slope*(pair[1]-pair[0]))) > 1e-12 || curvature != 0 {
+			t.Errorf("Unexpected extrapolation to %v: got %v, want %v", pair[1], got, value+slope*(pair[1]-pair[0]))
+		}
+	}
+
+	// two knots give a straight line
+	line := newNaturalSpline([]float64{1, 3}, []float64{2, 6})
+	if got, slope, _ := line.evaluate(2.5); got != 5 || slope != 2 {
+		t.Errorf("Unexpected spline through two knots: got %v and slope %v, want 5 and 2", got, slope)
+	}
+}
@@ -0,0 +1,33 @@
+package finance
+
+import (
+	"math"
+)
+
+// KirkSpreadOptionPrice computes Kirk's (1995) approximation to the price of a European option on the spread between
+// two futures prices, with payoff (F1 − F2 − K)⁺ for a call and (K − F1 + F2)⁺ for a put. It treats F2 + K as
+// lognormal, so that the option is a Black option on F1 struck at F2 + K with the volatility
+// √(σ1² + (σ2·w)² − 2ρσ1σ2·w), w = F2/(F2 + K). The approximation preserves put-call parity and is exact for a zero
+// strike, where it is the Margrabe exchange option; its error grows with the strike's share of F2 + K and with the
+// correlation. For strikes within 5% of F2 and correlations up to 0.9 it is within about 0.4% of the price, and within
+// a few percent for strikes up to 20% of F2; with correlations above 0.9, where the spread volatility is small next
+// to the volatilities of the legs, and out-of-the-money strikes of more than 20% of F2, the relative error can exceed
+// 10%, and a simulation is the better tool. At expiration it is worth its payoff. NaN with negative time to expiration,
+// a correlation outside [-1, 1] or a strike at or below −F2, where F2 + K is not positive
+// f1: the futures price of the first leg, received by a call
+// f2: the futures price of the second leg, paid by a call
+// strike: the strike on the spread
+// vol1: the volatility of the first leg
+// vol2: the volatility of the second leg
+// corr: the correlation of the returns of the two legs
+// r: the risk-free interest rate, used to discount the payoff
+// timeYears: the time to expiration in years
+// typ: the type of the option
+func KirkSpreadOptionPrice(f1, f2, strike, vol1, vol2, corr, r, timeYears float64, typ OptionType) float64 {
+	if timeYears < 0 || !(corr >= -1 && corr <= 1) || !(f2+strike > 0) {
+		return math.NaN()
+	}
+	weight := f2 / (f2 + strike)
+	vol := math.Sqrt(math.Max(vol1*vol1+vol2*vol2*weight*weight-2*corr*vol1*vol2*weight, 0))
+	return BlackScholesForwardPrice(f1, f2+strike, timeYears, r, vol, typ)
+}
@@ -0,0 +1,64 @@
+package finance
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestKirkSpreadOptionPrice(t *testing.T) {
+	const (
+		timeYears = 0.5
+		rate      = 0.05
+		paths     = 200000
+	)
+	for _, strike := range []float64{-5.0, 0, 5.0, 10.0} {
+		for _, corr := range []float64{-0.3, 0.5, 0.8} {
+			// simulate the two futures prices at expiration with correlated draws, each paired with its mirror image
+			rng := rand.New(rand.NewPCG(1, 2))
+			var sum, sumSquares float64
+			for range paths / 2 {
+				z1, z := rng.NormFloat64(), rng.NormFloat64()
+				z2 := corr*z1 + math.Sqrt(1-corr*corr)*z
+				var pair float64
+				for _, sign := range []float64{1, -1} {
+					f1 := 100.0 * math.Exp(-0.5*0.3*0.3*timeYears+0.3*math.Sqrt(timeYears)*sign*z1)
+					f2 := 95.0 * math.Exp(-0.5*0.25*0.25*timeYears+0.25*math.Sqrt(timeYears)*sign*z2)
+					pair += math.Max(f1-f2-strike, 0) / 2
+				}
+				sum += pair
+				sumSquares += pair * pair
+			}
+			mean := sum / (paths / 2)
+			stdError := math.Sqrt((sumSquares/(paths/2) - mean*mean) / (paths / 2))
+			want := math.Exp(-rate*timeYears) * mean
+			got := KirkSpreadOptionPrice(100.0, 95.0, strike, 0.3, 0.25, corr, rate, timeYears, Call)
+			if math.Abs(got-want) > 3*stdError+0.003*want {
+				t.Errorf("Unexpected spread call price for strike %v correlation %v: got %v, want %v ± %v", strike, corr, got, want, stdError)
+			}
+
+			// put-call parity on the spread
+			put := KirkSpreadOptionPrice(100.0, 95.0, strike, 0.3, 0.25, corr, rate, timeYears, Put)
+			if parity := math.Exp(-rate*timeYears) * (100.0 - 95.0 - strike); math.Abs(got-put-parity) > 1e-12 {
+				t.Errorf("Unexpected spread parity for strike %v correlation %v: got %v, want %v", strike, corr, got-put, parity)
+			}
+		}
+	}
+
+	// with a zero strike it is the exchange option on the two futures, which yield the risk-free rate
+	if got, want := KirkSpreadOptionPrice(100.0, 95.0, 0, 0.3, 0.25, 0.5, rate, timeYears, Call), MargrabePrice(100.0, 95.0, 0.3, 0.25, 0.5, rate, rate, timeYears); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected zero-strike spread price: got %v, want %v", got, want)
+	}
+	if got := KirkSpreadOptionPrice(100.0, 95.0, 2.0, 0.3, 0.25, 0.5, rate, 0, Call); math.Abs(got-3.0) > 1e-12 {
+		t.Errorf("Unexpected spread price at expiration: got %v, want 3", got)
+	}
+	for name, got := range map[string]float64{
+		"strike at minus the second leg": KirkSpreadOptionPrice(100.0, 95.0, -95.0, 0.3, 0.25, 0.5, rate, timeYears, Call),
+		"negative time":                  KirkSpreadOptionPrice(100.0, 95.0, 5.0, 0.3, 0.25, 0.5, rate, -1, Call),
+		"correlation below":              KirkSpreadOptionPrice(100.0, 95.0, 5.0, 0.3, 0.25, -1.5, rate, timeYears, Call),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected spread price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
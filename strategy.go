@@ -0,0 +1,177 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidStrategy is returned for the strikes or expirations of a strategy that are not in its order
+var ErrInvalidStrategy = errors.New("finance: invalid strategy")
+
+// Underlying is the market of an underlying asset on which strategies are built, whose options are priced by
+// Black-Scholes at one volatility
+type Underlying struct {
+	Price         float64    `json:"price"`                    // Current price of the underlying asset
+	RiskFreeRate  float64    `json:"risk_free_rate"`           // Risk-free interest rate
+	DividendYield float64    `json:"dividend_yield,omitempty"` // Continuous dividend yield of the underlying asset
+	Dividends     []Dividend `json:"dividends,omitempty"`      // Discrete cash dividends, priced with the escrowed dividend model
+	Volatility    float64    `json:"volatility"`               // Volatility at which the options are priced
+}
+
+// strategyLeg is a leg of a strategy before it is priced
+type strategyLeg struct {
+	side     Side
+	quantity float64
+	days     float64
+	strike   float64
+	typ      OptionType
+}
+
+// strategy constructs a portfolio of options on the underlying priced at its volatility. Returns the errors of
+// validatePricing for the first leg that cannot be priced
+// underlying: the underlying, whose price the shares are bought at
+// shares: the units of the underlying held
+// legs: the legs
+func strategy(underlying Underlying, shares float64, legs ...strategyLeg) (Portfolio, error) {
+	portfolio := Portfolio{Shares: shares}
+	if shares != 0 {
+		portfolio.SharePrice = underlying.Price
+	}
+	for _, leg := range legs {
+		option := Option{
+			Strike:           leg.strike,
+			DaysToExpiration: leg.days,
+			RiskFreeRate:     underlying.RiskFreeRate,
+			UnderlyingPrice:  underlying.Price,
+			OptionType:       leg.typ,
+			DividendYield:    underlying.DividendYield,
+			Dividends:        underlying.Dividends,
+		}
+		if err := validatePricing(option, underlying.Volatility); err != nil {
+			return Portfolio{}, fmt.Errorf("%v with strike %v in %v days: %w", leg.typ, leg.strike, leg.days, err)
+		}
+		option.Price = BlackScholesOptionPrice(option, underlying.Volatility)
+		portfolio.Legs = append(portfolio.Legs, Leg{Option: option, Volatility: underlying.Volatility, Quantity: leg.quantity, Side: leg.side})
+	}
+	return portfolio, nil
+}
+
+// NewVerticalSpread constructs a vertical spread, long one option and short another of the same type and
+// expiration. Bought below the short strike, a call spread is a bull spread paying a debit and a put spread a bear
+// spread receiving a credit. Returns ErrInvalidStrategy for equal strikes, and the errors of validatePricing
+// underlying: the underlying
+// expiryDays: the days to expiration
+// longStrike: the strike of the option bought
+// shortStrike: the strike of the option sold
+// typ: the type of the options
+func NewVerticalSpread(underlying Underlying, expiryDays, longStrike, shortStrike float64, typ OptionType) (Portfolio, error) {
+	if longStrike == shortStrike {
+		return Portfolio{}, fmt.Errorf("%w: vertical spread with both strikes at %v", ErrInvalidStrategy, longStrike)
+	}
+	return strategy(underlying, 0,
+		strategyLeg{Long, 1, expiryDays, longStrike, typ},
+		strategyLeg{Short, 1, expiryDays, shortStrike, typ},
+	)
+}
+
+// NewStraddle constructs a straddle, a call and a put at the same strike and expiration, bought to profit from a
+// large move or sold to collect the premium. Returns the errors of validatePricing
+// underlying: the underlying
+// expiryDays: the days to expiration
+// strike: the strike of both options
+// side: Long to buy the straddle, Short to sell it
+func NewStraddle(underlying Underlying, expiryDays, strike float64, side Side) (Portfolio, error) {
+	return strategy(underlying, 0,
+		strategyLeg{side, 1, expiryDays, strike, Call},
+		strategyLeg{side, 1, expiryDays, strike, Put},
+	)
+}
+
+// NewStrangle constructs a strangle, a put and a call at a higher strike with the same expiration. Returns
+// ErrInvalidStrategy if the put strike is not below the call strike, and the errors of validatePricing
+// underlying: the underlying
+// expiryDays: the days to expiration
+// putStrike: the strike of the put
+// callStrike: the strike of the call
+// side: Long to buy the strangle, Short to sell it
+func NewStrangle(underlying Underlying, expiryDays, putStrike, callStrike float64, side Side) (Portfolio, error) {
+	if !(putStrike < callStrike) {
+		return Portfolio{}, fmt.Errorf("%w: strangle with put strike %v not below call strike %v", ErrInvalidStrategy, putStrike, callStrike)
+	}
+	return strategy(underlying, 0,
+		strategyLeg{side, 1, expiryDays, putStrike, Put},
+		strategyLeg{side, 1, expiryDays, callStrike, Call},
+	)
+}
+
+// NewIronCondor constructs a short iron condor, selling a put and a call spread either side of the underlying price
+// with the same expiration for a credit: long a put at the lowest strike, short a put and a call at the inner
+// strikes and long a call at the highest. Its largest loss is the wider spread's width less the credit. Returns
+// ErrInvalidStrategy for strikes that are not ascending, and the errors of validatePricing
+// underlying: the underlying
+// expiryDays: the days to expiration
+// longPut: the strike of the put bought
+// shortPut: the strike of the put sold
+// shortCall: the strike of the call sold
+// longCall: the strike of the call bought
+func NewIronCondor(underlying Underlying, expiryDays, longPut, shortPut, shortCall, longCall float64) (Portfolio, error) {
+	if !(longPut < shortPut && shortPut < shortCall && shortCall < longCall) {
+		return Portfolio{}, fmt.Errorf("%w: iron condor with strikes %v, %v, %v and %v not ascending", ErrInvalidStrategy, longPut, shortPut, shortCall, longCall)
+	}
+	return strategy(underlying, 0,
+		strategyLeg{Long, 1, expiryDays, longPut, Put},
+		strategyLeg{Short, 1, expiryDays, shortPut, Put},
+		strategyLeg{Short, 1, expiryDays, shortCall, Call},
+		strategyLeg{Long, 1, expiryDays, longCall, Call},
+	)
+}
+
+// NewButterfly constructs a long butterfly of one option type, long an option at the lowest and the highest strike
+// and short two at the middle strike with the same expiration, which profits most with the underlying at the middle
+// strike at expiration. The wings need not be the same width. Returns ErrInvalidStrategy for strikes that are not
+// ascending, and the errors of validatePricing
+// underlying: the underlying
+// expiryDays: the days to expiration
+// lower: the lowest strike
+// middle: the middle strike
+// upper: the highest strike
+// typ: the type of the options
+func NewButterfly(underlying Underlying, expiryDays, lower, middle, upper float64, typ OptionType) (Portfolio, error) {
+	if !(lower < middle && middle < upper) {
+		return Portfolio{}, fmt.Errorf("%w: butterfly with strikes %v, %v and %v not ascending", ErrInvalidStrategy, lower, middle, upper)
+	}
+	return strategy(underlying, 0,
+		strategyLeg{Long, 1, expiryDays, lower, typ},
+		strategyLeg{Short, 2, expiryDays, middle, typ},
+		strategyLeg{Long, 1, expiryDays, upper, typ},
+	)
+}
+
+// NewCalendarSpread constructs a long calendar spread, short an option at the nearer expiration and long one of the
+// same type and strike at the farther, which profits from the faster decay of the nearer option. Returns
+// ErrInvalidStrategy if the nearer expiration is not before the farther, and the errors of validatePricing
+// underlying: the underlying
+// strike: the strike of both options
+// nearDays: the days to the expiration of the option sold
+// farDays: the days to the expiration of the option bought
+// typ: the type of the options
+func NewCalendarSpread(underlying Underlying, strike, nearDays, farDays float64, typ OptionType) (Portfolio, error) {
+	if !(nearDays < farDays) {
+		return Portfolio{}, fmt.Errorf("%w: calendar spread expiring in %v days not before %v days", ErrInvalidStrategy, nearDays, farDays)
+	}
+	return strategy(underlying, 0,
+		strategyLeg{Short, 1, nearDays, strike, typ},
+		strategyLeg{Long, 1, farDays, strike, typ},
+	)
+}
+
+// NewCoveredCall constructs a covered call, one unit of the underlying bought at its price and a call sold on it.
+// Returns the errors of validatePricing
+// underlying: the underlying
+// expiryDays: the days to expiration of the call
+// strike: the strike of the call
+func NewCoveredCall(underlying Underlying, expiryDays, strike float64) (Portfolio, error) {
+	return strategy(underlying, 1,
+		strategyLeg{Short, 1, expiryDays, strike, Call},
+	)
+}
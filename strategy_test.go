@@ -0,0 +1,163 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestStrategyLegs(t *testing.T) {
+	underlying := Underlying{Price: 100.0, RiskFreeRate: 0.04, DividendYield: 0.01, Volatility: 0.25}
+	type leg struct {
+		side     Side
+		quantity float64
+		strike   float64
+		typ      OptionType
+	}
+	build := func(portfolio Portfolio, err error) Portfolio {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return portfolio
+	}
+	tests := []struct {
+		name      string
+		portfolio Portfolio
+		legs      []leg
+		shares    float64
+	}{
+		{"vertical spread", build(NewVerticalSpread(underlying, 30.0, 95.0, 105.0, Call)), []leg{{Long, 1, 95.0, Call}, {Short, 1, 105.0, Call}}, 0},
+		{"straddle", build(NewStraddle(underlying, 30.0, 100.0, Short)), []leg{{Short, 1, 100.0, Call}, {Short, 1, 100.0, Put}}, 0},
+		{"strangle", build(NewStrangle(underlying, 30.0, 90.0, 110.0, Long)), []leg{{Long, 1, 90.0, Put}, {Long, 1, 110.0, Call}}, 0},
+		{"iron condor", build(NewIronCondor(underlying, 30.0, 85.0, 90.0, 110.0, 115.0)), []leg{{Long, 1, 85.0, Put}, {Short, 1, 90.0, Put}, {Short, 1, 110.0, Call}, {Long, 1, 115.0, Call}}, 0},
+		{"butterfly", build(NewButterfly(underlying, 30.0, 90.0, 100.0, 110.0, Put)), []leg{{Long, 1, 90.0, Put}, {Short, 2, 100.0, Put}, {Long, 1, 110.0, Put}}, 0},
+		{"calendar spread", build(NewCalendarSpread(underlying, 100.0, 30.0, 60.0, Call)), []leg{{Short, 1, 100.0, Call}, {Long, 1, 100.0, Call}}, 0},
+		{"covered call", build(NewCoveredCall(underlying, 30.0, 105.0)), []leg{{Short, 1, 105.0, Call}}, 1},
+	}
+	for _, test := range tests {
+		if len(test.portfolio.Legs) != len(test.legs) {
+			t.Errorf("Unexpected number of legs of the %s: got %d, want %d", test.name, len(test.portfolio.Legs), len(test.legs))
+			continue
+		}
+		if test.portfolio.Shares != test.shares {
+			t.Errorf("Unexpected shares of the %s: got %v, want %v", test.name, test.portfolio.Shares, test.shares)
+		}
+		for i, want := range test.legs {
+			got := test.portfolio.Legs[i]
+			if got.Side != want.side || got.Quantity != want.quantity || got.Option.Strike != want.strike || got.Option.OptionType != want.typ {
+				t.Errorf("Unexpected leg %d of the %s: got %+v, want %+v", i, test.name, got, want)
+			}
+			if price := BlackScholesOptionPrice(got.Option, 0.25); got.Option.Price != price || got.Volatility != 0.25 {
+				t.Errorf("Unexpected price of leg %d of the %s: got %v at %v, want %v at 0.25", i, test.name, got.Option.Price, got.Volatility, price)
+			}
+		}
+	}
+
+	if days := tests[5].portfolio.Legs[1].Option.DaysToExpiration; days != 60.0 {
+		t.Errorf("Unexpected far expiration of the calendar spread: got %v, want 60", days)
+	}
+	for name, err := range map[string]error{
+		"equal strikes":        second(NewVerticalSpread(underlying, 30.0, 100.0, 100.0, Call)),
+		"inverted strangle":    second(NewStrangle(underlying, 30.0, 110.0, 90.0, Long)),
+		"unordered condor":     second(NewIronCondor(underlying, 30.0, 90.0, 85.0, 110.0, 115.0)),
+		"unordered butterfly":  second(NewButterfly(underlying, 30.0, 100.0, 90.0, 110.0, Call)),
+		"inverted calendar":    second(NewCalendarSpread(underlying, 100.0, 60.0, 30.0, Call)),
+		"same calendar expiry": second(NewCalendarSpread(underlying, 100.0, 30.0, 30.0, Call)),
+	} {
+		if !errors.Is(err, ErrInvalidStrategy) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", name, err, ErrInvalidStrategy)
+		}
+	}
+	noVol := underlying
+	noVol.Volatility = 0
+	if _, err := NewStraddle(noVol, 30.0, 100.0, Long); !errors.Is(err, ErrNonPositiveVolatility) {
+		t.Errorf("Unexpected error for zero vol: got %v, want %v", err, ErrNonPositiveVolatility)
+	}
+	if _, err := NewCoveredCall(underlying, 30.0, -5.0); !errors.Is(err, ErrNonPositiveStrike) {
+		t.Errorf("Unexpected error for a negative strike: got %v, want %v", err, ErrNonPositiveStrike)
+	}
+}
+
+func TestStrategyAnalytics(t *testing.T) {
+	underlying := Underlying{Price: 100.0, RiskFreeRate: 0.04, Volatility: 0.25}
+	const tolerance = 1e-12
+
+	// a short iron condor collects a credit and loses at most the width of a wing less the credit
+	condor, err := NewIronCondor(underlying, 30.0, 85.0, 90.0, 110.0, 115.0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	credit := -condor.Cost()
+	if !(credit > 0) {
+		t.Errorf("Unexpected credit of the iron condor: got %v, want positive", credit)
+	}
+	if got, want := condor.MaxLoss(), 5.0-credit; math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected max loss of the iron condor: got %v, want %v", got, want)
+	}
+	if got := condor.MaxProfit(); math.Abs(got-credit) > tolerance {
+		t.Errorf("Unexpected max profit of the iron condor: got %v, want %v", got, credit)
+	}
+	if got := condor.ProfitAt(100.0); math.Abs(got-credit) > tolerance {
+		t.Errorf("Unexpected profit of the iron condor at 100: got %v, want %v", got, credit)
+	}
+	// near the money the condor is short gamma and vega
+	if greeks := condor.Greeks(); !(greeks.Gamma < 0 && greeks.Vega < 0) || math.Abs(greeks.Price+credit) > tolerance {
+		t.Errorf("Unexpected Greeks of the iron condor: got %+v", greeks)
+	}
+
+	spread, _ := NewVerticalSpread(underlying, 30.0, 95.0, 105.0, Call)
+	if got, want := spread.MaxLoss(), spread.Cost(); math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected max loss of the call spread: got %v, want %v", got, want)
+	}
+	if got, want := spread.MaxProfit(), 10.0-spread.Cost(); math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected max profit of the call spread: got %v, want %v", got, want)
+	}
+	if want := BlackScholesGreeks(spread.Legs[0].Option, 0.25).Delta - BlackScholesGreeks(spread.Legs[1].Option, 0.25).Delta; math.Abs(spread.Greeks().Delta-want) > tolerance {
+		t.Errorf("Unexpected delta of the call spread: got %v, want %v", spread.Greeks().Delta, want)
+	}
+
+	long, _ := NewStraddle(underlying, 30.0, 100.0, Long)
+	short, _ := NewStraddle(underlying, 30.0, 100.0, Short)
+	if got := long.MaxLoss(); math.Abs(got-long.Cost()) > tolerance || !math.IsInf(long.MaxProfit(), 1) {
+		t.Errorf("Unexpected max loss and profit of the long straddle: got %v and %v, want %v and +Inf", got, long.MaxProfit(), long.Cost())
+	}
+	if !math.IsInf(short.MaxLoss(), 1) {
+		t.Errorf("Unexpected max loss of the short straddle: got %v, want +Inf", short.MaxLoss())
+	}
+
+	butterfly, _ := NewButterfly(underlying, 30.0, 90.0, 100.0, 110.0, Call)
+	if got, want := butterfly.MaxProfit(), 10.0-butterfly.Cost(); math.Abs(got-want) > tolerance || math.Abs(butterfly.MaxLoss()-butterfly.Cost()) > tolerance {
+		t.Errorf("Unexpected max profit of the butterfly: got %v, want %v", got, want)
+	}
+
+	// a covered call is the share less the call, losing down to a zero price and capped at the strike
+	covered, _ := NewCoveredCall(underlying, 30.0, 105.0)
+	call := covered.Legs[0].Option
+	if got, want := covered.Greeks().Delta, 1-BlackScholesGreeks(call, 0.25).Delta; math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected delta of the covered call: got %v, want %v", got, want)
+	}
+	if got, want := covered.MaxLoss(), 100.0-call.Price; math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected max loss of the covered call: got %v, want %v", got, want)
+	}
+	if got, want := covered.MaxProfit(), 5.0+call.Price; math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected max profit of the covered call: got %v, want %v", got, want)
+	}
+
+	// at the near expiration a calendar spread holds the far option with the rest of its time
+	calendar, _ := NewCalendarSpread(underlying, 100.0, 30.0, 60.0, Call)
+	far := calendar.Legs[1].Option
+	far.DaysToExpiration = 30.0
+	far.UnderlyingPrice = 102.0
+	if got, want := calendar.ValueAt(102.0), BlackScholesOptionPrice(far, 0.25)-2.0; math.Abs(got-want) > tolerance {
+		t.Errorf("Unexpected value of the calendar spread at 102: got %v, want %v", got, want)
+	}
+	if !math.IsNaN(calendar.MaxLoss()) {
+		t.Errorf("Unexpected max loss of the calendar spread: got %v, want NaN", calendar.MaxLoss())
+	}
+}
+
+// second returns the second of two values, the error of a constructor
+func second[T any](_ T, err error) error {
+	return err
+}
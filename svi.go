@@ -0,0 +1,246 @@
+package finance
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// SVI calibration
+const (
+	sviMinQuotes     = 5     // Fewest quotes with positive weight, one for each parameter
+	sviMaxRho        = 0.999 // Largest magnitude of the correlation ρ
+	sviBisquareScale = 4.685 // Multiple of the median absolute residual beyond which the bisquare weight is zero
+	sviRobustPasses  = 5     // Largest number of fits, the first unweighted by the bisquare
+)
+
+// SVIParams holds the parameters of the raw stochastic volatility inspired parameterization of Gatheral (2004) of
+// the total implied variance w = σ²T of a smile in the log moneyness k = ln(K/F),
+// w(k) = a + b·(ρ·(k − m) + √((k − m)² + σ²)), whose wings are linear in k with slopes b·(1 ± ρ)
+type SVIParams struct {
+	A     float64 `json:"a"`     // Level of the total variance a
+	B     float64 `json:"b"`     // Angle between the wings b, not negative
+	Rho   float64 `json:"rho"`   // Rotation of the smile ρ, in (-1, 1)
+	M     float64 `json:"m"`     // Translation of the smile in log moneyness m
+	Sigma float64 `json:"sigma"` // Smoothness of the vertex σ, positive
+}
+
+// SVITotalVariance computes the total implied variance of the raw SVI parameterization at a log moneyness, which
+// can be negative for parameters with a + b·σ·√(1 − ρ²) < 0
+// k: the log moneyness ln(K/F)
+// p: the parameters
+func SVITotalVariance(k float64, p SVIParams) float64 {
+	shifted := k - p.M
+	return p.A + p.B*(p.Rho*shifted+math.Sqrt(shifted*shifted+p.Sigma*p.Sigma))
+}
+
+// CalibrateSVI fits the raw SVI parameterization to total implied variances by the quasi-explicit method of
+// Zeliade (2009). In y = (k − m)/σ the total variance is linear, w = a + d·y + c·√(y² + 1) with c = bσ and d = ρbσ,
+// so that for each m and σ the weighted least squares a, c and d solve a quadratic programme over the polytope
+// 0 ≤ a ≤ max w, |d| ≤ 0.999·c and |d| ≤ 4σ − c, exactly, by its active constraints. The bounds keep b ≥ 0,
+// |ρ| ≤ 0.999 and the variance positive, and the last is Lee's bound b·(1 + |ρ|) ≤ 4 on the slopes of the wings.
+// The outer search over m, within one of the quotes, and σ ∈ [1e-4, 10] is a leastSquares from the vertex of the
+// smile and from the middle of the quotes, each with a sharp and a wide σ. The fit is then repeated with Tukey's
+// bisquare weights, at 4.685 times the median absolute deviation of the residuals, so that a quote far from the
+// others is down-weighted to zero instead of bending the smile, until the weights settle or at most 5 passes
+// Returns ErrInvalidCalibration for fewer than five quotes with positive weight, for mismatched lengths and for
+// invalid quotes or weights, and ErrNotConverged with the best parameters found if the search does not converge
+// logMoneyness: the log moneyness ln(K/F) of each quote
+// totalVariances: the total implied variance σ²T of each quote
+// weights: the weight of each quote, all equal if nil
+func CalibrateSVI(logMoneyness, totalVariances, weights []float64) (SVIParams, error) {
+	if len(logMoneyness) != len(totalVariances) || (weights != nil && len(weights) != len(logMoneyness)) {
+		return SVIParams{}, fmt.Errorf("%w: %d log moneyness, %d total variances and %d weights", ErrInvalidCalibration, len(logMoneyness), len(totalVariances), len(weights))
+	}
+	base := make([]float64, len(logMoneyness))
+	positive := 0
+	for i := range logMoneyness {
+		base[i] = 1
+		if weights != nil {
+			base[i] = weights[i]
+		}
+		if math.IsNaN(logMoneyness[i]) || math.IsInf(logMoneyness[i], 0) || !(totalVariances[i] >= 0) || math.IsInf(totalVariances[i], 1) || !(base[i] >= 0) || math.IsInf(base[i], 1) {
+			return SVIParams{}, fmt.Errorf("%w: quote %d at %v with total variance %v and weight %v", ErrInvalidCalibration, i, logMoneyness[i], totalVariances[i], base[i])
+		}
+		if base[i] > 0 {
+			positive++
+		}
+	}
+	if positive < sviMinQuotes {
+		return SVIParams{}, fmt.Errorf("%w: %d quotes with positive weight for five parameters", ErrInvalidCalibration, positive)
+	}
+
+	fit := sviFit{k: logMoneyness, w: totalVariances, weights: base, left: math.Inf(1), right: math.Inf(-1)}
+	vertex := 0
+	for i, k := range logMoneyness {
+		fit.left, fit.right = math.Min(fit.left, k), math.Max(fit.right, k)
+		fit.highest = math.Max(fit.highest, totalVariances[i])
+		if base[i] > 0 && (base[vertex] == 0 || totalVariances[i] < totalVariances[vertex]) {
+			vertex = i
+		}
+	}
+	x, converged, best := []float64{logMoneyness[vertex], 0.05}, false, math.Inf(1)
+	for _, m := range []float64{logMoneyness[vertex], 0.5 * (fit.left + fit.right)} {
+		for _, sigma := range []float64{0.05, 0.5} {
+			if trial, ok, objective := fit.search([]float64{m, sigma}); objective < best {
+				x, converged, best = trial, ok, objective
+			}
+		}
+	}
+
+	residuals := make([]float64, len(logMoneyness))
+	for pass := 1; pass < sviRobustPasses; pass++ {
+		p := fit.params(x)
+		deviations := make([]float64, 0, positive)
+		for i := range logMoneyness {
+			residuals[i] = SVITotalVariance(logMoneyness[i], p) - totalVariances[i]
+			if base[i] > 0 {
+				deviations = append(deviations, math.Abs(residuals[i]))
+			}
+		}
+		slices.Sort(deviations)
+		scale := sviBisquareScale * deviations[len(deviations)/2]
+		if !(scale > 1e-12*fit.highest) {
+			break
+		}
+		robust := make([]float64, len(base))
+		count, changed := 0, 0.0
+		for i := range base {
+			if u := residuals[i] / scale; math.Abs(u) < 1 {
+				robust[i] = base[i] * (1 - u*u) * (1 - u*u)
+			}
+			if robust[i] > 0 {
+				count++
+			}
+			changed = math.Max(changed, math.Abs(robust[i]-fit.weights[i])/base[i])
+		}
+		if count < sviMinQuotes || changed < 1e-3 {
+			break
+		}
+		fit.weights = robust
+		x, converged, _ = fit.search(x)
+	}
+	if !converged {
+		return fit.params(x), fmt.Errorf("%w: SVI calibration to %d quotes", ErrNotConverged, len(logMoneyness))
+	}
+	return fit.params(x), nil
+}
+
+// sviFit holds the quotes and weights of CalibrateSVI
+type sviFit struct {
+	k, w, weights []float64
+	left, right   float64 // Lowest and highest log moneyness
+	highest       float64 // Highest total variance
+}
+
+// search fits m and σ by leastSquares from a start, with a, c and d solved for each by inner, and reports whether
+// it converged and the weighted sum of squares reached
+// start: the initial m and σ
+func (f sviFit) search(start []float64) ([]float64, bool, float64) {
+	lower := []float64{f.left - 1, 1e-4}
+	upper := []float64{f.right + 1, 10}
+	residuals := func(x []float64) ([]float64, error) {
+		p := f.params(x)
+		r := make([]float64, len(f.k))
+		for i := range f.k {
+			r[i] = math.Sqrt(f.weights[i]) * (SVITotalVariance(f.k[i], p) - f.w[i])
+		}
+		return r, nil
+	}
+	x, _, converged, err := leastSquares(residuals, start, lower, upper, calibrationMaxIterations)
+	r, _ := residuals(x)
+	return x, converged && err == nil, sumOfSquares(r)
+}
+
+// params returns the SVI parameters at m and σ, with a, b and ρ from inner
+// x: m and σ
+func (f sviFit) params(x []float64) SVIParams {
+	m, sigma := x[0], x[1]
+	a, d, c := f.inner(m, sigma)
+	p := SVIParams{A: a, B: c / sigma, M: m, Sigma: sigma}
+	if c > 0 {
+		p.Rho = math.Min(math.Max(d/c, -sviMaxRho), sviMaxRho)
+	}
+	return p
+}
+
+// inner solves the weighted least squares for a, d and c at fixed m and σ over the polytope of CalibrateSVI. The
+// minimum of a convex quadratic over a polytope is the minimum over its active constraints of the quadratic on the
+// affine hull they span, so every set of at most three of the six constraints is solved by its KKT system and the
+// best feasible solution kept
+// m: the translation m
+// sigma: the smoothness σ
+func (f sviFit) inner(m, sigma float64) (a, d, c float64) {
+	// normal equations in (a, d, c)
+	hessian := [][]float64{make([]float64, 3), make([]float64, 3), make([]float64, 3)}
+	gradient := make([]float64, 3)
+	var constant float64
+	for i := range f.k {
+		y := (f.k[i] - m) / sigma
+		basis := []float64{1, y, math.Sqrt(y*y + 1)}
+		for j := range basis {
+			for l := range basis {
+				hessian[j][l] += f.weights[i] * basis[j] * basis[l]
+			}
+			gradient[j] += f.weights[i] * basis[j] * f.w[i]
+		}
+		constant += f.weights[i] * f.w[i] * f.w[i]
+	}
+	// constraints G·x ≤ h
+	g := [][]float64{{-1, 0, 0}, {1, 0, 0}, {0, 1, -sviMaxRho}, {0, -1, -sviMaxRho}, {0, 1, 1}, {0, -1, 1}}
+	h := []float64{0, f.highest, 0, 0, 4 * sigma, 4 * sigma}
+	objective := func(x []float64) float64 {
+		value := constant
+		for j := range x {
+			value -= 2 * gradient[j] * x[j]
+			for l := range x {
+				value += x[j] * hessian[j][l] * x[l]
+			}
+		}
+		return value
+	}
+
+	best, bestObjective := []float64{0, 0, 0}, math.Inf(1)
+	for set := 0; set < 1<<len(g); set++ {
+		var active []int
+		for i := range g {
+			if set&(1<<i) != 0 {
+				active = append(active, i)
+			}
+		}
+		if len(active) > 3 {
+			continue
+		}
+		n := 3 + len(active)
+		kkt := make([][]float64, n)
+		rhs := make([]float64, n)
+		for j := range n {
+			kkt[j] = make([]float64, n)
+		}
+		for j := range 3 {
+			copy(kkt[j], hessian[j])
+			rhs[j] = gradient[j]
+		}
+		for row, i := range active {
+			for j := range 3 {
+				kkt[3+row][j], kkt[j][3+row] = g[i][j], g[i][j]
+			}
+			rhs[3+row] = h[i]
+		}
+		solution, ok := solveLinear(kkt, rhs)
+		if !ok {
+			continue
+		}
+		x := solution[:3]
+		feasible := true
+		for i := range g {
+			if g[i][0]*x[0]+g[i][1]*x[1]+g[i][2]*x[2] > h[i]+1e-12*math.Max(1, h[i]) {
+				feasible = false
+			}
+		}
+		if value := objective(x); feasible && value < bestObjective {
+			best, bestObjective = x, value
+		}
+	}
+	return best[0], best[1], math.Max(best[2], 0)
+}
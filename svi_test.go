@@ -0,0 +1,124 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// sviQuotes returns total variances of SVI parameters at log moneyness from −0.5 to 0.5, with Gaussian noise of a
+// standard deviation
+func sviQuotes(p SVIParams, noise float64, seed uint64) ([]float64, []float64) {
+	rng := rand.New(rand.NewPCG(seed, seed+1))
+	k, w := make([]float64, 21), make([]float64, 21)
+	for i := range k {
+		x := -0.5 + 0.05*float64(i)
+		k[i], w[i] = x, SVITotalVariance(x, p)+noise*rng.NormFloat64()
+	}
+	return k, w
+}
+
+func TestSVITotalVariance(t *testing.T) {
+	p := SVIParams{A: 0.02, B: 0.1, Rho: -0.5, M: 0.1, Sigma: 0.2}
+	if got, want := SVITotalVariance(0.1, p), 0.02+0.1*0.2; math.Abs(got-want) > 1e-15 {
+		t.Errorf("Unexpected total variance at the vertex: got %v, want %v", got, want)
+	}
+	// the wings are asymptotically linear with slopes b·(1 ± ρ)
+	for _, wing := range [][2]float64{{100, 0.1 * 0.5}, {-100, 0.1 * 1.5}} {
+		slope := (SVITotalVariance(wing[0]+1, p) - SVITotalVariance(wing[0], p)) * math.Copysign(1, wing[0])
+		if math.Abs(slope-wing[1]) > 1e-5 {
+			t.Errorf("Unexpected wing slope at %v: got %v, want %v", wing[0], slope, wing[1])
+		}
+	}
+}
+
+func TestCalibrateSVI(t *testing.T) {
+	for _, p := range []SVIParams{
+		{A: 0.01, B: 0.1, Rho: -0.4, M: 0.05, Sigma: 0.15},
+		{A: 0.04, B: 0.3, Rho: -0.7, M: -0.1, Sigma: 0.3},
+		{A: 0.002, B: 0.05, Rho: 0.3, M: 0.0, Sigma: 0.05},
+	} {
+		k, w := sviQuotes(p, 0, 1)
+		got, err := CalibrateSVI(k, w, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if math.Abs(got.A-p.A) > 1e-5 || math.Abs(got.B-p.B) > 1e-5 || math.Abs(got.Rho-p.Rho) > 1e-4 || math.Abs(got.M-p.M) > 1e-4 || math.Abs(got.Sigma-p.Sigma) > 1e-4 {
+			t.Errorf("Unexpected parameters from exact quotes: got %+v, want %+v", got, p)
+		}
+
+		// noise of 2e-4 in total variance, moves the smile by less than twice as much
+		k, w = sviQuotes(p, 2e-4, 2)
+		if got, err = CalibrateSVI(k, w, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, x := range []float64{-0.4, -0.1, 0, 0.2, 0.4} {
+			if diff := SVITotalVariance(x, got) - SVITotalVariance(x, p); math.Abs(diff) > 4e-4 {
+				t.Errorf("Unexpected total variance at %v from noisy quotes of %+v: got %+v, off by %v", x, p, got, diff)
+			}
+		}
+	}
+}
+
+func TestCalibrateSVIOutliers(t *testing.T) {
+	p := SVIParams{A: 0.01, B: 0.1, Rho: -0.4, M: 0.05, Sigma: 0.15}
+	k, w := sviQuotes(p, 1e-4, 3)
+	w[4] += 0.02
+	w[15] -= 0.008
+	got, err := CalibrateSVI(k, w, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, x := range []float64{-0.45, -0.3, 0, 0.25, 0.45} {
+		if diff := SVITotalVariance(x, got) - SVITotalVariance(x, p); math.Abs(diff) > 2e-4 {
+			t.Errorf("Unexpected total variance at %v with outliers: got %+v, off by %v", x, got, diff)
+		}
+	}
+
+	// a zero weight removes a quote
+	weights := make([]float64, len(k))
+	for i := range weights {
+		weights[i] = 1
+	}
+	weights[4], weights[15] = 0, 0
+	if got, err = CalibrateSVI(k, w, weights); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := SVITotalVariance(-0.3, got) - SVITotalVariance(-0.3, p); math.Abs(diff) > 2e-4 {
+		t.Errorf("Unexpected total variance with outliers weighted out: got %+v, off by %v", got, diff)
+	}
+
+	// the bounds hold however the quotes pull: b ≥ 0, |ρ| < 1, σ > 0 and b·(1 + |ρ|) ≤ 4
+	k = []float64{-0.2, -0.1, 0, 0.1, 0.2, 0.3}
+	w = []float64{2.0, 1.0, 0.001, 1.0, 2.0, 3.0}
+	if got, err = CalibrateSVI(k, w, nil); err != nil && !errors.Is(err, ErrNotConverged) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !(got.B >= 0) || !(math.Abs(got.Rho) < 1) || !(got.Sigma > 0) || got.B*(1+math.Abs(got.Rho)) > 4+1e-9 {
+		t.Errorf("Unexpected parameters out of bounds: got %+v", got)
+	}
+}
+
+func TestCalibrateSVIErrors(t *testing.T) {
+	k := []float64{-0.2, -0.1, 0, 0.1, 0.2}
+	w := []float64{0.05, 0.045, 0.04, 0.042, 0.046}
+	tests := []struct {
+		name    string
+		k, w    []float64
+		weights []float64
+	}{
+		{"too few", k[:4], w[:4], nil},
+		{"mismatched", k, w[:4], nil},
+		{"mismatched weights", k, w, []float64{1, 1}},
+		{"negative variance", k, []float64{0.05, -0.045, 0.04, 0.042, 0.046}, nil},
+		{"nan moneyness", []float64{-0.2, math.NaN(), 0, 0.1, 0.2}, w, nil},
+		{"negative weight", k, w, []float64{1, 1, -1, 1, 1}},
+		{"too few weighted", k, w, []float64{1, 1, 0, 1, 1}},
+	}
+	for _, tt := range tests {
+		if _, err := CalibrateSVI(tt.k, tt.w, tt.weights); !errors.Is(err, ErrInvalidCalibration) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", tt.name, err, ErrInvalidCalibration)
+		}
+	}
+}
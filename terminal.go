@@ -0,0 +1,164 @@
+package finance
+
+import "math"
+
+// LognormalDistribution is the risk-neutral distribution of the underlying price at expiration under geometric
+// Brownian motion, ln S_T normal with mean ln F − s²/2 and standard deviation s = σ√T, so that its mean is the forward
+// F. With no time to expiration it is a point mass at the forward
+type LognormalDistribution struct {
+	forward float64 // Forward price, the mean
+	stdDev  float64 // Standard deviation σ√T of the log price
+}
+
+// TerminalDistribution constructs the risk-neutral distribution of an option's underlying price at its expiration,
+// whose parameters are those of d2 in the Black-Scholes price. Discrete dividends are escrowed, all of them being
+// paid by expiration. Returns the errors of Validate, ErrNonPositiveVolatility for a volatility that is not positive
+// and finite, ErrInvalidRate and ErrNegativeAdjustedSpot
+// option: the option, whose strike and type are otherwise unused
+// vol: the volatility
+func TerminalDistribution(option Option, vol float64) (LognormalDistribution, error) {
+	if err := validatePricing(option, vol); err != nil {
+		return LognormalDistribution{forward: math.NaN(), stdDev: math.NaN()}, err
+	}
+	option = escrowed(option)
+	timeYears := option.DaysToExpiration / 365.0
+	return LognormalDistribution{
+		forward: ForwardPrice(option.UnderlyingPrice, option.RiskFreeRate, option.DividendYield, timeYears),
+		stdDev:  vol * math.Sqrt(timeYears),
+	}, nil
+}
+
+// Mean returns the mean of the price, the forward
+func (d LognormalDistribution) Mean() float64 {
+	return d.forward
+}
+
+// StdDev returns the standard deviation σ√T of the log price
+func (d LognormalDistribution) StdDev() float64 {
+	return d.stdDev
+}
+
+// CDF returns the probability that the price finishes at or below a level, N(−d2) at the level as a strike
+// price: the price level
+func (d LognormalDistribution) CDF(price float64) float64 {
+	switch {
+	case math.IsNaN(price):
+		return math.NaN()
+	case !(price > 0):
+		return 0
+	case d.stdDev == 0:
+		if price >= d.forward {
+			return 1
+		}
+		return 0
+	}
+	return Phi(d.moneyness(price))
+}
+
+// PDF returns the probability density of the price at a level, n(d2)/(K·σ√T) at the level as a strike, infinite at
+// the forward for a point mass
+// price: the price level
+func (d LognormalDistribution) PDF(price float64) float64 {
+	switch {
+	case math.IsNaN(price):
+		return math.NaN()
+	case !(price > 0):
+		return 0
+	case d.stdDev == 0:
+		if price == d.forward {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return NormalDistributionDerivative(d.moneyness(price)) / (price * d.stdDev)
+}
+
+// Quantile returns the price level at or below which the price finishes with a probability, F·e^{−s²/2 + s·N⁻¹(p)},
+// 0 at 0 and infinite at 1 before expiration. NaN for a probability outside [0, 1]
+// p: the probability
+func (d LognormalDistribution) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	if d.stdDev == 0 {
+		return d.forward
+	}
+	return d.forward * math.Exp(d.stdDev*(PhiInv(p)-0.5*d.stdDev))
+}
+
+// ProbabilityBetween returns the probability that the price finishes between two levels, 0 if the upper level is
+// not above the lower. Above the median it is the difference of the probabilities of finishing above the levels,
+// which keeps its precision in the upper tail
+// lo: the lower price level
+// hi: the upper price level
+func (d LognormalDistribution) ProbabilityBetween(lo, hi float64) float64 {
+	switch {
+	case math.IsNaN(lo) || math.IsNaN(hi):
+		return math.NaN()
+	case !(hi > lo):
+		return 0
+	case d.stdDev > 0 && lo > 0 && d.moneyness(lo) > 0:
+		return math.Max(0, PhiC(d.moneyness(lo))-PhiC(d.moneyness(hi)))
+	}
+	return math.Max(0, d.CDF(hi)-d.CDF(lo))
+}
+
+// ExpectedPriceGivenAbove returns the expected price given that it finishes above a level, the partial expectation
+// F·N(d1) over the probability N(d2) at the level as a strike, the expected price at which a call is exercised. The
+// ratio is taken of the logs of the probabilities, which stays finite far in the tail. The mean for a level that is
+// not positive, NaN for a level the price cannot finish above
+// strike: the price level
+func (d LognormalDistribution) ExpectedPriceGivenAbove(strike float64) float64 {
+	switch {
+	case math.IsNaN(strike) || math.IsInf(strike, 1):
+		return math.NaN()
+	case !(strike > 0):
+		return d.forward
+	case d.stdDev == 0:
+		if d.forward > strike {
+			return d.forward
+		}
+		return math.NaN()
+	}
+	d2 := -d.moneyness(strike)
+	return d.forward * math.Exp(logNormalCDF(d2+d.stdDev)-logNormalCDF(d2))
+}
+
+// ExpectedPriceGivenBelow returns the expected price given that it finishes at or below a level, the partial
+// expectation F·N(−d1) over the probability N(−d2) at the level as a strike, the expected price at which a short put
+// is assigned. The mean for an infinite level, NaN for a level the price cannot finish at or below
+// strike: the price level
+func (d LognormalDistribution) ExpectedPriceGivenBelow(strike float64) float64 {
+	switch {
+	case math.IsNaN(strike) || !(strike > 0):
+		return math.NaN()
+	case math.IsInf(strike, 1):
+		return d.forward
+	case d.stdDev == 0:
+		if d.forward <= strike {
+			return d.forward
+		}
+		return math.NaN()
+	}
+	z := d.moneyness(strike)
+	return d.forward * math.Exp(logNormalCDF(z-d.stdDev)-logNormalCDF(z))
+}
+
+// ExpectedPayoff computes the risk-neutral expectation of an option's payoff at expiration, its Black-Scholes price
+// compounded at the risk-free rate, the intrinsic value at expiration. NaN for an option that cannot be priced at the
+// volatility
+// option: the option
+// vol: the volatility
+func ExpectedPayoff(option Option, vol float64) float64 {
+	if err := validatePricing(option, vol); err != nil {
+		return math.NaN()
+	}
+	return BlackScholesOptionPrice(option, vol) * math.Exp(option.RiskFreeRate*option.DaysToExpiration/365.0)
+}
+
+// moneyness computes (ln(K/F) + s²/2)/s, minus d2 of a strike K at the price level, so that the probability of
+// finishing below the level is N of it
+// price: the price level
+func (d LognormalDistribution) moneyness(price float64) float64 {
+	return (math.Log(price/d.forward) + 0.5*d.stdDev*d.stdDev) / d.stdDev
+}
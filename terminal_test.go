@@ -0,0 +1,182 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTerminalDistribution(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 180.0,
+		RiskFreeRate:     0.04,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.01,
+	}
+	dist, err := TerminalDistribution(option, 0.25)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	timeYears := 180.0 / 365
+	if got, want := dist.Mean(), ForwardPrice(100.0, 0.04, 0.01, timeYears); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected mean: got %v, want %v", got, want)
+	}
+	if got, want := dist.StdDev(), 0.25*math.Sqrt(timeYears); math.Abs(got-want) > 1e-15 {
+		t.Errorf("Unexpected standard deviation: got %v, want %v", got, want)
+	}
+
+	// the probability of finishing below a strike is that of the put finishing in the money
+	for _, strike := range []float64{70.0, 100.0, 130.0} {
+		put := option
+		put.Strike, put.OptionType = strike, Put
+		if got, want := dist.CDF(strike), ProbabilityITM(put, 0.25); math.Abs(got-want) > 1e-15 {
+			t.Errorf("Unexpected CDF at %v: got %v, want %v", strike, got, want)
+		}
+		if got := dist.CDF(dist.Quantile(dist.CDF(strike))); math.Abs(got-dist.CDF(strike)) > 1e-14 {
+			t.Errorf("Unexpected round trip of the quantile at %v: got %v, want %v", strike, got, dist.CDF(strike))
+		}
+	}
+	if got, want := dist.Quantile(0.5), dist.Mean()*math.Exp(-0.5*dist.StdDev()*dist.StdDev()); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected median: got %v, want %v", got, want)
+	}
+
+	// the density integrates to the probabilities, with the forward as its mean
+	mass, _ := integrate(dist.PDF, 80.0, 120.0, 1e-12)
+	if want := dist.ProbabilityBetween(80.0, 120.0); math.Abs(mass-want) > 1e-10 {
+		t.Errorf("Unexpected mass between 80 and 120: got %v, want %v", mass, want)
+	}
+	mean, _ := integrateToInfinity(func(price float64) float64 { return price * dist.PDF(price) }, 0, 1e-10)
+	if math.Abs(mean-dist.Mean()) > 1e-7 {
+		t.Errorf("Unexpected mean of the density: got %v, want %v", mean, dist.Mean())
+	}
+	if got, want := dist.ProbabilityBetween(80.0, 120.0), dist.CDF(120.0)-dist.CDF(80.0); math.Abs(got-want) > 1e-15 {
+		t.Errorf("Unexpected probability between 80 and 120: got %v, want %v", got, want)
+	}
+	// far in the upper tail the probability keeps its relative precision
+	if got, want := dist.ProbabilityBetween(500.0, 600.0), PhiC(dist.moneyness(500))-PhiC(dist.moneyness(600)); got == 0 || math.Abs(got/want-1) > 1e-12 {
+		t.Errorf("Unexpected probability between 500 and 600: got %v, want %v", got, want)
+	}
+
+	for name, test := range map[string]struct{ got, want float64 }{
+		"CDF at zero":          {dist.CDF(0), 0},
+		"CDF at infinity":      {dist.CDF(math.Inf(1)), 1},
+		"PDF below zero":       {dist.PDF(-1), 0},
+		"quantile at zero":     {dist.Quantile(0), 0},
+		"quantile at one":      {dist.Quantile(1), math.Inf(1)},
+		"reversed levels":      {dist.ProbabilityBetween(120.0, 80.0), 0},
+		"everything":           {dist.ProbabilityBetween(0, math.Inf(1)), 1},
+		"quantile at expiry":   {mustTerminal(t, Option{Strike: 1, UnderlyingPrice: 100.0, OptionType: Put}).Quantile(0.3), 100.0},
+		"CDF below at expiry":  {mustTerminal(t, Option{Strike: 1, UnderlyingPrice: 100.0, OptionType: Put}).CDF(99.0), 0},
+		"CDF at expiry":        {mustTerminal(t, Option{Strike: 1, UnderlyingPrice: 100.0, OptionType: Put}).CDF(100.0), 1},
+		"PDF away from expiry": {mustTerminal(t, Option{Strike: 1, UnderlyingPrice: 100.0, OptionType: Put}).PDF(99.0), 0},
+	} {
+		if test.got != test.want {
+			t.Errorf("Unexpected %s: got %v, want %v", name, test.got, test.want)
+		}
+	}
+	for name, got := range map[string]float64{
+		"quantile above one": dist.Quantile(1.5),
+		"NaN CDF":            dist.CDF(math.NaN()),
+		"NaN level":          dist.ProbabilityBetween(math.NaN(), 100.0),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected %s: got %v, want NaN", name, got)
+		}
+	}
+
+	if _, err := TerminalDistribution(option, 0); !errors.Is(err, ErrNonPositiveVolatility) {
+		t.Errorf("Unexpected error for zero vol: got %v, want %v", err, ErrNonPositiveVolatility)
+	}
+	expired := option
+	expired.DaysToExpiration = -1
+	if _, err := TerminalDistribution(expired, 0.25); !errors.Is(err, ErrNegativeExpiry) {
+		t.Errorf("Unexpected error for negative expiry: got %v, want %v", err, ErrNegativeExpiry)
+	}
+}
+
+func TestExpectedPayoff(t *testing.T) {
+	call := Option{
+		Strike:           105.0,
+		DaysToExpiration: 90.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+		DividendYield:    0.02,
+	}
+	put := call
+	put.OptionType = Put
+	dist, err := TerminalDistribution(call, 0.3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compounding := math.Exp(0.05 * 90.0 / 365)
+	if got, want := ExpectedPayoff(call, 0.3), BlackScholesOptionPrice(call, 0.3)*compounding; math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected expected payoff: got %v, want %v", got, want)
+	}
+
+	// the exercise probability times the expected price given exercise, less the strike, is the undiscounted call
+	probability := ProbabilityITM(call, 0.3)
+	if got, want := probability*dist.ExpectedPriceGivenAbove(105.0)-105.0*probability, ExpectedPayoff(call, 0.3); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected undiscounted call: got %v, want %v", got, want)
+	}
+	// and likewise for the put, the expected assignment price of a short put being below the strike
+	probability = ProbabilityITM(put, 0.3)
+	assigned := dist.ExpectedPriceGivenBelow(105.0)
+	if got, want := 105.0*probability-probability*assigned, ExpectedPayoff(put, 0.3); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected undiscounted put: got %v, want %v", got, want)
+	}
+	if !(assigned < 105.0) {
+		t.Errorf("Unexpected expected assignment price: got %v, want below 105", assigned)
+	}
+	// the two conditional expectations average to the mean
+	below := dist.CDF(105.0)
+	if got := below*assigned + (1-below)*dist.ExpectedPriceGivenAbove(105.0); math.Abs(got-dist.Mean()) > 1e-12 {
+		t.Errorf("Unexpected mean of the conditional expectations: got %v, want %v", got, dist.Mean())
+	}
+	// far in the tails, where both probabilities underflow, the expectations stay just beyond the level
+	if got := dist.ExpectedPriceGivenAbove(1e4); !(got > 1e4 && got < 1.01e4) {
+		t.Errorf("Unexpected expected price given above 10000: got %v", got)
+	}
+	if got := dist.ExpectedPriceGivenBelow(1e-3); !(got < 1e-3 && got > 0.99e-3) {
+		t.Errorf("Unexpected expected price given below 0.001: got %v", got)
+	}
+
+	expired := mustTerminal(t, Option{Strike: 1, UnderlyingPrice: 100.0, OptionType: Call})
+	for name, test := range map[string]struct{ got, want float64 }{
+		"above zero":           {dist.ExpectedPriceGivenAbove(0), dist.Mean()},
+		"below infinity":       {dist.ExpectedPriceGivenBelow(math.Inf(1)), dist.Mean()},
+		"above at expiry":      {expired.ExpectedPriceGivenAbove(90.0), 100.0},
+		"below at expiry":      {expired.ExpectedPriceGivenBelow(100.0), 100.0},
+		"payoff at expiry":     {ExpectedPayoff(Option{Strike: 90.0, UnderlyingPrice: 100.0, OptionType: Call}, 0.3), 10.0},
+		"put payoff at expiry": {ExpectedPayoff(Option{Strike: 90.0, UnderlyingPrice: 100.0, OptionType: Put}, 0.3), 0},
+	} {
+		if test.got != test.want {
+			t.Errorf("Unexpected %s: got %v, want %v", name, test.got, test.want)
+		}
+	}
+	for name, got := range map[string]float64{
+		"above infinity":  dist.ExpectedPriceGivenAbove(math.Inf(1)),
+		"below zero":      dist.ExpectedPriceGivenBelow(0),
+		"above at expiry": expired.ExpectedPriceGivenAbove(100.0),
+		"below at expiry": expired.ExpectedPriceGivenBelow(99.0),
+		"zero vol":        ExpectedPayoff(call, 0),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected %s: got %v, want NaN", name, got)
+		}
+	}
+}
+
+// mustTerminal constructs the terminal distribution of an option at a volatility of 20%, failing the test on error
+// t: the test
+// option: the option
+func mustTerminal(t *testing.T, option Option) LognormalDistribution {
+	t.Helper()
+	dist, err := TerminalDistribution(option, 0.2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return dist
+}
@@ -0,0 +1,109 @@
+package finance
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+var (
+	// ErrInvalidTermStructure is returned for a term structure without pillars, with expirations or volatilities
+	// that are not positive and finite or that differ in number, or with two pillars at the same expiration
+	ErrInvalidTermStructure = errors.New("finance: invalid volatility term structure")
+	// ErrNegativeEventVariance is returned when a term structure implies a negative event variance, its variance
+	// after the event falling short of the baseline's
+	ErrNegativeEventVariance = errors.New("finance: negative event variance")
+)
+
+// TermStructure is a term structure of at-the-money implied volatilities by days to expiration. Between pillars the
+// total implied variance σ²T is linear in time, and before the first pillar and after the last the volatility is
+// that of the nearest pillar
+type TermStructure struct {
+	days, vols []float64 // Pillars, by ascending expiration
+}
+
+// NewTermStructure constructs a term structure from at-the-money implied volatilities at expirations, which need
+// not be sorted. Returns ErrInvalidTermStructure for invalid pillars
+// days: the days to expiration of each pillar
+// vols: the implied volatility of each pillar
+func NewTermStructure(days, vols []float64) (TermStructure, error) {
+	if len(days) == 0 || len(days) != len(vols) {
+		return TermStructure{}, fmt.Errorf("%w: %d expirations and %d volatilities", ErrInvalidTermStructure, len(days), len(vols))
+	}
+	order := make([]int, len(days))
+	for i := range order {
+		if !(days[i] > 0) || math.IsInf(days[i], 1) || !(vols[i] > 0) || math.IsInf(vols[i], 1) {
+			return TermStructure{}, fmt.Errorf("%w: volatility %v at %v days", ErrInvalidTermStructure, vols[i], days[i])
+		}
+		order[i] = i
+	}
+	slices.SortFunc(order, func(i, j int) int { return cmp.Compare(days[i], days[j]) })
+	var ts TermStructure
+	for n, i := range order {
+		if n > 0 && days[i] == ts.days[n-1] {
+			return TermStructure{}, fmt.Errorf("%w: two pillars at %v days", ErrInvalidTermStructure, days[i])
+		}
+		ts.days = append(ts.days, days[i])
+		ts.vols = append(ts.vols, vols[i])
+	}
+	return ts, nil
+}
+
+// VolAt returns the implied volatility at a number of days to expiration, NaN for a negative or undefined number
+// days: the days to expiration
+func (ts TermStructure) VolAt(days float64) float64 {
+	last := len(ts.days) - 1
+	switch {
+	case !(days >= 0) || math.IsInf(days, 1):
+		return math.NaN()
+	case days <= ts.days[0]:
+		return ts.vols[0]
+	case days >= ts.days[last]:
+		return ts.vols[last]
+	}
+	i, _ := slices.BinarySearch(ts.days, days)
+	w1 := ts.vols[i-1] * ts.vols[i-1] * ts.days[i-1]
+	w2 := ts.vols[i] * ts.vols[i] * ts.days[i]
+	weight := (days - ts.days[i-1]) / (ts.days[i] - ts.days[i-1])
+	return math.Sqrt(((1-weight)*w1 + weight*w2) / days)
+}
+
+// Days returns the days to expiration of the pillars, ascending
+func (ts TermStructure) Days() []float64 {
+	return slices.Clone(ts.days)
+}
+
+// EventVol decomposes a term structure into a flat baseline volatility and a one-day event, such as earnings, whose
+// annualized volatility σe replaces the baseline on the event day. Each pillar after the event then has total
+// variance σ²T = σb²·(T − 1/365) + σe²/365, and σe² is the average over those pillars of 365·σ²T − σb²·(365T − 1),
+// exact for a term structure of that form. The expected move on the event day is σe/√365
+// Returns ErrInvalidTermStructure if no pillar expires after the event, ErrNonPositiveVolatility for a baseline
+// volatility that is negative or not finite, and ErrNegativeEventVariance if the average is negative
+// termStructure: the term structure
+// eventDays: the days from today to the event
+// baselineVol: the volatility σb on days without events
+func EventVol(termStructure TermStructure, eventDays, baselineVol float64) (float64, error) {
+	if !(baselineVol >= 0) || math.IsInf(baselineVol, 1) {
+		return math.NaN(), fmt.Errorf("%w: baseline %v", ErrNonPositiveVolatility, baselineVol)
+	}
+	var sum float64
+	var count int
+	for i, days := range termStructure.days {
+		if !(days > eventDays) {
+			continue
+		}
+		vol := termStructure.vols[i]
+		sum += vol*vol*days - baselineVol*baselineVol*(days-1)
+		count++
+	}
+	if count == 0 {
+		return math.NaN(), fmt.Errorf("%w: no pillar after the event at %v days", ErrInvalidTermStructure, eventDays)
+	}
+	variance := sum / float64(count)
+	if variance < 0 {
+		return math.NaN(), fmt.Errorf("%w: %v", ErrNegativeEventVariance, variance/365.0)
+	}
+	return math.Sqrt(variance), nil
+}
@@ -0,0 +1,86 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTermStructure(t *testing.T) {
+	ts, err := NewTermStructure([]float64{90.0, 30.0, 180.0}, []float64{0.22, 0.25, 0.2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := ts.Days(); got[0] != 30.0 || got[2] != 180.0 {
+		t.Errorf("Unexpected days: got %v, want ascending", got)
+	}
+	tests := []struct {
+		name string
+		days float64
+		want float64
+	}{
+		{"on a pillar", 90.0, 0.22},
+		{"between pillars", 60.0, math.Sqrt((0.5*0.25*0.25*30.0 + 0.5*0.22*0.22*90.0) / 60.0)},
+		{"before the first", 10.0, 0.25},
+		{"today", 0, 0.25},
+		{"after the last", 365.0, 0.2},
+	}
+	for _, tt := range tests {
+		if got := ts.VolAt(tt.days); math.Abs(got-tt.want) > 1e-15 {
+			t.Errorf("Unexpected volatility %s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := ts.VolAt(-1); !math.IsNaN(got) {
+		t.Errorf("Unexpected volatility at negative days: got %v, want NaN", got)
+	}
+
+	errorTests := map[string][2][]float64{
+		"empty":         {nil, nil},
+		"mismatched":    {{30.0, 60.0}, {0.2}},
+		"zero days":     {{0, 60.0}, {0.2, 0.2}},
+		"negative vol":  {{30.0, 60.0}, {0.2, -0.2}},
+		"repeated days": {{30.0, 30.0}, {0.2, 0.21}},
+	}
+	for name, tt := range errorTests {
+		if _, err := NewTermStructure(tt[0], tt[1]); !errors.Is(err, ErrInvalidTermStructure) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", name, err, ErrInvalidTermStructure)
+		}
+	}
+}
+
+func TestEventVol(t *testing.T) {
+	// a 30% baseline with an earnings day 20 days out at 150% annualized, a move of about 7.9%
+	baseline, event := 0.3, 1.5
+	days := []float64{7.0, 14.0, 30.0, 60.0, 90.0}
+	vols := make([]float64, len(days))
+	for i, d := range days {
+		vols[i] = baseline
+		if d > 20.0 {
+			vols[i] = math.Sqrt((baseline*baseline*(d-1) + event*event) / d)
+		}
+	}
+	ts, err := NewTermStructure(days, vols)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := EventVol(ts, 20.0, baseline)
+	if err != nil || math.Abs(got-event) > 1e-12 {
+		t.Errorf("Unexpected event volatility: got %v (%v), want %v", got, err, event)
+	}
+
+	tests := []struct {
+		name      string
+		eventDays float64
+		baseline  float64
+		want      error
+	}{
+		{"after the last pillar", 120.0, baseline, ErrInvalidTermStructure},
+		{"baseline above the term structure", 20.0, 0.5, ErrNegativeEventVariance},
+		{"negative baseline", 20.0, -0.3, ErrNonPositiveVolatility},
+	}
+	for _, tt := range tests {
+		if _, err := EventVol(ts, tt.eventDays, tt.baseline); !errors.Is(err, tt.want) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package finance
+
+import (
+	"math"
+)
+
+// One-touch and no-touch options are binary options on a barrier monitored continuously, paying 1 unit of cash if
+// the underlying touches the barrier before expiration or if it never does. The barrier lies above the underlying
+// price for an up barrier and below it for a down one; the option's strike and type are ignored. Prices follow from
+// the reflected first-passage distribution of the log underlying price, evaluated in logarithms so that they stay
+// finite as the volatility falls to zero. Discrete dividends are escrowed, the barrier then applying to the escrowed
+// underlying price. NaN for negative days to expiration, or a volatility or barrier that is not positive and finite
+
+// OneTouchPrice computes the price of a one-touch option paying 1 when the underlying first touches the barrier, or
+// at expiration if it has touched it by then. With the underlying at the barrier, it has touched it already, and the
+// price is 1 paid now or discounted from expiration
+// option: the option
+// vol: the volatility
+// barrier: the barrier
+// payAtHit: whether the payout is made when the barrier is touched rather than at expiration
+func OneTouchPrice(option Option, vol, barrier float64, payAtHit bool) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration < 0 || !(vol > 0) || math.IsInf(vol, 1) || !(barrier > 0) || math.IsInf(barrier, 1) {
+		return math.NaN()
+	}
+	timeToExpiration := option.DaysToExpiration / 365.0
+	discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+	switch {
+	case option.UnderlyingPrice == barrier && payAtHit:
+		return 1
+	case option.UnderlyingPrice == barrier:
+		return discount
+	case option.DaysToExpiration == 0:
+		return 0
+	case !payAtHit:
+		return discount * (1 - noTouchProbability(option, vol, barrier))
+	}
+
+	// E[e^{−rτ}; τ ≤ T] for the first passage time τ, with eta 1 for a down and -1 for an up barrier
+	eta := 1.0
+	if barrier > option.UnderlyingPrice {
+		eta = -1
+	}
+	stdDev := vol * math.Sqrt(timeToExpiration)
+	mu := (option.RiskFreeRate-option.DividendYield)/(vol*vol) - 0.5
+	lambda := math.Sqrt(mu*mu + 2*option.RiskFreeRate/(vol*vol))
+	logRatio := math.Log(barrier / option.UnderlyingPrice)
+	z := logRatio/stdDev + lambda*stdDev
+	return math.Exp((mu+lambda)*logRatio+logNormalCDF(eta*z)) + math.Exp((mu-lambda)*logRatio+logNormalCDF(eta*(z-2*lambda*stdDev)))
+}
+
+// NoTouchPrice computes the price of a no-touch option paying 1 at expiration if the underlying never touches the
+// barrier. With the underlying at the barrier, it has touched it already, and the price is 0. A one-touch option paid
+// at expiration and a no-touch option on the same barrier add up to 1 paid at expiration
+// option: the option
+// vol: the volatility
+// barrier: the barrier
+func NoTouchPrice(option Option, vol, barrier float64) float64 {
+	option = escrowed(option)
+	if option.DaysToExpiration < 0 || !(vol > 0) || math.IsInf(vol, 1) || !(barrier > 0) || math.IsInf(barrier, 1) {
+		return math.NaN()
+	}
+	switch {
+	case option.UnderlyingPrice == barrier:
+		return 0
+	case option.DaysToExpiration == 0:
+		return 1
+	}
+	return math.Exp(-option.RiskFreeRate*option.DaysToExpiration/365.0) * noTouchProbability(option, vol, barrier)
+}
+
+// noTouchProbability computes the risk-neutral probability that the underlying never touches a barrier before
+// expiration, N(η·x) − (H/S)^{2μ}·N(η·y) with x = ln(S/H)/σ√T + μσ√T, y = ln(H/S)/σ√T + μσ√T,
+// μ = (r − q)/σ² − 1/2 and η 1 for a down and -1 for an up barrier
+// option: the option, with any discrete dividends already escrowed and positive days to expiration
+// vol: the volatility
+// barrier: the barrier, not at the underlying price
+func noTouchProbability(option Option, vol, barrier float64) float64 {
+	timeToExpiration := option.DaysToExpiration / 365.0
+	eta := 1.0
+	if barrier > option.UnderlyingPrice {
+		eta = -1
+	}
+	stdDev := vol * math.Sqrt(timeToExpiration)
+	mu := (option.RiskFreeRate-option.DividendYield)/(vol*vol) - 0.5
+	logRatio := math.Log(barrier / option.UnderlyingPrice)
+	x := -logRatio/stdDev + mu*stdDev
+	y := logRatio/stdDev + mu*stdDev
+	return math.Max(0, Phi(eta*x)-math.Exp(2*mu*logRatio+logNormalCDF(eta*y)))
+}
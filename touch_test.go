@@ -0,0 +1,115 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTouchPrices(t *testing.T) {
+	const tolerance = 1e-12
+
+	for _, barrier := range []float64{80.0, 95.0, 105.0, 130.0} {
+		option := Option{
+			Strike:           100.0,
+			DaysToExpiration: 180.0,
+			RiskFreeRate:     0.05,
+			UnderlyingPrice:  100.0,
+			OptionType:       Call,
+			DividendYield:    0.02,
+		}
+		timeToExpiration := option.DaysToExpiration / 365.0
+		discount := math.Exp(-option.RiskFreeRate * timeToExpiration)
+		atHit, atExpiry, noTouch := OneTouchPrice(option, 0.25, barrier, true), OneTouchPrice(option, 0.25, barrier, false), NoTouchPrice(option, 0.25, barrier)
+
+		if math.Abs(atExpiry+noTouch-discount) > tolerance {
+			t.Errorf("Unexpected one-touch plus no-touch for barrier %v: got %v + %v, want %v", barrier, atExpiry, noTouch, discount)
+		}
+		if !(atHit > atExpiry) || !(atExpiry > 0) || !(noTouch > 0) {
+			t.Errorf("Unexpected ordering for barrier %v: got %v at hit, %v at expiry and %v no-touch", barrier, atHit, atExpiry, noTouch)
+		}
+
+		// the touch probability of the log underlying price, a Brownian motion with drift ν, from its running extreme
+		nu := option.RiskFreeRate - option.DividendYield - 0.5*0.25*0.25
+		stdDev := 0.25 * math.Sqrt(timeToExpiration)
+		h := math.Abs(math.Log(barrier / option.UnderlyingPrice))
+		if barrier < option.UnderlyingPrice {
+			nu = -nu
+		}
+		touch := Phi((-h+nu*timeToExpiration)/stdDev) + math.Exp(2*nu*h/(0.25*0.25))*Phi((-h-nu*timeToExpiration)/stdDev)
+		if math.Abs(atExpiry-discount*touch) > tolerance {
+			t.Errorf("Unexpected one-touch price at expiry for barrier %v: got %v, want %v", barrier, atExpiry, discount*touch)
+		}
+
+		// the rebates of barrier options are one-touch and no-touch payouts
+		out, in := DownAndOut, DownAndIn
+		if barrier > option.UnderlyingPrice {
+			out, in = UpAndOut, UpAndIn
+		}
+		withRebate, _ := BarrierOptionPrice(option, 0.25, barrier, 1, out)
+		without, _ := BarrierOptionPrice(option, 0.25, barrier, 0, out)
+		if math.Abs(atHit-(withRebate-without)) > tolerance {
+			t.Errorf("Unexpected one-touch price at hit for barrier %v: got %v, want %v", barrier, atHit, withRebate-without)
+		}
+		withRebate, _ = BarrierOptionPrice(option, 0.25, barrier, 1, in)
+		without, _ = BarrierOptionPrice(option, 0.25, barrier, 0, in)
+		if math.Abs(noTouch-(withRebate-without)) > tolerance {
+			t.Errorf("Unexpected no-touch price for barrier %v: got %v, want %v", barrier, noTouch, withRebate-without)
+		}
+	}
+}
+
+func TestTouchPricesEdgeCases(t *testing.T) {
+	option := Option{
+		Strike:           100.0,
+		DaysToExpiration: 365.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	discount := math.Exp(-0.05)
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"one-touch at hit on the barrier", OneTouchPrice(option, 0.2, 100.0, true), 1},
+		{"one-touch at expiry on the barrier", OneTouchPrice(option, 0.2, 100.0, false), discount},
+		{"no-touch on the barrier", NoTouchPrice(option, 0.2, 100.0), 0},
+		// with almost no volatility the underlying grows at 5% a year, reaching 102 after ln(1.02)/0.05 years and
+		// never falling to 98
+		{"one-touch at hit on the forward path", OneTouchPrice(option, 1e-4, 102.0, true), math.Exp(-math.Log(1.02))},
+		{"one-touch at expiry on the forward path", OneTouchPrice(option, 1e-4, 102.0, false), discount},
+		{"one-touch off the forward path", OneTouchPrice(option, 1e-4, 98.0, true), 0},
+		{"no-touch off the forward path", NoTouchPrice(option, 1e-4, 98.0), discount},
+		{"one-touch beyond the forward", OneTouchPrice(option, 1e-4, 110.0, true), 0},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-12 {
+			t.Errorf("Unexpected %s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	option.DaysToExpiration = 0
+	if got := OneTouchPrice(option, 0.2, 110.0, true); got != 0 {
+		t.Errorf("Unexpected one-touch price at expiration: got %v, want 0", got)
+	}
+	if got := NoTouchPrice(option, 0.2, 110.0); got != 1 {
+		t.Errorf("Unexpected no-touch price at expiration: got %v, want 1", got)
+	}
+	option.DaysToExpiration = -1
+	if got := OneTouchPrice(option, 0.2, 110.0, true); !math.IsNaN(got) {
+		t.Errorf("Unexpected one-touch price for negative days to expiration: got %v, want NaN", got)
+	}
+	option.DaysToExpiration = 30
+	if got := NoTouchPrice(option, 0.2, 0); !math.IsNaN(got) {
+		t.Errorf("Unexpected no-touch price for a zero barrier: got %v, want NaN", got)
+	}
+	for _, vol := range []float64{0, -0.2, math.NaN(), math.Inf(1)} {
+		if got := OneTouchPrice(option, vol, 110.0, true); !math.IsNaN(got) {
+			t.Errorf("Unexpected one-touch price for volatility %v: got %v, want NaN", vol, got)
+		}
+		if got := NoTouchPrice(option, vol, 110.0); !math.IsNaN(got) {
+			t.Errorf("Unexpected no-touch price for volatility %v: got %v, want NaN", vol, got)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package finance
+
+import (
+	"math"
+)
+
+// TwoAssetOption is a European option on two underlying assets with lognormal prices, for the exchange, spread and
+// rainbow pricers
+type TwoAssetOption struct {
+	Strike           float64    `json:"strike"`                     // Option strike price, unused by exchange options
+	DaysToExpiration float64    `json:"days_to_expiration"`         // Days to expiration
+	RiskFreeRate     float64    `json:"risk_free_rate"`             // Risk-free interest rate
+	UnderlyingPrice1 float64    `json:"underlying_price_1"`         // Current price of the first asset
+	UnderlyingPrice2 float64    `json:"underlying_price_2"`         // Current price of the second asset
+	Vol1             float64    `json:"vol_1"`                      // Volatility of the first asset
+	Vol2             float64    `json:"vol_2"`                      // Volatility of the second asset
+	Correlation      float64    `json:"correlation"`                // Correlation of the returns of the two assets, in [-1, 1]
+	DividendYield1   float64    `json:"dividend_yield_1,omitempty"` // Continuous dividend yield of the first asset
+	DividendYield2   float64    `json:"dividend_yield_2,omitempty"` // Continuous dividend yield of the second asset
+	OptionType       OptionType `json:"option_type"`                // Option type, can be either Call or Put
+}
+
+// MargrabePrice computes the Margrabe (1978) price of the option to exchange the second asset for the first at
+// expiration, with payoff (S1 − S2)⁺. With the second asset as numéraire it is a Black-Scholes call on the first
+// struck at the second, with the volatility √(σ1² + σ2² − 2ρσ1σ2) of their ratio, and it does not depend on the
+// risk-free rate. At expiration it is worth its payoff. NaN with negative time to expiration or a correlation outside
+// [-1, 1]
+// s1: the price of the asset received
+// s2: the price of the asset given up
+// vol1: the volatility of the asset received
+// vol2: the volatility of the asset given up
+// corr: the correlation of the returns of the two assets
+// q1: the continuous dividend yield of the asset received
+// q2: the continuous dividend yield of the asset given up
+// timeYears: the time to expiration in years
+func MargrabePrice(s1, s2, vol1, vol2, corr, q1, q2, timeYears float64) float64 {
+	if timeYears < 0 || !(corr >= -1 && corr <= 1) {
+		return math.NaN()
+	}
+	vol := math.Sqrt(math.Max(vol1*vol1+vol2*vol2-2*corr*vol1*vol2, 0))
+	return BlackScholesForwardPrice(s1*math.Exp(-q1*timeYears), s2*math.Exp(-q2*timeYears), timeYears, 0, vol, Call)
+}
+
+// ExchangeOptionPrice computes the Margrabe price of an exchange option on two assets: a call receives the first
+// asset for the second and a put the second for the first. The option's strike is ignored
+// option: the option
+func ExchangeOptionPrice(option TwoAssetOption) float64 {
+	timeToExpiration := option.DaysToExpiration / 365.0
+	if option.OptionType == Put {
+		return MargrabePrice(option.UnderlyingPrice2, option.UnderlyingPrice1, option.Vol2, option.Vol1, option.Correlation,
+			option.DividendYield2, option.DividendYield1, timeToExpiration)
+	}
+	return MargrabePrice(option.UnderlyingPrice1, option.UnderlyingPrice2, option.Vol1, option.Vol2, option.Correlation,
+		option.DividendYield1, option.DividendYield2, timeToExpiration)
+}
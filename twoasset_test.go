@@ -0,0 +1,82 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMargrabePrice(t *testing.T) {
+	const timeYears = 0.75
+	for _, s1 := range []float64{80.0, 100.0, 120.0} {
+		for _, corr := range []float64{-0.5, 0, 0.7} {
+			// with the second asset riskless, a call on the first struck at the second with its yield as the rate
+			call := Option{
+				Strike:           100.0,
+				DaysToExpiration: timeYears * 365,
+				RiskFreeRate:     0.04,
+				UnderlyingPrice:  s1,
+				OptionType:       Call,
+				DividendYield:    0.01,
+			}
+			if got, want := MargrabePrice(s1, 100.0, 0.3, 0, corr, 0.01, 0.04, timeYears), BlackScholesOptionPrice(call, 0.3); math.Abs(got-want) > 1e-12 {
+				t.Errorf("Unexpected riskless exchange price for spot %v correlation %v: got %v, want %v", s1, corr, got, want)
+			}
+
+			// exchanging one way less the other is a forward exchange of the two assets
+			option := TwoAssetOption{
+				DaysToExpiration: timeYears * 365,
+				RiskFreeRate:     0.04,
+				UnderlyingPrice1: s1,
+				UnderlyingPrice2: 100.0,
+				Vol1:             0.3,
+				Vol2:             0.2,
+				Correlation:      corr,
+				DividendYield1:   0.01,
+				DividendYield2:   0.03,
+				OptionType:       Put,
+			}
+			swapped := TwoAssetOption{
+				DaysToExpiration: option.DaysToExpiration,
+				RiskFreeRate:     option.RiskFreeRate,
+				UnderlyingPrice1: option.UnderlyingPrice2,
+				UnderlyingPrice2: option.UnderlyingPrice1,
+				Vol1:             option.Vol2,
+				Vol2:             option.Vol1,
+				Correlation:      corr,
+				DividendYield1:   option.DividendYield2,
+				DividendYield2:   option.DividendYield1,
+				OptionType:       Call,
+			}
+			put := ExchangeOptionPrice(option)
+			if got := ExchangeOptionPrice(swapped); got != put {
+				t.Errorf("Unexpected swapped exchange price for spot %v correlation %v: got %v, want %v", s1, corr, got, put)
+			}
+			option.OptionType = Call
+			forward := s1*math.Exp(-0.01*timeYears) - 100.0*math.Exp(-0.03*timeYears)
+			if got := ExchangeOptionPrice(option) - put; math.Abs(got-forward) > 1e-12 {
+				t.Errorf("Unexpected exchange parity for spot %v correlation %v: got %v, want %v", s1, corr, got, forward)
+			}
+		}
+	}
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"expiration", MargrabePrice(110.0, 100.0, 0.3, 0.2, 0.5, 0, 0, 0), 10.0},
+		{"identical assets", MargrabePrice(100.0, 100.0, 0.3, 0.3, 1, 0.02, 0.02, 1), 0},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-12 {
+			t.Errorf("Unexpected exchange price for %s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	for name, got := range map[string]float64{
+		"negative time":     MargrabePrice(100.0, 100.0, 0.3, 0.2, 0.5, 0, 0, -1),
+		"correlation above": MargrabePrice(100.0, 100.0, 0.3, 0.2, 1.5, 0, 0, 1),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected exchange price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
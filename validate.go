@@ -0,0 +1,37 @@
+package finance
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrNonPositiveStrike is returned when an option's strike is zero, negative or NaN
+	ErrNonPositiveStrike = errors.New("finance: strike must be positive")
+	// ErrNonPositiveUnderlying is returned when an option's underlying price is zero, negative or NaN
+	ErrNonPositiveUnderlying = errors.New("finance: underlying price must be positive")
+	// ErrNegativeExpiry is returned when an option's days to expiration are negative or NaN
+	ErrNegativeExpiry = errors.New("finance: days to expiration must not be negative")
+	// ErrInvalidOptionType is returned when an option's type is neither Call nor Put
+	ErrInvalidOptionType = errors.New("finance: invalid option type")
+)
+
+// Validate checks that the fields of an option can be priced by the lognormal models in this package.
+// It returns ErrInvalidOptionType, ErrNonPositiveStrike, ErrNonPositiveUnderlying, ErrNegativeExpiry or
+// ErrNegativePrice for the first invalid field found, checked in that order, and nil otherwise.
+// A NaN field is invalid; a zero price is valid, and the price is only meaningful to the implied volatility solvers
+func (option Option) Validate() error {
+	switch {
+	case option.OptionType != Call && option.OptionType != Put:
+		return ErrInvalidOptionType
+	case !(option.Strike > 0):
+		return ErrNonPositiveStrike
+	case !(option.UnderlyingPrice > 0):
+		return ErrNonPositiveUnderlying
+	case !(option.DaysToExpiration >= 0):
+		return ErrNegativeExpiry
+	case option.Price < 0 || math.IsNaN(option.Price):
+		return ErrNegativePrice
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	valid := Option{
+		Price:            5.0,
+		Strike:           100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Unexpected error for valid option: %v", err)
+	}
+
+	tests := []struct {
+		description string
+		modify      func(*Option)
+		expected    error
+	}{
+		{"invalid option type", func(o *Option) { o.OptionType = OptionType(2) }, ErrInvalidOptionType},
+		{"negative option type", func(o *Option) { o.OptionType = OptionType(-1) }, ErrInvalidOptionType},
+		{"zero strike", func(o *Option) { o.Strike = 0 }, ErrNonPositiveStrike},
+		{"negative strike", func(o *Option) { o.Strike = -100.0 }, ErrNonPositiveStrike},
+		{"NaN strike", func(o *Option) { o.Strike = math.NaN() }, ErrNonPositiveStrike},
+		{"zero underlying", func(o *Option) { o.UnderlyingPrice = 0 }, ErrNonPositiveUnderlying},
+		{"negative underlying", func(o *Option) { o.UnderlyingPrice = -1.0 }, ErrNonPositiveUnderlying},
+		{"negative expiry", func(o *Option) { o.DaysToExpiration = -1.0 }, ErrNegativeExpiry},
+		{"NaN expiry", func(o *Option) { o.DaysToExpiration = math.NaN() }, ErrNegativeExpiry},
+		{"negative price", func(o *Option) { o.Price = -0.01 }, ErrNegativePrice},
+		{"NaN price", func(o *Option) { o.Price = math.NaN() }, ErrNegativePrice},
+	}
+
+	for _, test := range tests {
+		option := valid
+		test.modify(&option)
+		if err := option.Validate(); !errors.Is(err, test.expected) {
+			t.Errorf("Unexpected error for %v: got %v, want %v", test.description, err, test.expected)
+		}
+	}
+
+	expiring := valid
+	expiring.DaysToExpiration = 0
+	expiring.Price = 0
+	if err := expiring.Validate(); err != nil {
+		t.Errorf("Unexpected error for expiring option: %v", err)
+	}
+}
+
+func TestImpliedVolatilityValidates(t *testing.T) {
+	option := Option{
+		Price:            5.0,
+		Strike:           -100.0,
+		DaysToExpiration: 30.0,
+		RiskFreeRate:     0.05,
+		UnderlyingPrice:  100.0,
+		OptionType:       Call,
+	}
+
+	if _, err := ImpliedVolatility(option); !errors.Is(err, ErrNonPositiveStrike) {
+		t.Errorf("Unexpected error: got %v, want %v", err, ErrNonPositiveStrike)
+	}
+	if _, err := RationalImpliedVolatility(option); !errors.Is(err, ErrNonPositiveStrike) {
+		t.Errorf("Unexpected rational error: got %v, want %v", err, ErrNonPositiveStrike)
+	}
+	if _, err := AmericanImpliedVolatility(option, 100); !errors.Is(err, ErrNonPositiveStrike) {
+		t.Errorf("Unexpected American error: got %v, want %v", err, ErrNonPositiveStrike)
+	}
+}
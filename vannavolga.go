@@ -0,0 +1,59 @@
+package finance
+
+import "math"
+
+// VannaVolgaPrice computes the price of a currency option consistent with the smile quoted by an at-the-money
+// volatility, 25-delta risk reversal and butterfly, by the vanna-volga method of Castagna and Mercurio (2007). The
+// three pivots are the delta-neutral straddle strike K2 = F·e^{σ2²T/2} and the 25-delta put and call strikes, at
+// spot deltas ±e^{−rf·T}·N(±d1) without premium adjustment, with the volatilities σ1 = σATM + bf25 − rr25/2 and
+// σ3 = σATM + bf25 + rr25/2, the butterfly being read as the smile strangle. The option is priced at the
+// at-the-money volatility and adjusted by the market's premium over that price at each pivot, weighted so that the
+// vega, vanna and volga of the pivots match the option's:
+// x1 = V(K)/V(K1)·ln(K2/K)·ln(K3/K)/(ln(K2/K1)·ln(K3/K1)) and cyclically, the vegas V at the at-the-money
+// volatility. Each pivot reprices to its own market volatility. A put carries the adjustment of the call at its
+// strike, which put-call parity makes the same. At expiration the price is the intrinsic value. NaN for an option
+// that cannot be priced, or for an at-the-money or pivot volatility that is not positive
+// option: the option, with UnderlyingPrice holding the spot exchange rate
+// atmVol: the at-the-money volatility, of the delta-neutral straddle
+// rr25: the 25-delta risk reversal, the call volatility less the put volatility
+// bf25: the 25-delta butterfly, the average of the call and put volatilities less the at-the-money volatility
+// foreignRate: the foreign risk-free interest rate
+func VannaVolgaPrice(option Option, atmVol, rr25, bf25, foreignRate float64) float64 {
+	option = garmanKohlhagen(option, foreignRate)
+	putVol, callVol := atmVol+bf25-0.5*rr25, atmVol+bf25+0.5*rr25
+	if err := validatePricing(option, atmVol); err != nil || !(putVol > 0) || !(callVol > 0) || math.IsInf(rr25, 0) || math.IsInf(bf25, 0) {
+		return math.NaN()
+	}
+	if option.DaysToExpiration == 0 {
+		return option.IntrinsicValue()
+	}
+
+	timeYears := option.DaysToExpiration / 365.0
+	sqrtT := math.Sqrt(timeYears)
+	forward := ForwardPrice(option.UnderlyingPrice, option.RiskFreeRate, foreignRate, timeYears)
+	alpha := -PhiInv(0.25 * math.Exp(foreignRate*timeYears))
+	strikes := [3]float64{
+		forward * math.Exp(-alpha*putVol*sqrtT+0.5*putVol*putVol*timeYears),
+		forward * math.Exp(0.5*atmVol*atmVol*timeYears),
+		forward * math.Exp(alpha*callVol*sqrtT+0.5*callVol*callVol*timeYears),
+	}
+	vols := [3]float64{putVol, atmVol, callVol}
+
+	call := option
+	call.OptionType = Call
+	at := func(strike float64) Option {
+		pivot := call
+		pivot.Strike = strike
+		return pivot
+	}
+	vega := BlackScholesVega(option, atmVol)
+	price := BlackScholesOptionPrice(option, atmVol)
+	for i := range strikes {
+		j, k := (i+1)%3, (i+2)%3
+		weight := vega / BlackScholesVega(at(strikes[i]), atmVol) *
+			math.Log(strikes[j]/option.Strike) * math.Log(strikes[k]/option.Strike) /
+			(math.Log(strikes[j]/strikes[i]) * math.Log(strikes[k]/strikes[i]))
+		price += weight * (BlackScholesOptionPrice(at(strikes[i]), vols[i]) - BlackScholesOptionPrice(at(strikes[i]), atmVol))
+	}
+	return price
+}
@@ -0,0 +1,73 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVannaVolgaPrice(t *testing.T) {
+	spot, domestic, foreign, days := 1.2, 0.03, 0.01, 91.25
+	atm, rr, bf := 0.1, -0.015, 0.004
+	option := func(strike float64, typ OptionType) Option {
+		return Option{Strike: strike, DaysToExpiration: days, RiskFreeRate: domestic, UnderlyingPrice: spot, OptionType: typ}
+	}
+
+	// the pivots reprice to their own volatilities
+	timeYears := days / 365.0
+	forward := ForwardPrice(spot, domestic, foreign, timeYears)
+	alpha := -PhiInv(0.25 * math.Exp(foreign*timeYears))
+	putVol, callVol := atm+bf-0.5*rr, atm+bf+0.5*rr
+	pivots := map[float64]float64{
+		forward * math.Exp(-alpha*putVol*math.Sqrt(timeYears)+0.5*putVol*putVol*timeYears):   putVol,
+		forward * math.Exp(0.5*atm*atm*timeYears):                                            atm,
+		forward * math.Exp(alpha*callVol*math.Sqrt(timeYears)+0.5*callVol*callVol*timeYears): callVol,
+	}
+	for strike, want := range pivots {
+		for _, typ := range []OptionType{Call, Put} {
+			o := option(strike, typ)
+			o.Price = VannaVolgaPrice(o, atm, rr, bf, foreign)
+			result, err := GarmanKohlhagenImpliedVolatility(o, foreign, WithTolerance(1e-12))
+			if err != nil || math.Abs(result.Volatility-want) > 1e-8 {
+				t.Errorf("Unexpected implied volatility of the %v pivot at %v: got %v (%v), want %v", typ, strike, result.Volatility, err, want)
+			}
+			// the 25-delta pivots have their spot deltas at their own volatilities
+			if want != atm && typ == Call {
+				if delta := GarmanKohlhagenSpotDelta(o, want, foreign); strike > forward && math.Abs(delta-0.25) > 1e-12 {
+					t.Errorf("Unexpected spot delta of the call pivot: got %v, want 0.25", delta)
+				}
+			}
+		}
+	}
+
+	// between and beyond the pivots the smile is skewed to the downside, and puts and calls satisfy parity
+	for _, strike := range []float64{1.05, 1.15, 1.2, 1.25, 1.35} {
+		call, put := option(strike, Call), option(strike, Put)
+		callPrice, putPrice := VannaVolgaPrice(call, atm, rr, bf, foreign), VannaVolgaPrice(put, atm, rr, bf, foreign)
+		parity := spot*math.Exp(-foreign*timeYears) - strike*math.Exp(-domestic*timeYears)
+		if math.Abs(callPrice-putPrice-parity) > 1e-12 {
+			t.Errorf("Unexpected parity at %v: got %v, want %v", strike, callPrice-putPrice, parity)
+		}
+		call.Price = callPrice
+		result, _ := GarmanKohlhagenImpliedVolatility(call, foreign, WithTolerance(1e-12))
+		if strike < 1.15 && !(result.Volatility > atm) {
+			t.Errorf("Unexpected implied volatility at %v: got %v, want above %v", strike, result.Volatility, atm)
+		}
+	}
+
+	// without a smile the price is Garman-Kohlhagen's at the at-the-money volatility
+	flat := option(1.3, Call)
+	if got, want := VannaVolgaPrice(flat, atm, 0, 0, foreign), GarmanKohlhagenPrice(flat, atm, foreign); math.Abs(got-want) > 1e-15 {
+		t.Errorf("Unexpected price without a smile: got %v, want %v", got, want)
+	}
+	expired := option(1.1, Call)
+	expired.DaysToExpiration = 0
+	if got := VannaVolgaPrice(expired, atm, rr, bf, foreign); math.Abs(got-0.1) > 1e-12 {
+		t.Errorf("Unexpected price at expiration: got %v, want 0.1", got)
+	}
+
+	for name, quote := range map[string][3]float64{"zero atm": {0, rr, bf}, "negative put vol": {atm, 0.3, 0}, "nan butterfly": {atm, rr, math.NaN()}} {
+		if got := VannaVolgaPrice(option(1.2, Call), quote[0], quote[1], quote[2], foreign); !math.IsNaN(got) {
+			t.Errorf("Unexpected price for %s: got %v, want NaN", name, got)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package finance
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// VarianceGammaCharacteristic returns the characteristic function of the logarithm of the underlying price at
+// expiration relative to its forward under the variance gamma model of Madan, Carr and Chang (1998), Brownian motion
+// with drift θ and volatility σ run on a gamma clock of unit mean rate and variance rate ν,
+// φ(u) = e^{iuωT}·(1 − iuθν + σ²νu²/2)^{−T/ν}, where ω = ln(1 − θν − σ²ν/2)/ν makes the underlying grow at the
+// forward rate. Negative θ skews the distribution to the left and ν sets the excess kurtosis. The function is NaN
+// everywhere for a volatility or variance rate that is not positive, or for 1 − θν − σ²ν/2 not positive, when the
+// underlying has no finite mean
+// sigma: the volatility σ of the Brownian motion
+// nu: the variance rate ν of the gamma clock
+// theta: the drift θ of the Brownian motion
+func VarianceGammaCharacteristic(sigma, nu, theta float64) CharacteristicFunc {
+	base := 1 - theta*nu - 0.5*sigma*sigma*nu
+	if !(sigma > 0) || !(nu > 0) || !(base > 0) || math.IsInf(sigma, 1) || math.IsInf(nu, 1) || math.IsInf(theta, 0) {
+		return func(complex128, float64) complex128 { return cmplx.NaN() }
+	}
+	omega := math.Log(base) / nu
+	return func(u complex128, timeYears float64) complex128 {
+		iu := complex(0, 1) * u
+		inner := 1 - iu*complex(theta*nu, 0) + complex(0.5*sigma*sigma*nu, 0)*u*u
+		return cmplx.Exp(iu*complex(omega*timeYears, 0) - complex(timeYears/nu, 0)*cmplx.Log(inner))
+	}
+}
+
+// VarianceGammaPrice computes the price of a European option under the variance gamma model by FFTPrice with
+// VarianceGammaCharacteristic. As ν goes to zero the gamma clock runs at a constant rate and, for θ = 0, the price
+// tends to the Black-Scholes price with volatility σ. The characteristic function decays only as |u|^{−2T/ν}, so
+// that at maturities short against ν the truncation error falls only as 1/(Nη) and the grid needs more points. NaN
+// where either is
+// option: the option
+// sigma: the volatility σ of the Brownian motion
+// nu: the variance rate ν of the gamma clock
+// theta: the drift θ of the Brownian motion
+// cfg: the configuration of the transform
+func VarianceGammaPrice(option Option, sigma, nu, theta float64, cfg FFTConfig) float64 {
+	return FFTPrice(VarianceGammaCharacteristic(sigma, nu, theta), option, cfg)
+}
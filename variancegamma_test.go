@@ -0,0 +1,45 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVarianceGammaPrice(t *testing.T) {
+	// Black-Scholes prices with volatility σ²G averaged over the gamma clock G by quadrature
+	for _, test := range []struct {
+		strike, want float64
+	}{
+		{90.0, 11.880799423184158},
+		{100.0, 4.400649443717122},
+		{110.0, 0.7186585780371852},
+	} {
+		option := Option{Strike: test.strike, DaysToExpiration: 182.5, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Call, DividendYield: 0.02}
+		if got := VarianceGammaPrice(option, 0.12, 0.2, -0.14, FFTConfig{}); math.Abs(got-test.want) > 1e-6 {
+			t.Errorf("Unexpected price struck at %v: got %v, want %v", test.strike, got, test.want)
+		}
+		// and the put by parity
+		put := option
+		put.OptionType = Put
+		parity := test.want - math.Exp(-0.05*0.5)*(ForwardPrice(100.0, 0.05, 0.02, 0.5)-test.strike)
+		if got := VarianceGammaPrice(put, 0.12, 0.2, -0.14, FFTConfig{}); math.Abs(got-parity) > 1e-6 {
+			t.Errorf("Unexpected put struck at %v: got %v, want %v", test.strike, got, parity)
+		}
+	}
+
+	// a gamma clock without variance runs at a constant rate
+	option := Option{Strike: 100.0, DaysToExpiration: 90.0, RiskFreeRate: 0.05, UnderlyingPrice: 100.0, OptionType: Call}
+	if got, want := VarianceGammaPrice(option, 0.2, 1e-6, 0, FFTConfig{}), BlackScholesOptionPrice(option, 0.2); math.Abs(got-want) > 1e-5 {
+		t.Errorf("Unexpected price with a nearly constant clock: got %v, want %v", got, want)
+	}
+
+	for name, got := range map[string]float64{
+		"zero volatility":    VarianceGammaPrice(option, 0, 0.2, -0.14, FFTConfig{}),
+		"zero variance rate": VarianceGammaPrice(option, 0.12, 0, -0.14, FFTConfig{}),
+		"no finite mean":     VarianceGammaPrice(option, 0.12, 0.5, 2.5, FFTConfig{}),
+	} {
+		if !math.IsNaN(got) {
+			t.Errorf("Unexpected price with %s: got %v, want NaN", name, got)
+		}
+	}
+}
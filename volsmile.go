@@ -0,0 +1,235 @@
+package finance
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ErrInvalidSmile is returned for a volatility smile with a forward or time to expiration that is not positive and
+// finite, with strikes and volatilities that differ in number or are too few, or with quotes out of range
+var ErrInvalidSmile = errors.New("finance: invalid volatility smile")
+
+// smileMinVol is the lowest volatility a VolSmile returns, that of the lower bound of the implied volatility solver
+const smileMinVol = 1e-4
+
+// SmileInterpolation is how a VolSmile interpolates between its quotes
+type SmileInterpolation int
+
+const (
+	SplineInterpolation SmileInterpolation = iota // Natural cubic spline of the volatility in the log strike; the default
+	SVIInterpolation                              // Raw SVI total variance fitted by CalibrateSVI to five quotes or more
+)
+
+// SmileExtrapolation is how a VolSmile extends beyond its lowest and highest quoted strikes
+type SmileExtrapolation int
+
+const (
+	FlatExtrapolation        SmileExtrapolation = iota // The volatility at the outermost quoted strike; the default
+	StickyDeltaExtrapolation                           // Linear in the call delta at the at-the-money volatility
+)
+
+// VolSmile is a smile of Black implied volatilities for a single expiration, interpolated and extrapolated in
+// strike by explicit rules
+type VolSmile struct {
+	forward, timeYears float64
+	strikes, vols      []float64 // Quotes, by ascending strike
+	interpolation      SmileInterpolation
+	extrapolation      SmileExtrapolation
+	spline             naturalSpline // Volatility in the log strike, for SplineInterpolation
+	svi                SVIParams     // Total variance in the log moneyness, for SVIInterpolation
+	atmVol             float64       // Volatility at the forward, which sets the delta of StickyDeltaExtrapolation
+}
+
+// NewVolSmile constructs a smile from Black implied volatilities quoted by strike, which need not be sorted
+// Returns ErrInvalidSmile for invalid inputs, including repeated strikes and fewer than two quotes, or five with
+// SVIInterpolation, and with SVIInterpolation the errors of CalibrateSVI
+// forward: the forward price for the expiration
+// timeYears: the time to expiration in years
+// strikes: the strike of each quote
+// vols: the implied volatility of each quote
+// interpolation: how to interpolate between the quotes
+// extrapolation: how to extend beyond the outermost quotes
+func NewVolSmile(forward, timeYears float64, strikes, vols []float64, interpolation SmileInterpolation, extrapolation SmileExtrapolation) (VolSmile, error) {
+	if err := checkSmile(forward, timeYears, len(strikes), len(vols), interpolation, extrapolation); err != nil {
+		return VolSmile{}, err
+	}
+	order := make([]int, len(strikes))
+	for i := range order {
+		if !(strikes[i] > 0) || math.IsInf(strikes[i], 1) || !(vols[i] > 0) || math.IsInf(vols[i], 1) {
+			return VolSmile{}, fmt.Errorf("%w: strike %v with volatility %v", ErrInvalidSmile, strikes[i], vols[i])
+		}
+		order[i] = i
+	}
+	slices.SortFunc(order, func(i, j int) int { return cmp.Compare(strikes[i], strikes[j]) })
+
+	s := VolSmile{forward: forward, timeYears: timeYears, interpolation: interpolation, extrapolation: extrapolation}
+	logStrikes := make([]float64, len(order))
+	for n, i := range order {
+		if n > 0 && strikes[i] == s.strikes[n-1] {
+			return VolSmile{}, fmt.Errorf("%w: repeated strike %v", ErrInvalidSmile, strikes[i])
+		}
+		s.strikes = append(s.strikes, strikes[i])
+		s.vols = append(s.vols, vols[i])
+		logStrikes[n] = math.Log(strikes[i])
+	}
+
+	switch interpolation {
+	case SplineInterpolation:
+		s.spline = newNaturalSpline(logStrikes, s.vols)
+	case SVIInterpolation:
+		moneyness, variances := make([]float64, len(order)), make([]float64, len(order))
+		for n := range s.strikes {
+			moneyness[n] = math.Log(s.strikes[n] / forward)
+			variances[n] = s.vols[n] * s.vols[n] * timeYears
+		}
+		var err error
+		if s.svi, err = CalibrateSVI(moneyness, variances, nil); err != nil {
+			return VolSmile{}, err
+		}
+	}
+	s.atmVol, _, _ = s.interpolate(forward)
+	return s, nil
+}
+
+// NewVolSmileFromDeltas constructs a smile from Black implied volatilities quoted by forward delta, N(d1) for calls
+// and N(d1) − 1 for puts, without premium adjustment. Each delta is converted to its strike at its own volatility,
+// K = F·e^{σ²T/2 − σ√T·N⁻¹(Δ)}, Δ being the call delta. Returns the errors of NewVolSmile, and ErrInvalidSmile for a
+// delta that is zero or outside (−1, 1)
+// forward: the forward price for the expiration
+// timeYears: the time to expiration in years
+// deltas: the forward delta of each quote, positive for calls and negative for puts
+// vols: the implied volatility of each quote
+// interpolation: how to interpolate between the quotes
+// extrapolation: how to extend beyond the outermost quotes
+func NewVolSmileFromDeltas(forward, timeYears float64, deltas, vols []float64, interpolation SmileInterpolation, extrapolation SmileExtrapolation) (VolSmile, error) {
+	if err := checkSmile(forward, timeYears, len(deltas), len(vols), interpolation, extrapolation); err != nil {
+		return VolSmile{}, err
+	}
+	strikes := make([]float64, len(deltas))
+	for i, delta := range deltas {
+		callDelta := delta
+		if delta < 0 {
+			callDelta = 1 + delta
+		}
+		if !(callDelta > 0 && callDelta < 1) || delta == 0 || !(vols[i] > 0) || math.IsInf(vols[i], 1) {
+			return VolSmile{}, fmt.Errorf("%w: delta %v with volatility %v", ErrInvalidSmile, delta, vols[i])
+		}
+		stdDev := vols[i] * math.Sqrt(timeYears)
+		strikes[i] = forward * math.Exp(0.5*stdDev*stdDev-stdDev*PhiInv(callDelta))
+	}
+	return NewVolSmile(forward, timeYears, strikes, vols, interpolation, extrapolation)
+}
+
+// Vol returns the implied volatility of the smile at a strike, never below 1e-4. With StickyDeltaExtrapolation
+// the volatility beyond the outermost strikes follows the interpolant's slope in the call delta N(d1) computed at
+// the at-the-money volatility, from 0 to 1 as the strike falls from infinity to zero, so that it stays within that
+// slope of the outermost quote however far the strike is. NaN for a strike that is not positive
+// strike: the strike price
+func (s VolSmile) Vol(strike float64) float64 {
+	vol, _, _ := s.volDerivatives(strike)
+	return vol
+}
+
+// Forward returns the forward price the smile was constructed with
+func (s VolSmile) Forward() float64 {
+	return s.forward
+}
+
+// TimeYears returns the time to expiration of the smile in years
+func (s VolSmile) TimeYears() float64 {
+	return s.timeYears
+}
+
+// Strikes returns the quoted strikes of the smile, ascending
+func (s VolSmile) Strikes() []float64 {
+	return slices.Clone(s.strikes)
+}
+
+// volDerivatives computes the implied volatility of the smile at a strike, as Vol does, and its first and second
+// derivatives in strike, which are zero where the volatility is at its lower bound
+// strike: the strike price
+func (s VolSmile) volDerivatives(strike float64) (vol, slope, curvature float64) {
+	if !(strike > 0) {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	lowest, highest := s.strikes[0], s.strikes[len(s.strikes)-1]
+	if strike >= lowest && strike <= highest {
+		vol, slope, curvature = s.interpolate(strike)
+	} else {
+		wing := lowest
+		if strike > highest {
+			wing = highest
+		}
+		var wingSlope float64
+		vol, wingSlope, _ = s.interpolate(wing)
+		if s.extrapolation == StickyDeltaExtrapolation {
+			// dσ/dΔ = (dσ/d ln K)/(dΔ/d ln K), with dΔ/d ln K = −n(d1)/(σ√T)
+			stdDev := s.atmVol * math.Sqrt(s.timeYears)
+			d1 := (math.Log(s.forward/strike) + 0.5*stdDev*stdDev) / stdDev
+			wingD1 := (math.Log(s.forward/wing) + 0.5*stdDev*stdDev) / stdDev
+			scale := wingSlope * stdDev / -NormalDistributionDerivative(wingD1)
+			density := NormalDistributionDerivative(d1)
+			vol += scale * (Phi(d1) - Phi(wingD1))
+			slope = -scale * density / stdDev
+			curvature = -scale * d1 * density / (stdDev * stdDev)
+		}
+	}
+	if !(vol > smileMinVol) {
+		return smileMinVol, 0, 0
+	}
+	// from the log strike to the strike
+	return vol, slope / strike, (curvature - slope) / (strike * strike)
+}
+
+// interpolate computes the interpolated volatility and its first and second derivatives in the log strike, without
+// extrapolation or the lower bound on the volatility
+// strike: the strike price
+func (s VolSmile) interpolate(strike float64) (float64, float64, float64) {
+	if s.interpolation == SVIInterpolation {
+		k := math.Log(strike / s.forward)
+		w := SVITotalVariance(k, s.svi)
+		if !(w > 0) {
+			return 0, 0, 0
+		}
+		vol := math.Sqrt(w / s.timeYears)
+		shifted := k - s.svi.M
+		root := math.Sqrt(shifted*shifted + s.svi.Sigma*s.svi.Sigma)
+		slope := s.svi.B * (s.svi.Rho + shifted/root)
+		curvature := s.svi.B * s.svi.Sigma * s.svi.Sigma / (root * root * root)
+		// σ = √(w/T), so that σ' = w'/(2σT) and σ'' = w''/(2σT) − w'²/(4σ³T²)
+		return vol, slope / (2 * vol * s.timeYears), curvature/(2*vol*s.timeYears) - slope*slope/(4*vol*vol*vol*s.timeYears*s.timeYears)
+	}
+	return s.spline.evaluate(math.Log(strike))
+}
+
+// checkSmile returns ErrInvalidSmile if a smile's forward, time to expiration, number of quotes or rules are invalid
+// forward: the forward price
+// timeYears: the time to expiration in years
+// quotes: the number of strikes or deltas
+// vols: the number of volatilities
+// interpolation: the interpolation
+// extrapolation: the extrapolation
+func checkSmile(forward, timeYears float64, quotes, vols int, interpolation SmileInterpolation, extrapolation SmileExtrapolation) error {
+	minQuotes := 2
+	if interpolation == SVIInterpolation {
+		minQuotes = sviMinQuotes
+	}
+	switch {
+	case !(forward > 0) || math.IsInf(forward, 1):
+		return fmt.Errorf("%w: forward %v", ErrInvalidSmile, forward)
+	case !(timeYears > 0) || math.IsInf(timeYears, 1):
+		return fmt.Errorf("%w: time to expiration %v", ErrInvalidSmile, timeYears)
+	case quotes != vols:
+		return fmt.Errorf("%w: %d quotes and %d volatilities", ErrInvalidSmile, quotes, vols)
+	case quotes < minQuotes:
+		return fmt.Errorf("%w: %d quotes, fewer than %d", ErrInvalidSmile, quotes, minQuotes)
+	case interpolation != SplineInterpolation && interpolation != SVIInterpolation:
+		return fmt.Errorf("%w: unknown interpolation %d", ErrInvalidSmile, interpolation)
+	case extrapolation != FlatExtrapolation && extrapolation != StickyDeltaExtrapolation:
+		return fmt.Errorf("%w: unknown extrapolation %d", ErrInvalidSmile, extrapolation)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewVolSmile(t *testing.T) {
+	strikes := []float64{110.0, 80.0, 90.0, 100.0, 120.0}
+	vols := []float64{0.18, 0.28, 0.24, 0.2, 0.17}
+	smile, err := NewVolSmile(100.0, 0.5, strikes, vols, SplineInterpolation, FlatExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := range strikes {
+		if got := smile.Vol(strikes[i]); math.Abs(got-vols[i]) > 1e-12 {
+			t.Errorf("Unexpected volatility at quoted strike %v: got %v, want %v", strikes[i], got, vols[i])
+		}
+	}
+	if got := smile.Vol(95.0); !(got > 0.2 && got < 0.24) {
+		t.Errorf("Unexpected interpolated volatility at 95: got %v, want between 0.2 and 0.24", got)
+	}
+	if got := smile.Strikes(); got[0] != 80.0 || got[4] != 120.0 {
+		t.Errorf("Unexpected strikes: got %v, want ascending", got)
+	}
+	if smile.Forward() != 100.0 || smile.TimeYears() != 0.5 {
+		t.Errorf("Unexpected forward and time: got %v and %v, want 100 and 0.5", smile.Forward(), smile.TimeYears())
+	}
+
+	// SVI recovers a smile generated by SVI
+	p := SVIParams{A: 0.01, B: 0.1, Rho: -0.4, M: 0.05, Sigma: 0.15}
+	var sviStrikes, sviVols []float64
+	for k := -0.4; k <= 0.41; k += 0.1 {
+		sviStrikes = append(sviStrikes, 100.0*math.Exp(k))
+		sviVols = append(sviVols, math.Sqrt(SVITotalVariance(k, p)/0.5))
+	}
+	svi, err := NewVolSmile(100.0, 0.5, sviStrikes, sviVols, SVIInterpolation, FlatExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, k := range []float64{-0.35, -0.05, 0.12, 0.33} {
+		want := math.Sqrt(SVITotalVariance(k, p) / 0.5)
+		if got := svi.Vol(100.0 * math.Exp(k)); math.Abs(got-want) > 1e-5 {
+			t.Errorf("Unexpected SVI volatility at log moneyness %v: got %v, want %v", k, got, want)
+		}
+	}
+}
+
+func TestNewVolSmileFromDeltas(t *testing.T) {
+	deltas := []float64{-0.1, -0.25, 0.5, 0.25, 0.1}
+	vols := []float64{0.26, 0.23, 0.2, 0.19, 0.195}
+	smile, err := NewVolSmileFromDeltas(100.0, 0.25, deltas, vols, SplineInterpolation, FlatExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// each strike reproduces its delta at its own volatility
+	for i, delta := range deltas {
+		callDelta := delta
+		if delta < 0 {
+			callDelta = 1 + delta
+		}
+		for _, strike := range smile.Strikes() {
+			if smile.Vol(strike) != vols[i] {
+				continue
+			}
+			stdDev := vols[i] * math.Sqrt(0.25)
+			if got := Phi((math.Log(100.0/strike) + 0.5*stdDev*stdDev) / stdDev); math.Abs(got-callDelta) > 1e-9 {
+				t.Errorf("Unexpected call delta at strike %v: got %v, want %v", strike, got, callDelta)
+			}
+		}
+	}
+	if got := smile.Strikes(); len(got) != 5 || !(got[0] < 100.0 && got[4] > 100.0) {
+		t.Errorf("Unexpected strikes: got %v, want around the forward", got)
+	}
+}
+
+func TestVolSmileExtrapolation(t *testing.T) {
+	strikes := []float64{80.0, 90.0, 100.0, 110.0, 120.0}
+	vols := []float64{0.3, 0.25, 0.2, 0.18, 0.17}
+	for _, interpolation := range []SmileInterpolation{SplineInterpolation, SVIInterpolation} {
+		flat, err := NewVolSmile(100.0, 1.0, strikes, vols, interpolation, FlatExtrapolation)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		sticky, err := NewVolSmile(100.0, 1.0, strikes, vols, interpolation, StickyDeltaExtrapolation)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, strike := range []float64{0.1, 10.0, 60.0, 150.0, 1000.0, 1e5} {
+			wing := flat.Vol(80.0)
+			if strike > 120.0 {
+				wing = flat.Vol(120.0)
+			}
+			if got := flat.Vol(strike); got != wing {
+				t.Errorf("Unexpected flat extrapolation at %v with interpolation %d: got %v, want %v", strike, interpolation, got, wing)
+			}
+			// sticky delta follows the wing's slope, but stays positive and bounded however far the strike
+			if got := sticky.Vol(strike); !(got >= 1e-4) || got > 1.0 {
+				t.Errorf("Unexpected sticky-delta extrapolation at %v with interpolation %d: got %v, want within [1e-4, 1]", strike, interpolation, got)
+			}
+		}
+		if low, high := sticky.Vol(70.0), sticky.Vol(130.0); !(low > flat.Vol(70.0)) || !(high < flat.Vol(130.0)) {
+			t.Errorf("Unexpected sticky-delta wings with interpolation %d: got %v and %v, want beyond the flat %v and %v", interpolation, low, high, flat.Vol(70.0), flat.Vol(130.0))
+		}
+		// continuous at the outermost quotes
+		if got, want := sticky.Vol(119.999), sticky.Vol(120.001); math.Abs(got-want) > 1e-5 {
+			t.Errorf("Unexpected discontinuity at the highest strike with interpolation %d: got %v and %v", interpolation, got, want)
+		}
+	}
+
+	smile, _ := NewVolSmile(100.0, 1.0, strikes, vols, SplineInterpolation, StickyDeltaExtrapolation)
+	for name, strike := range map[string]float64{"zero": 0, "negative": -10.0, "nan": math.NaN()} {
+		if got := smile.Vol(strike); !math.IsNaN(got) {
+			t.Errorf("Unexpected volatility for %s strike: got %v, want NaN", name, got)
+		}
+	}
+}
+
+func TestNewVolSmileErrors(t *testing.T) {
+	strikes := []float64{90.0, 100.0, 110.0}
+	vols := []float64{0.22, 0.2, 0.19}
+	tests := []struct {
+		name          string
+		forward       float64
+		timeYears     float64
+		strikes       []float64
+		vols          []float64
+		interpolation SmileInterpolation
+		extrapolation SmileExtrapolation
+	}{
+		{"zero forward", 0, 1.0, strikes, vols, SplineInterpolation, FlatExtrapolation},
+		{"nan time", 100.0, math.NaN(), strikes, vols, SplineInterpolation, FlatExtrapolation},
+		{"mismatched", 100.0, 1.0, strikes, vols[:2], SplineInterpolation, FlatExtrapolation},
+		{"one quote", 100.0, 1.0, strikes[:1], vols[:1], SplineInterpolation, FlatExtrapolation},
+		{"too few for svi", 100.0, 1.0, strikes, vols, SVIInterpolation, FlatExtrapolation},
+		{"negative strike", 100.0, 1.0, []float64{-90.0, 100.0, 110.0}, vols, SplineInterpolation, FlatExtrapolation},
+		{"zero vol", 100.0, 1.0, strikes, []float64{0.22, 0, 0.19}, SplineInterpolation, FlatExtrapolation},
+		{"repeated strike", 100.0, 1.0, []float64{90.0, 100.0, 90.0}, vols, SplineInterpolation, FlatExtrapolation},
+		{"unknown interpolation", 100.0, 1.0, strikes, vols, SmileInterpolation(7), FlatExtrapolation},
+		{"unknown extrapolation", 100.0, 1.0, strikes, vols, SplineInterpolation, SmileExtrapolation(7)},
+	}
+	for _, tt := range tests {
+		if _, err := NewVolSmile(tt.forward, tt.timeYears, tt.strikes, tt.vols, tt.interpolation, tt.extrapolation); !errors.Is(err, ErrInvalidSmile) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", tt.name, err, ErrInvalidSmile)
+		}
+	}
+	for name, deltas := range map[string][]float64{"zero": {0, 0.5, 0.25}, "one": {1.0, 0.5, 0.25}, "beyond": {-1.2, 0.5, 0.25}} {
+		if _, err := NewVolSmileFromDeltas(100.0, 1.0, deltas, vols, SplineInterpolation, FlatExtrapolation); !errors.Is(err, ErrInvalidSmile) {
+			t.Errorf("Unexpected error for %s delta: got %v, want %v", name, err, ErrInvalidSmile)
+		}
+	}
+}
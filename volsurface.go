@@ -0,0 +1,214 @@
+package finance
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ErrInvalidSurface is returned for a volatility surface without smiles, with two smiles at the same expiration, or
+// from options that expire today
+var ErrInvalidSurface = errors.New("finance: invalid volatility surface")
+
+var (
+	// ErrCalendarArbitrage is returned when the total implied variance decreases from one expiration to a later one
+	ErrCalendarArbitrage = errors.New("finance: total variance decreases with expiration")
+	// ErrInvalidForwardPeriod is returned for a forward period that does not end after it starts
+	ErrInvalidForwardPeriod = errors.New("finance: forward period must end after it starts")
+)
+
+// SmileSurface is a VolSurface interpolated between VolSmiles at pillar expirations. Between pillars the total
+// implied variance σ²T is linear in the time to expiration at a fixed log moneyness ln(K/F_T), rather than the
+// volatility, so that it increases between pillars whose smiles are free of calendar arbitrage, and the log forward
+// is linear in time. Before the first pillar and after the last, the volatility at a fixed log moneyness is that of
+// the nearest smile, so that the total variance grows in proportion to time, and the forward extends the log-linear
+// line of the nearest two pillars, or stays at the only pillar's
+type SmileSurface struct {
+	smiles []VolSmile // Pillars, by ascending expiration
+}
+
+// NewSmileSurface constructs a surface from smiles at different expirations, which need not be sorted
+// Returns ErrInvalidSurface for no smiles or for two at the same expiration
+// smiles: the smile at each pillar expiration
+func NewSmileSurface(smiles []VolSmile) (SmileSurface, error) {
+	if len(smiles) == 0 {
+		return SmileSurface{}, fmt.Errorf("%w: no smiles", ErrInvalidSurface)
+	}
+	sorted := slices.Clone(smiles)
+	slices.SortFunc(sorted, func(a, b VolSmile) int { return cmp.Compare(a.timeYears, b.timeYears) })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].timeYears == sorted[i-1].timeYears {
+			return SmileSurface{}, fmt.Errorf("%w: two smiles at %v years", ErrInvalidSurface, sorted[i].timeYears)
+		}
+	}
+	return SmileSurface{smiles: sorted}, nil
+}
+
+// NewSmileSurfaceFromOptions constructs a surface from quoted options on one underlying, with a smile at each of
+// their days to expiration through the forward of the first option quoted there. Each option's implied volatility is
+// solved with the given options; where a call and a put share a strike and expiration the one out of the money is
+// used. Returns ErrInvalidSurface for no options or options that expire today, the errors of the solver, wrapped
+// with the strike and expiration of the option concerned, and the errors of NewVolSmile
+// options: the quoted options
+// interpolation: how each smile interpolates between its quotes
+// extrapolation: how each smile extends beyond its outermost quotes
+// opts: options of the implied volatility solver
+func NewSmileSurfaceFromOptions(options []Option, interpolation SmileInterpolation, extrapolation SmileExtrapolation, opts ...IVOption) (SmileSurface, error) {
+	if len(options) == 0 {
+		return SmileSurface{}, fmt.Errorf("%w: no options", ErrInvalidSurface)
+	}
+	solver := NewIVSolver(opts...)
+	type quote struct {
+		strike, vol float64
+		call        bool
+	}
+	var expirations []float64
+	forwards := map[float64]float64{}
+	quotes := map[float64][]quote{}
+	for _, option := range options {
+		days := option.DaysToExpiration
+		if !(days > 0) {
+			return SmileSurface{}, fmt.Errorf("%w: option with strike %v expiring in %v days", ErrInvalidSurface, option.Strike, days)
+		}
+		result, err := solver.Solve(option)
+		if err != nil {
+			return SmileSurface{}, fmt.Errorf("option with strike %v expiring in %v days: %w", option.Strike, days, err)
+		}
+		if _, ok := forwards[days]; !ok {
+			expirations = append(expirations, days)
+			e := escrowed(option)
+			forwards[days] = ForwardPrice(e.UnderlyingPrice, e.RiskFreeRate, e.DividendYield, days/365.0)
+		}
+		q := quote{strike: option.Strike, vol: result.Volatility, call: option.OptionType == Call}
+		i := slices.IndexFunc(quotes[days], func(other quote) bool { return other.strike == q.strike })
+		switch {
+		case i < 0:
+			quotes[days] = append(quotes[days], q)
+		case q.call == (q.strike >= forwards[days]):
+			quotes[days][i] = q
+		}
+	}
+
+	smiles := make([]VolSmile, 0, len(expirations))
+	for _, days := range expirations {
+		strikes, vols := make([]float64, len(quotes[days])), make([]float64, len(quotes[days]))
+		for i, q := range quotes[days] {
+			strikes[i], vols[i] = q.strike, q.vol
+		}
+		smile, err := NewVolSmile(forwards[days], days/365.0, strikes, vols, interpolation, extrapolation)
+		if err != nil {
+			return SmileSurface{}, fmt.Errorf("smile expiring in %v days: %w", days, err)
+		}
+		smiles = append(smiles, smile)
+	}
+	return NewSmileSurface(smiles)
+}
+
+// Vol returns the implied volatility at a strike and time to expiration, NaN for a strike that is not positive or a
+// time that is negative. At time zero it is the first smile's volatility at the corresponding log moneyness
+// strike: the strike price
+// timeYears: the time to expiration in years
+func (s SmileSurface) Vol(strike, timeYears float64) float64 {
+	if !(strike > 0) || !(timeYears >= 0) || math.IsInf(timeYears, 1) {
+		return math.NaN()
+	}
+	k := math.Log(strike / s.Forward(timeYears))
+	first, last := s.smiles[0], s.smiles[len(s.smiles)-1]
+	switch {
+	case timeYears <= first.timeYears:
+		return first.Vol(first.forward * math.Exp(k))
+	case timeYears >= last.timeYears:
+		return last.Vol(last.forward * math.Exp(k))
+	}
+	i, _ := slices.BinarySearchFunc(s.smiles, timeYears, func(smile VolSmile, t float64) int { return cmp.Compare(smile.timeYears, t) })
+	before, after := s.smiles[i-1], s.smiles[i]
+	w1 := totalVariance(before, k)
+	w2 := totalVariance(after, k)
+	weight := (timeYears - before.timeYears) / (after.timeYears - before.timeYears)
+	return math.Sqrt(((1-weight)*w1 + weight*w2) / timeYears)
+}
+
+// Forward returns the forward price at a time to expiration, interpolated log-linearly between the pillars and
+// extended from the nearest two beyond them
+// timeYears: the time to expiration in years
+func (s SmileSurface) Forward(timeYears float64) float64 {
+	if len(s.smiles) == 1 {
+		return s.smiles[0].forward
+	}
+	i, _ := slices.BinarySearchFunc(s.smiles, timeYears, func(smile VolSmile, t float64) int { return cmp.Compare(smile.timeYears, t) })
+	i = min(max(i, 1), len(s.smiles)-1)
+	before, after := s.smiles[i-1], s.smiles[i]
+	growth := math.Log(after.forward/before.forward) / (after.timeYears - before.timeYears)
+	return before.forward * math.Exp(growth*(timeYears-before.timeYears))
+}
+
+// ATMVol returns the at-the-money implied volatility, at the forward, at a time to expiration
+// timeYears: the time to expiration in years
+func (s SmileSurface) ATMVol(timeYears float64) float64 {
+	return s.Vol(s.Forward(timeYears), timeYears)
+}
+
+// Expirations returns the pillar times to expiration in years, ascending
+func (s SmileSurface) Expirations() []float64 {
+	times := make([]float64, len(s.smiles))
+	for i, smile := range s.smiles {
+		times[i] = smile.timeYears
+	}
+	return times
+}
+
+// ATMTermStructure returns the at-the-money implied volatility of each pillar smile, in the order of Expirations
+func (s SmileSurface) ATMTermStructure() []float64 {
+	vols := make([]float64, len(s.smiles))
+	for i, smile := range s.smiles {
+		vols[i] = smile.Vol(smile.forward)
+	}
+	return vols
+}
+
+// Smiles returns the pillar smiles, in the order of Expirations
+func (s SmileSurface) Smiles() []VolSmile {
+	return slices.Clone(s.smiles)
+}
+
+// ForwardVol computes the forward implied volatility between two expirations at a strike,
+// √((σ2²t2 − σ1²t1)/(t2 − t1)), the volatility the market implies for the period between them. A period that spans
+// an event carries its variance, so that the forward volatility of a calendar spread across an earnings date shows
+// whether the event is cheap
+// Returns ErrNegativeExpiry for a first expiration before today, ErrInvalidForwardPeriod if the second is not after
+// it, ErrNonPositiveVolatility if the surface's volatility at either is not positive and finite, the first being
+// unused from today, and ErrCalendarArbitrage if the total variance decreases between them
+// surface: the implied volatility surface
+// strike: the strike at which the surface is read at both expirations
+// t1: the first expiration in years
+// t2: the second expiration in years
+func ForwardVol(surface VolSurface, strike, t1, t2 float64) (float64, error) {
+	switch {
+	case !(t1 >= 0):
+		return math.NaN(), fmt.Errorf("%w: %v years", ErrNegativeExpiry, t1)
+	case !(t2 > t1) || math.IsInf(t2, 1):
+		return math.NaN(), fmt.Errorf("%w: from %v to %v years", ErrInvalidForwardPeriod, t1, t2)
+	}
+	v1, v2 := 0.0, surface.Vol(strike, t2)
+	if t1 > 0 {
+		v1 = surface.Vol(strike, t1)
+	}
+	if !(v1 > 0 || t1 == 0) || !(v2 > 0) || math.IsInf(v1, 1) || math.IsInf(v2, 1) {
+		return math.NaN(), fmt.Errorf("%w: %v and %v at strike %v", ErrNonPositiveVolatility, v1, v2, strike)
+	}
+	variance := v2*v2*t2 - v1*v1*t1
+	if variance < 0 {
+		return math.NaN(), fmt.Errorf("%w: from %v at %v years to %v at %v years", ErrCalendarArbitrage, v1*v1*t1, t1, v2*v2*t2, t2)
+	}
+	return math.Sqrt(variance / (t2 - t1)), nil
+}
+
+// totalVariance computes the total implied variance σ²T of a smile at a log moneyness
+// smile: the smile
+// k: the log moneyness ln(K/F)
+func totalVariance(smile VolSmile, k float64) float64 {
+	vol := smile.Vol(smile.forward * math.Exp(k))
+	return vol * vol * smile.timeYears
+}
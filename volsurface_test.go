@@ -0,0 +1,196 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// testSmile is a smile at a forward and time whose volatility falls linearly in the log strike from an
+// at-the-money level
+func testSmile(t *testing.T, forward, timeYears, atm float64) VolSmile {
+	t.Helper()
+	var strikes, vols []float64
+	for k := -0.3; k <= 0.31; k += 0.1 {
+		strikes = append(strikes, forward*math.Exp(k))
+		vols = append(vols, atm-0.1*k)
+	}
+	smile, err := NewVolSmile(forward, timeYears, strikes, vols, SplineInterpolation, FlatExtrapolation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return smile
+}
+
+func TestSmileSurface(t *testing.T) {
+	near := testSmile(t, 101.0, 0.25, 0.25)
+	far := testSmile(t, 104.0, 1.0, 0.2)
+	surface, err := NewSmileSurface([]VolSmile{far, near})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var _ VolSurface = surface
+
+	if got := surface.Expirations(); len(got) != 2 || got[0] != 0.25 || got[1] != 1.0 {
+		t.Errorf("Unexpected expirations: got %v, want [0.25 1]", got)
+	}
+	if got := surface.ATMTermStructure(); math.Abs(got[0]-0.25) > 1e-12 || math.Abs(got[1]-0.2) > 1e-12 {
+		t.Errorf("Unexpected ATM term structure: got %v, want [0.25 0.2]", got)
+	}
+
+	// on a pillar, the pillar's smile
+	for _, strike := range []float64{85.0, 101.0, 120.0} {
+		if got, want := surface.Vol(strike, 0.25), near.Vol(strike); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Unexpected volatility at %v on the first pillar: got %v, want %v", strike, got, want)
+		}
+	}
+
+	// between pillars, linear in total variance at fixed log moneyness, not in volatility
+	forward := 101.0 * math.Exp(math.Log(104.0/101.0)*(0.625-0.25)/0.75)
+	if got := surface.Forward(0.625); math.Abs(got-forward) > 1e-12 {
+		t.Errorf("Unexpected forward between pillars: got %v, want %v", got, forward)
+	}
+	for _, k := range []float64{-0.2, 0, 0.15} {
+		w1, w2 := math.Pow(0.25-0.1*k, 2)*0.25, math.Pow(0.2-0.1*k, 2)*1.0
+		want := math.Sqrt((0.5*w1 + 0.5*w2) / 0.625)
+		if got := surface.Vol(forward*math.Exp(k), 0.625); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Unexpected volatility at log moneyness %v between pillars: got %v, want %v", k, got, want)
+		}
+	}
+	if got, want := surface.ATMVol(0.625), math.Sqrt((0.5*0.25*0.25*0.25+0.5*0.2*0.2)/0.625); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected ATM volatility between pillars: got %v, want %v", got, want)
+	}
+
+	// before the first pillar and after the last, the nearest smile's volatility at the same log moneyness
+	for _, timeYears := range []float64{0, 0.1, 2.0, 10.0} {
+		smile := near
+		if timeYears > 1.0 {
+			smile = far
+		}
+		forward := surface.Forward(timeYears)
+		if got, want := surface.Vol(forward*1.1, timeYears), smile.Vol(smile.Forward()*1.1); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Unexpected extrapolated volatility at %v years: got %v, want %v", timeYears, got, want)
+		}
+	}
+	if got, want := surface.Forward(2.0), 104.0*math.Exp(math.Log(104.0/101.0)/0.75); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Unexpected extrapolated forward: got %v, want %v", got, want)
+	}
+
+	for name, point := range map[string][2]float64{"zero strike": {0, 0.5}, "negative time": {100.0, -0.1}, "nan time": {100.0, math.NaN()}} {
+		if got := surface.Vol(point[0], point[1]); !math.IsNaN(got) {
+			t.Errorf("Unexpected volatility for %s: got %v, want NaN", name, got)
+		}
+	}
+
+	single, _ := NewSmileSurface([]VolSmile{near})
+	if got := single.Forward(3.0); got != 101.0 {
+		t.Errorf("Unexpected forward of a single smile: got %v, want 101", got)
+	}
+
+	for name, smiles := range map[string][]VolSmile{"empty": nil, "repeated": {near, far, near}} {
+		if _, err := NewSmileSurface(smiles); !errors.Is(err, ErrInvalidSurface) {
+			t.Errorf("Unexpected error for %s smiles: got %v, want %v", name, err, ErrInvalidSurface)
+		}
+	}
+}
+
+func TestNewSmileSurfaceFromOptions(t *testing.T) {
+	vol := func(strike, days float64) float64 { return 0.2 + 0.02*days/365.0 - 0.1*math.Log(strike/100.0) }
+	var options []Option
+	for _, days := range []float64{91.25, 365.0} {
+		for _, strike := range []float64{80.0, 90.0, 100.0, 110.0, 120.0} {
+			for _, typ := range []OptionType{Call, Put} {
+				option := Option{
+					Strike:           strike,
+					DaysToExpiration: days,
+					RiskFreeRate:     0.05,
+					UnderlyingPrice:  100.0,
+					OptionType:       typ,
+					DividendYield:    0.01,
+				}
+				option.Price = BlackScholesOptionPrice(option, vol(strike, days))
+				options = append(options, option)
+			}
+		}
+	}
+	surface, err := NewSmileSurfaceFromOptions(options, SplineInterpolation, FlatExtrapolation, WithTolerance(1e-12))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := surface.Expirations(); len(got) != 2 || got[0] != 0.25 || got[1] != 1.0 {
+		t.Errorf("Unexpected expirations: got %v, want [0.25 1]", got)
+	}
+	for _, strike := range []float64{80.0, 100.0, 120.0} {
+		if got, want := surface.Vol(strike, 1.0), vol(strike, 365.0); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Unexpected volatility at %v on the last pillar: got %v, want %v", strike, got, want)
+		}
+	}
+	if got, want := surface.Forward(1.0), ForwardPrice(100.0, 0.05, 0.01, 1.0); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected forward: got %v, want %v", got, want)
+	}
+
+	expired := options[0]
+	expired.DaysToExpiration = 0
+	if _, err := NewSmileSurfaceFromOptions([]Option{expired}, SplineInterpolation, FlatExtrapolation); !errors.Is(err, ErrInvalidSurface) {
+		t.Errorf("Unexpected error for an expired option: got %v, want %v", err, ErrInvalidSurface)
+	}
+	if _, err := NewSmileSurfaceFromOptions(nil, SplineInterpolation, FlatExtrapolation); !errors.Is(err, ErrInvalidSurface) {
+		t.Errorf("Unexpected error for no options: got %v, want %v", err, ErrInvalidSurface)
+	}
+	cheap := options[0]
+	cheap.Price = 0.5 * cheap.IntrinsicValue()
+	if _, err := NewSmileSurfaceFromOptions([]Option{cheap}, SplineInterpolation, FlatExtrapolation); err == nil {
+		t.Errorf("Unexpected success for a price below intrinsic value")
+	}
+	if _, err := NewSmileSurfaceFromOptions(options[:1], SplineInterpolation, FlatExtrapolation); !errors.Is(err, ErrInvalidSmile) {
+		t.Errorf("Unexpected error for a single quote: got %v, want %v", err, ErrInvalidSmile)
+	}
+}
+
+func TestForwardVol(t *testing.T) {
+	// a flat 25% baseline with an earnings day at 30 days adding the variance of a 6% move
+	event := 0.06 * 0.06
+	bumped := termStructureSurface(func(timeYears float64) float64 {
+		variance := 0.25 * 0.25 * timeYears
+		if timeYears > 30.0/365.0 {
+			variance += event
+		}
+		return math.Sqrt(variance / timeYears)
+	})
+	tests := []struct {
+		name   string
+		t1, t2 float64
+		want   float64
+	}{
+		{"before the event", 10.0 / 365.0, 20.0 / 365.0, 0.25},
+		{"across the event", 20.0 / 365.0, 40.0 / 365.0, math.Sqrt(0.25*0.25 + event/(20.0/365.0))},
+		{"after the event", 40.0 / 365.0, 90.0 / 365.0, 0.25},
+	}
+	for _, tt := range tests {
+		if got, err := ForwardVol(bumped, 100.0, tt.t1, tt.t2); err != nil || math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Unexpected forward volatility %s: got %v (%v), want %v", tt.name, got, err, tt.want)
+		}
+	}
+	if got, err := ForwardVol(bumped, 100.0, 0, 1.0); err != nil || math.Abs(got-bumped.Vol(100.0, 1.0)) > 1e-15 {
+		t.Errorf("Unexpected forward volatility from today: got %v (%v), want %v", got, err, bumped.Vol(100.0, 1.0))
+	}
+
+	falling := termStructureSurface(func(timeYears float64) float64 { return 0.3 / (1 + 10*timeYears) })
+	errorTests := []struct {
+		name    string
+		surface VolSurface
+		t1, t2  float64
+		want    error
+	}{
+		{"calendar arbitrage", falling, 0.5, 1.0, ErrCalendarArbitrage},
+		{"negative start", bumped, -0.1, 1.0, ErrNegativeExpiry},
+		{"reversed", bumped, 1.0, 0.5, ErrInvalidForwardPeriod},
+		{"empty", bumped, 0.5, 0.5, ErrInvalidForwardPeriod},
+		{"zero vol", FlatVolSurface(0), 0.5, 1.0, ErrNonPositiveVolatility},
+	}
+	for _, tt := range errorTests {
+		if _, err := ForwardVol(tt.surface, 100.0, tt.t1, tt.t2); !errors.Is(err, tt.want) {
+			t.Errorf("Unexpected error for %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
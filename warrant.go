@@ -0,0 +1,60 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidWarrant is returned for a warrant that is not a call, or whose counts of shares or warrants outstanding
+// are not positive and finite, or negative for the warrants
+var ErrInvalidWarrant = errors.New("finance: invalid warrant")
+
+const (
+	warrantTolerance     = 1e-12 // Tolerance on the warrant price, relative to the underlying price
+	warrantMaxIterations = 50    // Maximum number of Newton iterations
+)
+
+// WarrantPrice computes the price of a warrant, a call written by the company on new shares. Exercising M warrants
+// against N shares dilutes the shares, so that each warrant is worth N/(N + M) calls on the company's equity per
+// share, S + (M/N)·W, which includes the warrants themselves: W = N/(N + M)·C(S + (M/N)·W). The fixed point is found
+// by Newton's method, from the naive dilution-adjusted price N/(N + M)·C(S), to within warrantTolerance of the
+// underlying price. With no warrants outstanding it is the Black-Scholes call, which it is always below otherwise.
+// Discrete dividends are escrowed
+// Returns the errors of BlackScholesOptionPriceE for invalid inputs, ErrInvalidWarrant for a put or invalid counts
+// and ErrNotConverged if Newton's method does not converge
+// option: the option, a call
+// vol: the volatility
+// sharesOutstanding: the number of shares outstanding N
+// warrantsOutstanding: the number of warrants outstanding M
+func WarrantPrice(option Option, vol float64, sharesOutstanding, warrantsOutstanding float64) (float64, error) {
+	if err := validatePricing(option, vol); err != nil {
+		return math.NaN(), err
+	}
+	switch {
+	case option.OptionType != Call:
+		return math.NaN(), fmt.Errorf("%w: warrants are calls", ErrInvalidWarrant)
+	case !(sharesOutstanding > 0) || math.IsInf(sharesOutstanding, 1):
+		return math.NaN(), fmt.Errorf("%w: %v shares outstanding", ErrInvalidWarrant, sharesOutstanding)
+	case !(warrantsOutstanding >= 0) || math.IsInf(warrantsOutstanding, 1):
+		return math.NaN(), fmt.Errorf("%w: %v warrants outstanding", ErrInvalidWarrant, warrantsOutstanding)
+	}
+	option = escrowed(option)
+	spot := option.UnderlyingPrice
+	dilution := sharesOutstanding / (sharesOutstanding + warrantsOutstanding)
+	ratio := warrantsOutstanding / sharesOutstanding
+
+	// f(W) = W − N/(N + M)·C(S + (M/N)·W) rises with slope 1 − M/(N + M)·Δ > 0, so Newton's method converges to its
+	// single root
+	warrant := dilution * BlackScholesOptionPrice(option, vol)
+	for range warrantMaxIterations {
+		option.UnderlyingPrice = spot + ratio*warrant
+		residual := warrant - dilution*BlackScholesOptionPrice(option, vol)
+		step := residual / (1 - dilution*ratio*BlackScholesDelta(option, vol))
+		warrant -= step
+		if math.Abs(step) <= warrantTolerance*spot {
+			return warrant, nil
+		}
+	}
+	return math.NaN(), fmt.Errorf("%w: warrant price after %d iterations", ErrNotConverged, warrantMaxIterations)
+}
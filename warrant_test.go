@@ -0,0 +1,62 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestWarrantPrice(t *testing.T) {
+	option := Option{
+		Strike:           40.0,
+		DaysToExpiration: 3 * 365,
+		RiskFreeRate:     0.04,
+		UnderlyingPrice:  35.0,
+		OptionType:       Call,
+		DividendYield:    0.01,
+	}
+	call := BlackScholesOptionPrice(option, 0.35)
+
+	previous := 0.0
+	for _, warrants := range []float64{5e6, 1e6, 1e5, 1e3} {
+		got, err := WarrantPrice(option, 0.35, 1e7, warrants)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v warrants: %v", warrants, err)
+		}
+		// the price solves the dilution fixed point and rises towards the call as dilution vanishes
+		diluted := option
+		diluted.UnderlyingPrice += warrants / 1e7 * got
+		if want := 1e7 / (1e7 + warrants) * BlackScholesOptionPrice(diluted, 0.35); math.Abs(got-want) > 1e-10 {
+			t.Errorf("Unexpected fixed point for %v warrants: got %v, want %v", warrants, got, want)
+		}
+		if !(got < call && got > previous) {
+			t.Errorf("Unexpected warrant price for %v warrants: got %v, want between %v and the call %v", warrants, got, previous, call)
+		}
+		previous = got
+	}
+	if got := call - previous; got > 1e-3 {
+		t.Errorf("Unexpected gap to the call with few warrants: got %v, want below 1e-3", got)
+	}
+	if got, err := WarrantPrice(option, 0.35, 1e7, 0); err != nil || got != call {
+		t.Errorf("Unexpected price with no warrants: got %v, %v; want %v", got, err, call)
+	}
+
+	put := option
+	put.OptionType = Put
+	tests := []struct {
+		name             string
+		option           Option
+		shares, warrants float64
+		err              error
+	}{
+		{"put", put, 1e7, 1e6, ErrInvalidWarrant},
+		{"no shares", option, 0, 1e6, ErrInvalidWarrant},
+		{"negative warrants", option, 1e7, -1, ErrInvalidWarrant},
+		{"negative days", Option{Strike: 40.0, DaysToExpiration: -1, UnderlyingPrice: 35.0}, 1e7, 1e6, ErrNegativeExpiry},
+	}
+	for _, test := range tests {
+		if got, err := WarrantPrice(test.option, 0.35, test.shares, test.warrants); !errors.Is(err, test.err) || !math.IsNaN(got) {
+			t.Errorf("Unexpected result for %s: got %v, %v; want NaN, %v", test.name, got, err, test.err)
+		}
+	}
+}